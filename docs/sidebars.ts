@@ -16,10 +16,20 @@ const sidebars: SidebarsConfig = {
         'commands/generate-scheduler',
         'commands/add',
         'commands/remove',
+        'commands/prop',
+        'commands/repo',
+        'commands/plugin',
+        'commands/boot',
+        'commands/migrate',
+        'commands/convert',
         'commands/dev',
         'commands/docker',
         'commands/doctor',
         'commands/info',
+        'commands/profile',
+        'commands/arch',
+        'commands/graph',
+        'commands/erd',
         'commands/routes',
         'commands/stats',
         'commands/template',
@@ -0,0 +1,183 @@
+package arch
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func javaFile(pkg, class string, imports ...string) *detector.JavaFile {
+	lines := make([]int, len(imports))
+	for i := range imports {
+		lines[i] = i + 3
+	}
+	return &detector.JavaFile{
+		Path:        "/project/src/main/java/" + pkg + "/" + class + ".java",
+		Package:     pkg,
+		ClassName:   class,
+		Imports:     imports,
+		ImportLines: lines,
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"..controller..", "com.example.app.controller.UserController", true},
+		{"..controller..", "com.example.app.user.UserController", false},
+		{"..controller..", "org.acme.controller.Foo", false},
+		{"..user.controller..", "com.example.app.user.controller.UserController", true},
+		{"..*adapter..", "com.example.app.webadapter.UserApi", true},
+		{"org.springframework..", "org.springframework.stereotype.Service", true},
+		{"org.springframework..", "org.springframeworks.Foo", false},
+		{"java.util", "java.util.List", true},
+		{"java.util", "java.util.concurrent.Future", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchPattern(tt.pattern, tt.name, "com.example.app"))
+		})
+	}
+}
+
+func TestCheckLayered(t *testing.T) {
+	profile := &detector.ProjectProfile{Architecture: detector.ArchLayered, BasePackage: "com.example.app"}
+	files := []*detector.JavaFile{
+		javaFile("com.example.app.controller", "UserController",
+			"com.example.app.service.UserService",
+			"com.example.app.repository.UserRepository",
+			"org.springframework.data.repository.CrudRepository"),
+		javaFile("com.example.app.service", "UserService", "com.example.app.repository.UserRepository"),
+		javaFile("com.example.app.entity", "User", "com.example.app.service.UserService"),
+	}
+	files = append(files, &detector.JavaFile{
+		Package:   "com.example.app.controller",
+		ClassName: "UserControllerTest",
+		FileType:  detector.FileTypeTest,
+		Imports:   []string{"com.example.app.repository.UserRepository"},
+	})
+
+	rules := DefaultRules(profile.Architecture, profile.BasePackage, files)
+	report := Check("/project", profile, files, rules)
+
+	require.Len(t, report.Violations, 2)
+	assert.Equal(t, 3, report.FilesChecked)
+	assert.Equal(t, 2, report.ErrorCount)
+	assert.True(t, report.HasErrors())
+
+	first := report.Violations[0]
+	assert.Equal(t, "controller-no-repository", first.Rule)
+	assert.Equal(t, "src/main/java/com.example.app.controller/UserController.java", first.File)
+	assert.Equal(t, 4, first.Line)
+	assert.Equal(t, "com.example.app.repository.UserRepository", first.Import)
+
+	assert.Equal(t, "entity-no-upper-layers", report.Violations[1].Rule)
+}
+
+func TestCheckHexagonalDomainFramework(t *testing.T) {
+	profile := &detector.ProjectProfile{Architecture: detector.ArchHexagonal, BasePackage: "com.example.app"}
+	files := []*detector.JavaFile{
+		javaFile("com.example.app.domain.model", "Order", "org.springframework.stereotype.Component", "java.util.List"),
+		javaFile("com.example.app.application.service", "OrderService", "com.example.app.adapter.out.persistence.OrderJpaRepository"),
+		javaFile("com.example.app.adapter.in.web", "OrderController", "com.example.app.application.port.in.CreateOrder"),
+	}
+
+	report := Check("/project", profile, files, DefaultRules(profile.Architecture, profile.BasePackage, files))
+
+	var rules []string
+	for _, violation := range report.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.ElementsMatch(t, []string{"domain-no-framework", "application-no-adapters"}, rules)
+}
+
+func TestCheckModularInternalAccess(t *testing.T) {
+	profile := &detector.ProjectProfile{Architecture: detector.ArchModular, BasePackage: "com.example.shop"}
+	files := []*detector.JavaFile{
+		javaFile("com.example.shop.orders", "OrderService", "com.example.shop.billing.internal.InvoiceRepository", "com.example.shop.billing.BillingApi"),
+		javaFile("com.example.shop.billing.internal", "InvoiceRepository", "com.example.shop.billing.internal.Invoice"),
+	}
+
+	report := Check("/project", profile, files, DefaultRules(profile.Architecture, profile.BasePackage, files))
+
+	require.Len(t, report.Violations, 1)
+	assert.Equal(t, moduleInternalRule, report.Violations[0].Rule)
+	assert.Equal(t, "com.example.shop.billing.internal.InvoiceRepository", report.Violations[0].Import)
+}
+
+func TestConfiguredRules(t *testing.T) {
+	defaults := DefaultRules(detector.ArchLayered, "com.example.app", nil)
+
+	rules, err := ConfiguredRules(defaults, config.ArchSettings{
+		DisabledRules: []string{"entity-no-upper-layers"},
+		Rules: []config.ArchRule{
+			{Name: "no-web-in-billing", From: []string{"..billing.."}, Forbid: []string{"..web.."}, Severity: "warning"},
+		},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	assert.NotContains(t, names, "entity-no-upper-layers")
+	assert.Contains(t, names, "no-web-in-billing")
+
+	custom := rules[len(rules)-1]
+	assert.True(t, custom.Custom)
+	assert.Equal(t, SeverityWarning, custom.Severity)
+}
+
+func TestConfiguredRulesRejectsInvalidRules(t *testing.T) {
+	_, err := ConfiguredRules(nil, config.ArchSettings{Rules: []config.ArchRule{{Name: "broken", From: []string{"..a.."}}}})
+	assert.ErrorContains(t, err, "needs both 'from' and 'forbid'")
+
+	_, err = ConfiguredRules(nil, config.ArchSettings{Rules: []config.ArchRule{{Name: "loud", From: []string{"..a.."}, Forbid: []string{"..b.."}, Severity: "fatal"}}})
+	assert.ErrorContains(t, err, "invalid severity")
+}
+
+func TestCheckWarningsDoNotFail(t *testing.T) {
+	profile := &detector.ProjectProfile{Architecture: detector.ArchFlat, BasePackage: "com.example.app"}
+	files := []*detector.JavaFile{javaFile("com.example.app.billing", "Invoice", "com.example.app.web.Api")}
+	rules, err := ConfiguredRules(nil, config.ArchSettings{Rules: []config.ArchRule{
+		{Name: "no-web-in-billing", From: []string{"..billing.."}, Forbid: []string{"..web.."}, Severity: "warning"},
+	}})
+	require.NoError(t, err)
+
+	report := Check("/project", profile, files, rules)
+	assert.Equal(t, 1, report.WarningCount)
+	assert.False(t, report.HasErrors())
+}
+
+func TestToSarif(t *testing.T) {
+	report := &Report{
+		Rules: []Rule{
+			{Name: "controller-no-repository", Description: "Controllers must not use repositories", Severity: SeverityError},
+			{Name: moduleInternalRule, Description: "internal", Severity: SeverityError},
+			{Name: moduleInternalRule, Description: "internal", Severity: SeverityError},
+		},
+		Violations: []Violation{
+			{Rule: "controller-no-repository", Severity: SeverityError, File: "src/main/java/A.java", Line: 7, Message: "A imports B"},
+		},
+	}
+
+	log := ToSarif(report, "1.2.0")
+
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "haft", log.Runs[0].Tool.Driver.Name)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "controller-no-repository", result.RuleID)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "src/main/java/A.java", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 7, result.Locations[0].PhysicalLocation.Region.StartLine)
+}
@@ -0,0 +1,98 @@
+package arch
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/detector"
+)
+
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Class    string   `json:"class"`
+	Import   string   `json:"import"`
+	Message  string   `json:"message"`
+}
+
+type Report struct {
+	Architecture detector.ArchitectureType `json:"architecture"`
+	BasePackage  string                    `json:"basePackage"`
+	Rules        []Rule                    `json:"rules"`
+	Violations   []Violation               `json:"violations"`
+	FilesChecked int                       `json:"filesChecked"`
+	ErrorCount   int                       `json:"errorCount"`
+	WarningCount int                       `json:"warningCount"`
+}
+
+func Check(projectDir string, profile *detector.ProjectProfile, files []*detector.JavaFile, rules []Rule) *Report {
+	report := &Report{
+		Architecture: profile.Architecture,
+		BasePackage:  profile.BasePackage,
+		Rules:        rules,
+		Violations:   []Violation{},
+	}
+
+	for _, file := range files {
+		if file.FileType == detector.FileTypeTest || file.ClassName == "" {
+			continue
+		}
+		report.FilesChecked++
+
+		className := file.ClassName
+		if file.Package != "" {
+			className = file.Package + "." + file.ClassName
+		}
+
+		for _, rule := range rules {
+			if !matchesAny(rule.From, className, profile.BasePackage) {
+				continue
+			}
+			for i, imported := range file.Imports {
+				if imported == "" || !matchesAny(rule.Forbid, imported, profile.BasePackage) {
+					continue
+				}
+				report.Violations = append(report.Violations, Violation{
+					Rule:     rule.Name,
+					Severity: rule.Severity,
+					File:     buildtool.RelativePath(projectDir, file.Path),
+					Line:     importLine(file, i),
+					Class:    className,
+					Import:   imported,
+					Message:  fmt.Sprintf("%s imports %s: %s", file.ClassName, imported, rule.Description),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+
+	for _, violation := range report.Violations {
+		if violation.Severity == SeverityWarning {
+			report.WarningCount++
+		} else {
+			report.ErrorCount++
+		}
+	}
+	return report
+}
+
+func (r *Report) HasErrors() bool {
+	return r.ErrorCount > 0
+}
+
+func importLine(file *detector.JavaFile, index int) int {
+	if index < len(file.ImportLines) {
+		return file.ImportLines[index]
+	}
+	return 1
+}
@@ -0,0 +1,274 @@
+package arch
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/KashifKhn/haft/internal/detector"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Rule struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	From        []string `json:"from"`
+	Forbid      []string `json:"forbid"`
+	Severity    Severity `json:"severity"`
+	Custom      bool     `json:"custom,omitempty"`
+}
+
+var frameworkPackages = []string{
+	"org.springframework..",
+	"jakarta.persistence..",
+	"javax.persistence..",
+}
+
+var layeredRules = []Rule{
+	{
+		Name:        "controller-no-repository",
+		Description: "Controllers must go through services instead of using repositories directly",
+		From:        []string{"..controller..", "..web.."},
+		Forbid:      []string{"..repository..", "..persistence.."},
+	},
+	{
+		Name:        "service-no-controller",
+		Description: "Services must not depend on controllers",
+		From:        []string{"..service.."},
+		Forbid:      []string{"..controller..", "..web.."},
+	},
+	{
+		Name:        "repository-no-upper-layers",
+		Description: "Repositories must not depend on services or controllers",
+		From:        []string{"..repository.."},
+		Forbid:      []string{"..service..", "..controller..", "..web.."},
+	},
+	{
+		Name:        "entity-no-upper-layers",
+		Description: "Entities must not depend on repositories, services or controllers",
+		From:        []string{"..entity..", "..model.."},
+		Forbid:      []string{"..repository..", "..service..", "..controller..", "..web.."},
+	},
+}
+
+var hexagonalRules = []Rule{
+	{
+		Name:        "domain-no-framework",
+		Description: "The domain must not depend on Spring or JPA",
+		From:        []string{"..domain.."},
+		Forbid:      frameworkPackages,
+	},
+	{
+		Name:        "domain-no-outer-layers",
+		Description: "The domain must not depend on the application, adapter or infrastructure layers",
+		From:        []string{"..domain.."},
+		Forbid:      []string{"..application..", "..adapter..", "..infrastructure.."},
+	},
+	{
+		Name:        "application-no-adapters",
+		Description: "The application layer must reach adapters only through ports",
+		From:        []string{"..application.."},
+		Forbid:      []string{"..adapter..", "..infrastructure.."},
+	},
+}
+
+var cleanRules = []Rule{
+	{
+		Name:        "domain-no-framework",
+		Description: "The domain must not depend on Spring or JPA",
+		From:        []string{"..domain.."},
+		Forbid:      frameworkPackages,
+	},
+	{
+		Name:        "domain-no-outer-layers",
+		Description: "The domain must not depend on use cases, infrastructure or presentation",
+		From:        []string{"..domain.."},
+		Forbid:      []string{"..usecase..", "..application..", "..infrastructure..", "..presenter..", "..controller.."},
+	},
+	{
+		Name:        "usecase-no-outer-layers",
+		Description: "Use cases must not depend on infrastructure or presentation",
+		From:        []string{"..usecase..", "..application.."},
+		Forbid:      []string{"..infrastructure..", "..presenter..", "..controller.."},
+	},
+}
+
+const moduleInternalRule = "module-internal-access"
+
+func DefaultRules(arch detector.ArchitectureType, basePackage string, files []*detector.JavaFile) []Rule {
+	var rules []Rule
+	switch arch {
+	case detector.ArchLayered, detector.ArchFeature:
+		rules = layeredRules
+	case detector.ArchHexagonal:
+		rules = hexagonalRules
+	case detector.ArchClean:
+		rules = cleanRules
+	case detector.ArchModular:
+		rules = modularRules(basePackage, files)
+	}
+	return withDefaults(rules, false)
+}
+
+func modularRules(basePackage string, files []*detector.JavaFile) []Rule {
+	if basePackage == "" {
+		return nil
+	}
+
+	modules := modulesOf(basePackage, files)
+	var rules []Rule
+	for _, module := range modules {
+		var forbid []string
+		for _, other := range modules {
+			if other != module {
+				forbid = append(forbid, basePackage+"."+other+".internal..")
+			}
+		}
+		if len(forbid) == 0 {
+			continue
+		}
+		rules = append(rules, Rule{
+			Name:        moduleInternalRule,
+			Description: "Modules must not use the internal packages of other modules",
+			From:        []string{basePackage + "." + module + ".."},
+			Forbid:      forbid,
+		})
+	}
+	return rules
+}
+
+func modulesOf(basePackage string, files []*detector.JavaFile) []string {
+	seen := make(map[string]bool)
+	var modules []string
+	for _, file := range files {
+		rest, ok := strings.CutPrefix(file.Package, basePackage+".")
+		if !ok {
+			continue
+		}
+		module, _, _ := strings.Cut(rest, ".")
+		if !seen[module] {
+			seen[module] = true
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+func ConfiguredRules(defaults []Rule, settings config.ArchSettings) ([]Rule, error) {
+	disabled := make(map[string]bool, len(settings.DisabledRules))
+	for _, name := range settings.DisabledRules {
+		disabled[name] = true
+	}
+
+	var rules []Rule
+	for _, rule := range defaults {
+		if !disabled[rule.Name] {
+			rules = append(rules, rule)
+		}
+	}
+
+	var custom []Rule
+	for _, rule := range settings.Rules {
+		if err := validateRule(rule); err != nil {
+			return nil, err
+		}
+		if disabled[rule.Name] {
+			continue
+		}
+		custom = append(custom, Rule{
+			Name:        rule.Name,
+			Description: rule.Description,
+			From:        rule.From,
+			Forbid:      rule.Forbid,
+			Severity:    Severity(rule.Severity),
+		})
+	}
+	return append(rules, withDefaults(custom, true)...), nil
+}
+
+func validateRule(rule config.ArchRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("architecture rule is missing a name")
+	}
+	if len(rule.From) == 0 || len(rule.Forbid) == 0 {
+		return fmt.Errorf("architecture rule '%s' needs both 'from' and 'forbid' patterns", rule.Name)
+	}
+	switch Severity(rule.Severity) {
+	case "", SeverityError, SeverityWarning:
+		return nil
+	default:
+		return fmt.Errorf("architecture rule '%s' has invalid severity '%s' (expected error or warning)", rule.Name, rule.Severity)
+	}
+}
+
+func withDefaults(rules []Rule, custom bool) []Rule {
+	result := make([]Rule, len(rules))
+	for i, rule := range rules {
+		if rule.Severity != SeverityWarning {
+			rule.Severity = SeverityError
+		}
+		rule.Custom = custom
+		result[i] = rule
+	}
+	return result
+}
+
+func matchesAny(patterns []string, name, basePackage string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, name, basePackage) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, name, basePackage string) bool {
+	if rest, ok := strings.CutPrefix(pattern, ".."); ok {
+		if basePackage != "" {
+			relative, inProject := strings.CutPrefix(name, basePackage+".")
+			if !inProject {
+				return false
+			}
+			name = relative
+		}
+		rest = strings.TrimSuffix(rest, "..")
+		return containsSegments(strings.Split(name, "."), strings.Split(rest, "."))
+	}
+
+	prefix := strings.Split(strings.TrimSuffix(pattern, ".."), ".")
+	parts := strings.Split(name, ".")
+	if len(parts) < len(prefix) {
+		return false
+	}
+	if !strings.HasSuffix(pattern, "..") && len(parts) > len(prefix)+1 {
+		return false
+	}
+	return segmentsMatch(parts[:len(prefix)], prefix)
+}
+
+func containsSegments(parts, segments []string) bool {
+	for i := 0; i+len(segments) <= len(parts); i++ {
+		if segmentsMatch(parts[i:i+len(segments)], segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func segmentsMatch(parts, patterns []string) bool {
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, parts[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package arch
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolURI      = "https://github.com/KashifKhn/haft"
+)
+
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     SarifMessage       `json:"shortDescription"`
+	DefaultConfiguration SarifConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func ToSarif(report *Report, version string) SarifLog {
+	driver := SarifDriver{
+		Name:           "haft",
+		Version:        version,
+		InformationURI: toolURI,
+		Rules:          []SarifRule{},
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range report.Rules {
+		if seen[rule.Name] {
+			continue
+		}
+		seen[rule.Name] = true
+		driver.Rules = append(driver.Rules, SarifRule{
+			ID:                   rule.Name,
+			ShortDescription:     SarifMessage{Text: rule.Description},
+			DefaultConfiguration: SarifConfiguration{Level: string(rule.Severity)},
+		})
+	}
+
+	results := make([]SarifResult, 0, len(report.Violations))
+	for _, violation := range report.Violations {
+		results = append(results, SarifResult{
+			RuleID:  violation.Rule,
+			Level:   string(violation.Severity),
+			Message: SarifMessage{Text: violation.Message},
+			Locations: []SarifLocation{{
+				PhysicalLocation: SarifPhysicalLocation{
+					ArtifactLocation: SarifArtifactLocation{URI: violation.File},
+					Region:           SarifRegion{StartLine: violation.Line},
+				},
+			}},
+		})
+	}
+
+	return SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SarifRun{{Tool: SarifTool{Driver: driver}, Results: results}},
+	}
+}
@@ -31,6 +31,19 @@ func Detect(startDir string, fs afero.Fs) (*DetectionResult, error) {
 	return nil, fmt.Errorf("no build file found in %s or any parent directory", startDir)
 }
 
+func Load(fs afero.Fs, dir string) (*DetectionResult, *Project, error) {
+	result, err := Detect(dir, fs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find build file: %w", err)
+	}
+
+	project, err := result.Parser.Parse(result.FilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse %s: %w", result.FilePath, err)
+	}
+	return result, project, nil
+}
+
 func detectInDirectory(dir string, fs afero.Fs) *DetectionResult {
 	pomPath := filepath.Join(dir, "pom.xml")
 	if exists(fs, pomPath) {
@@ -0,0 +1,358 @@
+package buildtool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	mavenModulesRegex   = regexp.MustCompile(`(?s)<modules>(.*?)</modules>`)
+	mavenModuleRegex    = regexp.MustCompile(`<module>\s*([^<\s]+)\s*</module>`)
+	xmlCommentRegex     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	gradleIncludeRegex  = regexp.MustCompile(`(?m)^\s*include\s*(?:\(([^)]*)\)|([^\n(]*))`)
+	gradleStringRegex   = regexp.MustCompile(`["']([^"']+)["']`)
+	gradleProjectDir    = regexp.MustCompile(`project\(\s*["']([^"']+)["']\s*\)\.projectDir\s*=\s*(?:file\(\s*)?["']([^"']+)["']`)
+	gradleCommentRegex  = regexp.MustCompile(`(?m)//.*$`)
+	gradleSettingsFiles = []string{"settings.gradle.kts", "settings.gradle"}
+)
+
+type Module struct {
+	Name      string
+	Dir       string
+	BuildFile string
+	BuildTool Type
+}
+
+type Workspace struct {
+	Root      string
+	BuildTool Type
+	Modules   []Module
+}
+
+func DetectWorkspace(startDir string, fs afero.Fs) (*Workspace, error) {
+	projectDir, tool := nearestBuildDir(startDir, fs)
+	if projectDir == "" {
+		projectDir = startDir
+	}
+
+	var workspace *Workspace
+	if tool != "" {
+		workspace = &Workspace{Root: projectDir, BuildTool: tool}
+	}
+
+	current := projectDir
+	for {
+		if candidate := loadWorkspace(current, fs); candidate != nil && candidate.contains(projectDir) {
+			workspace = candidate
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current || parent == "" {
+			break
+		}
+		current = parent
+	}
+
+	if workspace == nil {
+		return nil, fmt.Errorf("no build file found in %s or any parent directory", startDir)
+	}
+	return workspace, nil
+}
+
+func nearestBuildDir(startDir string, fs afero.Fs) (string, Type) {
+	current := startDir
+	for {
+		if _, tool := buildFileIn(current, fs); tool != "" {
+			return current, tool
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current || parent == "" {
+			return "", ""
+		}
+		current = parent
+	}
+}
+
+func loadWorkspace(dir string, fs afero.Fs) *Workspace {
+	for _, name := range gradleSettingsFiles {
+		content, err := afero.ReadFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		tool := Gradle
+		if strings.HasSuffix(name, ".kts") {
+			tool = GradleKotln
+		}
+		return &Workspace{Root: dir, BuildTool: tool, Modules: gradleModules(dir, string(content), fs)}
+	}
+
+	pomPath := filepath.Join(dir, "pom.xml")
+	if !exists(fs, pomPath) {
+		return nil
+	}
+	return &Workspace{Root: dir, BuildTool: Maven, Modules: mavenModules(dir, "", fs, map[string]bool{})}
+}
+
+func mavenModules(dir, prefix string, fs afero.Fs, seen map[string]bool) []Module {
+	if seen[dir] {
+		return nil
+	}
+	seen[dir] = true
+
+	content, err := afero.ReadFile(fs, filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		return nil
+	}
+
+	var modules []Module
+	for _, name := range ParseMavenModules(string(content)) {
+		moduleDir := filepath.Join(dir, filepath.FromSlash(name))
+		if !exists(fs, filepath.Join(moduleDir, "pom.xml")) {
+			continue
+		}
+		fullName := strings.TrimPrefix(prefix+"/"+filepath.ToSlash(filepath.Clean(filepath.FromSlash(name))), "/")
+		modules = append(modules, Module{
+			Name:      fullName,
+			Dir:       moduleDir,
+			BuildFile: filepath.Join(moduleDir, "pom.xml"),
+			BuildTool: Maven,
+		})
+		modules = append(modules, mavenModules(moduleDir, fullName, fs, seen)...)
+	}
+	return modules
+}
+
+func gradleModules(root, content string, fs afero.Fs) []Module {
+	dirs := make(map[string]string)
+	for _, match := range gradleProjectDir.FindAllStringSubmatch(content, -1) {
+		dirs[strings.TrimPrefix(match[1], ":")] = match[2]
+	}
+
+	var modules []Module
+	for _, path := range ParseGradleIncludes(content) {
+		name := strings.TrimPrefix(path, ":")
+		rel := strings.ReplaceAll(name, ":", "/")
+		if dir, ok := dirs[name]; ok {
+			rel = dir
+		}
+
+		module := Module{Name: name, Dir: filepath.Join(root, filepath.FromSlash(rel))}
+		module.BuildFile, module.BuildTool = buildFileIn(module.Dir, fs)
+		modules = append(modules, module)
+	}
+	return modules
+}
+
+func buildFileIn(dir string, fs afero.Fs) (string, Type) {
+	candidates := []struct {
+		name string
+		tool Type
+	}{
+		{"pom.xml", Maven},
+		{"build.gradle.kts", GradleKotln},
+		{"build.gradle", Gradle},
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(dir, candidate.name)
+		if exists(fs, path) {
+			return path, candidate.tool
+		}
+	}
+	return "", ""
+}
+
+func ParseMavenModules(content string) []string {
+	content = xmlCommentRegex.ReplaceAllString(content, "")
+
+	var modules []string
+	seen := make(map[string]bool)
+	for _, block := range mavenModulesRegex.FindAllStringSubmatch(content, -1) {
+		for _, match := range mavenModuleRegex.FindAllStringSubmatch(block[1], -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				modules = append(modules, match[1])
+			}
+		}
+	}
+	return modules
+}
+
+func ParseGradleIncludes(content string) []string {
+	content = gradleCommentRegex.ReplaceAllString(content, "")
+
+	var includes []string
+	seen := make(map[string]bool)
+	for _, match := range gradleIncludeRegex.FindAllStringSubmatch(content, -1) {
+		for _, name := range gradleStringRegex.FindAllStringSubmatch(match[1]+match[2], -1) {
+			path := ":" + strings.TrimPrefix(name[1], ":")
+			if !seen[path] {
+				seen[path] = true
+				includes = append(includes, path)
+			}
+		}
+	}
+	return includes
+}
+
+func (w *Workspace) IsMultiModule() bool {
+	return len(w.Modules) > 0
+}
+
+func (w *Workspace) ModuleNames() []string {
+	names := make([]string, len(w.Modules))
+	for i, module := range w.Modules {
+		names[i] = module.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (w *Workspace) Find(name string) (*Module, error) {
+	name = strings.Trim(strings.ReplaceAll(strings.TrimPrefix(name, ":"), ":", "/"), "/")
+
+	var matches []*Module
+	for i := range w.Modules {
+		module := &w.Modules[i]
+		full := strings.ReplaceAll(module.Name, ":", "/")
+		if full == name {
+			return module, nil
+		}
+		if filepath.Base(filepath.FromSlash(full)) == name || filepath.ToSlash(w.relative(module.Dir)) == name {
+			matches = append(matches, module)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if !w.IsMultiModule() {
+			return nil, fmt.Errorf("module '%s' not found: %s is not a multi-module project", name, w.Root)
+		}
+		return nil, fmt.Errorf("module '%s' not found (available: %s)", name, strings.Join(w.ModuleNames(), ", "))
+	default:
+		var names []string
+		for _, module := range matches {
+			names = append(names, module.Name)
+		}
+		return nil, fmt.Errorf("module '%s' is ambiguous (matches: %s)", name, strings.Join(names, ", "))
+	}
+}
+
+func (w *Workspace) ModuleFor(dir string) *Module {
+	var best *Module
+	for i := range w.Modules {
+		module := &w.Modules[i]
+		if isWithin(dir, module.Dir) && (best == nil || len(module.Dir) > len(best.Dir)) {
+			best = module
+		}
+	}
+	return best
+}
+
+func (w *Workspace) SourceModules(fs afero.Fs) []Module {
+	var modules []Module
+	for _, module := range w.Modules {
+		if hasSources(fs, module.Dir) {
+			modules = append(modules, module)
+		}
+	}
+	return modules
+}
+
+func hasSources(fs afero.Fs, dir string) bool {
+	for _, src := range []string{"src/main/java", "src/main/kotlin"} {
+		if ok, _ := afero.DirExists(fs, filepath.Join(dir, src)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Workspace) relative(dir string) string {
+	rel, err := filepath.Rel(w.Root, dir)
+	if err != nil {
+		return dir
+	}
+	return rel
+}
+
+func (w *Workspace) contains(dir string) bool {
+	if filepath.Clean(dir) == filepath.Clean(w.Root) {
+		return true
+	}
+	return w.ModuleFor(dir) != nil
+}
+
+func isWithin(dir, parent string) bool {
+	rel, err := filepath.Rel(parent, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func WorkingDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return dir, nil
+}
+
+func RelativePath(dir, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func WorkingModuleDir(fs afero.Fs, name string) (string, error) {
+	dir, err := WorkingDir()
+	if err != nil {
+		return "", err
+	}
+	return ModuleDir(fs, dir, name)
+}
+
+func ModuleDir(fs afero.Fs, dir, name string) (string, error) {
+	if name == "" {
+		return dir, nil
+	}
+
+	workspace, err := DetectWorkspace(dir, fs)
+	if err != nil {
+		return "", err
+	}
+
+	module, err := workspace.Find(name)
+	if err != nil {
+		return "", err
+	}
+	return module.Dir, nil
+}
+
+func SourceModuleDir(fs afero.Fs, dir, name string) (string, error) {
+	if name != "" {
+		return ModuleDir(fs, dir, name)
+	}
+
+	workspace, err := DetectWorkspace(dir, fs)
+	if err != nil || !workspace.IsMultiModule() {
+		return dir, nil
+	}
+	if workspace.ModuleFor(dir) != nil || hasSources(fs, dir) {
+		return dir, nil
+	}
+
+	candidates := workspace.SourceModules(fs)
+	if len(candidates) != 1 {
+		return "", fmt.Errorf("multi-module project: choose a module with --module (available: %s)", strings.Join(workspace.ModuleNames(), ", "))
+	}
+	return candidates[0].Dir, nil
+}
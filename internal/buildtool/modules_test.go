@@ -0,0 +1,223 @@
+package buildtool
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMavenModules(t *testing.T) {
+	content := `<project>
+    <modules>
+        <module>api</module>
+        <!-- <module>legacy</module> -->
+        <module> services/billing </module>
+        <module>api</module>
+    </modules>
+</project>`
+
+	assert.Equal(t, []string{"api", "services/billing"}, ParseMavenModules(content))
+	assert.Empty(t, ParseMavenModules("<project></project>"))
+}
+
+func TestParseGradleIncludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "groovy",
+			content:  "rootProject.name = 'shop'\ninclude 'api', ':core'\n// include 'legacy'\n",
+			expected: []string{":api", ":core"},
+		},
+		{
+			name:     "kotlin",
+			content:  "rootProject.name = \"shop\"\ninclude(\"api\")\ninclude(\":services:billing\")\n",
+			expected: []string{":api", ":services:billing"},
+		},
+		{
+			name:     "multiline",
+			content:  "include(\n    \"api\",\n    \"core\",\n)\n",
+			expected: []string{":api", ":core"},
+		},
+		{
+			name:     "none",
+			content:  "rootProject.name = \"shop\"\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseGradleIncludes(tt.content))
+		})
+	}
+}
+
+func TestDetectWorkspaceMavenNested(t *testing.T) {
+	fs := testutil.MemFs(t, "/", map[string]string{
+		"/shop/pom.xml":                   "<project><modules><module>api</module><module>services</module><module>missing</module></modules></project>",
+		"/shop/api/pom.xml":               "<project></project>",
+		"/shop/services/pom.xml":          "<project><modules><module>billing</module></modules></project>",
+		"/shop/services/billing/pom.xml":  "<project></project>",
+		"/shop/services/billing/src/a.go": "",
+	})
+
+	workspace, err := DetectWorkspace("/shop/services/billing/src", fs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/shop", workspace.Root)
+	assert.Equal(t, Maven, workspace.BuildTool)
+	assert.Equal(t, []string{"api", "services", "services/billing"}, workspace.ModuleNames())
+
+	module := workspace.ModuleFor("/shop/services/billing/src")
+	require.NotNil(t, module)
+	assert.Equal(t, "services/billing", module.Name)
+	assert.Equal(t, "/shop/services/billing/pom.xml", module.BuildFile)
+}
+
+func TestDetectWorkspaceGradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/", map[string]string{
+		"/shop/settings.gradle.kts":           "include(\"api\", \":services:billing\", \"web\")\nproject(\":web\").projectDir = file(\"frontend\")\n",
+		"/shop/api/build.gradle.kts":          "",
+		"/shop/services/billing/build.gradle": "",
+		"/shop/frontend/build.gradle.kts":     "",
+	})
+
+	workspace, err := DetectWorkspace("/shop", fs)
+	require.NoError(t, err)
+
+	assert.Equal(t, GradleKotln, workspace.BuildTool)
+	assert.True(t, workspace.IsMultiModule())
+
+	web, err := workspace.Find("web")
+	require.NoError(t, err)
+	assert.Equal(t, "/shop/frontend", web.Dir)
+
+	billing, err := workspace.Find(":services:billing")
+	require.NoError(t, err)
+	assert.Equal(t, Gradle, billing.BuildTool)
+}
+
+func TestDetectWorkspaceSingleModule(t *testing.T) {
+	fs := testutil.MemFs(t, "/", map[string]string{"/app/pom.xml": "<project></project>"})
+
+	workspace, err := DetectWorkspace("/app", fs)
+	require.NoError(t, err)
+	assert.False(t, workspace.IsMultiModule())
+
+	_, err = workspace.Find("api")
+	assert.ErrorContains(t, err, "not a multi-module project")
+}
+
+func TestWorkspaceFind(t *testing.T) {
+	workspace := &Workspace{
+		Root: "/shop",
+		Modules: []Module{
+			{Name: "api", Dir: "/shop/api"},
+			{Name: "services/api", Dir: "/shop/services/api"},
+			{Name: "services/billing", Dir: "/shop/services/billing"},
+		},
+	}
+
+	module, err := workspace.Find("billing")
+	require.NoError(t, err)
+	assert.Equal(t, "services/billing", module.Name)
+
+	module, err = workspace.Find("api")
+	require.NoError(t, err)
+	assert.Equal(t, "api", module.Name)
+
+	module, err = workspace.Find(":services:api")
+	require.NoError(t, err)
+	assert.Equal(t, "services/api", module.Name)
+
+	_, err = workspace.Find("web")
+	assert.ErrorContains(t, err, "available: api, services/api, services/billing")
+}
+
+func TestWorkspaceFindAmbiguous(t *testing.T) {
+	workspace := &Workspace{
+		Root: "/shop",
+		Modules: []Module{
+			{Name: "orders/api", Dir: "/shop/orders/api"},
+			{Name: "users/api", Dir: "/shop/users/api"},
+		},
+	}
+
+	_, err := workspace.Find("api")
+	assert.ErrorContains(t, err, "ambiguous")
+}
+
+func TestWorkspaceSourceModules(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/shop/api/src/main/kotlin", 0755))
+	require.NoError(t, fs.MkdirAll("/shop/bom", 0755))
+
+	workspace := &Workspace{
+		Root: "/shop",
+		Modules: []Module{
+			{Name: "api", Dir: "/shop/api"},
+			{Name: "bom", Dir: "/shop/bom"},
+		},
+	}
+
+	modules := workspace.SourceModules(fs)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "api", modules[0].Name)
+}
+
+func TestModuleDir(t *testing.T) {
+	fs := testutil.MemFs(t, "/", map[string]string{
+		"/shop/pom.xml":     "<project><modules><module>api</module><module>web</module></modules></project>",
+		"/shop/api/pom.xml": "<project></project>",
+		"/shop/web/pom.xml": "<project></project>",
+	})
+
+	dir, err := ModuleDir(fs, "/shop", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/shop", dir)
+
+	dir, err = ModuleDir(fs, "/shop/web", "api")
+	require.NoError(t, err)
+	assert.Equal(t, "/shop/api", dir)
+
+	_, err = ModuleDir(fs, "/shop", "billing")
+	assert.ErrorContains(t, err, "available: api, web")
+}
+
+func TestSourceModuleDir(t *testing.T) {
+	fs := testutil.MemFs(t, "/", map[string]string{
+		"/shop/pom.xml":     "<project><modules><module>api</module><module>bom</module></modules></project>",
+		"/shop/api/pom.xml": "<project></project>",
+		"/shop/api/src/main/java/com/shop/App.java": "package com.shop;",
+		"/shop/bom/pom.xml":                         "<project></project>",
+		"/single/pom.xml":                           "<project></project>",
+	})
+
+	dir, err := SourceModuleDir(fs, "/shop", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/shop/api", dir)
+
+	dir, err = SourceModuleDir(fs, "/shop/bom", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/shop/bom", dir)
+
+	dir, err = SourceModuleDir(fs, "/single", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/single", dir)
+
+	require.NoError(t, afero.WriteFile(fs, "/shop/bom/src/main/java/com/shop/Bom.java", []byte("package com.shop;"), 0644))
+	_, err = SourceModuleDir(fs, "/shop", "")
+	assert.ErrorContains(t, err, "choose a module with --module")
+}
+
+func TestRelativePath(t *testing.T) {
+	assert.Equal(t, "src/main/java/App.java", RelativePath("/shop", "/shop/src/main/java/App.java"))
+	assert.Equal(t, "../web/pom.xml", RelativePath("/shop/api", "/shop/web/pom.xml"))
+	assert.Equal(t, "pom.xml", RelativePath("/shop", "pom.xml"))
+}
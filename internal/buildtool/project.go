@@ -0,0 +1,187 @@
+package buildtool
+
+func (p *Project) Property(key string) (string, bool) {
+	for _, prop := range p.Properties {
+		if prop.Key == key {
+			return prop.Value, true
+		}
+	}
+	return "", false
+}
+
+func (p *Project) SetProperty(key, value string) {
+	for i, prop := range p.Properties {
+		if prop.Key == key {
+			p.Properties[i].Value = value
+			return
+		}
+	}
+	p.Properties = append(p.Properties, Property{Key: key, Value: value})
+}
+
+func (p *Project) RemoveProperty(key string) bool {
+	for i, prop := range p.Properties {
+		if prop.Key == key {
+			p.Properties = append(p.Properties[:i], p.Properties[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Project) Repository(id string) (Repository, bool) {
+	for _, repo := range p.Repositories {
+		if repo.ID == id || repo.URL == id {
+			return repo, true
+		}
+	}
+	return Repository{}, false
+}
+
+func (p *Project) AddRepository(repo Repository) bool {
+	for _, existing := range p.Repositories {
+		if existing.Plugin == repo.Plugin && (existing.ID == repo.ID || existing.URL == repo.URL) {
+			return false
+		}
+	}
+	p.Repositories = append(p.Repositories, repo)
+	return true
+}
+
+func (p *Project) RemoveRepository(id string) bool {
+	found := false
+	var repos []Repository
+	for _, repo := range p.Repositories {
+		if repo.ID == id || repo.URL == id {
+			found = true
+			continue
+		}
+		repos = append(repos, repo)
+	}
+	p.Repositories = repos
+	return found
+}
+
+func (p *Project) ManagedDependency(groupId, artifactId string) (Dependency, bool) {
+	for _, dep := range p.ManagedDependencies {
+		if dep.GroupId == groupId && dep.ArtifactId == artifactId {
+			return dep, true
+		}
+	}
+	return Dependency{}, false
+}
+
+func (p *Project) AddManagedDependency(dep Dependency) bool {
+	if _, exists := p.ManagedDependency(dep.GroupId, dep.ArtifactId); exists {
+		return false
+	}
+	p.ManagedDependencies = append(p.ManagedDependencies, dep)
+	return true
+}
+
+func (p *Project) RemoveManagedDependency(groupId, artifactId string) bool {
+	found := false
+	var deps []Dependency
+	for _, dep := range p.ManagedDependencies {
+		if dep.GroupId == groupId && dep.ArtifactId == artifactId {
+			found = true
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	p.ManagedDependencies = deps
+	return found
+}
+
+func (p *Project) Profile(id string) *Profile {
+	for i := range p.Profiles {
+		if p.Profiles[i].ID == id {
+			return &p.Profiles[i]
+		}
+	}
+	return nil
+}
+
+func (p *Project) AddProfileDependency(profile string, dep Dependency) bool {
+	target := p.Profile(profile)
+	if target == nil {
+		p.Profiles = append(p.Profiles, Profile{ID: profile})
+		target = &p.Profiles[len(p.Profiles)-1]
+	}
+	for _, existing := range target.Dependencies {
+		if existing.GroupId == dep.GroupId && existing.ArtifactId == dep.ArtifactId {
+			return false
+		}
+	}
+	target.Dependencies = append(target.Dependencies, dep)
+	return true
+}
+
+func (p *Project) RemoveProfileDependency(profile, groupId, artifactId string) bool {
+	target := p.Profile(profile)
+	if target == nil {
+		return false
+	}
+	found := false
+	var deps []Dependency
+	for _, dep := range target.Dependencies {
+		if dep.GroupId == groupId && dep.ArtifactId == artifactId {
+			found = true
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	target.Dependencies = deps
+	return found
+}
+
+const defaultMavenPluginGroup = "org.apache.maven.plugins"
+
+func (p Plugin) Key() string {
+	if p.ArtifactId == "" {
+		return p.ID
+	}
+	group := p.GroupId
+	if group == "" {
+		group = defaultMavenPluginGroup
+	}
+	return group + ":" + p.ArtifactId
+}
+
+func (p Plugin) Matches(other Plugin) bool {
+	if p.ID != "" && p.ID == other.ID {
+		return true
+	}
+	return p.ArtifactId != "" && p.Key() == other.Key()
+}
+
+func (p *Project) Plugin(plugin Plugin) (Plugin, bool) {
+	for _, existing := range p.Plugins {
+		if existing.Matches(plugin) {
+			return existing, true
+		}
+	}
+	return Plugin{}, false
+}
+
+func (p *Project) AddPlugin(plugin Plugin) bool {
+	if _, exists := p.Plugin(plugin); exists {
+		return false
+	}
+	p.Plugins = append(p.Plugins, plugin)
+	return true
+}
+
+func (p *Project) RemovePlugin(plugin Plugin) bool {
+	found := false
+	var plugins []Plugin
+	for _, existing := range p.Plugins {
+		if existing.Matches(plugin) {
+			found = true
+			continue
+		}
+		plugins = append(plugins, existing)
+	}
+	p.Plugins = plugins
+	return found
+}
@@ -18,20 +18,65 @@ type Dependency struct {
 	Optional   bool
 	Type       string
 	Classifier string
+	Exclusions []Exclusion
+}
+
+type Exclusion struct {
+	GroupId    string
+	ArtifactId string
+}
+
+type Property struct {
+	Key   string
+	Value string
+}
+
+type Repository struct {
+	ID     string
+	Name   string
+	URL    string
+	Plugin bool
+}
+
+type Profile struct {
+	ID           string
+	Dependencies []Dependency
+}
+
+type Plugin struct {
+	ID            string
+	GroupId       string
+	ArtifactId    string
+	Version       string
+	Configuration []Setting
+	Script        string
+	KotlinScript  string
+}
+
+type Setting struct {
+	Name     string
+	Value    string
+	Children []Setting
 }
 
 type Project struct {
-	GroupId           string
-	ArtifactId        string
-	Version           string
-	Name              string
-	Description       string
-	JavaVersion       string
-	SpringBootVersion string
-	Packaging         string
-	Dependencies      []Dependency
-	BuildTool         Type
-	Raw               any
+	GroupId             string
+	ArtifactId          string
+	Version             string
+	Name                string
+	Description         string
+	JavaVersion         string
+	SpringBootVersion   string
+	Packaging           string
+	Dependencies        []Dependency
+	ManagedDependencies []Dependency
+	Properties          []Property
+	Repositories        []Repository
+	Profiles            []Profile
+	Plugins             []Plugin
+	Files               []string
+	BuildTool           Type
+	Raw                 any
 }
 
 type Parser interface {
@@ -46,8 +91,29 @@ type Parser interface {
 	GetDependencies(project *Project) []Dependency
 	GetDependency(project *Project, groupId, artifactId string) *Dependency
 
+	GetProperties(project *Project) []Property
+	GetProperty(project *Project, key string) (string, bool)
+	SetProperty(project *Project, key, value string)
+	RemoveProperty(project *Project, key string) bool
+
+	GetRepositories(project *Project) []Repository
+	AddRepository(project *Project, repo Repository) bool
+	RemoveRepository(project *Project, id string) bool
+
+	GetManagedDependencies(project *Project) []Dependency
+	AddManagedDependency(project *Project, dep Dependency) bool
+	RemoveManagedDependency(project *Project, groupId, artifactId string) bool
+
+	AddProfileDependency(project *Project, profile string, dep Dependency) bool
+	RemoveProfileDependency(project *Project, profile, groupId, artifactId string) bool
+
+	GetPlugins(project *Project) []Plugin
+	AddPlugin(project *Project, plugin Plugin) bool
+	RemovePlugin(project *Project, plugin Plugin) bool
+
 	GetJavaVersion(project *Project) string
 	GetSpringBootVersion(project *Project) string
+	SetSpringBootVersion(project *Project, version string) bool
 	GetBasePackage(project *Project) string
 
 	HasLombok(project *Project) bool
@@ -3,10 +3,12 @@ package add
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/KashifKhn/haft/internal/buildtool"
 	_ "github.com/KashifKhn/haft/internal/gradle"
+	"github.com/KashifKhn/haft/internal/hooks"
 	"github.com/KashifKhn/haft/internal/logger"
 	_ "github.com/KashifKhn/haft/internal/maven"
 	"github.com/KashifKhn/haft/internal/output"
@@ -28,6 +30,11 @@ The add command modifies your build file (pom.xml or build.gradle) to add new de
   - Shortcuts: haft add lombok, haft add jpa
   - Maven coordinates: haft add org.example:my-lib
   - With version: haft add org.example:my-lib:1.0.0
+  - BOMs: haft add --bom spring-cloud
+
+Catalog entries that need a BOM, such as feign, import it automatically,
+picking the release that matches the project's Spring Boot version.
+Versions managed by an imported BOM are left out of the dependency.
 
 Dependencies are auto-detected from the catalog or verified against Maven Central.`,
 		Example: `  # Interactive search picker
@@ -46,6 +53,16 @@ Dependencies are auto-detected from the catalog or verified against Maven Centra
   # Add with specific scope
   haft add h2 --scope test
 
+  # Add to the dev profile (Maven) or source set (Gradle)
+  haft add h2 --profile dev
+
+  # Import a BOM into dependencyManagement (Maven) or as a platform (Gradle)
+  haft add --bom spring-cloud
+  haft add --bom software.amazon.awssdk:bom:2.29.0
+
+  # Add to a module of a multi-module project
+  haft add jpa --module api
+
   # List available shortcuts
   haft add --list
 
@@ -57,8 +74,11 @@ Dependencies are auto-detected from the catalog or verified against Maven Centra
 
 	cmd.Flags().String("scope", "", "Dependency scope (compile, runtime, test, provided)")
 	cmd.Flags().String("version", "", "Override dependency version")
+	cmd.Flags().String("profile", "", "Add to a Maven profile or Gradle source set")
+	cmd.Flags().Bool("bom", false, "Import the arguments as BOMs (dependencyManagement or platform)")
 	cmd.Flags().Bool("list", false, "List available dependency shortcuts")
 	cmd.Flags().BoolP("browse", "b", false, "Browse dependencies by category")
+	cmd.Flags().String("module", "", "Target module in a multi-module project")
 	cmd.Flags().Bool("no-interactive", false, "Skip interactive picker (requires dependency argument)")
 	cmd.Flags().Bool("json", false, "Output as JSON (use with --list)")
 
@@ -96,6 +116,8 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return runBrowser(cmd)
 	}
 
+	fs := afero.NewOsFs()
+	moduleName, _ := cmd.Flags().GetString("module")
 	cwd, err := os.Getwd()
 	if err != nil {
 		if jsonFlag {
@@ -104,7 +126,14 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fs := afero.NewOsFs()
+	cwd, err = buildtool.ModuleDir(fs, cwd, moduleName)
+	if err != nil {
+		if jsonFlag {
+			return output.Error("MODULE_NOT_FOUND", "could not select module", err.Error())
+		}
+		return err
+	}
+
 	result, err := buildtool.Detect(cwd, fs)
 	if err != nil {
 		if jsonFlag {
@@ -123,10 +152,42 @@ func runAdd(cmd *cobra.Command, args []string) error {
 
 	scopeOverride, _ := cmd.Flags().GetString("scope")
 	versionOverride, _ := cmd.Flags().GetString("version")
+	profile, _ := cmd.Flags().GetString("profile")
+	bomFlag, _ := cmd.Flags().GetBool("bom")
 
 	var added, skipped, errors []string
 
+	if bomFlag && profile != "" {
+		if jsonFlag {
+			return output.Error("INVALID_FLAGS", "--bom cannot be combined with --profile")
+		}
+		return fmt.Errorf("--bom cannot be combined with --profile")
+	}
+
 	for _, arg := range args {
+		if bomFlag {
+			bom, property, err := resolveBom(arg, project.SpringBootVersion)
+			if err != nil {
+				if !jsonFlag {
+					log.Error("Invalid BOM", "input", arg, "error", err.Error())
+				}
+				errors = append(errors, fmt.Sprintf("%s: %s", arg, err.Error()))
+				continue
+			}
+			if !addBom(result.Parser, project, bom, property) {
+				if !jsonFlag {
+					log.Warning("Skipped (already imported)", "bom", formatDependency(bom))
+				}
+				skipped = append(skipped, formatDependency(bom))
+				continue
+			}
+			if !jsonFlag {
+				log.Success("Added", "bom", formatDependency(bom))
+			}
+			added = append(added, formatDependency(bom))
+			continue
+		}
+
 		deps, entryName, err := resolveDependency(arg)
 		if err != nil {
 			if !jsonFlag {
@@ -136,15 +197,29 @@ func runAdd(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		if entry, ok := GetCatalogEntry(arg); ok && entry.Bom != "" {
+			bomEntry, _ := GetBomEntry(entry.Bom)
+			bom := bomEntry.Dependency(project.SpringBootVersion)
+			if addBom(result.Parser, project, bom, bomEntry.Property) {
+				if !jsonFlag {
+					log.Success("Added", "bom", formatDependency(bom))
+				}
+				added = append(added, formatDependency(bom))
+			}
+		}
+
 		for _, dep := range deps {
 			if scopeOverride != "" {
 				dep.Scope = scopeOverride
 			}
+			if strings.Count(arg, ":") != 2 && managedByBom(result.Parser.GetManagedDependencies(project), dep) {
+				dep.Version = ""
+			}
 			if versionOverride != "" {
 				dep.Version = versionOverride
 			}
 
-			if result.Parser.HasDependency(project, dep.GroupId, dep.ArtifactId) {
+			if !addDependency(result.Parser, project, profile, dep) {
 				if !jsonFlag {
 					log.Warning("Skipped (already exists)", "dependency", formatDependency(dep))
 				}
@@ -152,7 +227,6 @@ func runAdd(cmd *cobra.Command, args []string) error {
 				continue
 			}
 
-			result.Parser.AddDependency(project, dep)
 			if !jsonFlag {
 				if entryName != "" {
 					log.Success("Added", "dependency", entryName, "artifact", dep.ArtifactId)
@@ -179,6 +253,17 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	files := append([]string{result.FilePath}, project.Files...)
+	rollback := hooks.NewRollback(fs, cwd)
+	for _, file := range files {
+		if err := rollback.TrackModified(file); err != nil {
+			if jsonFlag {
+				return output.Error("READ_ERROR", fmt.Sprintf("could not read %s", file), err.Error())
+			}
+			return err
+		}
+	}
+
 	if err := result.Parser.Write(result.FilePath, project); err != nil {
 		if jsonFlag {
 			return output.Error("WRITE_ERROR", fmt.Sprintf("could not write %s", result.FilePath), err.Error())
@@ -186,17 +271,45 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not write %s: %w", result.FilePath, err)
 	}
 
+	if !jsonFlag {
+		log.Success(fmt.Sprintf("Added %d dependencies to %s", len(added), buildtool.GetBuildFileName(result.BuildTool)))
+	}
+
+	var changed []string
+	for _, file := range files {
+		if rel, err := filepath.Rel(cwd, file); err == nil {
+			file = rel
+		}
+		changed = append(changed, file)
+	}
+	report, hookErr := hooks.Execute(fs, cwd, hooks.PostAdd, changed, rollback, jsonFlag)
+
 	if jsonFlag {
-		return output.Success(output.AddRemoveResult{
+		addResult := output.AddRemoveResult{
 			Action:  "add",
 			Added:   added,
 			Skipped: skipped,
 			Errors:  errors,
-		})
+			Hooks:   report.ToOutput(),
+		}
+		if hookErr != nil {
+			return output.ErrorWithData("HOOK_FAILED", hookErr.Error(), addResult)
+		}
+		return output.Success(addResult)
 	}
 
-	log.Success(fmt.Sprintf("Added %d dependencies to %s", len(added), buildtool.GetBuildFileName(result.BuildTool)))
-	return nil
+	return hookErr
+}
+
+func addDependency(parser buildtool.Parser, project *buildtool.Project, profile string, dep buildtool.Dependency) bool {
+	if profile != "" {
+		return parser.AddProfileDependency(project, profile, dep)
+	}
+	if parser.HasDependency(project, dep.GroupId, dep.ArtifactId) {
+		return false
+	}
+	parser.AddDependency(project, dep)
+	return true
 }
 
 func runInteractivePicker(cmd *cobra.Command) error {
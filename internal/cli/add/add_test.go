@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/maven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -250,6 +251,10 @@ func TestNewCommandHasFlags(t *testing.T) {
 	assert.NotNil(t, versionFlag)
 	assert.Equal(t, "string", versionFlag.Value.Type())
 
+	profileFlag := cmd.Flag("profile")
+	assert.NotNil(t, profileFlag)
+	assert.Equal(t, "string", profileFlag.Value.Type())
+
 	listFlag := cmd.Flag("list")
 	assert.NotNil(t, listFlag)
 	assert.Equal(t, "bool", listFlag.Value.Type())
@@ -261,6 +266,85 @@ func TestNewCommandHasFlags(t *testing.T) {
 	noInteractiveFlag := cmd.Flag("no-interactive")
 	assert.NotNil(t, noInteractiveFlag)
 	assert.Equal(t, "bool", noInteractiveFlag.Value.Type())
+
+	bomFlag := cmd.Flag("bom")
+	assert.NotNil(t, bomFlag)
+	assert.Equal(t, "bool", bomFlag.Value.Type())
+}
+
+func TestAddDependencyToProfile(t *testing.T) {
+	parser := maven.NewParser()
+	project := &buildtool.Project{
+		Dependencies: []buildtool.Dependency{{GroupId: "com.h2database", ArtifactId: "h2"}},
+	}
+	h2 := buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2", Scope: "runtime"}
+
+	assert.False(t, addDependency(parser, project, "", h2))
+	assert.True(t, addDependency(parser, project, "dev", h2))
+	assert.False(t, addDependency(parser, project, "dev", h2))
+
+	require.Len(t, project.Profiles, 1)
+	assert.Equal(t, "dev", project.Profiles[0].ID)
+	assert.Len(t, project.Dependencies, 1)
+}
+
+func TestCatalogBomsExist(t *testing.T) {
+	for alias, entry := range dependencyCatalog {
+		if entry.Bom == "" {
+			continue
+		}
+		_, ok := GetBomEntry(entry.Bom)
+		assert.True(t, ok, "entry %s references unknown BOM %s", alias, entry.Bom)
+	}
+}
+
+func TestBomEntryDependency(t *testing.T) {
+	cloud, ok := GetBomEntry("spring-cloud")
+	require.True(t, ok)
+
+	tests := []struct {
+		boot     string
+		expected string
+	}{
+		{"3.4.1", "2024.0.1"},
+		{"3.3.7", "2023.0.5"},
+		{"3.0.0", "2022.0.5"},
+		{"", "2024.0.1"},
+		{"9.9.0", "2024.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.boot, func(t *testing.T) {
+			dep := cloud.Dependency(tt.boot)
+			assert.Equal(t, tt.expected, dep.Version)
+			assert.Equal(t, "pom", dep.Type)
+			assert.Equal(t, "import", dep.Scope)
+		})
+	}
+}
+
+func TestAddBom(t *testing.T) {
+	parser := maven.NewParser()
+	project := &buildtool.Project{BuildTool: buildtool.Maven}
+	cloud, _ := GetBomEntry("spring-cloud")
+	bom := cloud.Dependency("3.3.0")
+
+	assert.True(t, addBom(parser, project, bom, cloud.Property))
+	assert.False(t, addBom(parser, project, bom, cloud.Property))
+
+	require.Len(t, project.ManagedDependencies, 1)
+	assert.Equal(t, "${spring-cloud.version}", project.ManagedDependencies[0].Version)
+	version, ok := project.Property("spring-cloud.version")
+	assert.True(t, ok)
+	assert.Equal(t, "2023.0.5", version)
+
+	assert.True(t, managedByBom(project.ManagedDependencies, buildtool.Dependency{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-openfeign"}))
+	assert.False(t, managedByBom(project.ManagedDependencies, buildtool.Dependency{GroupId: "software.amazon.awssdk", ArtifactId: "s3"}))
+	assert.False(t, managedByBom(project.ManagedDependencies, buildtool.Dependency{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-dataflow-rest-client"}))
+}
+
+func TestResolveBom_Unknown(t *testing.T) {
+	_, _, err := resolveBom("spring-clod", "3.4.1")
+	assert.ErrorContains(t, err, "unknown BOM 'spring-clod'")
 }
 
 func TestNewMavenClient(t *testing.T) {
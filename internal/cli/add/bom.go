@@ -0,0 +1,139 @@
+package add
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+type BomEntry struct {
+	Name         string
+	Description  string
+	Bom          buildtool.Dependency
+	Property     string
+	BootVersions map[string]string
+}
+
+var bomCatalog = map[string]BomEntry{
+	"spring-cloud": {
+		Name:        "Spring Cloud",
+		Description: "Spring Cloud release train matching the Spring Boot version",
+		Bom:         buildtool.Dependency{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-dependencies", Version: "2024.0.1"},
+		Property:    "spring-cloud.version",
+		BootVersions: map[string]string{
+			"2.6": "2021.0.9",
+			"2.7": "2021.0.9",
+			"3.0": "2022.0.5",
+			"3.1": "2022.0.5",
+			"3.2": "2023.0.5",
+			"3.3": "2023.0.5",
+			"3.4": "2024.0.1",
+			"3.5": "2025.0.0",
+		},
+	},
+	"testcontainers": {
+		Name:        "Testcontainers",
+		Description: "Testcontainers modules",
+		Bom:         buildtool.Dependency{GroupId: "org.testcontainers", ArtifactId: "testcontainers-bom", Version: "1.20.4"},
+		Property:    "testcontainers.version",
+	},
+	"aws-sdk": {
+		Name:        "AWS SDK",
+		Description: "AWS SDK for Java 2.x service clients",
+		Bom:         buildtool.Dependency{GroupId: "software.amazon.awssdk", ArtifactId: "bom", Version: "2.25.0"},
+		Property:    "aws-sdk.version",
+	},
+}
+
+func GetBomEntry(shortcut string) (BomEntry, bool) {
+	entry, ok := bomCatalog[shortcut]
+	return entry, ok
+}
+
+func GetAllBoms() []string {
+	shortcuts := make([]string, 0, len(bomCatalog))
+	for shortcut := range bomCatalog {
+		shortcuts = append(shortcuts, shortcut)
+	}
+	sort.Strings(shortcuts)
+	return shortcuts
+}
+
+func (e BomEntry) Dependency(bootVersion string) buildtool.Dependency {
+	dep := e.Bom
+	dep.Type, dep.Scope = "pom", "import"
+	if version, ok := e.BootVersions[minorVersion(bootVersion)]; ok {
+		dep.Version = version
+	}
+	return dep
+}
+
+func minorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "." + parts[1]
+}
+
+func resolveBom(input, bootVersion string) (buildtool.Dependency, string, error) {
+	if entry, ok := GetBomEntry(input); ok {
+		return entry.Dependency(bootVersion), entry.Property, nil
+	}
+
+	parts := strings.Split(input, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return buildtool.Dependency{}, "", fmt.Errorf("unknown BOM '%s'. Use one of %s or specify as groupId:artifactId[:version]", input, strings.Join(GetAllBoms(), ", "))
+	}
+	version := ""
+	if len(parts) == 3 {
+		version = parts[2]
+	}
+	deps, _, err := verifyAndResolve(parts[0], parts[1], version)
+	if err != nil {
+		return buildtool.Dependency{}, "", err
+	}
+	dep := deps[0]
+	if dep.Version == "" {
+		return buildtool.Dependency{}, "", fmt.Errorf("BOM '%s' needs a version", input)
+	}
+	dep.Type, dep.Scope = "pom", "import"
+	return dep, "", nil
+}
+
+func addBom(parser buildtool.Parser, project *buildtool.Project, bom buildtool.Dependency, property string) bool {
+	if _, exists := project.ManagedDependency(bom.GroupId, bom.ArtifactId); exists {
+		return false
+	}
+	if property != "" && project.BuildTool == buildtool.Maven {
+		parser.SetProperty(project, property, bom.Version)
+		bom.Version = "${" + property + "}"
+	}
+	return parser.AddManagedDependency(project, bom)
+}
+
+func managedByBom(managed []buildtool.Dependency, dep buildtool.Dependency) bool {
+	for _, entry := range dependencyCatalog {
+		if entry.Bom == "" || !containsArtifact(entry.Dependencies, dep) {
+			continue
+		}
+		bom := bomCatalog[entry.Bom].Bom
+		for _, m := range managed {
+			if m.GroupId == bom.GroupId && m.ArtifactId == bom.ArtifactId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsArtifact(deps []buildtool.Dependency, dep buildtool.Dependency) bool {
+	for _, d := range deps {
+		if d.GroupId == dep.GroupId && d.ArtifactId == dep.ArtifactId {
+			return true
+		}
+	}
+	return false
+}
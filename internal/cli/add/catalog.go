@@ -6,6 +6,7 @@ type CatalogEntry struct {
 	Name         string
 	Description  string
 	Category     string
+	Bom          string
 	Dependencies []buildtool.Dependency
 }
 
@@ -330,6 +331,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud OpenFeign",
 		Description: "Declarative REST client",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-openfeign"},
 		},
@@ -530,32 +532,36 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "AWS S3",
 		Description: "Amazon S3 file storage",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "s3", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "s3"},
 		},
 	},
 	"aws-sqs": {
 		Name:        "AWS SQS",
 		Description: "Amazon Simple Queue Service",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "sqs", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "sqs"},
 		},
 	},
 	"aws-ses": {
 		Name:        "AWS SES",
 		Description: "Amazon Simple Email Service",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "ses", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "ses"},
 		},
 	},
 	"aws-dynamodb": {
 		Name:        "AWS DynamoDB",
 		Description: "Amazon DynamoDB NoSQL database",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "dynamodb-enhanced", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "dynamodb-enhanced"},
 		},
 	},
 
@@ -911,8 +917,9 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "AWS SNS",
 		Description: "Amazon Simple Notification Service",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "sns", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "sns"},
 		},
 	},
 	"aws-lambda": {
@@ -928,32 +935,36 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "AWS Cognito",
 		Description: "Amazon Cognito user authentication",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "cognitoidentityprovider", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "cognitoidentityprovider"},
 		},
 	},
 	"aws-secretsmanager": {
 		Name:        "AWS Secrets Manager",
 		Description: "Amazon Secrets Manager",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "secretsmanager", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "secretsmanager"},
 		},
 	},
 	"aws-cloudwatch": {
 		Name:        "AWS CloudWatch",
 		Description: "Amazon CloudWatch monitoring",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "cloudwatch", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "cloudwatch"},
 		},
 	},
 	"aws-kinesis": {
 		Name:        "AWS Kinesis",
 		Description: "Amazon Kinesis data streaming",
 		Category:    "Cloud",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "kinesis", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "kinesis"},
 		},
 	},
 
@@ -1140,8 +1151,9 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "DynamoDB Enhanced",
 		Description: "DynamoDB enhanced client with mapping",
 		Category:    "NoSQL",
+		Bom:         "aws-sdk",
 		Dependencies: []buildtool.Dependency{
-			{GroupId: "software.amazon.awssdk", ArtifactId: "dynamodb-enhanced", Version: "2.25.0"},
+			{GroupId: "software.amazon.awssdk", ArtifactId: "dynamodb-enhanced"},
 		},
 	},
 	"arangodb": {
@@ -1720,6 +1732,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud Gateway",
 		Description: "API Gateway for microservices",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-gateway"},
 		},
@@ -1728,6 +1741,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Eureka Client",
 		Description: "Service discovery with Eureka",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-netflix-eureka-client"},
 		},
@@ -1736,6 +1750,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Consul",
 		Description: "Service discovery with Consul",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-consul-discovery"},
 		},
@@ -1744,6 +1759,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Config Server",
 		Description: "Centralized configuration",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-config-server"},
 		},
@@ -1752,6 +1768,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Config Client",
 		Description: "Config server client",
 		Category:    "Web",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-config"},
 		},
@@ -1760,6 +1777,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Vault",
 		Description: "HashiCorp Vault integration",
 		Category:    "Security",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-vault-config"},
 		},
@@ -2264,6 +2282,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud Stream",
 		Description: "Event-driven framework (Kafka/RabbitMQ abstraction)",
 		Category:    "Microservices",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-stream-kafka"},
 		},
@@ -2272,6 +2291,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud Bus",
 		Description: "Broadcasts state changes across nodes",
 		Category:    "Microservices",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-bus-amqp"},
 		},
@@ -2280,6 +2300,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud Function",
 		Description: "Write once, run as Web/Lambda/Serverless",
 		Category:    "Microservices",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-function-web"},
 		},
@@ -2373,6 +2394,7 @@ var dependencyCatalog = map[string]CatalogEntry{
 		Name:        "Spring Cloud Task",
 		Description: "Short-lived microservices (ephemeral tasks)",
 		Category:    "Integration",
+		Bom:         "spring-cloud",
 		Dependencies: []buildtool.Dependency{
 			{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-task"},
 		},
@@ -0,0 +1,33 @@
+package arch
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:   "arch",
+		Short: "Enforce the project's architecture",
+		Long: `Check that the code follows the architecture Haft detected.
+
+Rules are derived from the detected architecture (layered, feature,
+hexagonal, clean or modular) and evaluated against the imports of every
+source file. Custom rules can be added in .haft.json.`,
+		Example: `  # Check imports against the architecture rules
+  haft arch check
+
+  # Report violations as SARIF for code scanning
+  haft arch check --sarif > arch.sarif
+
+  # Check one module of a multi-module project
+  haft arch check --module api`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newCheckCommand())
+
+	return cmd
+}
@@ -0,0 +1,68 @@
+package arch
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func layeredProject() map[string]string {
+	return map[string]string{
+		"src/main/java/com/example/app/controller/UserController.java": "package com.example.app.controller;\n\nimport com.example.app.repository.UserRepository;\n\n@RestController\npublic class UserController {\n}",
+		"src/main/java/com/example/app/service/UserService.java":       "package com.example.app.service;\n\nimport com.example.app.repository.UserRepository;\n\n@Service\npublic class UserService {\n}",
+		"src/main/java/com/example/app/repository/UserRepository.java": "package com.example.app.repository;\n\n@Repository\npublic interface UserRepository {\n}",
+		"src/main/java/com/example/app/entity/User.java":               "package com.example.app.entity;\n\n@Entity\npublic class User {\n}",
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "arch", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("module"))
+
+	check, _, err := cmd.Find([]string{"check"})
+	require.NoError(t, err)
+	assert.NotNil(t, check.Flags().Lookup("json"))
+	assert.NotNil(t, check.Flags().Lookup("sarif"))
+}
+
+func TestCheckProjectReportsViolations(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", layeredProject())
+
+	report, err := CheckProject(fs, "/project")
+	require.NoError(t, err)
+
+	assert.Equal(t, "layered", string(report.Architecture))
+	require.Len(t, report.Violations, 1)
+	violation := report.Violations[0]
+	assert.Equal(t, "controller-no-repository", violation.Rule)
+	assert.Equal(t, "src/main/java/com/example/app/controller/UserController.java", violation.File)
+	assert.Equal(t, 3, violation.Line)
+}
+
+func TestCheckProjectUsesCustomRules(t *testing.T) {
+	files := layeredProject()
+	files[".haft.json"] = `{"architecture": {"disabledRules": ["controller-no-repository"], "rules": [{"name": "service-no-repository", "from": ["..service.."], "forbid": ["..repository.."], "severity": "warning"}]}}`
+	fs := testutil.MemFs(t, "/project", files)
+
+	report, err := CheckProject(fs, "/project")
+	require.NoError(t, err)
+
+	require.Len(t, report.Violations, 1)
+	assert.Equal(t, "service-no-repository", report.Violations[0].Rule)
+	assert.Equal(t, 1, report.WarningCount)
+	assert.False(t, report.HasErrors())
+}
+
+func TestCheckProjectRejectsInvalidConfig(t *testing.T) {
+	files := layeredProject()
+	files[".haft.json"] = `{"architecture": {"rules": [{"name": "broken"}]}}`
+	fs := testutil.MemFs(t, "/project", files)
+
+	_, err := CheckProject(fs, "/project")
+	assert.ErrorContains(t, err, "invalid .haft.json")
+}
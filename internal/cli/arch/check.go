@@ -0,0 +1,222 @@
+package arch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/arch"
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	passedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+)
+
+func newCheckCommand() *cobra.Command {
+	var jsonOutput bool
+	var sarifOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check imports against the architecture rules",
+		Long: `Evaluate import rules for the detected architecture and report every
+violation with its file and line.
+
+Built-in rules per architecture:
+  layered, feature  controllers must not use repositories; lower layers
+                    must not depend on upper layers
+  hexagonal         the domain must not import Spring, JPA, application or
+                    adapter code; the application must not import adapters
+  clean             the domain must not import frameworks or outer layers;
+                    use cases must not import infrastructure or presentation
+  modular           modules must not use other modules' internal packages
+
+Add rules or disable built-in ones in .haft.json:
+
+  "architecture": {
+    "rules": [
+      {"name": "no-web-in-billing", "from": ["..billing.."], "forbid": ["..web.."]}
+    ],
+    "disabledRules": ["entity-no-upper-layers"]
+  }
+
+The command exits with a non-zero status when an error-level rule is
+violated, so it can gate CI builds.`,
+		Example: `  # Check the current project
+  haft arch check
+
+  # Output as JSON
+  haft arch check --json
+
+  # Output as SARIF
+  haft arch check --sarif > arch.sarif`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheck(cmd, jsonOutput, sarifOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	cmd.Flags().BoolVar(&sarifOutput, "sarif", false, "Output results as SARIF 2.1.0")
+
+	return cmd
+}
+
+func runCheck(cmd *cobra.Command, jsonOutput, sarifOutput bool) error {
+	machineOutput := jsonOutput || sarifOutput
+
+	fs := afero.NewOsFs()
+	dir, err := buildtool.WorkingDir()
+	if err != nil {
+		return checkError(machineOutput, err)
+	}
+
+	module, _ := cmd.Flags().GetString("module")
+	dir, err = buildtool.SourceModuleDir(fs, dir, module)
+	if err != nil {
+		return checkError(machineOutput, err)
+	}
+
+	report, err := CheckProject(fs, dir)
+	if err != nil {
+		return checkError(machineOutput, err)
+	}
+
+	switch {
+	case sarifOutput:
+		if err := output.JSON(arch.ToSarif(report, "")); err != nil {
+			return err
+		}
+	case jsonOutput && report.HasErrors():
+		if err := output.ErrorWithData("ARCH_VIOLATIONS", violationSummary(report), report); err != nil {
+			return err
+		}
+	case jsonOutput:
+		if err := output.Success(report); err != nil {
+			return err
+		}
+	default:
+		printReport(report)
+	}
+
+	if report.HasErrors() {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s", violationSummary(report))
+	}
+	return nil
+}
+
+func CheckProject(fs afero.Fs, dir string) (*arch.Report, error) {
+	d := detector.NewDetector(dir, detector.WithFileSystem(fs))
+	profile, err := d.Detect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect project profile: %w", err)
+	}
+
+	cache := detector.NewProfileCacheWithFs(fs, dir)
+	if previous, err := cache.Load(); err == nil {
+		profile.PreserveLocked(previous)
+	}
+
+	scan := d.LastScan()
+	logger.Default().Debug("Project scanned",
+		"files", scan.Stats.Files,
+		"parsed", scan.Stats.Parsed,
+		"cached", scan.Stats.Reused,
+		"architecture", string(profile.Architecture))
+
+	settings, err := loadArchSettings(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := arch.DefaultRules(profile.Architecture, profile.BasePackage, scan.SourceFiles)
+	rules, err := arch.ConfiguredRules(defaults, settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", config.ProjectConfigFile, err)
+	}
+
+	return arch.Check(dir, profile, scan.SourceFiles, rules), nil
+}
+
+func loadArchSettings(fs afero.Fs, dir string) (config.ArchSettings, error) {
+	manager := config.NewConfigManager(fs, dir, "")
+	if !manager.ProjectConfigExists() {
+		return config.ArchSettings{}, nil
+	}
+
+	cfg, err := manager.LoadProjectConfig()
+	if err != nil {
+		return config.ArchSettings{}, fmt.Errorf("failed to read %s: %w", config.ProjectConfigFile, err)
+	}
+	return cfg.Architecture, nil
+}
+
+func printReport(report *arch.Report) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Architecture Check"))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%s architecture, %d rules, %d files", report.Architecture, len(report.Rules), report.FilesChecked)))
+	fmt.Println()
+
+	if len(report.Rules) == 0 {
+		fmt.Println(labelStyle.Render("  No rules apply to this architecture. Add custom rules in " + config.ProjectConfigFile + "."))
+		fmt.Println()
+		return
+	}
+
+	if len(report.Violations) == 0 {
+		fmt.Println(passedStyle.Render("  ✓ No violations found"))
+		fmt.Println()
+		return
+	}
+
+	for _, violation := range report.Violations {
+		marker := errorStyle.Render("✗")
+		if violation.Severity == arch.SeverityWarning {
+			marker = warningStyle.Render("!")
+		}
+		fmt.Printf("  %s %s:%d %s\n", marker, violation.File, violation.Line, labelStyle.Render("["+violation.Rule+"]"))
+		fmt.Printf("    %s\n", violation.Message)
+	}
+	fmt.Println()
+	fmt.Println(violationSummary(report))
+	fmt.Println()
+}
+
+func violationSummary(report *arch.Report) string {
+	var parts []string
+	if report.ErrorCount > 0 {
+		parts = append(parts, plural(report.ErrorCount, "error"))
+	}
+	if report.WarningCount > 0 {
+		parts = append(parts, plural(report.WarningCount, "warning"))
+	}
+	if len(parts) == 0 {
+		return "architecture check passed"
+	}
+	return "architecture check found " + strings.Join(parts, " and ")
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func checkError(jsonOutput bool, err error) error {
+	if jsonOutput {
+		return output.Error("ARCH_CHECK_FAILED", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package boot
+
+import (
+	"fmt"
+
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valueStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	passedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:   "boot",
+		Short: "Spring Boot version tools",
+		Long: `Tools for the Spring Boot version of a project.
+
+The upgrade subcommand moves the project to a newer Spring Boot line and
+reports what still needs a manual look.`,
+		Example: `  # Upgrade to the latest known Spring Boot release
+  haft boot upgrade
+
+  # Upgrade to the latest 3.4 release
+  haft boot upgrade --to 3.4.x`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newUpgradeCommand())
+
+	return cmd
+}
+
+func printUpgrade(result output.BootUpgradeOutput) {
+	title := "Spring Boot Upgrade"
+	if result.DryRun {
+		title += " (dry run)"
+	}
+	fmt.Println()
+	fmt.Println(titleStyle.Render(title))
+	fmt.Printf("%s %s %s %s\n", labelStyle.Render(result.BuildFile), valueStyle.Render(result.From), labelStyle.Render("→"), valueStyle.Render(result.To))
+	fmt.Println()
+
+	if len(result.Changes) == 0 && len(result.Manual) == 0 {
+		fmt.Println(labelStyle.Render("  Nothing to change"))
+		fmt.Println()
+		return
+	}
+
+	if len(result.Changes) > 0 {
+		fmt.Println(headerStyle.Render("Changes:"))
+		for _, change := range result.Changes {
+			from := change.From
+			if change.Item != "property" {
+				from = change.Item + " " + from
+			}
+			fmt.Printf("  %s %s %s %s %s\n", passedStyle.Render("✓"), labelStyle.Render(change.File), from, labelStyle.Render("→"), change.To)
+		}
+		fmt.Println()
+	}
+
+	if len(result.Manual) > 0 {
+		fmt.Println(headerStyle.Render("Needs attention:"))
+		for _, issue := range result.Manual {
+			location := issue.File
+			if issue.Line > 0 {
+				location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+			}
+			fmt.Printf("  %s %s %s\n", warningStyle.Render("⚠"), warningStyle.Render(issue.Item), labelStyle.Render(location))
+			fmt.Printf("    %s\n", issue.Message)
+		}
+		fmt.Println()
+	}
+}
+
+func bootError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
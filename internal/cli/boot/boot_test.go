@@ -0,0 +1,247 @@
+package boot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-starter-parent</artifactId>
+        <version>2.7.18</version>
+    </parent>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+
+    <properties>
+        <java.version>11</java.version>
+        <spring-cloud.version>2021.0.9</spring-cloud.version>
+    </properties>
+
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.springframework.cloud</groupId>
+                <artifactId>spring-cloud-dependencies</artifactId>
+                <version>${spring-cloud.version}</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.flywaydb</groupId>
+            <artifactId>flyway-core</artifactId>
+            <version>8.5.13</version>
+        </dependency>
+        <dependency>
+            <groupId>org.springdoc</groupId>
+            <artifactId>springdoc-openapi-ui</artifactId>
+            <version>1.8.0</version>
+        </dependency>
+    </dependencies>
+</project>
+`
+
+const gradleBuild = `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.3.5'
+    id 'io.spring.dependency-management' version '1.1.7'
+}
+
+java {
+    sourceCompatibility = '21'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-actuator'
+}
+`
+
+const applicationYml = `spring:
+  application:
+    name: demo
+  redis:
+    host: localhost
+  sleuth:
+    enabled: true
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "boot", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	require.Len(t, cmd.Commands(), 1)
+
+	upgrade := cmd.Commands()[0]
+	assert.Equal(t, "upgrade", upgrade.Name())
+	for _, flag := range []string{"to", "dry-run", "json"} {
+		assert.NotNil(t, upgrade.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{"", bootLines[GetBootLines()[len(GetBootLines())-1]].Latest, false},
+		{"3.4.x", bootLines["3.4"].Latest, false},
+		{"3.4", bootLines["3.4"].Latest, false},
+		{"3.4.1", "3.4.1", false},
+		{"3.x", bootLines["3.5"].Latest, false},
+		{"2", bootLines["2.7"].Latest, false},
+		{"9.x", "", true},
+		{"9.9", "", true},
+		{"3.x.1", "", true},
+		{"latest", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			target, err := resolveTarget(tt.input)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("2.7.18", "3.0"))
+	assert.Equal(t, 1, compareVersions("3.10.0", "3.9.9"))
+	assert.Equal(t, 0, compareVersions("2.3.12.RELEASE", "2.3.12"))
+}
+
+func TestCheckJava(t *testing.T) {
+	assert.Contains(t, checkJava("11", "3.4.7"), "requires Java 17 or later")
+	assert.Contains(t, checkJava("1.8", "3.0.13"), "requires Java 17 or later")
+	assert.Contains(t, checkJava("24", "3.3.13"), "supported up to Java 22")
+	assert.Empty(t, checkJava("21", "3.4.7"))
+	assert.Empty(t, checkJava("17", "4.0.0"))
+}
+
+func TestCheckDependency(t *testing.T) {
+	tests := []struct {
+		dep      buildtool.Dependency
+		expected string
+	}{
+		{buildtool.Dependency{GroupId: "org.hibernate.orm", ArtifactId: "hibernate-core", Version: "6.2.7.Final"}, "overrides Hibernate ORM 6.6.x"},
+		{buildtool.Dependency{GroupId: "org.hibernate.orm", ArtifactId: "hibernate-core", Version: "6.6.4.Final"}, ""},
+		{buildtool.Dependency{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web", Version: "3.3.5"}, "does not match Spring Boot 3.4.7"},
+		{buildtool.Dependency{GroupId: "javax.servlet", ArtifactId: "javax.servlet-api", Version: "4.0.1"}, "Jakarta EE"},
+		{buildtool.Dependency{GroupId: "org.projectlombok", ArtifactId: "lombok", Version: "1.18.36"}, ""},
+		{buildtool.Dependency{GroupId: "org.flywaydb", ArtifactId: "flyway-core", Version: "${flyway.version}"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dep.ArtifactId+":"+tt.dep.Version, func(t *testing.T) {
+			message := checkDependency(tt.dep, "3.4.7")
+			if tt.expected == "" {
+				assert.Empty(t, message)
+				return
+			}
+			assert.Contains(t, message, tt.expected)
+		})
+	}
+}
+
+func TestUpgradeProject_Maven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml":                            pom,
+		"src/main/resources/application.yml": applicationYml,
+	})
+
+	out, err := upgradeProject(fs, "/project", "3.4.x", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.7.18", out.From)
+	assert.Equal(t, "3.4.7", out.To)
+	assert.Equal(t, []output.UpgradeChange{
+		{File: "pom.xml", Item: "spring-boot", From: "2.7.18", To: "3.4.7"},
+		{File: "pom.xml", Item: "spring-cloud", From: "2021.0.9", To: "2024.0.1"},
+		{File: "src/main/resources/application.yml", Item: "property", From: "spring.redis", To: "spring.data.redis"},
+	}, out.Changes)
+
+	var items []string
+	for _, issue := range out.Manual {
+		items = append(items, issue.Item)
+	}
+	assert.Equal(t, []string{"java 11", "org.flywaydb:flyway-core", "org.springdoc:springdoc-openapi-ui", "spring.sleuth"}, items)
+	assert.Equal(t, 7, out.Manual[3].Line)
+
+	expected := strings.Replace(pom, "<version>2.7.18</version>", "<version>3.4.7</version>", 1)
+	expected = strings.Replace(expected, "2021.0.9", "2024.0.1", 1)
+	assert.Equal(t, expected, testutil.ReadFile(t, fs, "/project/pom.xml"))
+	assert.Equal(t, `spring:
+  application:
+    name: demo
+  data:
+    redis:
+      host: localhost
+  sleuth:
+    enabled: true
+`, testutil.ReadFile(t, fs, "/project/src/main/resources/application.yml"))
+}
+
+func TestUpgradeProject_Gradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"build.gradle": gradleBuild,
+		"src/main/resources/application.properties": "management.endpoints.enabled-by-default=false\n",
+	})
+
+	out, err := upgradeProject(fs, "/project", "3.4.1", false)
+	require.NoError(t, err)
+
+	assert.Len(t, out.Changes, 2)
+	assert.Empty(t, out.Manual)
+	assert.Equal(t, strings.Replace(gradleBuild, "3.3.5", "3.4.1", 1), testutil.ReadFile(t, fs, "/project/build.gradle"))
+	assert.Equal(t, "management.endpoints.access.default=none\n", testutil.ReadFile(t, fs, "/project/src/main/resources/application.properties"))
+}
+
+func TestUpgradeProject_DryRun(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml":                            pom,
+		"src/main/resources/application.yml": applicationYml,
+	})
+
+	out, err := upgradeProject(fs, "/project", "3.5", true)
+	require.NoError(t, err)
+
+	assert.True(t, out.DryRun)
+	assert.NotEmpty(t, out.Changes)
+	assert.Equal(t, pom, testutil.ReadFile(t, fs, "/project/pom.xml"))
+	assert.Equal(t, applicationYml, testutil.ReadFile(t, fs, "/project/src/main/resources/application.yml"))
+}
+
+func TestUpgradeProject_Errors(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"build.gradle": "plugins {\n    id 'java'\n}\n"})
+	_, err := upgradeProject(fs, "/project", "", false)
+	assert.ErrorContains(t, err, "could not find the Spring Boot version")
+
+	fs = testutil.MemFs(t, "/project", map[string]string{"build.gradle": gradleBuild})
+	_, err = upgradeProject(fs, "/project", "3.2.x", false)
+	assert.ErrorContains(t, err, "older than the current Spring Boot 3.3.5")
+
+	out, err := upgradeProject(fs, "/project", "3.3.5", false)
+	require.NoError(t, err)
+	assert.Empty(t, out.Changes)
+}
@@ -0,0 +1,424 @@
+package boot
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+)
+
+var configDirs = []string{
+	"src/main/resources",
+	"src/main/resources/config",
+	"src/test/resources",
+	"src/test/resources/config",
+}
+
+type configRewrite struct {
+	content string
+	changes []output.UpgradeChange
+	manual  []output.UpgradeIssue
+}
+
+func findConfigFiles(fs afero.Fs, dir string) []string {
+	var files []string
+	for _, configDir := range configDirs {
+		entries, err := afero.ReadDir(fs, filepath.Join(dir, configDir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !isConfigFile(name) {
+				continue
+			}
+			files = append(files, filepath.Join(dir, configDir, name))
+		}
+	}
+	return files
+}
+
+func isConfigFile(name string) bool {
+	if !strings.HasPrefix(name, "application") && !strings.HasPrefix(name, "bootstrap") {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".yml", ".yaml", ".properties":
+		return true
+	}
+	return false
+}
+
+func rewriteConfig(name, content, from, to string) configRewrite {
+	var renames []PropertyRename
+	for _, rename := range propertyRenames {
+		if rename.applies(from, to) {
+			renames = append(renames, rename)
+		}
+	}
+	var removals []PropertyRemoval
+	for _, removal := range propertyRemovals {
+		if removal.applies(from, to) {
+			removals = append(removals, removal)
+		}
+	}
+
+	var result configRewrite
+	if filepath.Ext(name) == ".properties" {
+		result = rewriteProperties(content, renames, removals)
+	} else {
+		result = rewriteYAML(content, renames, removals)
+	}
+	for i := range result.changes {
+		result.changes[i].File = name
+	}
+	for i := range result.manual {
+		result.manual[i].File = name
+	}
+	return result
+}
+
+func rewriteProperties(content string, renames []PropertyRename, removals []PropertyRemoval) configRewrite {
+	result := configRewrite{}
+	lines := strings.Split(content, "\n")
+	continued := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		wasContinued := continued
+		continued = strings.HasSuffix(strings.TrimRight(line, "\r"), "\\")
+		if wasContinued || trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			continue
+		}
+
+		start := len(line) - len(strings.TrimLeft(line, " \t"))
+		end := start + strings.IndexAny(trimmed+"=", "=: \t")
+		key := strings.Split(line[start:end], ".")
+
+		for _, removal := range removals {
+			if removal.match(key) {
+				result.manual = append(result.manual, output.UpgradeIssue{Line: i + 1, Item: strings.Join(key, "."), Message: removal.Message})
+			}
+		}
+		for _, rename := range renames {
+			renamed, ok := rename.match(key)
+			if !ok {
+				continue
+			}
+			rest := line[end:]
+			if len(rename.Values) > 0 {
+				sep := len(rest) - len(strings.TrimLeft(rest, " \t=:"))
+				rest = rest[:sep] + rename.value(rest[sep:])
+			}
+			lines[i] = line[:start] + strings.Join(renamed, ".") + rest
+			result.changes = append(result.changes, output.UpgradeChange{Item: "property", From: strings.Join(key, "."), To: strings.Join(renamed, ".")})
+			break
+		}
+	}
+	result.content = strings.Join(lines, "\n")
+	return result
+}
+
+type yamlNode struct {
+	line   int
+	indent int
+	key    string
+	path   []string
+	value  string
+	end    int
+	doc    int
+}
+
+func (n yamlNode) parentDepth() int {
+	return len(n.path) - len(splitYAMLKey(n.key))
+}
+
+func parseYAML(lines []string) []yamlNode {
+	var nodes []yamlNode
+	var stack []int
+	doc, skip, dash := 0, -1, false
+	keyLines := make(map[int]bool)
+
+	for i, raw := range lines {
+		trimmed := strings.TrimSpace(raw)
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if skip >= 0 {
+			if indent > skip || (dash && indent == skip && strings.HasPrefix(trimmed, "-")) {
+				continue
+			}
+			skip, dash = -1, false
+		}
+		if trimmed == "---" || strings.HasPrefix(trimmed, "--- ") || trimmed == "..." {
+			doc++
+			stack = nil
+			continue
+		}
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			skip, dash = indent, true
+			continue
+		}
+		key, value, ok := splitYAMLLine(trimmed)
+		if !ok {
+			continue
+		}
+
+		for len(stack) > 0 && nodes[stack[len(stack)-1]].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		var path []string
+		if len(stack) > 0 {
+			path = append(path, nodes[stack[len(stack)-1]].path...)
+		}
+		path = append(path, splitYAMLKey(key)...)
+
+		nodes = append(nodes, yamlNode{line: i, indent: indent, key: key, path: path, value: value, doc: doc})
+		stack = append(stack, len(nodes)-1)
+		keyLines[i] = true
+		if v := strings.TrimSpace(value); strings.HasPrefix(v, "|") || strings.HasPrefix(v, ">") {
+			skip = indent
+		}
+	}
+
+	for n := range nodes {
+		node := &nodes[n]
+		node.end = node.line + 1
+		for j := node.line + 1; j < len(lines); j++ {
+			trimmed := strings.TrimSpace(lines[j])
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			indent := len(lines[j]) - len(strings.TrimLeft(lines[j], " "))
+			if trimmed == "---" || strings.HasPrefix(trimmed, "--- ") || trimmed == "..." ||
+				(keyLines[j] && indent <= node.indent) || indent < node.indent {
+				break
+			}
+			node.end = j + 1
+		}
+	}
+	return nodes
+}
+
+func splitYAMLLine(trimmed string) (string, string, bool) {
+	if trimmed[0] == '"' || trimmed[0] == '\'' {
+		closing := strings.IndexByte(trimmed[1:], trimmed[0])
+		if closing < 0 || !strings.HasPrefix(trimmed[closing+2:], ":") {
+			return "", "", false
+		}
+		return trimmed[1 : closing+1], trimmed[closing+3:], true
+	}
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return "", "", false
+	}
+	for i := 0; i < len(trimmed); i++ {
+		if trimmed[i] == ':' && (i+1 == len(trimmed) || trimmed[i+1] == ' ') {
+			return trimmed[:i], trimmed[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func splitYAMLKey(key string) []string {
+	if strings.HasPrefix(key, "[") {
+		return []string{key}
+	}
+	return strings.Split(key, ".")
+}
+
+func rewriteYAML(content string, renames []PropertyRename, removals []PropertyRemoval) configRewrite {
+	result := configRewrite{}
+	lines := strings.Split(content, "\n")
+	unit := yamlIndentUnit(lines)
+
+	for moved := true; moved; {
+		moved = false
+		for _, node := range parseYAML(lines) {
+			for _, rename := range renames {
+				renamed, ok := rename.match(node.path)
+				if !ok || node.parentDepth() >= len(strings.Split(rename.From, ".")) {
+					continue
+				}
+				lines = moveYAMLNode(lines, node, renamed, rename, unit)
+				result.changes = append(result.changes, output.UpgradeChange{Item: "property", From: strings.Join(node.path, "."), To: strings.Join(renamed, ".")})
+				moved = true
+				break
+			}
+			if moved {
+				break
+			}
+		}
+	}
+
+	for _, node := range parseYAML(lines) {
+		for _, removal := range removals {
+			if removal.match(node.path) && node.parentDepth() < len(strings.Split(removal.Key, ".")) {
+				result.manual = append(result.manual, output.UpgradeIssue{Line: node.line + 1, Item: strings.Join(node.path, "."), Message: removal.Message})
+			}
+		}
+	}
+
+	result.content = strings.Join(lines, "\n")
+	return result
+}
+
+func moveYAMLNode(lines []string, node yamlNode, path []string, rename PropertyRename, unit int) []string {
+	value := node.value
+	if len(rename.Values) > 0 {
+		core, comment := value, ""
+		if at := strings.Index(value, " #"); at >= 0 {
+			core, comment = value[:at], value[at:]
+		}
+		lead := len(core) - len(strings.TrimLeft(core, " "))
+		value = core[:lead] + rename.value(core[lead:]) + comment
+	}
+	body := lines[node.line+1 : node.end]
+	dotted := strings.Contains(node.key, ".")
+
+	rest := append([]string(nil), lines[:node.line]...)
+	lines = append(rest, lines[node.end:]...)
+	lines = pruneYAMLAncestors(lines, node)
+
+	parent := yamlNode{line: -1, indent: -unit}
+	siblings := parseYAML(lines)
+	for _, candidate := range siblings {
+		if candidate.doc != node.doc {
+			continue
+		}
+		if strings.TrimSpace(candidate.value) == "" && len(candidate.path) <= len(path) &&
+			len(candidate.path) > len(parent.path) && hasPrefix(path, candidate.path) {
+			parent = candidate
+		}
+	}
+
+	indent := parent.indent + unit
+	at := docEnd(lines, node.doc)
+	if samePath(parent.path, node.path[:node.parentDepth()]) {
+		at = node.line
+	} else if parent.line >= 0 {
+		at = parent.end
+	}
+	if parent.line >= 0 {
+		for _, child := range siblings {
+			if child.line > parent.line && child.line < parent.end {
+				indent = child.indent
+				break
+			}
+		}
+	}
+
+	segments := path[len(parent.path):]
+	if dotted && len(segments) > 0 {
+		segments = []string{strings.Join(segments, ".")}
+	}
+	var inserted []string
+	for i, segment := range segments {
+		line := strings.Repeat(" ", indent+i*unit) + segment + ":"
+		if i == len(segments)-1 {
+			line += value
+		}
+		inserted = append(inserted, line)
+	}
+	delta := indent + (len(segments)-1)*unit - node.indent
+	if len(segments) == 0 {
+		delta = indent - bodyIndent(body)
+	}
+	for _, line := range body {
+		inserted = append(inserted, reindent(line, delta))
+	}
+
+	rest = append([]string(nil), lines[:at]...)
+	rest = append(rest, inserted...)
+	return append(rest, lines[at:]...)
+}
+
+func pruneYAMLAncestors(lines []string, removed yamlNode) []string {
+	for depth := removed.parentDepth(); depth > 0; {
+		pruned := false
+		for _, node := range parseYAML(lines) {
+			if node.doc == removed.doc && len(node.path) == depth && hasPrefix(removed.path, node.path) &&
+				strings.TrimSpace(node.value) == "" && node.end == node.line+1 {
+				lines = append(lines[:node.line:node.line], lines[node.line+1:]...)
+				depth = node.parentDepth()
+				pruned = true
+				break
+			}
+		}
+		if !pruned {
+			break
+		}
+	}
+	return lines
+}
+
+func docEnd(lines []string, doc int) int {
+	current, end := 0, len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" || strings.HasPrefix(trimmed, "--- ") || trimmed == "..." {
+			if current == doc {
+				end = i
+				break
+			}
+			current++
+		}
+	}
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+func bodyIndent(body []string) int {
+	indent := -1
+	for _, line := range body {
+		if trimmed := strings.TrimLeft(line, " "); trimmed != "" {
+			if n := len(line) - len(trimmed); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+	}
+	return max(indent, 0)
+}
+
+func reindent(line string, delta int) string {
+	if strings.TrimSpace(line) == "" {
+		return line
+	}
+	if delta >= 0 {
+		return strings.Repeat(" ", delta) + line
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	return line[min(-delta, indent):]
+}
+
+func yamlIndentUnit(lines []string) int {
+	unit := 0
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if indent := len(line) - len(trimmed); indent > 0 && trimmed != "" && (unit == 0 || indent < unit) {
+			unit = indent
+		}
+	}
+	if unit == 0 {
+		return 2
+	}
+	return unit
+}
+
+func samePath(a, b []string) bool {
+	return len(a) == len(b) && hasPrefix(a, b)
+}
+
+func hasPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package boot
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteProperties(t *testing.T) {
+	content := `# Redis
+spring.redis.host=localhost
+spring.redis.port = 6379
+server.max-http-header-size: 16KB
+management.metrics.export.prometheus.enabled=true
+management.endpoint.health.enabled=false
+spring.jpa.hibernate.use-new-id-generator-mappings=true
+`
+	result := rewriteConfig("application.properties", content, "2.7.18", "3.4.7")
+
+	assert.Equal(t, `# Redis
+spring.data.redis.host=localhost
+spring.data.redis.port = 6379
+server.max-http-request-header-size: 16KB
+management.prometheus.metrics.export.enabled=true
+management.endpoint.health.access=none
+spring.jpa.hibernate.use-new-id-generator-mappings=true
+`, result.content)
+	assert.Len(t, result.changes, 5)
+	assert.Equal(t, output.UpgradeChange{File: "application.properties", Item: "property", From: "spring.redis.host", To: "spring.data.redis.host"}, result.changes[0])
+	assert.Equal(t, []output.UpgradeIssue{{
+		File:    "application.properties",
+		Line:    7,
+		Item:    "spring.jpa.hibernate.use-new-id-generator-mappings",
+		Message: "Hibernate 6 always uses the new id generator mappings",
+	}}, result.manual)
+}
+
+func TestRewriteProperties_OnlyNewRenames(t *testing.T) {
+	content := "spring.redis.host=localhost\nmanagement.endpoints.enabled-by-default=true\n"
+	result := rewriteConfig("application.properties", content, "3.3.5", "3.4.7")
+
+	assert.Equal(t, "spring.redis.host=localhost\nmanagement.endpoints.access.default=unrestricted\n", result.content)
+}
+
+func TestRewriteYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "moves subtree under existing parent",
+			content: `spring:
+  application:
+    name: demo
+  redis:
+    host: localhost # local
+    port: 6379
+
+server:
+  port: 8080
+`,
+			expected: `spring:
+  application:
+    name: demo
+  data:
+    redis:
+      host: localhost # local
+      port: 6379
+
+server:
+  port: 8080
+`,
+		},
+		{
+			name: "merges into existing mapping",
+			content: `spring:
+  data:
+    redis:
+      host: localhost
+  redis:
+    port: 6379
+`,
+			expected: `spring:
+  data:
+    redis:
+      host: localhost
+      port: 6379
+`,
+		},
+		{
+			name: "renames leaf in place and maps value",
+			content: `management:
+  endpoint:
+    health:
+      enabled: false
+      show-details: always
+`,
+			expected: `management:
+  endpoint:
+    health:
+      access: none
+      show-details: always
+`,
+		},
+		{
+			name: "wildcard and pruned parents",
+			content: `management:
+  metrics:
+    export:
+      prometheus:
+        enabled: true
+`,
+			expected: `management:
+  prometheus:
+    metrics:
+      export:
+        enabled: true
+`,
+		},
+		{
+			name: "dotted keys",
+			content: `server.max-http-header-size: 16KB
+spring.redis.host: localhost
+`,
+			expected: `server.max-http-request-header-size: 16KB
+spring.data.redis.host: localhost
+`,
+		},
+		{
+			name: "multiple documents",
+			content: `spring:
+  application:
+    name: demo
+---
+spring:
+  config:
+    activate:
+      on-profile: prod
+  redis:
+    host: redis.internal
+`,
+			expected: `spring:
+  application:
+    name: demo
+---
+spring:
+  config:
+    activate:
+      on-profile: prod
+  data:
+    redis:
+      host: redis.internal
+`,
+		},
+		{
+			name: "ignores lists and block scalars",
+			content: `app:
+  notes: |
+    spring.redis.host: not a key
+  hosts:
+    - redis:
+        host: a
+`,
+			expected: `app:
+  notes: |
+    spring.redis.host: not a key
+  hosts:
+    - redis:
+        host: a
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rewriteConfig("application.yml", tt.content, "2.7.18", "3.4.7")
+			assert.Equal(t, tt.expected, result.content)
+		})
+	}
+}
+
+func TestRewriteYAML_RemovedProperty(t *testing.T) {
+	content := `spring:
+  sleuth:
+    sampler:
+      probability: 1.0
+`
+	result := rewriteConfig("application.yml", content, "2.7.18", "3.0.13")
+
+	assert.Equal(t, content, result.content)
+	assert.Equal(t, []output.UpgradeIssue{{File: "application.yml", Line: 2, Item: "spring.sleuth", Message: propertyRemovals[4].Message}}, result.manual)
+}
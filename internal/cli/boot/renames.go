@@ -0,0 +1,115 @@
+package boot
+
+import "strings"
+
+type PropertyRename struct {
+	From   string
+	To     string
+	Since  string
+	Values map[string]string
+}
+
+type PropertyRemoval struct {
+	Key     string
+	Since   string
+	Message string
+}
+
+var accessValues = map[string]string{"true": "unrestricted", "false": "none"}
+
+var propertyRenames = []PropertyRename{
+	{From: "spring.resources", To: "spring.web.resources", Since: "2.4"},
+	{From: "management.server.servlet.context-path", To: "management.server.base-path", Since: "2.4"},
+	{From: "spring.datasource.initialization-mode", To: "spring.sql.init.mode", Since: "2.5"},
+	{From: "spring.datasource.schema", To: "spring.sql.init.schema-locations", Since: "2.5"},
+	{From: "spring.datasource.data", To: "spring.sql.init.data-locations", Since: "2.5"},
+	{From: "spring.datasource.platform", To: "spring.sql.init.platform", Since: "2.5"},
+	{From: "spring.datasource.continue-on-error", To: "spring.sql.init.continue-on-error", Since: "2.5"},
+	{From: "spring.datasource.separator", To: "spring.sql.init.separator", Since: "2.5"},
+	{From: "spring.datasource.sql-script-encoding", To: "spring.sql.init.encoding", Since: "2.5"},
+	{From: "spring.mvc.locale", To: "spring.web.locale", Since: "2.5"},
+	{From: "spring.mvc.locale-resolver", To: "spring.web.locale-resolver", Since: "2.5"},
+	{From: "spring.elasticsearch.rest.uris", To: "spring.elasticsearch.uris", Since: "2.6"},
+	{From: "spring.elasticsearch.rest.username", To: "spring.elasticsearch.username", Since: "2.6"},
+	{From: "spring.elasticsearch.rest.password", To: "spring.elasticsearch.password", Since: "2.6"},
+	{From: "spring.elasticsearch.rest.connection-timeout", To: "spring.elasticsearch.connection-timeout", Since: "2.6"},
+	{From: "spring.elasticsearch.rest.read-timeout", To: "spring.elasticsearch.socket-timeout", Since: "2.6"},
+	{From: "spring.redis", To: "spring.data.redis", Since: "3.0"},
+	{From: "spring.data.cassandra", To: "spring.cassandra", Since: "3.0"},
+	{From: "server.max-http-header-size", To: "server.max-http-request-header-size", Since: "3.0"},
+	{From: "management.metrics.export.*", To: "management.*.metrics.export", Since: "3.0"},
+	{From: "management.trace.http.enabled", To: "management.httpexchanges.recording.enabled", Since: "3.0"},
+	{From: "management.trace.http.include", To: "management.httpexchanges.recording.include", Since: "3.0"},
+	{From: "management.metrics.web.server.request.metric-name", To: "management.observations.http.server.requests.name", Since: "3.0"},
+	{From: "management.metrics.web.client.request.metric-name", To: "management.observations.http.client.requests.name", Since: "3.0"},
+	{From: "spring.kafka.streams.cache-max-size-buffering", To: "spring.kafka.streams.state-store-cache-max-size", Since: "3.0"},
+	{From: "spring.security.saml2.relyingparty.registration.*.identityprovider", To: "spring.security.saml2.relyingparty.registration.*.assertingparty", Since: "3.0"},
+	{From: "spring.gson.lenient", To: "spring.gson.strictness", Since: "3.4", Values: map[string]string{"true": "lenient", "false": "strict"}},
+	{From: "management.endpoints.enabled-by-default", To: "management.endpoints.access.default", Since: "3.4", Values: accessValues},
+	{From: "management.endpoint.*.enabled", To: "management.endpoint.*.access", Since: "3.4", Values: accessValues},
+	{From: "spring.codec.max-in-memory-size", To: "spring.http.codecs.max-in-memory-size", Since: "3.5"},
+	{From: "spring.codec.log-request-details", To: "spring.http.codecs.log-request-details", Since: "3.5"},
+}
+
+var propertyRemovals = []PropertyRemoval{
+	{Key: "spring.config.use-legacy-processing", Since: "3.0", Message: "legacy config processing was removed; migrate to spring.config.import and spring.config.activate.*"},
+	{Key: "spring.jpa.hibernate.use-new-id-generator-mappings", Since: "3.0", Message: "Hibernate 6 always uses the new id generator mappings"},
+	{Key: "server.servlet.session.cookie.comment", Since: "3.0", Message: "cookie comments are no longer supported by the Servlet API"},
+	{Key: "management.metrics.web.server.request.autotime", Since: "3.0", Message: "request timing is configured through Micrometer Observation (management.observations.*)"},
+	{Key: "spring.sleuth", Since: "3.0", Message: "Spring Cloud Sleuth is replaced by Micrometer Tracing (management.tracing.*)"},
+	{Key: "spring.mvc.throw-exception-if-no-handler-found", Since: "3.2", Message: "NoHandlerFoundException is now thrown by default; the property has no effect"},
+}
+
+func (r PropertyRename) applies(from, to string) bool {
+	return (from == "" || compareVersions(from, r.Since) < 0) && compareVersions(to, r.Since) >= 0
+}
+
+func (r PropertyRemoval) applies(from, to string) bool {
+	return (from == "" || compareVersions(from, r.Since) < 0) && compareVersions(to, r.Since) >= 0
+}
+
+func (r PropertyRename) match(key []string) ([]string, bool) {
+	pattern := strings.Split(r.From, ".")
+	if len(key) < len(pattern) {
+		return nil, false
+	}
+	var wildcards []string
+	for i, segment := range pattern {
+		if segment == "*" {
+			wildcards = append(wildcards, key[i])
+			continue
+		}
+		if segment != key[i] {
+			return nil, false
+		}
+	}
+
+	var renamed []string
+	for _, segment := range strings.Split(r.To, ".") {
+		if segment == "*" {
+			segment, wildcards = wildcards[0], wildcards[1:]
+		}
+		renamed = append(renamed, segment)
+	}
+	return append(renamed, key[len(pattern):]...), true
+}
+
+func (r PropertyRename) value(value string) string {
+	if mapped, ok := r.Values[strings.TrimSpace(value)]; ok {
+		return mapped
+	}
+	return value
+}
+
+func (r PropertyRemoval) match(key []string) bool {
+	pattern := strings.Split(r.Key, ".")
+	if len(key) < len(pattern) {
+		return false
+	}
+	for i, segment := range pattern {
+		if segment != key[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package boot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/cli/add"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var springCloudProperties = []string{"spring-cloud.version", "springCloudVersion"}
+
+func newUpgradeCommand() *cobra.Command {
+	var to string
+	var dryRun bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the Spring Boot version",
+		Long: `Upgrade the project to a newer Spring Boot version.
+
+The command:
+  - Bumps the spring-boot-starter-parent, spring-boot-dependencies BOM or
+    org.springframework.boot plugin version in pom.xml, build.gradle(.kts)
+    or gradle/libs.versions.toml
+  - Moves the Spring Cloud release train along when its version is kept
+    in a spring-cloud.version or springCloudVersion property
+  - Rewrites renamed configuration properties in application*.yml and
+    application*.properties using a bundled rename table
+
+It then reports what needs a manual look: a Java version outside the range
+supported by the new Spring Boot line, explicit dependency versions that
+override the ones Spring Boot manages, artifacts known not to work with
+the new line and configuration properties that were removed.
+
+--to takes a full version (3.4.1) or a line (3.4 or 3.4.x), which resolves
+to the latest release of that line known to haft.`,
+		Example: `  # Upgrade to the latest known release
+  haft boot upgrade
+
+  # Upgrade to the latest 3.4 release
+  haft boot upgrade --to 3.4.x
+
+  # Show what would change without writing files
+  haft boot upgrade --to 3.5.3 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return bootError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := upgradeProject(afero.NewOsFs(), dir, to, dryRun)
+			if err != nil {
+				return bootError(jsonOutput, "UPGRADE_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printUpgrade(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Target Spring Boot version or line (e.g. 3.4.x)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report changes without writing files")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func upgradeProject(fs afero.Fs, dir, to string, dryRun bool) (output.BootUpgradeOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.BootUpgradeOutput{}, err
+	}
+	parser := result.Parser
+	buildFile := buildtool.RelativePath(dir, result.FilePath)
+
+	from := parser.GetSpringBootVersion(project)
+	if from == "" {
+		return output.BootUpgradeOutput{}, fmt.Errorf("could not find the Spring Boot version in %s", buildFile)
+	}
+	target, err := resolveTarget(to)
+	if err != nil {
+		return output.BootUpgradeOutput{}, err
+	}
+	if compareVersions(target, from) < 0 {
+		return output.BootUpgradeOutput{}, fmt.Errorf("target version %s is older than the current Spring Boot %s", target, from)
+	}
+
+	out := output.BootUpgradeOutput{
+		BuildFile: result.FilePath,
+		From:      from,
+		To:        target,
+		DryRun:    dryRun,
+		Changes:   []output.UpgradeChange{},
+		Manual:    []output.UpgradeIssue{},
+	}
+	if target == from {
+		return out, nil
+	}
+
+	if !parser.SetSpringBootVersion(project, target) {
+		return output.BootUpgradeOutput{}, fmt.Errorf("could not update the Spring Boot version in %s", buildFile)
+	}
+	out.Changes = append(out.Changes, output.UpgradeChange{File: buildFile, Item: "spring-boot", From: from, To: target})
+
+	change, issue := upgradeSpringCloud(parser, project, target)
+	if change != nil {
+		change.File = buildFile
+		out.Changes = append(out.Changes, *change)
+	}
+	if issue != nil {
+		issue.File = buildFile
+		out.Manual = append(out.Manual, *issue)
+	}
+
+	if javaVersion := parser.GetJavaVersion(project); javaVersion != "" {
+		if message := checkJava(javaVersion, target); message != "" {
+			out.Manual = append(out.Manual, output.UpgradeIssue{File: buildFile, Item: "java " + javaVersion, Message: message})
+		}
+	}
+	for _, dep := range project.Dependencies {
+		dep.Version = resolveVersion(project, dep.Version)
+		if message := checkDependency(dep, target); message != "" {
+			out.Manual = append(out.Manual, output.UpgradeIssue{File: buildFile, Item: dep.GroupId + ":" + dep.ArtifactId, Message: message})
+		}
+	}
+
+	if !dryRun {
+		if err := parser.Write(result.FilePath, project); err != nil {
+			return output.BootUpgradeOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+		}
+	}
+
+	for _, path := range findConfigFiles(fs, dir) {
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return output.BootUpgradeOutput{}, fmt.Errorf("could not read %s: %w", path, err)
+		}
+		rewrite := rewriteConfig(buildtool.RelativePath(dir, path), string(data), from, target)
+		out.Changes = append(out.Changes, rewrite.changes...)
+		out.Manual = append(out.Manual, rewrite.manual...)
+		if dryRun || rewrite.content == string(data) {
+			continue
+		}
+		if err := afero.WriteFile(fs, path, []byte(rewrite.content), 0644); err != nil {
+			return output.BootUpgradeOutput{}, fmt.Errorf("could not write %s: %w", path, err)
+		}
+	}
+	return out, nil
+}
+
+func upgradeSpringCloud(parser buildtool.Parser, project *buildtool.Project, target string) (*output.UpgradeChange, *output.UpgradeIssue) {
+	entry, _ := add.GetBomEntry("spring-cloud")
+	bom, ok := project.ManagedDependency(entry.Bom.GroupId, entry.Bom.ArtifactId)
+	if !ok {
+		return nil, nil
+	}
+	current := resolveVersion(project, bom.Version)
+	wanted, known := entry.BootVersions[lineOf(target)]
+	if !known || current == wanted {
+		return nil, nil
+	}
+
+	for _, key := range springCloudProperties {
+		if value, ok := project.Property(key); ok && value == current {
+			parser.SetProperty(project, key, wanted)
+			return &output.UpgradeChange{Item: "spring-cloud", From: current, To: wanted}, nil
+		}
+	}
+	return nil, &output.UpgradeIssue{
+		Item:    "spring-cloud " + current,
+		Message: fmt.Sprintf("Spring Boot %s needs the Spring Cloud %s release train", lineOf(target), wanted),
+	}
+}
+
+func resolveVersion(project *buildtool.Project, version string) string {
+	if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+		if value, ok := project.Property(version[2 : len(version)-1]); ok {
+			return value
+		}
+	}
+	return version
+}
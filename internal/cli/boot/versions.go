@@ -0,0 +1,251 @@
+package boot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+type BootLine struct {
+	Latest  string
+	MinJava int
+	MaxJava int
+}
+
+var bootLines = map[string]BootLine{
+	"2.7": {Latest: "2.7.18", MinJava: 8, MaxJava: 21},
+	"3.0": {Latest: "3.0.13", MinJava: 17, MaxJava: 19},
+	"3.1": {Latest: "3.1.12", MinJava: 17, MaxJava: 21},
+	"3.2": {Latest: "3.2.12", MinJava: 17, MaxJava: 21},
+	"3.3": {Latest: "3.3.13", MinJava: 17, MaxJava: 22},
+	"3.4": {Latest: "3.4.7", MinJava: 17, MaxJava: 23},
+	"3.5": {Latest: "3.5.3", MinJava: 17, MaxJava: 24},
+}
+
+type managedLibrary struct {
+	Name     string
+	Groups   []string
+	Versions map[string]string
+}
+
+var managedLibraries = []managedLibrary{
+	{
+		Name:     "Spring Framework",
+		Groups:   []string{"org.springframework"},
+		Versions: map[string]string{"2.7": "5.3", "3.0": "6.0", "3.1": "6.0", "3.2": "6.1", "3.3": "6.1", "3.4": "6.2", "3.5": "6.2"},
+	},
+	{
+		Name:     "Spring Security",
+		Groups:   []string{"org.springframework.security"},
+		Versions: map[string]string{"2.7": "5.7", "3.0": "6.0", "3.1": "6.1", "3.2": "6.2", "3.3": "6.3", "3.4": "6.4", "3.5": "6.5"},
+	},
+	{
+		Name:     "Hibernate ORM",
+		Groups:   []string{"org.hibernate", "org.hibernate.orm"},
+		Versions: map[string]string{"2.7": "5.6", "3.0": "6.1", "3.1": "6.2", "3.2": "6.4", "3.3": "6.5", "3.4": "6.6", "3.5": "6.6"},
+	},
+	{
+		Name:     "Jackson",
+		Groups:   []string{"com.fasterxml.jackson.core", "com.fasterxml.jackson.datatype", "com.fasterxml.jackson.module", "com.fasterxml.jackson.dataformat"},
+		Versions: map[string]string{"2.7": "2.13", "3.0": "2.14", "3.1": "2.15", "3.2": "2.15", "3.3": "2.17", "3.4": "2.18", "3.5": "2.19"},
+	},
+	{
+		Name:     "Flyway",
+		Groups:   []string{"org.flywaydb"},
+		Versions: map[string]string{"2.7": "8.5", "3.0": "9.5", "3.1": "9.16", "3.2": "9.22", "3.3": "10.10", "3.4": "10.20", "3.5": "11.7"},
+	},
+	{
+		Name:     "Liquibase",
+		Groups:   []string{"org.liquibase"},
+		Versions: map[string]string{"2.7": "4.9", "3.0": "4.17", "3.1": "4.20", "3.2": "4.24", "3.3": "4.27", "3.4": "4.29", "3.5": "4.31"},
+	},
+	{
+		Name:     "PostgreSQL JDBC",
+		Groups:   []string{"org.postgresql"},
+		Versions: map[string]string{"2.7": "42.3", "3.0": "42.5", "3.1": "42.6", "3.2": "42.6", "3.3": "42.7", "3.4": "42.7", "3.5": "42.7"},
+	},
+	{
+		Name:     "Micrometer",
+		Groups:   []string{"io.micrometer"},
+		Versions: map[string]string{"2.7": "1.9", "3.0": "1.10", "3.1": "1.11", "3.2": "1.12", "3.3": "1.13", "3.4": "1.14", "3.5": "1.15"},
+	},
+	{
+		Name:     "JUnit Jupiter",
+		Groups:   []string{"org.junit.jupiter"},
+		Versions: map[string]string{"2.7": "5.8", "3.0": "5.9", "3.1": "5.9", "3.2": "5.10", "3.3": "5.10", "3.4": "5.11", "3.5": "5.12"},
+	},
+	{
+		Name:     "Mockito",
+		Groups:   []string{"org.mockito"},
+		Versions: map[string]string{"2.7": "4.5", "3.0": "4.8", "3.1": "5.3", "3.2": "5.7", "3.3": "5.11", "3.4": "5.14", "3.5": "5.17"},
+	},
+	{
+		Name:     "Testcontainers",
+		Groups:   []string{"org.testcontainers"},
+		Versions: map[string]string{"3.1": "1.18", "3.2": "1.19", "3.3": "1.19", "3.4": "1.20", "3.5": "1.21"},
+	},
+	{
+		Name:     "Apache Kafka",
+		Groups:   []string{"org.apache.kafka"},
+		Versions: map[string]string{"2.7": "3.1", "3.0": "3.3", "3.1": "3.4", "3.2": "3.6", "3.3": "3.7", "3.4": "3.8", "3.5": "3.9"},
+	},
+}
+
+type incompatibleArtifact struct {
+	GroupId    string
+	ArtifactId string
+	Since      string
+	Message    string
+}
+
+var incompatibleArtifacts = []incompatibleArtifact{
+	{GroupId: "org.springdoc", ArtifactId: "springdoc-openapi-ui", Since: "3.0", Message: "springdoc 1.x does not support Spring Boot 3; use org.springdoc:springdoc-openapi-starter-webmvc-ui 2.x"},
+	{GroupId: "org.springdoc", ArtifactId: "springdoc-openapi-webflux-ui", Since: "3.0", Message: "springdoc 1.x does not support Spring Boot 3; use org.springdoc:springdoc-openapi-starter-webflux-ui 2.x"},
+	{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-starter-sleuth", Since: "3.0", Message: "Spring Cloud Sleuth is replaced by Micrometer Tracing (io.micrometer:micrometer-tracing-bridge-brave)"},
+	{GroupId: "javax.servlet", Since: "3.0", Message: "Spring Boot 3 uses Jakarta EE; replace javax.servlet with jakarta.servlet"},
+	{GroupId: "javax.persistence", Since: "3.0", Message: "Spring Boot 3 uses Jakarta EE; replace javax.persistence with jakarta.persistence"},
+	{GroupId: "javax.validation", Since: "3.0", Message: "Spring Boot 3 uses Jakarta EE; replace javax.validation with jakarta.validation"},
+	{GroupId: "javax.annotation", Since: "3.0", Message: "Spring Boot 3 uses Jakarta EE; replace javax.annotation with jakarta.annotation"},
+	{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt", Since: "3.0", Message: "the single jjwt artifact relies on javax.xml.bind; use jjwt-api, jjwt-impl and jjwt-jackson"},
+}
+
+func GetBootLines() []string {
+	lines := make([]string, 0, len(bootLines))
+	for line := range bootLines {
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return compareVersions(lines[i], lines[j]) < 0 })
+	return lines
+}
+
+func resolveTarget(input string) (string, error) {
+	if input == "" {
+		lines := GetBootLines()
+		return bootLines[lines[len(lines)-1]].Latest, nil
+	}
+	input = strings.TrimSuffix(strings.TrimSuffix(input, ".x"), ".X")
+	parts := strings.Split(input, ".")
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return "", fmt.Errorf("invalid Spring Boot version '%s'", input)
+		}
+	}
+	switch len(parts) {
+	case 1:
+		lines := GetBootLines()
+		for i := len(lines) - 1; i >= 0; i-- {
+			if strings.HasPrefix(lines[i], input+".") {
+				return bootLines[lines[i]].Latest, nil
+			}
+		}
+		return "", fmt.Errorf("unknown Spring Boot major version '%s'. Use one of %s or a full version", input, strings.Join(GetBootLines(), ", "))
+	case 2:
+		line, ok := bootLines[input]
+		if !ok {
+			return "", fmt.Errorf("unknown Spring Boot line '%s'. Use one of %s or a full version", input, strings.Join(GetBootLines(), ", "))
+		}
+		return line.Latest, nil
+	case 3:
+		return input, nil
+	}
+	return "", fmt.Errorf("invalid Spring Boot version '%s'", input)
+}
+
+func lineOf(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "." + parts[1]
+}
+
+func compareVersions(a, b string) int {
+	pa, pb := versionNumbers(a), versionNumbers(b)
+	for i := 0; i < 3; i++ {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionNumbers(version string) [3]int {
+	var numbers [3]int
+	for i, part := range strings.SplitN(version, ".", 4) {
+		if i == 3 {
+			break
+		}
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		numbers[i], _ = strconv.Atoi(part[:end])
+	}
+	return numbers
+}
+
+func javaRelease(version string) int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "1.")
+	release, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return release
+}
+
+func checkJava(javaVersion, target string) string {
+	line, ok := bootLines[lineOf(target)]
+	release := javaRelease(javaVersion)
+	if !ok || release == 0 {
+		return ""
+	}
+	switch {
+	case release < line.MinJava:
+		return fmt.Sprintf("Spring Boot %s requires Java %d or later, the project targets Java %s", target, line.MinJava, javaVersion)
+	case release > line.MaxJava:
+		return fmt.Sprintf("Spring Boot %s is supported up to Java %d, the project targets Java %s", target, line.MaxJava, javaVersion)
+	}
+	return ""
+}
+
+func checkDependency(dep buildtool.Dependency, target string) string {
+	for _, artifact := range incompatibleArtifacts {
+		if dep.GroupId == artifact.GroupId && (artifact.ArtifactId == "" || dep.ArtifactId == artifact.ArtifactId) &&
+			compareVersions(target, artifact.Since) >= 0 {
+			return artifact.Message
+		}
+	}
+
+	if dep.Version == "" || strings.Contains(dep.Version, "$") {
+		return ""
+	}
+	if dep.GroupId == "org.springframework.boot" {
+		if dep.Version != target {
+			return fmt.Sprintf("explicit version %s does not match Spring Boot %s; remove it to use the managed version", dep.Version, target)
+		}
+		return ""
+	}
+	for _, library := range managedLibraries {
+		managed, ok := library.Versions[lineOf(target)]
+		if !ok || !containsGroup(library.Groups, dep.GroupId) || lineOf(dep.Version) == managed {
+			continue
+		}
+		return fmt.Sprintf("explicit version %s overrides %s %s.x managed by Spring Boot %s", dep.Version, library.Name, managed, lineOf(target))
+	}
+	return ""
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, candidate := range groups {
+		if candidate == group {
+			return true
+		}
+	}
+	return false
+}
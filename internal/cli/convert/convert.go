@@ -0,0 +1,267 @@
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/generator"
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	passedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+type wrapperFile struct {
+	template string
+	path     string
+	perm     os.FileMode
+}
+
+var wrapperFiles = map[buildtool.Type][]wrapperFile{
+	buildtool.Maven: {
+		{"wrapper/maven-wrapper.properties", ".mvn/wrapper/maven-wrapper.properties", 0644},
+		{"wrapper/mvnw", "mvnw", 0755},
+		{"wrapper/mvnw.cmd", "mvnw.cmd", 0644},
+	},
+	buildtool.Gradle: {
+		{"wrapper/gradle-wrapper.properties", "gradle/wrapper/gradle-wrapper.properties", 0644},
+		{"wrapper/gradlew", "gradlew", 0755},
+		{"wrapper/gradlew.bat", "gradlew.bat", 0644},
+	},
+}
+
+var settingsFiles = map[buildtool.Type]string{
+	buildtool.Gradle:      "settings.gradle",
+	buildtool.GradleKotln: "settings.gradle.kts",
+}
+
+func NewCommand() *cobra.Command {
+	var to string
+	var removeOld bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert the project to another build tool",
+		Long: `Convert the build file of the project to Maven, Gradle (Groovy DSL)
+or Gradle (Kotlin DSL).
+
+The command translates the coordinates, Java version, Spring Boot version,
+properties, repositories, BOM imports, dependencies with their scopes and
+the known build plugins. The matching wrapper and, for Gradle, a settings
+file are added next to the new build file.
+
+The old build file is kept unless --remove-old is given. Everything that
+could not be translated is listed in the report.`,
+		Example: `  # Move a Maven project to Gradle with the Kotlin DSL
+  haft convert --to gradle-kotlin
+
+  # Move a Gradle project to Maven and delete the Gradle files
+  haft convert --to maven --remove-old`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := buildtool.WorkingDir()
+			if err != nil {
+				return convertError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := convertProject(afero.NewOsFs(), dir, to, removeOld)
+			if err != nil {
+				return convertError(jsonOutput, "CONVERT_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printConversion(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Target build tool (maven, gradle, gradle-kotlin)")
+	cmd.Flags().BoolVar(&removeOld, "remove-old", false, "Delete the old build file and wrapper")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func convertProject(fs afero.Fs, dir, to string, removeOld bool) (output.ConvertOutput, error) {
+	out := output.ConvertOutput{Created: []string{}, Removed: []string{}, Unmapped: []output.UpgradeIssue{}}
+
+	target := buildtool.ParseType(to)
+	if target == "" {
+		return out, fmt.Errorf("unknown build tool %q; use maven, gradle or gradle-kotlin", to)
+	}
+
+	result, source, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return out, err
+	}
+	root := filepath.Dir(result.FilePath)
+	out.From, out.To = string(result.BuildTool), string(target)
+
+	if workspace, err := buildtool.DetectWorkspace(root, fs); err == nil && workspace.IsMultiModule() {
+		return out, fmt.Errorf("%s is a multi-module project; converting modules is not supported", buildtool.RelativePath(dir, root))
+	}
+	if result.BuildTool == target {
+		return out, fmt.Errorf("the project already uses %s", target.DisplayName())
+	}
+	buildFile := filepath.Join(root, buildtool.GetBuildFileName(target))
+	if exists(fs, buildFile) {
+		return out, fmt.Errorf("%s already exists", buildtool.RelativePath(dir, buildFile))
+	}
+
+	var issues []output.UpgradeIssue
+	if target == buildtool.Maven {
+		issues, err = writeMaven(fs, buildFile, source)
+	} else {
+		issues, err = writeGradle(fs, buildFile, source, target == buildtool.GradleKotln)
+	}
+	if err != nil {
+		return out, err
+	}
+	out.BuildFile = buildtool.RelativePath(dir, buildFile)
+	out.Created = append(out.Created, out.BuildFile)
+	for _, issue := range issues {
+		issue.File = buildtool.RelativePath(dir, result.FilePath)
+		out.Unmapped = append(out.Unmapped, issue)
+	}
+
+	engine := generator.NewEngine(fs)
+	created, err := addSupportFiles(engine, root, target, source)
+	if err != nil {
+		return out, err
+	}
+	for _, path := range created {
+		out.Created = append(out.Created, buildtool.RelativePath(dir, path))
+	}
+
+	if !removeOld {
+		return out, nil
+	}
+	for _, path := range oldFiles(root, result.BuildTool, target) {
+		if !exists(fs, path) {
+			continue
+		}
+		if err := fs.RemoveAll(path); err != nil {
+			return out, fmt.Errorf("could not remove %s: %w", path, err)
+		}
+		out.Removed = append(out.Removed, buildtool.RelativePath(dir, path))
+	}
+	if entries, err := afero.ReadDir(fs, filepath.Join(root, "gradle")); err == nil && len(entries) == 0 && !target.IsGradle() {
+		_ = fs.Remove(filepath.Join(root, "gradle"))
+	}
+	return out, nil
+}
+
+func addSupportFiles(engine *generator.Engine, root string, target buildtool.Type, source *buildtool.Project) ([]string, error) {
+	var created []string
+	if name, ok := settingsFiles[target]; ok && !engine.FileExists(filepath.Join(root, name)) {
+		path := filepath.Join(root, name)
+		if err := engine.RenderAndWrite("project/"+name+".tmpl", path, map[string]any{"ArtifactId": projectName(source, root)}); err != nil {
+			return nil, fmt.Errorf("could not write %s: %w", name, err)
+		}
+		created = append(created, path)
+	}
+
+	wrapper := buildtool.Maven
+	if target.IsGradle() {
+		wrapper = buildtool.Gradle
+	}
+	for _, file := range wrapperFiles[wrapper] {
+		path := filepath.Join(root, filepath.FromSlash(file.path))
+		if engine.FileExists(path) {
+			continue
+		}
+		content, err := engine.ReadTemplateFile(file.template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.template, err)
+		}
+		if err := engine.WriteFileWithPerm(path, content, file.perm); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
+		created = append(created, path)
+	}
+	return created, nil
+}
+
+func oldFiles(root string, from, to buildtool.Type) []string {
+	names := []string{buildtool.GetBuildFileName(from)}
+	switch {
+	case from == buildtool.Maven:
+		names = append(names, "mvnw", "mvnw.cmd", ".mvn")
+	case to == buildtool.Maven:
+		names = append(names, settingsFiles[from], "gradlew", "gradlew.bat", "gradle/wrapper", "gradle/libs.versions.toml")
+	default:
+		names = append(names, settingsFiles[from])
+	}
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(root, filepath.FromSlash(name)))
+	}
+	return paths
+}
+
+func projectName(source *buildtool.Project, root string) string {
+	if source.ArtifactId != "" {
+		return source.ArtifactId
+	}
+	return filepath.Base(root)
+}
+
+func printConversion(result output.ConvertOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Convert %s → %s", buildtool.Type(result.From).DisplayName(), buildtool.Type(result.To).DisplayName())))
+	fmt.Println()
+
+	fmt.Println(headerStyle.Render("Created:"))
+	for _, file := range result.Created {
+		fmt.Printf("  %s %s\n", passedStyle.Render("✓"), file)
+	}
+	fmt.Println()
+
+	if len(result.Removed) > 0 {
+		fmt.Println(headerStyle.Render("Removed:"))
+		for _, file := range result.Removed {
+			fmt.Printf("  %s %s\n", removedStyle.Render("✗"), file)
+		}
+		fmt.Println()
+	} else if result.From == string(buildtool.Maven) {
+		fmt.Printf("%s %s\n\n", warningStyle.Render("⚠"), labelStyle.Render("pom.xml is kept and haft reads it first; use --remove-old once the Gradle build works"))
+	}
+
+	if len(result.Unmapped) > 0 {
+		fmt.Println(headerStyle.Render("Not converted:"))
+		for _, issue := range result.Unmapped {
+			fmt.Printf("  %s %s %s\n", warningStyle.Render("⚠"), warningStyle.Render(issue.Item), labelStyle.Render(issue.File))
+			fmt.Printf("    %s\n", issue.Message)
+		}
+		fmt.Println()
+	}
+}
+
+func convertError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
+
+func exists(fs afero.Fs, path string) bool {
+	_, err := fs.Stat(path)
+	return err == nil
+}
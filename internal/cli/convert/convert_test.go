@@ -0,0 +1,333 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-starter-parent</artifactId>
+        <version>3.4.7</version>
+    </parent>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+    <version>0.0.1-SNAPSHOT</version>
+    <description>Demo project</description>
+
+    <properties>
+        <java.version>21</java.version>
+        <mapstruct.version>1.6.3</mapstruct.version>
+        <app.region>eu</app.region>
+    </properties>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.mapstruct</groupId>
+            <artifactId>mapstruct</artifactId>
+            <version>${mapstruct.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>org.projectlombok</groupId>
+            <artifactId>lombok</artifactId>
+            <optional>true</optional>
+        </dependency>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-devtools</artifactId>
+            <scope>runtime</scope>
+            <optional>true</optional>
+        </dependency>
+        <dependency>
+            <groupId>org.postgresql</groupId>
+            <artifactId>postgresql</artifactId>
+            <scope>runtime</scope>
+        </dependency>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-test</artifactId>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <annotationProcessorPaths>
+                        <path>
+                            <groupId>org.mapstruct</groupId>
+                            <artifactId>mapstruct-processor</artifactId>
+                            <version>${mapstruct.version}</version>
+                        </path>
+                    </annotationProcessorPaths>
+                    <showWarnings>true</showWarnings>
+                </configuration>
+            </plugin>
+            <plugin>
+                <groupId>org.springframework.boot</groupId>
+                <artifactId>spring-boot-maven-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <groupId>org.jacoco</groupId>
+                <artifactId>jacoco-maven-plugin</artifactId>
+                <version>0.8.12</version>
+            </plugin>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-surefire-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-enforcer-plugin</artifactId>
+                <version>3.4.1</version>
+            </plugin>
+            <plugin>
+                <groupId>com.example.build</groupId>
+                <artifactId>custom-maven-plugin</artifactId>
+                <version>1.0.0</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>
+`
+
+const buildGradle = `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.4.7'
+    id 'io.spring.dependency-management' version '1.1.7'
+    id 'jacoco'
+    id 'com.github.johnrengelman.shadow' version '8.1.1'
+}
+
+group = 'com.example'
+version = '1.0.0'
+description = 'Orders service'
+
+java {
+    sourceCompatibility = JavaVersion.VERSION_21
+}
+
+repositories {
+    mavenCentral()
+    maven { url 'https://repo.spring.io/milestone' }
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+    compileOnly 'org.projectlombok:lombok'
+    annotationProcessor 'org.projectlombok:lombok'
+    developmentOnly 'org.springframework.boot:spring-boot-devtools'
+    testImplementation 'org.springframework.boot:spring-boot-starter-test'
+}
+
+dependencyManagement {
+    imports {
+        mavenBom 'org.springframework.cloud:spring-cloud-dependencies:2024.0.1'
+    }
+}
+
+jacoco {
+    toolVersion = '0.8.12'
+}
+
+bootJar {
+    archiveFileName = 'app.jar'
+}
+
+tasks.named('test') {
+    useJUnitPlatform()
+}
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "convert", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	for _, flag := range []string{"to", "remove-old", "json"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestConvertProject_MavenToGradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom})
+
+	out, err := convertProject(fs, "/project", "gradle", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "maven", out.From)
+	assert.Equal(t, "gradle", out.To)
+	assert.Equal(t, []string{
+		"build.gradle",
+		"settings.gradle",
+		"gradle/wrapper/gradle-wrapper.properties",
+		"gradlew",
+		"gradlew.bat",
+	}, out.Created)
+	assert.Empty(t, out.Removed)
+	assert.Equal(t, []output.UpgradeIssue{
+		{File: "pom.xml", Item: "maven-compiler-plugin", Message: "plugin configuration is not converted (showWarnings)"},
+		{File: "pom.xml", Item: "org.apache.maven.plugins:maven-enforcer-plugin", Message: "no Gradle equivalent known"},
+		{File: "pom.xml", Item: "com.example.build:custom-maven-plugin", Message: "no Gradle equivalent known"},
+	}, out.Unmapped)
+
+	build := testutil.ReadFile(t, fs, "/project/build.gradle")
+	for _, line := range []string{
+		"id 'org.springframework.boot' version '3.4.7'",
+		"id 'io.spring.dependency-management' version '1.1.7'",
+		"group = 'com.example'",
+		"version = '0.0.1-SNAPSHOT'",
+		"description = 'Demo project'",
+		"languageVersion = JavaLanguageVersion.of(21)",
+		"implementation 'org.springframework.boot:spring-boot-starter-web'",
+		"implementation 'org.mapstruct:mapstruct:1.6.3'",
+		"compileOnly 'org.projectlombok:lombok'",
+		"annotationProcessor 'org.projectlombok:lombok'",
+		"annotationProcessor 'org.mapstruct:mapstruct-processor:1.6.3'",
+		"developmentOnly 'org.springframework.boot:spring-boot-devtools'",
+		"runtimeOnly 'org.postgresql:postgresql'",
+		"testImplementation 'org.springframework.boot:spring-boot-starter-test'",
+		"testRuntimeOnly 'org.junit.platform:junit-platform-launcher'",
+		"id 'jacoco'",
+		"useJUnitPlatform()",
+	} {
+		assert.Contains(t, build, line)
+	}
+	assert.NotContains(t, build, "mapstruct.version")
+	assert.NotContains(t, build, "java.version")
+	assert.Contains(t, build, "app.region")
+
+	assert.Equal(t, "rootProject.name = 'demo'\n", testutil.ReadFile(t, fs, "/project/settings.gradle"))
+	info, err := fs.Stat("/project/gradlew")
+	require.NoError(t, err)
+	assert.Equal(t, "-rwxr-xr-x", info.Mode().String())
+	assert.Equal(t, pom, testutil.ReadFile(t, fs, "/project/pom.xml"))
+}
+
+func TestConvertProject_MavenToGradleKotlin(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom, "mvnw": "#!/bin/sh", ".mvn/wrapper/maven-wrapper.properties": ""})
+
+	out, err := convertProject(fs, "/project", "gradle-kotlin", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "build.gradle.kts", out.BuildFile)
+	assert.Equal(t, []string{"pom.xml", "mvnw", ".mvn"}, out.Removed)
+	for _, name := range []string{"pom.xml", "mvnw", ".mvn"} {
+		exists, _ := afero.Exists(fs, "/project/"+name)
+		assert.False(t, exists, name)
+	}
+
+	build := testutil.ReadFile(t, fs, "/project/build.gradle.kts")
+	for _, line := range []string{
+		"    java\n",
+		`id("org.springframework.boot") version "3.4.7"`,
+		`group = "com.example"`,
+		"languageVersion.set(JavaLanguageVersion.of(21))",
+		`compileOnly("org.projectlombok:lombok")`,
+		`developmentOnly("org.springframework.boot:spring-boot-devtools")`,
+		"tasks.withType<Test> {",
+	} {
+		assert.Contains(t, build, line)
+	}
+	assert.Equal(t, "rootProject.name = \"demo\"\n", testutil.ReadFile(t, fs, "/project/settings.gradle.kts"))
+}
+
+func TestConvertProject_GradleToMaven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"build.gradle":    buildGradle,
+		"settings.gradle": "rootProject.name = 'orders'\n",
+		"gradlew":         "#!/bin/sh",
+		"gradle/wrapper/gradle-wrapper.properties": "",
+	})
+
+	out, err := convertProject(fs, "/project", "maven", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pom.xml", ".mvn/wrapper/maven-wrapper.properties", "mvnw", "mvnw.cmd"}, out.Created)
+	assert.Equal(t, []string{"build.gradle", "settings.gradle", "gradlew", "gradle/wrapper"}, out.Removed)
+	assert.Equal(t, []output.UpgradeIssue{
+		{File: "build.gradle", Item: "plugin com.github.johnrengelman.shadow", Message: "no Maven equivalent known"},
+		{File: "build.gradle", Item: "bootJar", Message: "build script section is not converted"},
+	}, out.Unmapped)
+	exists, _ := afero.DirExists(fs, "/project/gradle")
+	assert.False(t, exists)
+
+	project, err := buildtool.GetParser(buildtool.Maven, fs).Parse("/project/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", project.ArtifactId)
+	assert.Equal(t, "Orders service", project.Description)
+	assert.Equal(t, "3.4.7", project.SpringBootVersion)
+	assert.Equal(t, "21", project.JavaVersion)
+
+	scopes := map[string]string{}
+	for _, dep := range project.Dependencies {
+		scopes[dep.ArtifactId] = dep.Scope
+	}
+	assert.Equal(t, map[string]string{
+		"spring-boot-starter-web":  "",
+		"lombok":                   "provided",
+		"spring-boot-devtools":     "runtime",
+		"spring-boot-starter-test": "test",
+	}, scopes)
+	assert.Equal(t, "spring-cloud-dependencies", project.ManagedDependencies[0].ArtifactId)
+	assert.Equal(t, "https://repo.spring.io/milestone", project.Repositories[0].URL)
+
+	_, hasJacoco := project.Plugin(buildtool.Plugin{ArtifactId: "jacoco-maven-plugin", GroupId: "org.jacoco"})
+	assert.True(t, hasJacoco)
+}
+
+func TestConvertProject_GradleToKotlin(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"build.gradle": buildGradle, "settings.gradle": "rootProject.name = 'orders'\n"})
+
+	out, err := convertProject(fs, "/project", "gradle-kotlin", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []output.UpgradeIssue{
+		{File: "build.gradle", Item: "bootJar", Message: "build script section is not converted"},
+	}, out.Unmapped)
+	build := testutil.ReadFile(t, fs, "/project/build.gradle.kts")
+	assert.Contains(t, build, `id("com.github.johnrengelman.shadow") version "8.1.1"`)
+	assert.Contains(t, build, `mavenBom("org.springframework.cloud:spring-cloud-dependencies:2024.0.1")`)
+	assert.Contains(t, build, "tasks.jacocoTestReport {")
+	assert.Equal(t, buildGradle, testutil.ReadFile(t, fs, "/project/build.gradle"))
+}
+
+func TestConvertProject_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		to    string
+		err   string
+	}{
+		{name: "unknown target", files: map[string]string{"pom.xml": pom}, to: "ant", err: "unknown build tool"},
+		{name: "same build tool", files: map[string]string{"pom.xml": pom}, to: "maven", err: "already uses Maven"},
+		{name: "target exists", files: map[string]string{"pom.xml": pom, "build.gradle": buildGradle}, to: "gradle", err: "build.gradle already exists"},
+		{name: "multi-module", files: map[string]string{"pom.xml": "<project><modules><module>api</module></modules></project>", "api/pom.xml": pom}, to: "gradle", err: "multi-module"},
+		{name: "no build file", files: map[string]string{"README.md": ""}, to: "gradle", err: "could not find build file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := testutil.MemFs(t, "/project", tt.files)
+			_, err := convertProject(fs, "/project", tt.to, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/gradle"
+	"github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+)
+
+var wellKnownRepositories = map[string]bool{"google": true, "mavenLocal": true, "gradlePluginPortal": true}
+
+type gradleBuild struct {
+	source   *buildtool.Project
+	project  *buildtool.Project
+	parser   *gradle.Parser
+	consumed map[string]bool
+	declared map[string]bool
+	issues   []output.UpgradeIssue
+}
+
+func writeGradle(fs afero.Fs, path string, source *buildtool.Project, kotlin bool) ([]output.UpgradeIssue, error) {
+	if err := afero.WriteFile(fs, path, []byte(gradleSkeleton(source, kotlin)), 0644); err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", path, err)
+	}
+	parser := gradle.NewParserWithFs(fs, kotlin)
+	project, err := parser.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &gradleBuild{source: source, project: project, parser: parser, consumed: map[string]bool{}, declared: map[string]bool{}}
+	build.managedDependencies()
+	build.dependencies()
+	build.plugins()
+	build.properties()
+	build.repositories()
+
+	if err := parser.Write(path, project); err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", path, err)
+	}
+	return build.issues, nil
+}
+
+func gradleSkeleton(source *buildtool.Project, kotlin bool) string {
+	quote := "'"
+	if kotlin {
+		quote = `"`
+	}
+	str := func(value string) string { return quote + value + quote }
+
+	var b strings.Builder
+	b.WriteString("plugins {\n")
+	if kotlin {
+		b.WriteString("    java\n")
+	} else {
+		b.WriteString("    id 'java'\n")
+	}
+	if source.SpringBootVersion != "" {
+		managementVersion := dependencyManagementLatest
+		if declared, ok := source.Plugin(buildtool.Plugin{ID: dependencyManagementPlugin}); ok && declared.Version != "" {
+			managementVersion = declared.Version
+		}
+		for _, declared := range [][2]string{{bootPluginId, source.SpringBootVersion}, {dependencyManagementPlugin, managementVersion}} {
+			if kotlin {
+				fmt.Fprintf(&b, "    id(%s) version %s\n", str(declared[0]), str(declared[1]))
+			} else {
+				fmt.Fprintf(&b, "    id %s version %s\n", str(declared[0]), str(declared[1]))
+			}
+		}
+	}
+	b.WriteString("}\n\n")
+
+	for _, field := range [][2]string{{"group", source.GroupId}, {"version", source.Version}, {"description", source.Description}} {
+		if field[1] != "" {
+			fmt.Fprintf(&b, "%s = %s\n", field[0], str(field[1]))
+		}
+	}
+
+	if java := toolchainVersion(source); java != "" {
+		b.WriteString("\njava {\n    toolchain {\n")
+		if kotlin {
+			fmt.Fprintf(&b, "        languageVersion.set(JavaLanguageVersion.of(%s))\n", java)
+		} else {
+			fmt.Fprintf(&b, "        languageVersion = JavaLanguageVersion.of(%s)\n", java)
+		}
+		b.WriteString("    }\n}\n")
+	}
+
+	b.WriteString("\nrepositories {\n    mavenCentral()\n")
+	for _, repo := range source.Repositories {
+		if wellKnownRepositories[repo.ID] {
+			fmt.Fprintf(&b, "    %s()\n", repo.ID)
+		}
+	}
+	b.WriteString("}\n\ndependencies {\n}\n\n")
+
+	if kotlin {
+		b.WriteString("tasks.withType<Test> {\n    useJUnitPlatform()\n}\n")
+	} else {
+		b.WriteString("tasks.named('test') {\n    useJUnitPlatform()\n}\n")
+	}
+	return b.String()
+}
+
+func toolchainVersion(source *buildtool.Project) string {
+	version := source.JavaVersion
+	for _, key := range []string{"maven.compiler.release", "maven.compiler.source"} {
+		if value, ok := source.Property(key); ok && version == "" {
+			version = value
+		}
+	}
+	return strings.TrimPrefix(version, "1.")
+}
+
+func (g *gradleBuild) resolve(version string) string {
+	if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+		key := version[2 : len(version)-1]
+		if value, ok := g.source.Property(key); ok {
+			g.consumed[key] = true
+			return value
+		}
+	}
+	return version
+}
+
+func (g *gradleBuild) managedDependencies() {
+	for _, dep := range g.source.ManagedDependencies {
+		if dep.Type == "pom" && dep.Scope == "import" {
+			if dep.GroupId == bootGroup && dep.ArtifactId == bootBom && g.source.SpringBootVersion != "" {
+				g.resolve(dep.Version)
+				continue
+			}
+			dep.Version = g.resolve(dep.Version)
+			g.parser.AddManagedDependency(g.project, dep)
+			continue
+		}
+		g.issues = append(g.issues, unmapped("managed "+coordinates(dep), "only BOM imports can be converted; the version is written on the dependency instead"))
+	}
+}
+
+func (g *gradleBuild) managedVersion(dep buildtool.Dependency) string {
+	if dep.Version != "" {
+		return g.resolve(dep.Version)
+	}
+	for _, managed := range g.source.ManagedDependencies {
+		if managed.GroupId == dep.GroupId && managed.ArtifactId == dep.ArtifactId && managed.Type != "pom" {
+			return g.resolve(managed.Version)
+		}
+	}
+	return ""
+}
+
+func (g *gradleBuild) declare(configuration string, dep buildtool.Dependency) {
+	key := configuration + " " + coordinates(dep)
+	if g.declared[key] {
+		return
+	}
+	g.declared[key] = true
+	g.parser.AddDeclaration(g.project, configuration, dep)
+}
+
+func (g *gradleBuild) dependencies() {
+	seen := map[string]bool{}
+	for _, dep := range g.source.Dependencies {
+		if seen[coordinates(dep)] {
+			continue
+		}
+		seen[coordinates(dep)] = true
+		dep.Version = g.managedVersion(dep)
+		dep.Optional = false
+
+		if dep.Scope == "system" {
+			g.issues = append(g.issues, unmapped(coordinates(dep), "system scope dependencies have no Gradle equivalent"))
+			continue
+		}
+		if dep.Classifier != "" || (dep.Type != "" && dep.Type != "jar") {
+			g.issues = append(g.issues, unmapped(coordinates(dep), "the classifier and type of this dependency are not converted"))
+			dep.Classifier, dep.Type = "", ""
+		}
+
+		configurations := gradleConfigurations[coordinates(dep)]
+		if dep.Scope == "test" {
+			configurations = testConfigurations[coordinates(dep)]
+		}
+		if developmentOnly[coordinates(dep)] && g.source.SpringBootVersion == "" {
+			configurations = nil
+		}
+		if len(configurations) == 0 {
+			g.parser.AddDependency(g.project, dep)
+			continue
+		}
+		for _, configuration := range configurations {
+			g.declare(configuration, dep)
+		}
+	}
+
+	for _, declared := range g.source.Plugins {
+		for _, dep := range processorPaths(declared) {
+			dep.Version = g.resolve(dep.Version)
+			g.declare("annotationProcessor", dep)
+		}
+	}
+
+	if seen[bootGroup+":spring-boot-starter-test"] && !seen["org.junit.platform:junit-platform-launcher"] {
+		g.declare("testRuntimeOnly", buildtool.Dependency{GroupId: "org.junit.platform", ArtifactId: "junit-platform-launcher"})
+	}
+}
+
+func (g *gradleBuild) plugins() {
+	if g.source.Packaging == "war" {
+		g.parser.AddPlugin(g.project, buildtool.Plugin{ID: "war"})
+	} else if g.source.Packaging != "" && g.source.Packaging != "jar" {
+		g.issues = append(g.issues, unmapped("packaging "+g.source.Packaging, "no Gradle equivalent known"))
+	}
+
+	if raw, ok := g.source.Raw.(*maven.MavenProject); ok && raw.Build != nil && raw.Build.PluginManagement != nil {
+		g.issues = append(g.issues, unmapped("pluginManagement", "plugin management is not converted"))
+	}
+
+	for _, declared := range g.source.Plugins {
+		if g.source.BuildTool == buildtool.Maven {
+			g.mavenPlugin(declared)
+			continue
+		}
+		if implicitGradlePlugins[declared.ID] {
+			continue
+		}
+		if entry, ok := catalogEntry(declared, g.source.BuildTool); ok {
+			declared = entry.Gradle
+		}
+		g.parser.AddPlugin(g.project, declared)
+	}
+
+	if g.source.BuildTool != buildtool.Maven {
+		g.issues = append(g.issues, gradleLeftovers(g.source, g.scripted())...)
+	}
+}
+
+func (g *gradleBuild) mavenPlugin(declared buildtool.Plugin) {
+	if declared.ArtifactId == bootMavenPlugin {
+		if names := settingNames(declared.Configuration, "configuration"); len(names) > 0 && !(len(names) == 1 && names[0] == "excludes") {
+			g.issues = append(g.issues, unmapped(declared.ArtifactId, "plugin configuration is not converted"))
+		}
+		return
+	}
+
+	if entry, ok := catalogEntry(declared, buildtool.Maven); ok {
+		if customized(declared, entry.Maven) {
+			g.issues = append(g.issues, unmapped(declared.Key(), "the plugin is added with haft's default configuration; its own configuration is not converted"))
+		}
+		g.parser.AddPlugin(g.project, entry.Gradle)
+		return
+	}
+
+	if (declared.GroupId != "" && declared.GroupId != mavenPluginGroup) || !gradleBuiltinPlugins[declared.ArtifactId] {
+		g.issues = append(g.issues, unmapped(declared.Key(), "no Gradle equivalent known"))
+		return
+	}
+	var unknown []string
+	for _, name := range settingNames(declared.Configuration, "configuration") {
+		if declared.ArtifactId != "maven-compiler-plugin" || !compilerSettings[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		g.issues = append(g.issues, unmapped(declared.ArtifactId, "plugin configuration is not converted ("+strings.Join(unknown, ", ")+")"))
+	}
+}
+
+func (g *gradleBuild) scripted() map[string]bool {
+	scripted := map[string]bool{}
+	for _, declared := range g.source.Plugins {
+		if entry, ok := catalogEntry(declared, g.source.BuildTool); ok {
+			for _, script := range []string{entry.Gradle.Script, entry.Gradle.KotlinScript} {
+				for _, statement := range gradle.TopLevelStatements(script) {
+					scripted[sectionName(statement)] = true
+				}
+			}
+		}
+	}
+	return scripted
+}
+
+func (g *gradleBuild) properties() {
+	for _, prop := range g.source.Properties {
+		if mavenOnlyProperties[prop.Key] || g.consumed[prop.Key] {
+			continue
+		}
+		g.parser.SetProperty(g.project, prop.Key, prop.Value)
+	}
+}
+
+func (g *gradleBuild) repositories() {
+	for _, repo := range g.source.Repositories {
+		switch {
+		case repo.ID == "mavenCentral" || wellKnownRepositories[repo.ID] || strings.Contains(repo.URL, "repo.maven.apache.org") || strings.Contains(repo.URL, "repo1.maven.org"):
+			continue
+		case repo.Plugin:
+			g.issues = append(g.issues, unmapped("pluginRepository "+repo.ID, "add it to pluginManagement in the settings file"))
+			continue
+		}
+		g.parser.AddRepository(g.project, repo)
+	}
+
+	for _, profile := range g.source.Profiles {
+		if g.source.BuildTool == buildtool.Maven {
+			g.issues = append(g.issues, unmapped("profile "+profile.ID, fmt.Sprintf("%d dependencies of this Maven profile have no Gradle equivalent", len(profile.Dependencies))))
+			continue
+		}
+		for _, dep := range profile.Dependencies {
+			g.parser.AddProfileDependency(g.project, profile.ID, dep)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package convert
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/cli/plugin"
+	"github.com/KashifKhn/haft/internal/output"
+)
+
+const (
+	bootGroup                  = "org.springframework.boot"
+	bootPluginId               = "org.springframework.boot"
+	bootMavenPlugin            = "spring-boot-maven-plugin"
+	bootBom                    = "spring-boot-dependencies"
+	dependencyManagementPlugin = "io.spring.dependency-management"
+	dependencyManagementLatest = "1.1.7"
+	mavenPluginGroup           = "org.apache.maven.plugins"
+)
+
+var gradleConfigurations = map[string][]string{
+	"org.projectlombok:lombok":                                     {"compileOnly", "annotationProcessor"},
+	"org.mapstruct:mapstruct-processor":                            {"annotationProcessor"},
+	"org.springframework.boot:spring-boot-configuration-processor": {"annotationProcessor"},
+	"org.hibernate.orm:hibernate-jpamodelgen":                      {"annotationProcessor"},
+	"org.springframework.boot:spring-boot-devtools":                {"developmentOnly"},
+	"org.springframework.boot:spring-boot-docker-compose":          {"developmentOnly"},
+}
+
+var testConfigurations = map[string][]string{
+	"org.projectlombok:lombok": {"testCompileOnly", "testAnnotationProcessor"},
+}
+
+var developmentOnly = map[string]bool{
+	"org.springframework.boot:spring-boot-devtools":       true,
+	"org.springframework.boot:spring-boot-docker-compose": true,
+}
+
+var mavenOnlyProperties = map[string]bool{
+	"java.version":                     true,
+	"project.build.sourceEncoding":     true,
+	"project.reporting.outputEncoding": true,
+	"maven.compiler.source":            true,
+	"maven.compiler.target":            true,
+	"maven.compiler.release":           true,
+}
+
+var compilerSettings = map[string]bool{
+	"annotationProcessorPaths": true,
+	"source":                   true,
+	"target":                   true,
+	"release":                  true,
+	"parameters":               true,
+	"encoding":                 true,
+}
+
+var gradleBuiltinPlugins = map[string]bool{
+	"maven-compiler-plugin":  true,
+	"maven-surefire-plugin":  true,
+	"maven-resources-plugin": true,
+	"maven-jar-plugin":       true,
+	"maven-install-plugin":   true,
+	"maven-deploy-plugin":    true,
+}
+
+var implicitGradlePlugins = map[string]bool{
+	"java":                     true,
+	"java-library":             true,
+	bootPluginId:               true,
+	dependencyManagementPlugin: true,
+}
+
+var gradleSections = map[string]bool{
+	"plugins":              true,
+	"group":                true,
+	"version":              true,
+	"description":          true,
+	"java":                 true,
+	"sourceCompatibility":  true,
+	"targetCompatibility":  true,
+	"repositories":         true,
+	"dependencies":         true,
+	"dependencyManagement": true,
+	"ext":                  true,
+	"extra":                true,
+	"buildscript":          true,
+	"apply":                true,
+	"configurations":       true,
+}
+
+var (
+	sectionHeadPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+	testTaskPattern    = regexp.MustCompile(`^(tasks\.named\(['"]test['"]\)|tasks\.withType<Test>|tasks\.test|test)\{useJUnitPlatform\(\)\}$`)
+)
+
+func coordinates(dep buildtool.Dependency) string {
+	return dep.GroupId + ":" + dep.ArtifactId
+}
+
+func unmapped(item, message string) output.UpgradeIssue {
+	return output.UpgradeIssue{Item: item, Message: message}
+}
+
+func catalogEntry(declared buildtool.Plugin, tool buildtool.Type) (plugin.CatalogEntry, bool) {
+	for _, shortcut := range plugin.GetAllShortcuts() {
+		entry, _ := plugin.GetCatalogEntry(shortcut)
+		candidate := entry.Gradle
+		if tool == buildtool.Maven {
+			candidate = entry.Maven
+		}
+		if candidate.Matches(declared) {
+			return entry, true
+		}
+	}
+	return plugin.CatalogEntry{}, false
+}
+
+func customized(declared, defaults buildtool.Plugin) bool {
+	return len(declared.Configuration) > 0 && !reflect.DeepEqual(declared.Configuration, defaults.Configuration)
+}
+
+func settingNames(settings []buildtool.Setting, name string) []string {
+	var names []string
+	for _, setting := range settings {
+		if setting.Name != name {
+			names = append(names, setting.Name)
+			continue
+		}
+		for _, child := range setting.Children {
+			names = append(names, child.Name)
+		}
+	}
+	return names
+}
+
+func findSetting(settings []buildtool.Setting, path ...string) (buildtool.Setting, bool) {
+	for _, setting := range settings {
+		if setting.Name != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return setting, true
+		}
+		return findSetting(setting.Children, path[1:]...)
+	}
+	return buildtool.Setting{}, false
+}
+
+func settingValue(settings []buildtool.Setting, name string) string {
+	if setting, ok := findSetting(settings, name); ok {
+		return setting.Value
+	}
+	return ""
+}
+
+func processorPaths(declared buildtool.Plugin) []buildtool.Dependency {
+	paths, ok := findSetting(declared.Configuration, "configuration", "annotationProcessorPaths")
+	if !ok {
+		return nil
+	}
+	var deps []buildtool.Dependency
+	for _, path := range paths.Children {
+		deps = append(deps, buildtool.Dependency{
+			GroupId:    settingValue(path.Children, "groupId"),
+			ArtifactId: settingValue(path.Children, "artifactId"),
+			Version:    settingValue(path.Children, "version"),
+		})
+	}
+	return deps
+}
+
+func sectionName(statement string) string {
+	line := strings.SplitN(statement, "\n", 2)[0]
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "{"))
+}
+
+func knownSection(statement string, scripted map[string]bool) bool {
+	head := sectionHeadPattern.FindString(statement)
+	if head == "" {
+		return false
+	}
+	if gradleSections[strings.SplitN(head, ".", 2)[0]] || scripted[sectionName(statement)] {
+		return true
+	}
+	return testTaskPattern.MatchString(strings.Join(strings.Fields(statement), ""))
+}
@@ -0,0 +1,131 @@
+package convert
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/gradle"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+)
+
+func writeMaven(fs afero.Fs, path string, source *buildtool.Project) ([]output.UpgradeIssue, error) {
+	project, issues := toMaven(source)
+	parser := buildtool.GetParser(buildtool.Maven, fs)
+	if err := parser.Write(path, project); err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", path, err)
+	}
+	return issues, nil
+}
+
+func toMaven(source *buildtool.Project) (*buildtool.Project, []output.UpgradeIssue) {
+	project := &buildtool.Project{
+		GroupId:           source.GroupId,
+		ArtifactId:        source.ArtifactId,
+		Version:           source.Version,
+		Name:              source.Name,
+		Description:       source.Description,
+		JavaVersion:       source.JavaVersion,
+		SpringBootVersion: source.SpringBootVersion,
+		BuildTool:         buildtool.Maven,
+	}
+	var issues []output.UpgradeIssue
+	if project.GroupId == "" {
+		issues = append(issues, unmapped("group", "the Gradle build sets no group; add a groupId to pom.xml"))
+	}
+
+	for _, prop := range source.Properties {
+		if prop.Key != "java.version" {
+			project.Properties = append(project.Properties, prop)
+		}
+	}
+
+	for _, repo := range source.Repositories {
+		switch repo.ID {
+		case "mavenCentral", "mavenLocal":
+			continue
+		case "gradlePluginPortal":
+			issues = append(issues, unmapped("repository gradlePluginPortal", "the Gradle plugin portal has no use in a Maven build"))
+			continue
+		}
+		if repo.ID == repo.URL {
+			repo.ID = repositoryId(repo.URL)
+		}
+		project.Repositories = append(project.Repositories, repo)
+	}
+
+	seen := map[string]bool{}
+	for _, dep := range source.Dependencies {
+		if seen[coordinates(dep)] {
+			continue
+		}
+		seen[coordinates(dep)] = true
+		if dep.Scope == "compile" {
+			dep.Scope = ""
+		}
+		if developmentOnly[coordinates(dep)] {
+			dep.Optional = true
+		}
+		project.Dependencies = append(project.Dependencies, dep)
+	}
+
+	for _, dep := range source.ManagedDependencies {
+		dep.Type, dep.Scope = "pom", "import"
+		project.ManagedDependencies = append(project.ManagedDependencies, dep)
+	}
+
+	for _, profile := range source.Profiles {
+		issues = append(issues, unmapped("source set "+profile.ID, fmt.Sprintf("%d dependencies of this source set have no Maven equivalent", len(profile.Dependencies))))
+	}
+
+	scripted := map[string]bool{}
+	for _, declared := range source.Plugins {
+		switch {
+		case declared.ID == bootPluginId:
+			project.Plugins = append(project.Plugins, buildtool.Plugin{GroupId: bootGroup, ArtifactId: bootMavenPlugin})
+			continue
+		case implicitGradlePlugins[declared.ID]:
+			continue
+		case declared.ID == "war":
+			project.Packaging = "war"
+			continue
+		}
+		entry, ok := catalogEntry(declared, source.BuildTool)
+		if !ok {
+			issues = append(issues, unmapped("plugin "+declared.ID, "no Maven equivalent known"))
+			continue
+		}
+		project.Plugins = append(project.Plugins, entry.Maven)
+		for _, script := range []string{entry.Gradle.Script, entry.Gradle.KotlinScript} {
+			for _, statement := range gradle.TopLevelStatements(script) {
+				scripted[sectionName(statement)] = true
+			}
+		}
+	}
+
+	return project, append(issues, gradleLeftovers(source, scripted)...)
+}
+
+func gradleLeftovers(source *buildtool.Project, scripted map[string]bool) []output.UpgradeIssue {
+	raw, ok := source.Raw.(*gradle.GradleProject)
+	if !ok {
+		return nil
+	}
+	var issues []output.UpgradeIssue
+	for _, statement := range gradle.TopLevelStatements(raw.Content) {
+		if !knownSection(statement, scripted) {
+			issues = append(issues, unmapped(sectionName(statement), "build script section is not converted"))
+		}
+	}
+	return issues
+}
+
+func repositoryId(address string) string {
+	parsed, err := url.Parse(address)
+	if err != nil || parsed.Host == "" {
+		return address
+	}
+	return strings.ReplaceAll(parsed.Hostname(), ".", "-")
+}
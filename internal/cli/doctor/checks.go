@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/KashifKhn/haft/internal/source"
 	"github.com/spf13/afero"
 )
 
@@ -256,7 +257,7 @@ func (c *Checker) checkMainClass() CheckResult {
 			if err != nil {
 				return nil
 			}
-			if strings.Contains(string(content), "@SpringBootApplication") {
+			if hasSpringBootApplication(source.Parse(string(content), source.LanguageFor(path))) {
 				found = true
 				return filepath.SkipAll
 			}
@@ -277,6 +278,15 @@ func (c *Checker) checkMainClass() CheckResult {
 	return result
 }
 
+func hasSpringBootApplication(file *source.File) bool {
+	for _, typ := range file.AllTypes() {
+		if typ.Annotations.Has("SpringBootApplication") {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Checker) checkConfigFile() CheckResult {
 	result := CheckResult{
 		Name:     "config_file",
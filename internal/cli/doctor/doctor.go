@@ -2,9 +2,9 @@ package doctor
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
+	"github.com/KashifKhn/haft/internal/buildtool"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +13,7 @@ func NewCommand() *cobra.Command {
 	var jsonOutput bool
 	var strict bool
 	var category string
+	var module string
 
 	cmd := &cobra.Command{
 		Use:     "doctor",
@@ -29,7 +30,11 @@ The doctor command performs comprehensive health checks including:
   - Dependency recommendations
   - Docker configuration (Dockerfile, docker-compose, .dockerignore)
 
-It helps identify problems early and suggests improvements.`,
+It helps identify problems early and suggests improvements.
+
+At the root of a multi-module Maven or Gradle project, every module is
+checked and results are tagged with their module. Use --module to check
+a single module.`,
 		Example: `  # Run full health check
   haft doctor
 
@@ -41,31 +46,36 @@ It helps identify problems early and suggests improvements.`,
 
   # Filter by category
   haft doctor --category security
-  haft doctor --category docker`,
+  haft doctor --category docker
+
+  # Check a single module
+  haft doctor --module api`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDoctor(Options{
 				JSON:     jsonOutput,
 				Strict:   strict,
 				Category: category,
+				Module:   module,
 			})
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
 	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with code 1 on any warning")
+	cmd.Flags().StringVar(&module, "module", "", "Target module in a multi-module project")
 	cmd.Flags().StringVar(&category, "category", "", "Filter by category (build, source, config, security, dependencies, best-practice, docker)")
 
 	return cmd
 }
 
 func runDoctor(opts Options) error {
-	cwd, err := os.Getwd()
+	fs := afero.NewOsFs()
+	dir, err := buildtool.WorkingModuleDir(fs, opts.Module)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
-	fs := afero.NewOsFs()
-	report := RunDoctorChecks(fs, cwd, opts)
+	report := RunDoctorChecks(fs, dir, opts)
 
 	output := FormatReport(report, opts)
 	fmt.Print(output)
@@ -83,7 +93,17 @@ func runDoctor(opts Options) error {
 
 func RunDoctorChecks(fs afero.Fs, projectPath string, opts Options) *Report {
 	checker := NewChecker(fs, projectPath)
-	results := checker.RunAllChecks()
+	modules := workspaceModules(fs, projectPath)
+
+	var results []CheckResult
+	if len(modules) == 0 {
+		results = checker.RunAllChecks()
+	} else {
+		checker.detectBuildTool()
+	}
+	for _, module := range modules {
+		results = append(results, moduleResults(fs, module)...)
+	}
 
 	if opts.Category != "" {
 		results = filterByCategory(results, Category(opts.Category))
@@ -95,6 +115,9 @@ func RunDoctorChecks(fs afero.Fs, projectPath string, opts Options) *Report {
 		BuildTool:   checker.buildTool,
 		Results:     results,
 	}
+	for _, module := range modules {
+		report.Modules = append(report.Modules, module.Name)
+	}
 
 	report.CalculateCounts()
 	return report
@@ -109,3 +132,22 @@ func filterByCategory(results []CheckResult, category Category) []CheckResult {
 	}
 	return filtered
 }
+
+func workspaceModules(fs afero.Fs, projectPath string) []buildtool.Module {
+	workspace, err := buildtool.DetectWorkspace(projectPath, fs)
+	if err != nil || !workspace.IsMultiModule() || workspace.ModuleFor(projectPath) != nil {
+		return nil
+	}
+	if filepath.Clean(workspace.Root) != filepath.Clean(projectPath) {
+		return nil
+	}
+	return workspace.SourceModules(fs)
+}
+
+func moduleResults(fs afero.Fs, module buildtool.Module) []CheckResult {
+	results := NewChecker(fs, module.Dir).RunAllChecks()
+	for i := range results {
+		results[i].Module = module.Name
+	}
+	return results
+}
@@ -224,6 +224,40 @@ func TestCheckMainClass_NotFound(t *testing.T) {
 	assert.Equal(t, SeverityError, result.Severity)
 }
 
+func TestCheckMainClass_IgnoresCommentsAndStrings(t *testing.T) {
+	fs := setupFs(t)
+	mkdirAll(t, fs, "/project/src/main/java/com/example")
+	writeFile(t, fs, "/project/src/main/java/com/example/Application.java", `package com.example;
+
+// @SpringBootApplication
+public class Application {
+    private static final String NOTE = "@SpringBootApplication";
+}`)
+
+	checker := NewChecker(fs, "/project")
+	result := checker.checkMainClass()
+
+	assert.False(t, result.Passed)
+}
+
+func TestCheckMainClass_Kotlin(t *testing.T) {
+	fs := setupFs(t)
+	mkdirAll(t, fs, "/project/src/main/kotlin/com/example")
+	writeFile(t, fs, "/project/src/main/kotlin/com/example/Application.kt", `package com.example
+
+@org.springframework.boot.autoconfigure.SpringBootApplication(scanBasePackages = ["com.example"])
+class Application
+
+fun main(args: Array<String>) {
+    runApplication<Application>(*args)
+}`)
+
+	checker := NewChecker(fs, "/project")
+	result := checker.checkMainClass()
+
+	assert.True(t, result.Passed)
+}
+
 func TestCheckConfigFile_YmlExists(t *testing.T) {
 	fs := setupFs(t)
 	mkdirAll(t, fs, "/project/src/main/resources")
@@ -780,3 +814,23 @@ func TestFilterByCategory_Docker(t *testing.T) {
 	dockerResults := filterByCategory(results, CategoryDocker)
 	assert.Len(t, dockerResults, 2)
 }
+
+func TestRunDoctorChecks_MultiModule(t *testing.T) {
+	fs := setupFs(t)
+	writeFile(t, fs, "/shop/pom.xml", "<project><modules><module>api</module><module>bom</module></modules></project>")
+	writeFile(t, fs, "/shop/api/pom.xml", "<project></project>")
+	writeFile(t, fs, "/shop/bom/pom.xml", "<project></project>")
+	mkdirAll(t, fs, "/shop/api/src/main/java/com/example")
+
+	report := RunDoctorChecks(fs, "/shop", Options{})
+
+	assert.Equal(t, []string{"api"}, report.Modules)
+	require.NotEmpty(t, report.Results)
+	for _, result := range report.Results {
+		assert.Equal(t, "api", result.Module)
+	}
+
+	text := FormatReport(report, Options{})
+	assert.Contains(t, text, "Modules: api")
+	assert.Contains(t, text, "[api] ")
+}
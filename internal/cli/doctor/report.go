@@ -65,6 +65,9 @@ func formatText(report *Report, opts Options) string {
 	if report.BuildTool != "" {
 		fmt.Fprintf(&sb, "Build Tool: %s\n", report.BuildTool)
 	}
+	if len(report.Modules) > 0 {
+		fmt.Fprintf(&sb, "Modules: %s\n", strings.Join(report.Modules, ", "))
+	}
 	if report.JavaVersion != "" {
 		fmt.Fprintf(&sb, "Java: %s\n", report.JavaVersion)
 	}
@@ -85,7 +88,7 @@ func formatText(report *Report, opts Options) string {
 		for _, r := range passed {
 			fmt.Fprintf(&sb, "  %s %s\n",
 				passedStyle.Render("✓"),
-				r.Message,
+				resultMessage(r),
 			)
 		}
 		sb.WriteString("\n")
@@ -97,7 +100,7 @@ func formatText(report *Report, opts Options) string {
 		for _, r := range errors {
 			fmt.Fprintf(&sb, "  %s %s\n",
 				errorStyle.Render("✗"),
-				errorStyle.Render(r.Message),
+				errorStyle.Render(resultMessage(r)),
 			)
 			if r.Details != "" {
 				fmt.Fprintf(&sb, "    %s\n", mutedStyle.Render(r.Details))
@@ -115,7 +118,7 @@ func formatText(report *Report, opts Options) string {
 		for _, r := range warnings {
 			fmt.Fprintf(&sb, "  %s %s\n",
 				warningStyle.Render("⚠"),
-				warningStyle.Render(r.Message),
+				warningStyle.Render(resultMessage(r)),
 			)
 			if r.Details != "" {
 				fmt.Fprintf(&sb, "    %s\n", mutedStyle.Render(r.Details))
@@ -133,7 +136,7 @@ func formatText(report *Report, opts Options) string {
 		for _, r := range infos {
 			fmt.Fprintf(&sb, "  %s %s\n",
 				infoStyle.Render("ℹ"),
-				r.Message,
+				resultMessage(r),
 			)
 			if r.Details != "" {
 				fmt.Fprintf(&sb, "    %s\n", mutedStyle.Render(r.Details))
@@ -148,7 +151,7 @@ func formatText(report *Report, opts Options) string {
 		for _, r := range suggestions {
 			fmt.Fprintf(&sb, "  %s %s\n",
 				suggestionStyle.Render("💡"),
-				r.Message,
+				resultMessage(r),
 			)
 			if r.Details != "" {
 				fmt.Fprintf(&sb, "    %s\n", mutedStyle.Render(r.Details))
@@ -182,6 +185,13 @@ func formatText(report *Report, opts Options) string {
 	return sb.String()
 }
 
+func resultMessage(r CheckResult) string {
+	if r.Module == "" {
+		return r.Message
+	}
+	return fmt.Sprintf("[%s] %s", r.Module, r.Message)
+}
+
 func filterResults(results []CheckResult, passed bool, severity string) []CheckResult {
 	var filtered []CheckResult
 	for _, r := range results {
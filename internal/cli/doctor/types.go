@@ -29,12 +29,14 @@ type CheckResult struct {
 	Message  string   `json:"message"`
 	Details  string   `json:"details,omitempty"`
 	FixHint  string   `json:"fix_hint,omitempty"`
+	Module   string   `json:"module,omitempty"`
 }
 
 type Report struct {
 	ProjectPath     string        `json:"project_path"`
 	ProjectName     string        `json:"project_name"`
 	BuildTool       string        `json:"build_tool"`
+	Modules         []string      `json:"modules,omitempty"`
 	SpringVersion   string        `json:"spring_version,omitempty"`
 	JavaVersion     string        `json:"java_version,omitempty"`
 	Results         []CheckResult `json:"results"`
@@ -50,6 +52,7 @@ type Options struct {
 	Fix      bool
 	Strict   bool
 	Category string
+	Module   string
 }
 
 func (r *Report) CalculateCounts() {
@@ -0,0 +1,146 @@
+package erd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/erd"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	Format string
+	Output string
+	JSON   bool
+	Module string
+}
+
+func NewCommand() *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "erd",
+		Short: "Render an entity relationship diagram",
+		Long: `Parse every @Entity and @Document class of the project and render
+an entity relationship diagram.
+
+Tables and columns use the names from @Table, @Column and @JoinColumn,
+falling back to the snake_case names Hibernate would generate. Fields
+inherited from a base class such as BaseEntity are included and marked
+as inherited. Relationships come from @OneToOne, @OneToMany, @ManyToOne
+and @ManyToMany (and @DBRef/@DocumentReference for MongoDB); the inverse
+side of a bidirectional relationship (mappedBy) is merged into the
+owning side.`,
+		Example: `  # Mermaid diagram, ready to paste into a PR description
+  haft erd
+
+  # PlantUML diagram written to the design docs
+  haft erd --format plantuml --output docs/erd.puml
+
+  # Graphviz DOT rendered to SVG
+  haft erd --format dot | dot -Tsvg -o erd.svg
+
+  # Machine-readable entities and relations
+  haft erd --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runErd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "mermaid", "Output format (mermaid, plantuml, dot, json)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Write the diagram to a file instead of stdout")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON (same as --format json)")
+	cmd.Flags().StringVar(&opts.Module, "module", "", "Target module in a multi-module project")
+
+	return cmd
+}
+
+func runErd(opts Options) error {
+	if opts.JSON {
+		opts.Format = string(erd.FormatJSON)
+	}
+	jsonOutput := opts.Format == string(erd.FormatJSON)
+
+	format, err := erd.ParseFormat(opts.Format)
+	if err != nil {
+		return erdError(jsonOutput, err)
+	}
+
+	fs := afero.NewOsFs()
+	dir, err := os.Getwd()
+	if err != nil {
+		return erdError(jsonOutput, fmt.Errorf("failed to get current directory: %w", err))
+	}
+
+	dir, err = buildtool.SourceModuleDir(fs, dir, opts.Module)
+	if err != nil {
+		return erdError(jsonOutput, err)
+	}
+
+	diagram, err := BuildDiagram(fs, dir)
+	if err != nil {
+		return erdError(jsonOutput, err)
+	}
+
+	if format == erd.FormatJSON {
+		return output.Success(diagram)
+	}
+
+	if len(diagram.Entities) == 0 {
+		logger.Default().Warning("No @Entity or @Document classes found")
+	}
+
+	rendered := erd.Render(diagram, format)
+	if opts.Output == "" {
+		fmt.Print(rendered)
+		return nil
+	}
+
+	if err := afero.WriteFile(fs, opts.Output, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.Output, err)
+	}
+	logger.Default().Info("Diagram written",
+		"file", opts.Output,
+		"entities", len(diagram.Entities),
+		"relations", len(diagram.Relations))
+	return nil
+}
+
+func BuildDiagram(fs afero.Fs, dir string) (*erd.Diagram, error) {
+	d := detector.NewDetector(dir, detector.WithFileSystem(fs))
+	if _, err := d.Detect(); err != nil {
+		return nil, fmt.Errorf("failed to detect project profile: %w", err)
+	}
+
+	scan := d.LastScan()
+	logger.Default().Debug("Project scanned",
+		"files", scan.Stats.Files,
+		"parsed", scan.Stats.Parsed,
+		"cached", scan.Stats.Reused)
+
+	var sources []erd.Source
+	for _, file := range scan.SourceFiles {
+		if file.FileType == detector.FileTypeTest {
+			continue
+		}
+		content, err := afero.ReadFile(fs, file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		sources = append(sources, erd.Source{Path: buildtool.RelativePath(dir, file.Path), Content: string(content)})
+	}
+
+	return erd.Build(sources), nil
+}
+
+func erdError(jsonOutput bool, err error) error {
+	if jsonOutput {
+		return output.Error("ERD_FAILED", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package erd
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shopEntities() map[string]string {
+	return map[string]string{
+		"src/main/java/com/shop/common/BaseEntity.java":      "package com.shop.common;\n\n@MappedSuperclass\npublic abstract class BaseEntity {\n    @Id\n    private Long id;\n}",
+		"src/main/java/com/shop/user/User.java":              "package com.shop.user;\n\n@Entity\n@Table(name = \"users\")\npublic class User extends BaseEntity {\n    private String email;\n}",
+		"src/main/java/com/shop/order/Order.java":            "package com.shop.order;\n\n@Entity\npublic class Order extends BaseEntity {\n    @ManyToOne\n    private User user;\n}",
+		"src/main/java/com/shop/order/OrderService.java":     "package com.shop.order;\n\n@Service\npublic class OrderService {\n}",
+		"src/test/java/com/shop/order/OrderServiceTest.java": "package com.shop.order;\n\n@Entity\nclass OrderServiceTest {\n}",
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "erd", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	for _, name := range []string{"format", "output", "json", "module"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "mermaid", cmd.Flags().Lookup("format").DefValue)
+}
+
+func TestBuildDiagram(t *testing.T) {
+	d, err := BuildDiagram(testutil.MemFs(t, "/project", shopEntities()), "/project")
+	require.NoError(t, err)
+
+	require.Len(t, d.Entities, 2)
+	assert.Equal(t, "Order", d.Entities[0].Name)
+	assert.Equal(t, "src/main/java/com/shop/order/Order.java", d.Entities[0].Path)
+	assert.Equal(t, "users", d.Entities[1].Table)
+	assert.True(t, d.Entities[1].Columns[0].Inherited)
+
+	require.Len(t, d.Relations, 1)
+	assert.Equal(t, "user", d.Relations[0].Field)
+}
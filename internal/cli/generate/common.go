@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/KashifKhn/haft/internal/buildtool"
 	"github.com/KashifKhn/haft/internal/detector"
 	"github.com/KashifKhn/haft/internal/generator"
 	_ "github.com/KashifKhn/haft/internal/gradle"
+	"github.com/KashifKhn/haft/internal/hooks"
 	"github.com/KashifKhn/haft/internal/logger"
 	_ "github.com/KashifKhn/haft/internal/maven"
 	"github.com/KashifKhn/haft/internal/output"
@@ -20,18 +22,22 @@ import (
 	"github.com/spf13/afero"
 )
 
+const minRecordJavaVersion = 16
+
 type ComponentConfig struct {
 	Name          string
 	BasePackage   string
 	HasLombok     bool
 	HasJpa        bool
 	HasValidation bool
+	JavaVersion   string
+	UseRecords    bool
 }
 
 func DetectProjectConfig() (ComponentConfig, error) {
 	var cfg ComponentConfig
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		return cfg, err
 	}
@@ -51,10 +57,46 @@ func DetectProjectConfig() (ComponentConfig, error) {
 	cfg.HasLombok = result.Parser.HasLombok(project)
 	cfg.HasJpa = result.Parser.HasSpringDataJpa(project)
 	cfg.HasValidation = result.Parser.HasValidation(project)
+	cfg.JavaVersion = result.Parser.GetJavaVersion(project)
 
 	return cfg, nil
 }
 
+func DetectJavaVersion() string {
+	cfg, err := DetectProjectConfig()
+	if err != nil {
+		return ""
+	}
+	return cfg.JavaVersion
+}
+
+func JavaMajorVersion(version string) int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "1.")
+	if idx := strings.IndexAny(version, ".-+"); idx >= 0 {
+		version = version[:idx]
+	}
+	major, err := strconv.Atoi(version)
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+func ResolveRecordDTOs(requested, detected bool, javaVersion string) (bool, error) {
+	if !requested && !detected {
+		return false, nil
+	}
+
+	major := JavaMajorVersion(javaVersion)
+	if major == 0 || major >= minRecordJavaVersion {
+		return true, nil
+	}
+	if requested {
+		return false, fmt.Errorf("record DTOs require Java %d or newer, but the project targets Java %s", minRecordJavaVersion, javaVersion)
+	}
+	return false, nil
+}
+
 func RunComponentWizard(title string, cfg ComponentConfig, componentType string) (ComponentConfig, error) {
 	steps, stepKeys := buildComponentWizardSteps(cfg, componentType)
 
@@ -156,20 +198,20 @@ func ValidatePackageName(pkg string) error {
 	return nil
 }
 
-func GenerateComponent(cfg ComponentConfig, templateName, subPackage, fileNamePattern string) (bool, error) {
+func GenerateComponent(cfg ComponentConfig, templateName, subPackage, fileNamePattern string) (string, bool, error) {
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
-		return false, err
+		return "", false, err
 	}
 
 	engine := generator.NewEngineWithLoader(fs, cwd)
 
 	srcPath := FindSourcePath(cwd)
 	if srcPath == "" {
-		return false, fmt.Errorf("could not find src/main/java directory")
+		return "", false, fmt.Errorf("could not find src/main/java directory")
 	}
 
 	basePath := filepath.Join(srcPath, strings.ReplaceAll(cfg.BasePackage, ".", string(os.PathSeparator)))
@@ -177,18 +219,19 @@ func GenerateComponent(cfg ComponentConfig, templateName, subPackage, fileNamePa
 
 	fileName := strings.ReplaceAll(fileNamePattern, "{Name}", cfg.Name)
 	outputPath := filepath.Join(basePath, subPackage, fileName)
+	relPath := FormatRelativePath(cwd, outputPath)
 
 	if engine.FileExists(outputPath) {
-		log.Warning("Skipped (already exists)", "file", FormatRelativePath(cwd, outputPath))
-		return false, nil
+		log.Warning("Skipped (already exists)", "file", relPath)
+		return relPath, false, nil
 	}
 
 	if err := engine.RenderAndWrite(templateName, outputPath, data); err != nil {
-		return false, fmt.Errorf("failed to generate %s: %w", fileName, err)
+		return "", false, fmt.Errorf("failed to generate %s: %w", fileName, err)
 	}
 
-	log.Success("Created", "file", FormatRelativePath(cwd, outputPath))
-	return true, nil
+	log.Success("Created", "file", relPath)
+	return relPath, true, nil
 }
 
 func BuildTemplateData(cfg ComponentConfig) map[string]any {
@@ -200,6 +243,8 @@ func BuildTemplateData(cfg ComponentConfig) map[string]any {
 		"HasLombok":     cfg.HasLombok,
 		"HasJpa":        cfg.HasJpa,
 		"HasValidation": cfg.HasValidation,
+		"UseRecords":    cfg.UseRecords,
+		"ResourcePath":  ResourcePath(cfg.Name, detector.RestPathStyle{}),
 	}
 }
 
@@ -297,7 +342,7 @@ func DetectProjectProfile() (*detector.ProjectProfile, error) {
 }
 
 func DetectProjectProfileWithRefresh(forceRefresh bool) (*detector.ProjectProfile, error) {
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		return nil, err
 	}
@@ -320,11 +365,27 @@ func DetectProjectProfileWithRefresh(forceRefresh bool) (*detector.ProjectProfil
 		return nil, err
 	}
 
+	scan := d.LastScan()
+	log.Debug("Project scanned",
+		"files", scan.Stats.Files,
+		"parsed", scan.Stats.Parsed,
+		"cached", scan.Stats.Reused,
+		"workers", scan.Stats.Workers,
+		"duration", scan.Stats.Duration.String())
+	cache.SetSourceChecksum(scan.SourceChecksum)
+
+	if previous, err := cache.Load(); err == nil {
+		profile.PreserveLocked(previous)
+	}
+
 	if err := cache.Save(profile); err != nil {
 		log.Debug("Failed to cache profile", "error", err.Error())
 	} else {
 		log.Debug("Profile cached to .haft/profile.json")
 	}
+	if err := d.SaveIndex(); err != nil {
+		log.Debug("Failed to save scan index", "error", err.Error())
+	}
 
 	return profile, nil
 }
@@ -368,6 +429,15 @@ type TemplateContext struct {
 
 	ValidationImport string
 
+	UseRecords           bool
+	ResourcePath         string
+	FieldInjection       bool
+	LombokInjection      bool
+	ConstructorInjection bool
+	OptionalLookup       bool
+	FinalLocals          bool
+	TestNaming           string
+
 	Lombok detector.LombokProfile
 }
 
@@ -412,9 +482,26 @@ func BuildTemplateContextFromProfile(name string, profile *detector.ProjectProfi
 		RequestSuffix:    name + profile.GetDTORequestSuffix(),
 		ResponseSuffix:   name + profile.GetDTOResponseSuffix(),
 
+		UseRecords:   profile.Conventions.DTOStyle == detector.DTOStyleRecord,
+		ResourcePath: ResourcePath(name, profile.Conventions.RestPaths),
+		TestNaming:   string(profile.Conventions.TestNaming),
+		FinalLocals:  profile.Conventions.FinalLocals,
+
 		Lombok: profile.Lombok,
 	}
 
+	switch profile.Conventions.Injection {
+	case detector.InjectionField:
+		ctx.FieldInjection = true
+	case detector.InjectionLombok:
+		ctx.LombokInjection = ctx.HasLombok
+		ctx.ConstructorInjection = !ctx.HasLombok
+	case detector.InjectionConstructor:
+		ctx.ConstructorInjection = true
+	}
+
+	ctx.OptionalLookup = profile.Conventions.Lookup == detector.LookupOptional
+
 	if profile.BaseEntity != nil {
 		ctx.HasBaseEntity = true
 		ctx.BaseEntityName = profile.BaseEntity.Name
@@ -475,10 +562,34 @@ func (ctx TemplateContext) ToMap() map[string]any {
 		"HasGlobalException":    ctx.HasGlobalException,
 		"ExceptionPackage":      ctx.ExceptionPackage,
 		"ValidationImport":      ctx.ValidationImport,
+		"UseRecords":            ctx.UseRecords,
+		"ResourcePath":          ctx.ResourcePath,
+		"FieldInjection":        ctx.FieldInjection,
+		"LombokInjection":       ctx.LombokInjection,
+		"ConstructorInjection":  ctx.ConstructorInjection,
+		"OptionalLookup":        ctx.OptionalLookup,
+		"FinalLocals":           ctx.FinalLocals,
+		"TestNaming":            ctx.TestNaming,
 		"Lombok":                ctx.Lombok,
 	}
 }
 
+func ResourcePath(name string, style detector.RestPathStyle) string {
+	if style.Confidence == 0 {
+		return "/api/" + generator.Pluralize(strings.ToLower(name))
+	}
+
+	segment := strings.ToLower(name)
+	if style.KebabCase {
+		segment = generator.KebabCase(name)
+	}
+	if style.Plural {
+		segment = generator.Pluralize(segment)
+	}
+
+	return style.Prefix + "/" + segment
+}
+
 func GetTemplateDir(profile *detector.ProjectProfile) string {
 	switch profile.Architecture {
 	case detector.ArchFeature:
@@ -561,12 +672,41 @@ func (t *GenerateTracker) ToOutput() output.GenerateResult {
 }
 
 func OutputGenerateResult(jsonOutput bool, tracker *GenerateTracker) error {
+	report, hookErr := runGenerateHooks(tracker, jsonOutput)
+
 	if jsonOutput {
-		return output.Success(output.GenerateOutput{
+		result := output.GenerateOutput{
 			Results:        []output.GenerateResult{tracker.ToOutput()},
 			TotalGenerated: len(tracker.Generated),
 			TotalSkipped:   len(tracker.Skipped),
-		})
+			Hooks:          report.ToOutput(),
+		}
+		if hookErr != nil {
+			return output.ErrorWithData("HOOK_FAILED", hookErr.Error(), result)
+		}
+		return output.Success(result)
 	}
-	return nil
+	return hookErr
+}
+
+func runGenerateHooks(tracker *GenerateTracker, quiet bool) (*hooks.Report, error) {
+	if len(tracker.Generated) == 0 {
+		return nil, nil
+	}
+
+	cwd, err := workingDir()
+	if err != nil {
+		return nil, err
+	}
+
+	fs := afero.NewOsFs()
+	rollback := hooks.NewRollback(fs, cwd)
+	for _, file := range tracker.Generated {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(cwd, file)
+		}
+		rollback.TrackCreated(file)
+	}
+
+	return hooks.Execute(fs, cwd, hooks.PostGenerate, tracker.Generated, rollback, quiet)
 }
@@ -247,7 +247,7 @@ func generateConfigs(profile *detector.ProjectProfile, selection configSelection
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Could not get current directory", err.Error())
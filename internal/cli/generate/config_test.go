@@ -431,10 +431,19 @@ func TestGenerateConfigsSkipsExisting(t *testing.T) {
 }
 
 func TestRunConfigNoInteractiveWithoutAll(t *testing.T) {
+	originalCwd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalCwd) }()
+
+	tmpDir, err := os.MkdirTemp("", "test-config-noall-*")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	require.NoError(t, os.Chdir(tmpDir))
+
 	cmd := newConfigCommand()
 	cmd.SetArgs([]string{"--no-interactive", "--package", "com.example.app"})
 
-	err := cmd.Execute()
+	err = cmd.Execute()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "use --all flag")
 }
@@ -94,7 +94,7 @@ func runController(cmd *cobra.Command, args []string) error {
 		log.Info("Generating controller", "name", cfg.Name)
 	}
 
-	generated, err := GenerateComponent(cfg, "resource/layered/Controller.java.tmpl", "controller", "{Name}Controller.java")
+	relPath, generated, err := GenerateComponent(cfg, "resource/layered/Controller.java.tmpl", "controller", "{Name}Controller.java")
 	if err != nil {
 		if jsonOutput {
 			tracker.AddError(err.Error())
@@ -104,9 +104,9 @@ func runController(cmd *cobra.Command, args []string) error {
 	}
 
 	if generated {
-		tracker.AddGenerated(cfg.Name + "Controller.java")
+		tracker.AddGenerated(relPath)
 	} else {
-		tracker.AddSkipped(cfg.Name + "Controller.java")
+		tracker.AddSkipped(relPath)
 	}
 
 	return OutputGenerateResult(jsonOutput, tracker)
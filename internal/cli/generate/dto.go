@@ -3,6 +3,7 @@ package generate
 import (
 	"fmt"
 
+	"github.com/KashifKhn/haft/internal/detector"
 	"github.com/KashifKhn/haft/internal/logger"
 	"github.com/KashifKhn/haft/internal/output"
 	"github.com/spf13/cobra"
@@ -19,7 +20,9 @@ Creates two DTO classes:
   - {Name}Response.java - For outgoing response data
 
 The command auto-detects your project's base package from your build file
-and checks for Lombok and Validation dependencies to add annotations.`,
+and checks for Lombok and Validation dependencies to add annotations.
+DTOs are generated as Java records when --records is passed or the project
+already uses records for its DTOs, provided it targets Java 16 or newer.`,
 		Example: `  # Interactive mode
   haft generate dto
 
@@ -34,6 +37,9 @@ and checks for Lombok and Validation dependencies to add annotations.`,
   haft generate dto user --request-only
   haft generate dto user --response-only
 
+  # Generate Java records instead of classes (Java 16+)
+  haft generate dto user --records
+
   # Output as JSON
   haft generate dto user --json --no-interactive`,
 		Args: cobra.MaximumNArgs(1),
@@ -44,6 +50,7 @@ and checks for Lombok and Validation dependencies to add annotations.`,
 	cmd.Flags().Bool("no-interactive", false, "Skip interactive wizard")
 	cmd.Flags().Bool("request-only", false, "Generate only Request DTO")
 	cmd.Flags().Bool("response-only", false, "Generate only Response DTO")
+	cmd.Flags().Bool("records", false, "Generate Java records instead of classes (Java 16+)")
 	cmd.Flags().Bool("json", false, "Output as JSON")
 
 	return cmd
@@ -75,6 +82,18 @@ func runDto(cmd *cobra.Command, args []string) error {
 		cfg.BasePackage = pkg
 	}
 
+	useRecords, _ := cmd.Flags().GetBool("records")
+	detectedRecords := false
+	if profile, err := DetectProjectProfile(); err == nil {
+		detectedRecords = profile.Conventions.DTOStyle == detector.DTOStyleRecord
+	}
+	if cfg.UseRecords, err = ResolveRecordDTOs(useRecords, detectedRecords, cfg.JavaVersion); err != nil {
+		if jsonOutput {
+			return output.Error("VALIDATION_ERROR", err.Error())
+		}
+		return err
+	}
+
 	if !noInteractive {
 		cfg, err = RunComponentWizard("Generate DTO", cfg, "DTO")
 		if err != nil {
@@ -101,28 +120,28 @@ func runDto(cmd *cobra.Command, args []string) error {
 	generateBoth := !requestOnly && !responseOnly
 
 	if generateBoth || requestOnly {
-		if generated, err := GenerateComponent(cfg, "resource/layered/Request.java.tmpl", "dto", "{Name}Request.java"); err != nil {
+		if relPath, generated, err := GenerateComponent(cfg, "resource/layered/Request.java.tmpl", "dto", "{Name}Request.java"); err != nil {
 			tracker.AddError(err.Error())
 			if !jsonOutput {
 				return err
 			}
 		} else if generated {
-			tracker.AddGenerated(cfg.Name + "Request.java")
+			tracker.AddGenerated(relPath)
 		} else {
-			tracker.AddSkipped(cfg.Name + "Request.java")
+			tracker.AddSkipped(relPath)
 		}
 	}
 
 	if generateBoth || responseOnly {
-		if generated, err := GenerateComponent(cfg, "resource/layered/Response.java.tmpl", "dto", "{Name}Response.java"); err != nil {
+		if relPath, generated, err := GenerateComponent(cfg, "resource/layered/Response.java.tmpl", "dto", "{Name}Response.java"); err != nil {
 			tracker.AddError(err.Error())
 			if !jsonOutput {
 				return err
 			}
 		} else if generated {
-			tracker.AddGenerated(cfg.Name + "Response.java")
+			tracker.AddGenerated(relPath)
 		} else {
-			tracker.AddSkipped(cfg.Name + "Response.java")
+			tracker.AddSkipped(relPath)
 		}
 	}
 
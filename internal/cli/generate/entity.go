@@ -98,15 +98,15 @@ func runEntity(cmd *cobra.Command, args []string) error {
 		log.Info("Generating entity", "name", cfg.Name)
 	}
 
-	if generated, err := GenerateComponent(cfg, "resource/layered/Entity.java.tmpl", "entity", "{Name}.java"); err != nil {
+	if relPath, generated, err := GenerateComponent(cfg, "resource/layered/Entity.java.tmpl", "entity", "{Name}.java"); err != nil {
 		tracker.AddError(err.Error())
 		if !jsonOutput {
 			return err
 		}
 	} else if generated {
-		tracker.AddGenerated(cfg.Name + ".java")
+		tracker.AddGenerated(relPath)
 	} else {
-		tracker.AddSkipped(cfg.Name + ".java")
+		tracker.AddSkipped(relPath)
 	}
 
 	return OutputGenerateResult(jsonOutput, tracker)
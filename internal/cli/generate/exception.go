@@ -154,7 +154,7 @@ func runException(cmd *cobra.Command, args []string) error {
 }
 
 func enrichProfileFromBuildFile(profile *detector.ProjectProfile) {
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		return
 	}
@@ -277,7 +277,7 @@ func generateExceptionHandler(profile *detector.ProjectProfile, cfg exceptionCon
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Could not get current directory", err.Error())
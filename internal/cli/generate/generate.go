@@ -1,8 +1,17 @@
 package generate
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
 
 func NewCommand() *cobra.Command {
+	var module string
+
 	cmd := &cobra.Command{
 		Use:     "generate",
 		Aliases: []string{"g"},
@@ -16,7 +25,10 @@ All generators auto-detect your project configuration from your build file (pom.
   - Base package
   - Lombok dependency (for annotations)
   - Spring Data JPA (for entity/repository)
-  - Validation (for @Valid annotations)`,
+  - Validation (for @Valid annotations)
+
+In multi-module Maven or Gradle projects, components are generated into the
+module containing the current directory. Use --module to pick one explicitly.`,
 		Example: `  # Generate a complete CRUD resource (recommended)
   haft generate resource user
   haft g r product
@@ -50,9 +62,17 @@ All generators auto-detect your project configuration from your build file (pom.
 
   # Generate scheduled task
   haft generate scheduler cleanup
-  haft g sch report --cron "0 0 8 * * *"`,
+  haft g sch report --cron "0 0 8 * * *"
+
+  # Generate into a module of a multi-module project
+  haft generate resource user --module api`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return selectModule(module)
+		},
 	}
 
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
 	cmd.AddCommand(newResourceCommand())
 	cmd.AddCommand(newControllerCommand())
 	cmd.AddCommand(newServiceCommand())
@@ -66,3 +86,30 @@ All generators auto-detect your project configuration from your build file (pom.
 
 	return cmd
 }
+
+// moduleDir is the directory components are generated into. It is set from
+// --module before a subcommand runs; when empty the current directory is used.
+var moduleDir string
+
+func selectModule(name string) error {
+	cwd, err := buildtool.WorkingDir()
+	if err != nil {
+		return err
+	}
+	dir, err := buildtool.SourceModuleDir(afero.NewOsFs(), cwd, name)
+	if err != nil {
+		return err
+	}
+	if dir != cwd {
+		logger.Default().Debug("Using module", "path", dir)
+	}
+	moduleDir = dir
+	return nil
+}
+
+func workingDir() (string, error) {
+	if moduleDir != "" {
+		return moduleDir, nil
+	}
+	return os.Getwd()
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/generator"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -1445,3 +1446,227 @@ func TestTemplateContextToMapHasTestFields(t *testing.T) {
 	assert.Equal(t, "com.example.user", data["TestPackage"])
 	assert.Equal(t, "UUID.randomUUID()", data["TestIdValue"])
 }
+
+func TestResourcePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    detector.RestPathStyle
+		expected string
+	}{
+		{"undetected", detector.RestPathStyle{}, "/api/orderitems"},
+		{"plural", detector.RestPathStyle{Prefix: "/api", Plural: true, Confidence: 0.8}, "/api/orderitems"},
+		{"kebab", detector.RestPathStyle{Prefix: "/api/v1", Plural: true, KebabCase: true, Confidence: 0.8}, "/api/v1/order-items"},
+		{"singular", detector.RestPathStyle{Prefix: "/api", Confidence: 0.8}, "/api/orderitem"},
+		{"no prefix", detector.RestPathStyle{Plural: true, KebabCase: true, Confidence: 0.8}, "/order-items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResourcePath("OrderItem", tt.style))
+		})
+	}
+}
+
+func TestBuildTemplateContextWithConventions(t *testing.T) {
+	profile := &detector.ProjectProfile{
+		Architecture: detector.ArchFeature,
+		BasePackage:  "com.example.app",
+		Lombok:       detector.LombokProfile{Detected: true},
+		Conventions: detector.ConventionProfile{
+			Injection:   detector.InjectionLombok,
+			Lookup:      detector.LookupOptional,
+			FinalLocals: true,
+			TestNaming:  detector.TestNamingGivenWhenThen,
+			RestPaths:   detector.RestPathStyle{Prefix: "/api/v1", Plural: true, KebabCase: true, Confidence: 0.9},
+		},
+	}
+
+	ctx := BuildTemplateContextFromProfile("OrderItem", profile)
+
+	assert.True(t, ctx.LombokInjection)
+	assert.False(t, ctx.ConstructorInjection)
+	assert.False(t, ctx.FieldInjection)
+	assert.True(t, ctx.OptionalLookup)
+	assert.True(t, ctx.FinalLocals)
+	assert.Equal(t, "given_when_then", ctx.TestNaming)
+	assert.Equal(t, "/api/v1/order-items", ctx.ResourcePath)
+
+	profile.Lombok.Detected = false
+	ctx = BuildTemplateContextFromProfile("OrderItem", profile)
+
+	assert.False(t, ctx.LombokInjection)
+	assert.True(t, ctx.ConstructorInjection)
+}
+
+func TestResourceTemplatesHonourConventions(t *testing.T) {
+	profile := &detector.ProjectProfile{
+		Architecture: detector.ArchFeature,
+		BasePackage:  "com.example.app",
+		Database:     detector.DatabaseJPA,
+		IDType:       "Long",
+		Lombok:       detector.LombokProfile{Detected: true},
+		Conventions: detector.ConventionProfile{
+			Injection:   detector.InjectionField,
+			Lookup:      detector.LookupOptional,
+			FinalLocals: true,
+			TestNaming:  detector.TestNamingGivenWhenThen,
+			RestPaths:   detector.RestPathStyle{Prefix: "/api/v1", Plural: true, KebabCase: true, Confidence: 0.9},
+		},
+	}
+	data := BuildTemplateContextFromProfile("OrderItem", profile).ToMap()
+	engine := generator.NewEngine(afero.NewMemMapFs())
+
+	controller, err := engine.RenderTemplate("resource/feature/Controller.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, controller, `@RequestMapping("/api/v1/order-items")`)
+	assert.Contains(t, controller, "@Autowired\n    private OrderItemService orderItemService;")
+	assert.Contains(t, controller, ".orElseGet(() -> ResponseEntity.notFound().build())")
+
+	serviceImpl, err := engine.RenderTemplate("resource/feature/ServiceImpl.java.tmpl", data)
+	require.NoError(t, err)
+	assert.NotContains(t, serviceImpl, "@RequiredArgsConstructor")
+	assert.Contains(t, serviceImpl, "public Optional<OrderItemResponse> findById(Long id)")
+	assert.Contains(t, serviceImpl, "final OrderItem saved = ")
+
+	serviceTest, err := engine.RenderTemplate("test/feature/ServiceTest.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, serviceTest, "void givenExisting_whenFindAll_thenReturnsAll()")
+	assert.Contains(t, serviceTest, "void givenMissingId_whenFindById_thenEmpty()")
+	assert.NotContains(t, serviceTest, "void should")
+}
+
+func TestResourceTemplatesKeepDefaultsWithoutConventions(t *testing.T) {
+	profile := &detector.ProjectProfile{
+		Architecture: detector.ArchLayered,
+		BasePackage:  "com.example.app",
+		Database:     detector.DatabaseJPA,
+	}
+	data := BuildTemplateContextFromProfile("User", profile).ToMap()
+	engine := generator.NewEngine(afero.NewMemMapFs())
+
+	controller, err := engine.RenderTemplate("resource/layered/Controller.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, controller, `@RequestMapping("/api/users")`)
+	assert.Contains(t, controller, "public UserController(UserService userService)")
+
+	serviceImpl, err := engine.RenderTemplate("resource/layered/ServiceImpl.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, serviceImpl, "public UserResponse findById(Long id)")
+	assert.Contains(t, serviceImpl, "User saved = ")
+	assert.NotContains(t, serviceImpl, "final User saved")
+
+	controllerTest, err := engine.RenderTemplate("test/layered/ControllerTest.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, controllerTest, "void shouldGetById()")
+}
+
+func TestJavaMajorVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected int
+	}{
+		{"21", 21},
+		{"17.0.2", 17},
+		{"1.8", 8},
+		{"11-ea", 11},
+		{"", 0},
+		{"${java.version}", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JavaMajorVersion(tt.version))
+		})
+	}
+}
+
+func TestResolveRecordDTOs(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested bool
+		detected  bool
+		version   string
+		expected  bool
+		wantErr   bool
+	}{
+		{"not requested", false, false, "21", false, false},
+		{"requested on java 17", true, false, "17", true, false},
+		{"detected on java 21", false, true, "21", true, false},
+		{"unknown version", true, false, "", true, false},
+		{"requested on java 11", true, false, "11", false, true},
+		{"detected on java 8", false, true, "1.8", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ResolveRecordDTOs(tt.requested, tt.detected, tt.version)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "Java 16")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestResourceTemplatesGenerateRecords(t *testing.T) {
+	profile := &detector.ProjectProfile{
+		Architecture:    detector.ArchFeature,
+		BasePackage:     "com.example.app",
+		Database:        detector.DatabaseJPA,
+		IDType:          "Long",
+		Lombok:          detector.LombokProfile{Detected: true},
+		HasValidation:   true,
+		ValidationStyle: detector.ValidationJakarta,
+		Conventions:     detector.ConventionProfile{DTOStyle: detector.DTOStyleRecord},
+	}
+	ctx := BuildTemplateContextFromProfile("User", profile)
+	assert.True(t, ctx.UseRecords)
+	data := ctx.ToMap()
+	engine := generator.NewEngine(afero.NewMemMapFs())
+
+	request, err := engine.RenderTemplate("resource/feature/Request.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, request, "public record UserRequest() {\n}")
+	assert.NotContains(t, request, "lombok")
+
+	response, err := engine.RenderTemplate("resource/feature/Response.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, response, "public record UserResponse(\n    Long id\n)")
+
+	mapper, err := engine.RenderTemplate("resource/feature/Mapper.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, mapper, "return new UserResponse(entity.getId());")
+	assert.NotContains(t, mapper, "UserResponse.builder()")
+
+	serviceTest, err := engine.RenderTemplate("test/feature/ServiceTest.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, serviceTest, "request = new UserRequest();")
+	assert.Contains(t, serviceTest, "response = new UserResponse(testId);")
+}
+
+func TestLayeredTemplatesGenerateRecords(t *testing.T) {
+	data := BuildTemplateData(ComponentConfig{
+		Name:          "Product",
+		BasePackage:   "com.example.shop",
+		HasLombok:     true,
+		HasValidation: true,
+		UseRecords:    true,
+	})
+	engine := generator.NewEngine(afero.NewMemMapFs())
+
+	request, err := engine.RenderTemplate("resource/layered/Request.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, request, "public record ProductRequest() {\n}")
+	assert.NotContains(t, request, "@NotBlank")
+	assert.Contains(t, request, "import jakarta.validation.constraints.*;")
+
+	response, err := engine.RenderTemplate("resource/layered/Response.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, response, "public record ProductResponse(\n    Long id\n)")
+
+	mapper, err := engine.RenderTemplate("resource/layered/Mapper.java.tmpl", data)
+	require.NoError(t, err)
+	assert.Contains(t, mapper, "return new ProductResponse(entity.getId());")
+}
@@ -93,15 +93,15 @@ func runRepository(cmd *cobra.Command, args []string) error {
 		log.Info("Generating repository", "name", cfg.Name)
 	}
 
-	if generated, err := GenerateComponent(cfg, "resource/layered/Repository.java.tmpl", "repository", "{Name}Repository.java"); err != nil {
+	if relPath, generated, err := GenerateComponent(cfg, "resource/layered/Repository.java.tmpl", "repository", "{Name}Repository.java"); err != nil {
 		tracker.AddError(err.Error())
 		if !jsonOutput {
 			return err
 		}
 	} else if generated {
-		tracker.AddGenerated(cfg.Name + "Repository.java")
+		tracker.AddGenerated(relPath)
 	} else {
-		tracker.AddSkipped(cfg.Name + "Repository.java")
+		tracker.AddSkipped(relPath)
 	}
 
 	return OutputGenerateResult(jsonOutput, tracker)
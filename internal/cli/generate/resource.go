@@ -23,6 +23,8 @@ type ResourceConfig struct {
 	HasLombok     bool
 	HasJpa        bool
 	HasValidation bool
+	JavaVersion   string
+	UseRecords    bool
 }
 
 func newResourceCommand() *cobra.Command {
@@ -64,6 +66,9 @@ generates code that matches your existing conventions:
   # Force re-detection of project profile
   haft generate resource user --refresh
 
+  # Emit Request/Response DTOs as Java records (Java 16+)
+  haft generate resource user --records
+
   # Output as JSON
   haft generate resource user --json --no-interactive`,
 		Args: cobra.MaximumNArgs(1),
@@ -76,6 +81,7 @@ generates code that matches your existing conventions:
 	cmd.Flags().Bool("skip-repository", false, "Skip repository generation")
 	cmd.Flags().Bool("skip-tests", false, "Skip test generation")
 	cmd.Flags().Bool("legacy", false, "Use legacy layered generation (ignores architecture detection)")
+	cmd.Flags().Bool("records", false, "Generate Request/Response DTOs as Java records (Java 16+)")
 	cmd.Flags().Bool("refresh", false, "Force re-detection of project profile (ignore cache)")
 	cmd.Flags().Bool("json", false, "Output as JSON")
 
@@ -111,6 +117,20 @@ func runResource(cmd *cobra.Command, args []string) error {
 		profile.BasePackage = pkg
 	}
 
+	useRecords, _ := cmd.Flags().GetBool("records")
+	records, err := ResolveRecordDTOs(useRecords, profile.Conventions.DTOStyle == detector.DTOStyleRecord, DetectJavaVersion())
+	if err != nil {
+		if jsonOutput {
+			return output.Error("VALIDATION_ERROR", err.Error())
+		}
+		return err
+	}
+	if records {
+		profile.Conventions.DTOStyle = detector.DTOStyleRecord
+	} else if profile.Conventions.DTOStyle == detector.DTOStyleRecord {
+		profile.Conventions.DTOStyle = detector.DTOStyleClass
+	}
+
 	if !noInteractive {
 		var wizErr error
 		resourceName, wizErr = runResourceNameWizard(resourceName)
@@ -193,7 +213,7 @@ func generateResourceWithProfile(name string, profile *detector.ProjectProfile,
 	fs := afero.NewOsFs()
 	tracker := NewGenerateTracker("resource", name)
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Failed to get current directory", err.Error())
@@ -277,25 +297,23 @@ func generateResourceWithProfile(name string, profile *detector.ProjectProfile,
 		_ = testSkipped
 	}
 
-	if jsonOutput {
-		return OutputGenerateResult(true, tracker)
-	}
-
-	if len(tracker.Generated) > 0 {
-		log.Success(fmt.Sprintf("Generated %d files for %s resource", len(tracker.Generated), name))
-	}
-	if len(tracker.Skipped) > 0 {
-		log.Info(fmt.Sprintf("Skipped %d existing files", len(tracker.Skipped)))
+	if !jsonOutput {
+		if len(tracker.Generated) > 0 {
+			log.Success(fmt.Sprintf("Generated %d files for %s resource", len(tracker.Generated), name))
+		}
+		if len(tracker.Skipped) > 0 {
+			log.Info(fmt.Sprintf("Skipped %d existing files", len(tracker.Skipped)))
+		}
 	}
 
-	return nil
+	return OutputGenerateResult(jsonOutput, tracker)
 }
 
 func generateTestsWithProfileTracked(name string, profile *detector.ProjectProfile, ctx TemplateContext, skipEntity, skipRepository bool, tracker *GenerateTracker, jsonOutput bool) (int, int, error) {
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		return 0, 0, err
 	}
@@ -498,6 +516,11 @@ func runLegacyResource(cmd *cobra.Command, args []string) error {
 		cfg.BasePackage = pkg
 	}
 
+	useRecords, _ := cmd.Flags().GetBool("records")
+	if cfg.UseRecords, err = ResolveRecordDTOs(useRecords, false, cfg.JavaVersion); err != nil {
+		return err
+	}
+
 	if !noInteractive {
 		cfg, err = runResourceWizard(cfg)
 		if err != nil {
@@ -543,7 +566,7 @@ func generateResource(cfg ResourceConfig, skipEntity, skipRepository bool) error
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		return err
 	}
@@ -642,5 +665,7 @@ func buildResourceTemplateData(cfg ResourceConfig) map[string]any {
 		"HasLombok":     cfg.HasLombok,
 		"HasJpa":        cfg.HasJpa,
 		"HasValidation": cfg.HasValidation,
+		"UseRecords":    cfg.UseRecords,
+		"ResourcePath":  ResourcePath(cfg.Name, detector.RestPathStyle{}),
 	}
 }
@@ -410,7 +410,7 @@ func generateScheduler(profile *detector.ProjectProfile, cfg schedulerConfig, js
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Could not get current directory", err.Error())
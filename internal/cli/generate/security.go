@@ -202,7 +202,7 @@ func runSecurity(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Could not get current directory", err.Error())
@@ -555,7 +555,7 @@ func generateSecurity(profile *detector.ProjectProfile, cfg securityConfig, json
 	log := logger.Default()
 	fs := afero.NewOsFs()
 
-	cwd, err := os.Getwd()
+	cwd, err := workingDir()
 	if err != nil {
 		if jsonOutput {
 			return output.Error("DIRECTORY_ERROR", "Could not get current directory", err.Error())
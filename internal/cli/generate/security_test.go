@@ -921,10 +921,19 @@ func TestGenerateSecurityHexagonalArchitecture(t *testing.T) {
 }
 
 func TestRunSecurityNoInteractiveWithoutType(t *testing.T) {
+	originalCwd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalCwd) }()
+
+	tmpDir, err := os.MkdirTemp("", "test-security-notype-*")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	require.NoError(t, os.Chdir(tmpDir))
+
 	cmd := newSecurityCommand()
 	cmd.SetArgs([]string{"--no-interactive", "--package", "com.example.app"})
 
-	err := cmd.Execute()
+	err = cmd.Execute()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "specify authentication type")
 }
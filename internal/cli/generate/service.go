@@ -91,26 +91,26 @@ func runService(cmd *cobra.Command, args []string) error {
 		log.Info("Generating service", "name", cfg.Name)
 	}
 
-	if generated, err := GenerateComponent(cfg, "resource/layered/Service.java.tmpl", "service", "{Name}Service.java"); err != nil {
+	if relPath, generated, err := GenerateComponent(cfg, "resource/layered/Service.java.tmpl", "service", "{Name}Service.java"); err != nil {
 		tracker.AddError(err.Error())
 		if !jsonOutput {
 			return err
 		}
 	} else if generated {
-		tracker.AddGenerated(cfg.Name + "Service.java")
+		tracker.AddGenerated(relPath)
 	} else {
-		tracker.AddSkipped(cfg.Name + "Service.java")
+		tracker.AddSkipped(relPath)
 	}
 
-	if generated, err := GenerateComponent(cfg, "resource/layered/ServiceImpl.java.tmpl", "service/impl", "{Name}ServiceImpl.java"); err != nil {
+	if relPath, generated, err := GenerateComponent(cfg, "resource/layered/ServiceImpl.java.tmpl", "service/impl", "{Name}ServiceImpl.java"); err != nil {
 		tracker.AddError(err.Error())
 		if !jsonOutput {
 			return err
 		}
 	} else if generated {
-		tracker.AddGenerated(cfg.Name + "ServiceImpl.java")
+		tracker.AddGenerated(relPath)
 	} else {
-		tracker.AddSkipped(cfg.Name + "ServiceImpl.java")
+		tracker.AddSkipped(relPath)
 	}
 
 	return OutputGenerateResult(jsonOutput, tracker)
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/graph"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	Format string
+	Level  string
+	Focus  string
+	JSON   bool
+	Module string
+}
+
+func NewCommand() *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the package or module dependency graph",
+		Long: `Build a dependency graph from the imports of your source files.
+
+Nodes are packages, or feature modules with --level module, and edges are
+labelled with the number of imports between them. Only dependencies
+between classes of the project are included; test sources are skipped.
+
+Dependency cycles are highlighted in red and listed in JSON output.
+Use --focus to keep a single package or module together with what it
+depends on and what depends on it.`,
+		Example: `  # Package graph as Graphviz DOT
+  haft graph > deps.dot
+  dot -Tsvg deps.dot -o deps.svg
+
+  # Feature module graph as Mermaid
+  haft graph --level module --format mermaid
+
+  # What billing depends on and what depends on it
+  haft graph --level module --focus billing
+
+  # Machine-readable graph with cycles
+  haft graph --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGraph(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "dot", "Output format (dot, mermaid, json)")
+	cmd.Flags().StringVarP(&opts.Level, "level", "l", "package", "Graph level (package, module)")
+	cmd.Flags().StringVar(&opts.Focus, "focus", "", "Show only this package or module and its direct neighbours")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON (same as --format json)")
+	cmd.Flags().StringVar(&opts.Module, "module", "", "Target module in a multi-module project")
+
+	return cmd
+}
+
+func runGraph(opts Options) error {
+	if opts.JSON {
+		opts.Format = string(graph.FormatJSON)
+	}
+	jsonOutput := opts.Format == string(graph.FormatJSON)
+
+	format, err := graph.ParseFormat(opts.Format)
+	if err != nil {
+		return graphError(jsonOutput, err)
+	}
+	level, err := graph.ParseLevel(opts.Level)
+	if err != nil {
+		return graphError(jsonOutput, err)
+	}
+
+	fs := afero.NewOsFs()
+	dir, err := os.Getwd()
+	if err != nil {
+		return graphError(jsonOutput, fmt.Errorf("failed to get current directory: %w", err))
+	}
+
+	dir, err = buildtool.SourceModuleDir(fs, dir, opts.Module)
+	if err != nil {
+		return graphError(jsonOutput, err)
+	}
+
+	g, err := BuildGraph(fs, dir, level, opts.Focus)
+	if err != nil {
+		return graphError(jsonOutput, err)
+	}
+
+	switch format {
+	case graph.FormatJSON:
+		return output.Success(g)
+	case graph.FormatMermaid:
+		fmt.Print(graph.RenderMermaid(g))
+	default:
+		fmt.Print(graph.RenderDOT(g))
+	}
+
+	for _, cycle := range g.Cycles {
+		logger.Default().Warning("Dependency cycle", "nodes", strings.Join(cycle, ", "))
+	}
+	return nil
+}
+
+func BuildGraph(fs afero.Fs, dir string, level graph.Level, focus string) (*graph.Graph, error) {
+	d := detector.NewDetector(dir, detector.WithFileSystem(fs))
+	profile, err := d.Detect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect project profile: %w", err)
+	}
+
+	cache := detector.NewProfileCacheWithFs(fs, dir)
+	if previous, err := cache.Load(); err == nil {
+		profile.PreserveLocked(previous)
+	}
+
+	scan := d.LastScan()
+	logger.Default().Debug("Project scanned",
+		"files", scan.Stats.Files,
+		"parsed", scan.Stats.Parsed,
+		"cached", scan.Stats.Reused)
+
+	g := graph.Build(scan.SourceFiles, profile.BasePackage, level, profile.FeatureModules)
+	if focus == "" {
+		return g, nil
+	}
+	return g.WithFocus(focus)
+}
+
+func graphError(jsonOutput bool, err error) error {
+	if jsonOutput {
+		return output.Error("GRAPH_FAILED", err.Error())
+	}
+	return err
+}
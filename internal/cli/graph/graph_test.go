@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/graph"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shopServices() map[string]string {
+	return map[string]string{
+		"src/main/java/com/shop/user/UserService.java":       "package com.shop.user;\n\nimport com.shop.billing.InvoiceService;\n\n@Service\npublic class UserService {\n}",
+		"src/main/java/com/shop/billing/InvoiceService.java": "package com.shop.billing;\n\nimport com.shop.user.UserService;\n\n@Service\npublic class InvoiceService {\n}",
+		"src/main/java/com/shop/order/OrderService.java":     "package com.shop.order;\n\nimport com.shop.billing.InvoiceService;\n\n@Service\npublic class OrderService {\n}",
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "graph", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	for _, name := range []string{"format", "level", "focus", "json", "module"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "dot", cmd.Flags().Lookup("format").DefValue)
+	assert.Equal(t, "package", cmd.Flags().Lookup("level").DefValue)
+}
+
+func TestBuildGraphModuleLevel(t *testing.T) {
+	g, err := BuildGraph(testutil.MemFs(t, "/project", shopServices()), "/project", graph.LevelModule, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "com.shop", g.BasePackage)
+	assert.Len(t, g.Nodes, 3)
+	assert.Equal(t, [][]string{{"billing", "user"}}, g.Cycles)
+}
+
+func TestBuildGraphWithFocus(t *testing.T) {
+	g, err := BuildGraph(testutil.MemFs(t, "/project", shopServices()), "/project", graph.LevelModule, "order")
+	require.NoError(t, err)
+
+	require.Len(t, g.Edges, 1)
+	assert.Equal(t, "order", g.Edges[0].From)
+	assert.Equal(t, "billing", g.Edges[0].To)
+
+	_, err = BuildGraph(testutil.MemFs(t, "/project", shopServices()), "/project", graph.LevelModule, "shipping")
+	assert.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const (
+	kindBuild    = "build"
+	kindSource   = "source"
+	kindTemplate = "template"
+)
+
+func newJakartaCommand() *cobra.Command {
+	var dryRun bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "jakarta",
+		Short: "Migrate from javax.* to jakarta.*",
+		Long: `Move the project from the javax.* Java EE namespace to the jakarta.*
+Jakarta EE namespace.
+
+The command:
+  - Rewrites javax.persistence, javax.validation, javax.servlet and the
+    other Jakarta EE packages in .java and .kt sources, including
+    imports, wildcard imports and fully qualified names
+  - Swaps javax API dependencies in the build file for their jakarta
+    counterparts, and bumps jakarta.* artifacts whose version still uses
+    the javax namespace
+  - Rewrites custom templates in .haft/templates
+  - Refreshes the cached profile in .haft/profile.json
+
+Packages that stay in the JDK, such as javax.sql, javax.crypto and
+javax.transaction.xa, are left alone. Wildcard javax.annotation.* imports
+mix JDK and Jakarta types and are reported instead of rewritten.
+
+Use --dry-run to print a unified diff of every change without writing files.`,
+		Example: `  # Migrate the project
+  haft migrate jakarta
+
+  # Show the diff without writing files
+  haft migrate jakarta --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return migrateError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := migrateJakarta(afero.NewOsFs(), dir, dryRun)
+			if err != nil {
+				return migrateError(jsonOutput, "MIGRATE_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printMigration(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the diff without writing files")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func migrateJakarta(fs afero.Fs, dir string, dryRun bool) (output.MigrateOutput, error) {
+	layer := afero.NewMemMapFs()
+	work := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), layer)
+
+	out := output.MigrateOutput{
+		Migration:    "jakarta",
+		DryRun:       dryRun,
+		Files:        []output.MigratedFile{},
+		Dependencies: []output.UpgradeChange{},
+		Manual:       []output.UpgradeIssue{},
+	}
+
+	result, project, err := buildtool.Load(work, dir)
+	if err != nil {
+		return out, err
+	}
+	buildFile := buildtool.RelativePath(dir, result.FilePath)
+
+	bootVersion := result.Parser.GetSpringBootVersion(project)
+	if bootVersion != "" && majorVersion(bootVersion) < 3 {
+		out.Manual = append(out.Manual, output.UpgradeIssue{
+			File:    buildFile,
+			Item:    "spring-boot " + bootVersion,
+			Message: "Spring Boot 2.x is built on javax.*; move to Spring Boot 3 with 'haft boot upgrade --to 3.x'",
+		})
+	}
+
+	out.Dependencies = migrateDependencies(result.Parser, project, majorVersion(bootVersion))
+	for i := range out.Dependencies {
+		out.Dependencies[i].File = buildFile
+	}
+	if len(out.Dependencies) > 0 {
+		if err := result.Parser.Write(result.FilePath, project); err != nil {
+			return out, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+		}
+	}
+
+	replacements := map[string]int{}
+	paths := append(findSources(fs, dir), findTemplates(fs, dir)...)
+	for _, path := range paths {
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return out, fmt.Errorf("could not read %s: %w", path, err)
+		}
+		for _, line := range wildcardLines(string(data), "javax.annotation.*") {
+			out.Manual = append(out.Manual, output.UpgradeIssue{
+				File:    buildtool.RelativePath(dir, path),
+				Line:    line,
+				Item:    "javax.annotation.*",
+				Message: "javax.annotation mixes JDK and Jakarta types; import the jakarta.annotation classes one by one",
+			})
+		}
+		content, count := rewriteNamespace(string(data))
+		if count == 0 {
+			continue
+		}
+		if err := afero.WriteFile(work, path, []byte(content), 0644); err != nil {
+			return out, fmt.Errorf("could not write %s: %w", path, err)
+		}
+		replacements[path] = count
+	}
+
+	files, err := collectChanges(fs, layer, dir, dryRun)
+	if err != nil {
+		return out, err
+	}
+	for _, file := range files {
+		file.Replacements = replacements[filepath.Join(dir, filepath.FromSlash(file.File))]
+		out.Files = append(out.Files, file)
+	}
+
+	if dryRun || len(out.Files) == 0 {
+		return out, nil
+	}
+	out.ProfileRefreshed, err = refreshProfile(fs, dir)
+	return out, err
+}
+
+func migrateDependencies(parser buildtool.Parser, project *buildtool.Project, bootMajor int) []output.UpgradeChange {
+	changes := []output.UpgradeChange{}
+	for _, move := range artifactMoves {
+		if dep := parser.GetDependency(project, move.From.GroupId, move.From.ArtifactId); dep != nil {
+			moved := *dep
+			moved.GroupId = move.To.GroupId
+			moved.ArtifactId = move.To.ArtifactId
+			if dep.Version != "" || bootMajor < 3 {
+				moved.Version = move.Version
+			}
+			parser.RemoveDependency(project, dep.GroupId, dep.ArtifactId)
+			parser.AddDependency(project, moved)
+			changes = append(changes, output.UpgradeChange{
+				Item: "dependency",
+				From: coordinates(move.From, dep.Version),
+				To:   coordinates(move.To, moved.Version),
+			})
+			continue
+		}
+
+		dep := parser.GetDependency(project, move.To.GroupId, move.To.ArtifactId)
+		if dep == nil || strings.Contains(dep.Version, "$") || majorVersion(dep.Version) == 0 || majorVersion(dep.Version) >= move.Major {
+			continue
+		}
+		bumped := *dep
+		bumped.Version = move.Version
+		parser.RemoveDependency(project, dep.GroupId, dep.ArtifactId)
+		parser.AddDependency(project, bumped)
+		changes = append(changes, output.UpgradeChange{
+			Item: "dependency",
+			From: coordinates(move.To, dep.Version),
+			To:   coordinates(move.To, bumped.Version),
+		})
+	}
+	return changes
+}
+
+func coordinates(coordinate Coordinate, version string) string {
+	if version == "" {
+		return coordinate.GroupId + ":" + coordinate.ArtifactId
+	}
+	return coordinate.GroupId + ":" + coordinate.ArtifactId + ":" + version
+}
+
+func majorVersion(version string) int {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+func findSources(fs afero.Fs, dir string) []string {
+	var paths []string
+	_ = afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "build" || name == "target" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(path); ext == ".java" || ext == ".kt" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths
+}
+
+func findTemplates(fs afero.Fs, dir string) []string {
+	root := filepath.Join(dir, generator.ProjectTemplateDir)
+	var paths []string
+	_ = afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths
+}
+
+func collectChanges(fs, layer afero.Fs, dir string, dryRun bool) ([]output.MigratedFile, error) {
+	var files []output.MigratedFile
+	err := afero.Walk(layer, string(filepath.Separator), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		after, err := afero.ReadFile(layer, path)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+		before, _ := afero.ReadFile(fs, path)
+		if string(before) == string(after) {
+			return nil
+		}
+
+		rel := buildtool.RelativePath(dir, path)
+		file := output.MigratedFile{File: rel, Kind: fileKind(rel)}
+		if dryRun {
+			file.Diff = generator.UnifiedDiff("a/"+rel, "b/"+rel, string(before), string(after), 3)
+		} else if err := afero.WriteFile(fs, path, after, 0644); err != nil {
+			return fmt.Errorf("could not write %s: %w", path, err)
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+func fileKind(rel string) string {
+	switch {
+	case strings.HasPrefix(rel, generator.ProjectTemplateDir+"/"):
+		return kindTemplate
+	case strings.HasSuffix(rel, ".java"), strings.HasSuffix(rel, ".kt"):
+		return kindSource
+	default:
+		return kindBuild
+	}
+}
+
+func refreshProfile(fs afero.Fs, dir string) (bool, error) {
+	cache := detector.NewProfileCacheWithFs(fs, dir)
+	if !cache.Exists() {
+		return false, nil
+	}
+	previous, err := cache.Load()
+	if err != nil {
+		return false, err
+	}
+
+	d := detector.NewDetector(dir, detector.WithFileSystem(fs))
+	profile, err := d.Detect()
+	if err != nil {
+		return false, fmt.Errorf("failed to detect project profile: %w", err)
+	}
+	profile.PreserveLocked(previous)
+	if profile.ValidationStyle == detector.ValidationJavax {
+		profile.ValidationStyle = detector.ValidationJakarta
+	}
+
+	cache.SetSourceChecksum(d.LastScan().SourceChecksum)
+	if err := cache.Save(profile); err != nil {
+		return false, err
+	}
+	_ = d.SaveIndex()
+	return true, nil
+}
@@ -0,0 +1,240 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-starter-parent</artifactId>
+        <version>3.4.7</version>
+    </parent>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>javax.servlet</groupId>
+            <artifactId>javax.servlet-api</artifactId>
+            <version>4.0.1</version>
+            <scope>provided</scope>
+        </dependency>
+        <dependency>
+            <groupId>javax.validation</groupId>
+            <artifactId>validation-api</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>jakarta.persistence</groupId>
+            <artifactId>jakarta.persistence-api</artifactId>
+            <version>2.2.3</version>
+        </dependency>
+    </dependencies>
+</project>
+`
+
+const gradleBuild = `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '2.7.18'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-data-jpa'
+    compileOnly 'javax.servlet:javax.servlet-api'
+}
+`
+
+const userEntity = `package com.example.demo.user;
+
+import javax.persistence.Entity;
+import javax.persistence.Id;
+import javax.validation.constraints.NotBlank;
+
+@Entity
+public class User {
+    @Id
+    private Long id;
+
+    @NotBlank
+    private String name;
+}
+`
+
+const userService = `package com.example.demo.user
+
+import javax.annotation.PostConstruct
+import javax.sql.DataSource
+
+class UserService(private val dataSource: DataSource) {
+    @PostConstruct
+    fun init() {}
+}
+`
+
+const entityTemplate = `package {{.BasePackage}}.entity;
+
+import javax.persistence.*;
+import javax.sql.DataSource;
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "migrate", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("module"))
+	require.Len(t, cmd.Commands(), 1)
+
+	jakarta := cmd.Commands()[0]
+	assert.Equal(t, "jakarta", jakarta.Name())
+	for _, flag := range []string{"dry-run", "json"} {
+		assert.NotNil(t, jakarta.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestMigrateJakarta_Maven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml": pom,
+		"src/main/java/com/example/demo/user/User.java":        userEntity,
+		"src/main/kotlin/com/example/demo/user/UserService.kt": userService,
+		".haft/templates/resource/layered/Entity.java.tmpl":    entityTemplate,
+		"target/generated-sources/Stale.java":                  userEntity,
+	})
+
+	out, err := migrateJakarta(fs, "/project", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []output.MigratedFile{
+		{File: ".haft/templates/resource/layered/Entity.java.tmpl", Kind: kindTemplate, Replacements: 1},
+		{File: "pom.xml", Kind: kindBuild},
+		{File: "src/main/java/com/example/demo/user/User.java", Kind: kindSource, Replacements: 3},
+		{File: "src/main/kotlin/com/example/demo/user/UserService.kt", Kind: kindSource, Replacements: 1},
+	}, out.Files)
+	assert.Equal(t, []output.UpgradeChange{
+		{File: "pom.xml", Item: "dependency", From: "javax.servlet:javax.servlet-api:4.0.1", To: "jakarta.servlet:jakarta.servlet-api:6.0.0"},
+		{File: "pom.xml", Item: "dependency", From: "jakarta.persistence:jakarta.persistence-api:2.2.3", To: "jakarta.persistence:jakarta.persistence-api:3.1.0"},
+		{File: "pom.xml", Item: "dependency", From: "javax.validation:validation-api", To: "jakarta.validation:jakarta.validation-api"},
+	}, out.Dependencies)
+	assert.Empty(t, out.Manual)
+	assert.False(t, out.ProfileRefreshed)
+
+	user := testutil.ReadFile(t, fs, "/project/src/main/java/com/example/demo/user/User.java")
+	assert.Contains(t, user, "import jakarta.persistence.Entity;")
+	assert.Contains(t, user, "import jakarta.validation.constraints.NotBlank;")
+	assert.NotContains(t, user, "javax")
+
+	service := testutil.ReadFile(t, fs, "/project/src/main/kotlin/com/example/demo/user/UserService.kt")
+	assert.Contains(t, service, "import jakarta.annotation.PostConstruct")
+	assert.Contains(t, service, "import javax.sql.DataSource")
+
+	template := testutil.ReadFile(t, fs, "/project/.haft/templates/resource/layered/Entity.java.tmpl")
+	assert.Contains(t, template, "import jakarta.persistence.*;")
+	assert.Contains(t, template, "import javax.sql.DataSource;")
+
+	assert.Equal(t, userEntity, testutil.ReadFile(t, fs, "/project/target/generated-sources/Stale.java"))
+
+	build := testutil.ReadFile(t, fs, "/project/pom.xml")
+	assert.NotContains(t, build, "javax")
+	assert.Contains(t, build, "<artifactId>jakarta.servlet-api</artifactId>")
+	assert.Contains(t, build, "<version>6.0.0</version>")
+	assert.Contains(t, build, "<scope>provided</scope>")
+	assert.Contains(t, build, "<version>3.1.0</version>")
+	assert.NotContains(t, build, "2.2.3")
+}
+
+func TestMigrateJakarta_DryRun(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml": pom,
+		"src/main/java/com/example/demo/user/User.java": userEntity,
+	})
+
+	out, err := migrateJakarta(fs, "/project", true)
+	require.NoError(t, err)
+
+	assert.True(t, out.DryRun)
+	require.Len(t, out.Files, 2)
+	assert.Contains(t, out.Files[0].Diff, "-            <groupId>javax.servlet</groupId>")
+	assert.Contains(t, out.Files[1].Diff, "--- a/src/main/java/com/example/demo/user/User.java\n+++ b/src/main/java/com/example/demo/user/User.java\n")
+	assert.Contains(t, out.Files[1].Diff, "-import javax.persistence.Entity;\n")
+	assert.Contains(t, out.Files[1].Diff, "+import jakarta.persistence.Entity;\n")
+
+	assert.Equal(t, pom, testutil.ReadFile(t, fs, "/project/pom.xml"))
+	assert.Equal(t, userEntity, testutil.ReadFile(t, fs, "/project/src/main/java/com/example/demo/user/User.java"))
+}
+
+func TestMigrateJakarta_Gradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"build.gradle": gradleBuild,
+		"src/main/java/com/example/demo/user/User.java": "import javax.annotation.*;\n",
+	})
+
+	out, err := migrateJakarta(fs, "/project", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []output.UpgradeChange{
+		{File: "build.gradle", Item: "dependency", From: "javax.servlet:javax.servlet-api", To: "jakarta.servlet:jakarta.servlet-api:6.0.0"},
+	}, out.Dependencies)
+
+	build := testutil.ReadFile(t, fs, "/project/build.gradle")
+	assert.Contains(t, build, "compileOnly 'jakarta.servlet:jakarta.servlet-api:6.0.0'")
+	assert.NotContains(t, build, "javax")
+
+	require.Len(t, out.Manual, 2)
+	assert.Equal(t, "spring-boot 2.7.18", out.Manual[0].Item)
+	assert.Equal(t, output.UpgradeIssue{
+		File:    "src/main/java/com/example/demo/user/User.java",
+		Line:    1,
+		Item:    "javax.annotation.*",
+		Message: out.Manual[1].Message,
+	}, out.Manual[1])
+}
+
+func TestMigrateJakarta_RefreshesProfile(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml": pom,
+		"src/main/java/com/example/demo/user/User.java": userEntity,
+	})
+
+	cache := detector.NewProfileCacheWithFs(fs, "/project")
+	d := detector.NewDetector("/project", detector.WithFileSystem(fs))
+	profile, err := d.Detect()
+	require.NoError(t, err)
+	require.Equal(t, detector.ValidationJavax, profile.ValidationStyle)
+	require.NoError(t, profile.Lock("validation_style"))
+	require.NoError(t, cache.Save(profile))
+
+	out, err := migrateJakarta(fs, "/project", false)
+	require.NoError(t, err)
+	assert.True(t, out.ProfileRefreshed)
+
+	refreshed, err := cache.Load()
+	require.NoError(t, err)
+	require.NotNil(t, refreshed)
+	assert.Equal(t, detector.ValidationJakarta, refreshed.ValidationStyle)
+	assert.True(t, refreshed.IsLocked("validation_style"))
+}
+
+func TestMigrateJakarta_NothingToDo(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{
+		"pom.xml": "<project><modelVersion>4.0.0</modelVersion></project>",
+		"src/main/java/com/example/demo/Config.java": "import javax.sql.DataSource;\n",
+	})
+
+	out, err := migrateJakarta(fs, "/project", false)
+	require.NoError(t, err)
+	assert.Empty(t, out.Files)
+	assert.Empty(t, out.Dependencies)
+	assert.False(t, out.ProfileRefreshed)
+}
@@ -0,0 +1,135 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	labelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	passedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	addedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	hunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate project sources to newer APIs",
+		Long: `Migrate the sources, build file and custom templates of a project
+to newer APIs.
+
+The jakarta subcommand moves a project from the javax.* Java EE namespace
+to the jakarta.* Jakarta EE namespace.`,
+		Example: `  # Move javax.* imports to jakarta.*
+  haft migrate jakarta
+
+  # Show the diff without writing files
+  haft migrate jakarta --dry-run`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newJakartaCommand())
+
+	return cmd
+}
+
+func printMigration(result output.MigrateOutput) {
+	title := "Jakarta EE Migration"
+	if result.DryRun {
+		title += " (dry run)"
+	}
+	fmt.Println()
+	fmt.Println(titleStyle.Render(title))
+	fmt.Println()
+
+	if len(result.Files) == 0 && len(result.Manual) == 0 {
+		fmt.Println(labelStyle.Render("  Nothing to migrate"))
+		fmt.Println()
+		return
+	}
+
+	if len(result.Files) > 0 {
+		fmt.Println(headerStyle.Render("Files:"))
+		for _, file := range result.Files {
+			detail := file.Kind
+			if file.Replacements > 0 {
+				detail = fmt.Sprintf("%s, %d name(s)", file.Kind, file.Replacements)
+			}
+			fmt.Printf("  %s %s %s\n", passedStyle.Render("✓"), file.File, labelStyle.Render("("+detail+")"))
+		}
+		fmt.Println()
+	}
+
+	if len(result.Dependencies) > 0 {
+		fmt.Println(headerStyle.Render("Dependencies:"))
+		for _, change := range result.Dependencies {
+			fmt.Printf("  %s %s %s %s\n", passedStyle.Render("✓"), change.From, labelStyle.Render("→"), change.To)
+		}
+		fmt.Println()
+	}
+
+	if result.ProfileRefreshed {
+		fmt.Printf("%s %s\n\n", passedStyle.Render("✓"), labelStyle.Render("Profile refreshed"))
+	}
+
+	if len(result.Manual) > 0 {
+		fmt.Println(headerStyle.Render("Needs attention:"))
+		for _, issue := range result.Manual {
+			location := issue.File
+			if issue.Line > 0 {
+				location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+			}
+			fmt.Printf("  %s %s %s\n", warningStyle.Render("⚠"), warningStyle.Render(issue.Item), labelStyle.Render(location))
+			fmt.Printf("    %s\n", issue.Message)
+		}
+		fmt.Println()
+	}
+
+	if result.DryRun {
+		for _, file := range result.Files {
+			printDiff(file.Diff)
+		}
+	}
+}
+
+func printDiff(diff string) {
+	if diff == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Println(headerStyle.Render(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(hunkStyle.Render(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(addedStyle.Render(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(removedStyle.Render(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+}
+
+func migrateError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
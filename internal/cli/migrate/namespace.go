@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Coordinate struct {
+	GroupId    string
+	ArtifactId string
+}
+
+type ArtifactMove struct {
+	From    Coordinate
+	To      Coordinate
+	Version string
+	Major   int
+}
+
+var qualifiedJavax = regexp.MustCompile(`\bjavax(\.[A-Za-z_][A-Za-z0-9_]*)+(\.\*)?`)
+
+var jakartaPackages = []string{
+	"activation",
+	"annotation.Generated",
+	"annotation.ManagedBean",
+	"annotation.PostConstruct",
+	"annotation.PreDestroy",
+	"annotation.Priority",
+	"annotation.Resource",
+	"annotation.Resources",
+	"annotation.security",
+	"annotation.sql",
+	"batch",
+	"decorator",
+	"ejb",
+	"el",
+	"enterprise",
+	"faces",
+	"inject",
+	"interceptor",
+	"jms",
+	"json",
+	"jws",
+	"mail",
+	"persistence",
+	"resource",
+	"security.auth.message",
+	"security.enterprise",
+	"security.jacc",
+	"servlet",
+	"transaction",
+	"validation",
+	"websocket",
+	"ws.rs",
+	"xml.bind",
+	"xml.soap",
+	"xml.ws",
+}
+
+var jdkPackages = []string{
+	"transaction.xa",
+}
+
+var artifactMoves = []ArtifactMove{
+	{From: Coordinate{"javax.servlet", "javax.servlet-api"}, To: Coordinate{"jakarta.servlet", "jakarta.servlet-api"}, Version: "6.0.0", Major: 5},
+	{From: Coordinate{"javax.persistence", "javax.persistence-api"}, To: Coordinate{"jakarta.persistence", "jakarta.persistence-api"}, Version: "3.1.0", Major: 3},
+	{From: Coordinate{"javax.validation", "validation-api"}, To: Coordinate{"jakarta.validation", "jakarta.validation-api"}, Version: "3.0.2", Major: 3},
+	{From: Coordinate{"javax.annotation", "javax.annotation-api"}, To: Coordinate{"jakarta.annotation", "jakarta.annotation-api"}, Version: "2.1.1", Major: 2},
+	{From: Coordinate{"javax.transaction", "javax.transaction-api"}, To: Coordinate{"jakarta.transaction", "jakarta.transaction-api"}, Version: "2.0.1", Major: 2},
+	{From: Coordinate{"javax.inject", "javax.inject"}, To: Coordinate{"jakarta.inject", "jakarta.inject-api"}, Version: "2.0.1", Major: 2},
+	{From: Coordinate{"javax.ws.rs", "javax.ws.rs-api"}, To: Coordinate{"jakarta.ws.rs", "jakarta.ws.rs-api"}, Version: "3.1.0", Major: 3},
+	{From: Coordinate{"javax.xml.bind", "jaxb-api"}, To: Coordinate{"jakarta.xml.bind", "jakarta.xml.bind-api"}, Version: "4.0.2", Major: 3},
+	{From: Coordinate{"javax.mail", "javax.mail-api"}, To: Coordinate{"jakarta.mail", "jakarta.mail-api"}, Version: "2.1.3", Major: 2},
+	{From: Coordinate{"com.sun.mail", "javax.mail"}, To: Coordinate{"org.eclipse.angus", "angus-mail"}, Version: "2.0.3", Major: 1},
+	{From: Coordinate{"javax.websocket", "javax.websocket-api"}, To: Coordinate{"jakarta.websocket", "jakarta.websocket-api"}, Version: "2.1.1", Major: 2},
+	{From: Coordinate{"javax.json", "javax.json-api"}, To: Coordinate{"jakarta.json", "jakarta.json-api"}, Version: "2.1.3", Major: 2},
+	{From: Coordinate{"javax.el", "javax.el-api"}, To: Coordinate{"jakarta.el", "jakarta.el-api"}, Version: "5.0.1", Major: 4},
+	{From: Coordinate{"javax.activation", "javax.activation-api"}, To: Coordinate{"jakarta.activation", "jakarta.activation-api"}, Version: "2.1.3", Major: 2},
+	{From: Coordinate{"javax.jms", "javax.jms-api"}, To: Coordinate{"jakarta.jms", "jakarta.jms-api"}, Version: "3.1.0", Major: 3},
+}
+
+func jakartaName(name string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(name, "javax."), ".")
+	for _, pkg := range jdkPackages {
+		if hasSegments(segments, strings.Split(pkg, ".")) {
+			return name, false
+		}
+	}
+	for _, pkg := range jakartaPackages {
+		if hasSegments(segments, strings.Split(pkg, ".")) {
+			return "jakarta." + strings.TrimPrefix(name, "javax."), true
+		}
+	}
+	return name, false
+}
+
+func hasSegments(segments, prefix []string) bool {
+	if len(segments) < len(prefix) {
+		return false
+	}
+	for i, segment := range prefix {
+		if segments[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+func rewriteNamespace(content string) (string, int) {
+	count := 0
+	rewritten := qualifiedJavax.ReplaceAllStringFunc(content, func(match string) string {
+		name := strings.TrimSuffix(match, ".*")
+		renamed, ok := jakartaName(name)
+		if !ok {
+			return match
+		}
+		count++
+		return renamed + strings.TrimPrefix(match, name)
+	})
+	return rewritten, count
+}
+
+func wildcardLines(content, name string) []int {
+	var lines []int
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, name) {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJakartaName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		migrated bool
+	}{
+		{"javax.persistence.Entity", "jakarta.persistence.Entity", true},
+		{"javax.validation.constraints.NotNull", "jakarta.validation.constraints.NotNull", true},
+		{"javax.servlet.http.HttpServletRequest", "jakarta.servlet.http.HttpServletRequest", true},
+		{"javax.ws.rs.GET", "jakarta.ws.rs.GET", true},
+		{"javax.annotation.PostConstruct", "jakarta.annotation.PostConstruct", true},
+		{"javax.annotation.security.RolesAllowed", "jakarta.annotation.security.RolesAllowed", true},
+		{"javax.transaction.Transactional", "jakarta.transaction.Transactional", true},
+		{"javax.transaction.xa.XAResource", "javax.transaction.xa.XAResource", false},
+		{"javax.annotation.Nullable", "javax.annotation.Nullable", false},
+		{"javax.annotation.processing.Processor", "javax.annotation.processing.Processor", false},
+		{"javax.sql.DataSource", "javax.sql.DataSource", false},
+		{"javax.crypto.Cipher", "javax.crypto.Cipher", false},
+		{"javax.xml.parsers.DocumentBuilder", "javax.xml.parsers.DocumentBuilder", false},
+		{"javax.security.auth.Subject", "javax.security.auth.Subject", false},
+		{"javax.persistencex.Foo", "javax.persistencex.Foo", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renamed, ok := jakartaName(tt.name)
+			assert.Equal(t, tt.expected, renamed)
+			assert.Equal(t, tt.migrated, ok)
+		})
+	}
+}
+
+func TestRewriteNamespace_Java(t *testing.T) {
+	source := `package com.example.demo;
+
+import javax.persistence.*;
+import javax.validation.Valid;
+import javax.sql.DataSource;
+import javax.annotation.PostConstruct;
+
+@javax.persistence.Table(name = "users")
+public class User {
+    @javax.validation.constraints.NotBlank
+    private String name;
+}
+`
+	expected := `package com.example.demo;
+
+import jakarta.persistence.*;
+import jakarta.validation.Valid;
+import javax.sql.DataSource;
+import jakarta.annotation.PostConstruct;
+
+@jakarta.persistence.Table(name = "users")
+public class User {
+    @jakarta.validation.constraints.NotBlank
+    private String name;
+}
+`
+	rewritten, count := rewriteNamespace(source)
+	assert.Equal(t, expected, rewritten)
+	assert.Equal(t, 5, count)
+}
+
+func TestRewriteNamespace_Kotlin(t *testing.T) {
+	source := `import javax.servlet.http.HttpServletRequest
+import javax.inject.Inject
+
+class Handler @Inject constructor(private val request: HttpServletRequest)
+`
+	rewritten, count := rewriteNamespace(source)
+	assert.Contains(t, rewritten, "import jakarta.servlet.http.HttpServletRequest\n")
+	assert.Contains(t, rewritten, "import jakarta.inject.Inject\n")
+	assert.Equal(t, 2, count)
+}
+
+func TestRewriteNamespace_Unchanged(t *testing.T) {
+	source := "import javax.annotation.*;\nimport javax.crypto.Cipher;\nString javaxName = \"javax\";\n"
+	rewritten, count := rewriteNamespace(source)
+	assert.Equal(t, source, rewritten)
+	assert.Zero(t, count)
+	assert.Equal(t, []int{1}, wildcardLines(source, "javax.annotation.*"))
+}
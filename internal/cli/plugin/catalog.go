@@ -0,0 +1,311 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+type CatalogEntry struct {
+	Name        string
+	Description string
+	Category    string
+	Maven       buildtool.Plugin
+	Gradle      buildtool.Plugin
+}
+
+func value(name, text string) buildtool.Setting {
+	return buildtool.Setting{Name: name, Value: text}
+}
+
+func group(name string, children ...buildtool.Setting) buildtool.Setting {
+	return buildtool.Setting{Name: name, Children: children}
+}
+
+func execution(id, phase string, goals ...string) buildtool.Setting {
+	exec := group("execution", value("id", id))
+	if phase != "" {
+		exec.Children = append(exec.Children, value("phase", phase))
+	}
+	goalsSetting := group("goals")
+	for _, goal := range goals {
+		goalsSetting.Children = append(goalsSetting.Children, value("goal", goal))
+	}
+	exec.Children = append(exec.Children, goalsSetting)
+	return exec
+}
+
+var pluginCatalog = map[string]CatalogEntry{
+	"jacoco": {
+		Name:        "JaCoCo",
+		Description: "Code coverage reports for tests",
+		Category:    "Testing",
+		Maven: buildtool.Plugin{
+			GroupId:    "org.jacoco",
+			ArtifactId: "jacoco-maven-plugin",
+			Version:    "0.8.12",
+			Configuration: []buildtool.Setting{
+				group("executions",
+					execution("prepare-agent", "", "prepare-agent"),
+					execution("report", "test", "report"),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID: "jacoco",
+			Script: `jacoco {
+	toolVersion = '0.8.12'
+}
+
+jacocoTestReport {
+	dependsOn test
+	reports {
+		xml.required = true
+		html.required = true
+	}
+}
+
+test.finalizedBy jacocoTestReport`,
+			KotlinScript: `jacoco {
+	toolVersion = "0.8.12"
+}
+
+tasks.jacocoTestReport {
+	dependsOn(tasks.test)
+	reports {
+		xml.required = true
+		html.required = true
+	}
+}
+
+tasks.test {
+	finalizedBy(tasks.jacocoTestReport)
+}`,
+		},
+	},
+	"spotless": {
+		Name:        "Spotless",
+		Description: "Format Java sources with google-java-format",
+		Category:    "Code Quality",
+		Maven: buildtool.Plugin{
+			GroupId:    "com.diffplug.spotless",
+			ArtifactId: "spotless-maven-plugin",
+			Version:    "2.44.0",
+			Configuration: []buildtool.Setting{
+				group("configuration",
+					group("java", group("googleJavaFormat"), group("removeUnusedImports")),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID:      "com.diffplug.spotless",
+			Version: "7.0.2",
+			Script: `spotless {
+	java {
+		googleJavaFormat()
+		removeUnusedImports()
+	}
+}`,
+			KotlinScript: `spotless {
+	java {
+		googleJavaFormat()
+		removeUnusedImports()
+	}
+}`,
+		},
+	},
+	"checkstyle": {
+		Name:        "Checkstyle",
+		Description: "Check code style against Google Java Style",
+		Category:    "Code Quality",
+		Maven: buildtool.Plugin{
+			ArtifactId: "maven-checkstyle-plugin",
+			Version:    "3.6.0",
+			Configuration: []buildtool.Setting{
+				group("configuration",
+					value("configLocation", "google_checks.xml"),
+					value("consoleOutput", "true"),
+					value("failOnViolation", "false"),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID: "checkstyle",
+			Script: `checkstyle {
+	toolVersion = '10.21.1'
+	ignoreFailures = true
+}`,
+			KotlinScript: `checkstyle {
+	toolVersion = "10.21.1"
+	isIgnoreFailures = true
+}`,
+		},
+	},
+	"versions": {
+		Name:        "Versions",
+		Description: "Report dependency and plugin updates",
+		Category:    "Dependencies",
+		Maven: buildtool.Plugin{
+			GroupId:    "org.codehaus.mojo",
+			ArtifactId: "versions-maven-plugin",
+			Version:    "2.18.0",
+			Configuration: []buildtool.Setting{
+				group("configuration", value("generateBackupPoms", "false")),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID:      "com.github.ben-manes.versions",
+			Version: "0.52.0",
+			Script: `tasks.named('dependencyUpdates').configure {
+	rejectVersionIf {
+		def version = it.candidate.version.toUpperCase()
+		!(['RELEASE', 'FINAL', 'GA'].any { version.contains(it) } || version.matches('^[0-9,.V-]+(-R)?$'))
+	}
+}`,
+			KotlinScript: `tasks.named<com.github.benmanes.gradle.versions.updates.DependencyUpdatesTask>("dependencyUpdates") {
+	rejectVersionIf {
+		val version = candidate.version.uppercase()
+		!(listOf("RELEASE", "FINAL", "GA").any { version.contains(it) } || version.matches(Regex("^[0-9,.V-]+(-R)?$")))
+	}
+}`,
+		},
+	},
+	"native": {
+		Name:        "GraalVM Native",
+		Description: "Compile the application to a native image",
+		Category:    "Packaging",
+		Maven: buildtool.Plugin{
+			GroupId:    "org.graalvm.buildtools",
+			ArtifactId: "native-maven-plugin",
+			Version:    "0.10.4",
+			Configuration: []buildtool.Setting{
+				group("configuration",
+					group("metadataRepository", value("enabled", "true")),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID:      "org.graalvm.buildtools.native",
+			Version: "0.10.4",
+			Script: `graalvmNative {
+	metadataRepository {
+		enabled = true
+	}
+}`,
+			KotlinScript: `graalvmNative {
+	metadataRepository {
+		enabled = true
+	}
+}`,
+		},
+	},
+	"jib": {
+		Name:        "Jib",
+		Description: "Build container images without a Dockerfile",
+		Category:    "Packaging",
+		Maven: buildtool.Plugin{
+			GroupId:    "com.google.cloud.tools",
+			ArtifactId: "jib-maven-plugin",
+			Version:    "3.4.4",
+			Configuration: []buildtool.Setting{
+				group("configuration",
+					group("to", value("image", "${project.artifactId}:${project.version}")),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID:      "com.google.cloud.tools.jib",
+			Version: "3.4.4",
+			Script: `jib {
+	to {
+		image = "${project.name}:${project.version}"
+	}
+}`,
+			KotlinScript: `jib {
+	to {
+		image = "${project.name}:${project.version}"
+	}
+}`,
+		},
+	},
+	"git-properties": {
+		Name:        "Git Properties",
+		Description: "Expose git commit details through /actuator/info",
+		Category:    "Ops",
+		Maven: buildtool.Plugin{
+			GroupId:    "io.github.git-commit-id",
+			ArtifactId: "git-commit-id-maven-plugin",
+			Version:    "9.0.1",
+			Configuration: []buildtool.Setting{
+				group("executions", execution("get-the-git-infos", "initialize", "revision")),
+				group("configuration",
+					value("generateGitPropertiesFile", "true"),
+					value("failOnNoGitDirectory", "false"),
+				),
+			},
+		},
+		Gradle: buildtool.Plugin{
+			ID:      "com.gorylenko.gradle-git-properties",
+			Version: "2.4.2",
+		},
+	},
+}
+
+func GetCatalogEntry(shortcut string) (CatalogEntry, bool) {
+	entry, ok := pluginCatalog[shortcut]
+	return entry, ok
+}
+
+func GetAllShortcuts() []string {
+	shortcuts := make([]string, 0, len(pluginCatalog))
+	for shortcut := range pluginCatalog {
+		shortcuts = append(shortcuts, shortcut)
+	}
+	sort.Strings(shortcuts)
+	return shortcuts
+}
+
+func catalogPlugin(entry CatalogEntry, tool buildtool.Type) buildtool.Plugin {
+	if tool == buildtool.Maven {
+		return entry.Maven
+	}
+	return entry.Gradle
+}
+
+func shortcutFor(plugin buildtool.Plugin, tool buildtool.Type) string {
+	for _, shortcut := range GetAllShortcuts() {
+		if catalogPlugin(pluginCatalog[shortcut], tool).Matches(plugin) {
+			return shortcut
+		}
+	}
+	return ""
+}
+
+func resolvePlugin(arg string, tool buildtool.Type) (buildtool.Plugin, error) {
+	if entry, ok := pluginCatalog[arg]; ok {
+		return catalogPlugin(entry, tool), nil
+	}
+
+	parts := strings.Split(arg, ":")
+	if tool == buildtool.Maven {
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return buildtool.Plugin{}, fmt.Errorf("unknown plugin '%s' (use a shortcut or groupId:artifactId[:version])", arg)
+		}
+		plugin := buildtool.Plugin{GroupId: parts[0], ArtifactId: parts[1]}
+		if len(parts) == 3 {
+			plugin.Version = parts[2]
+		}
+		return plugin, nil
+	}
+
+	if len(parts) > 2 || parts[0] == "" {
+		return buildtool.Plugin{}, fmt.Errorf("unknown plugin '%s' (use a shortcut or id[:version])", arg)
+	}
+	plugin := buildtool.Plugin{ID: parts[0]}
+	if len(parts) == 2 {
+		plugin.Version = parts[1]
+	}
+	return plugin, nil
+}
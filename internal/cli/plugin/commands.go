@@ -0,0 +1,205 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newListCommand() *cobra.Command {
+	var available bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List build plugins",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if available {
+				out := availableOutput()
+				if jsonOutput {
+					return output.Success(out)
+				}
+				printAvailable(out)
+				return nil
+			}
+
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return pluginError(jsonOutput, "CWD_ERROR", err)
+			}
+			result, project, err := buildtool.Load(afero.NewOsFs(), dir)
+			if err != nil {
+				return pluginError(jsonOutput, "NO_BUILD_FILE", err)
+			}
+
+			out := buildOutput(result, project)
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printPlugins(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&available, "available", false, "Show plugins in the catalog")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newAddCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "add <plugin...>",
+		Short: "Add build plugins",
+		Long: `Add plugins to the build file.
+
+Catalog shortcuts come with default configuration: executions and a
+<configuration> section for Maven, configuration blocks after the
+plugins { } block for Gradle. When the project uses a version catalog
+(gradle/libs.versions.toml), Gradle plugins with a version are added to
+its [plugins] table and referenced with alias(libs.plugins.x).`,
+		Example: `  # Add from the catalog
+  haft plugin add jacoco
+
+  # Add several at once
+  haft plugin add spotless checkstyle
+
+  # Add a Maven plugin by coordinates
+  haft plugin add org.apache.maven.plugins:maven-enforcer-plugin:3.5.0
+
+  # Add a Gradle plugin by id
+  haft plugin add org.openapi.generator:7.10.0`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return pluginError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := addPlugins(afero.NewOsFs(), dir, args)
+			if err != nil {
+				return pluginError(jsonOutput, "PLUGIN_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			log := logger.Default()
+			for _, id := range out.Added {
+				log.Success("Added", "plugin", id)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newRemoveCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <plugin...>",
+		Aliases: []string{"rm"},
+		Short:   "Remove build plugins",
+		Long: `Remove plugins from the build file.
+
+For Gradle, configuration blocks that haft added with the plugin are
+removed too, as long as they have not been edited since.`,
+		Example: `  # Remove by shortcut
+  haft plugin remove jacoco
+
+  # Remove by coordinates or id
+  haft plugin remove com.google.cloud.tools:jib-maven-plugin
+  haft plugin remove com.google.cloud.tools.jib`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return pluginError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := removePlugins(afero.NewOsFs(), dir, args)
+			if err != nil {
+				return pluginError(jsonOutput, "PLUGIN_NOT_FOUND", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			log := logger.Default()
+			for _, id := range out.Removed {
+				log.Success("Removed", "plugin", id)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func addPlugins(fs afero.Fs, dir string, args []string) (output.PluginsOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.PluginsOutput{}, err
+	}
+
+	buildFile := buildtool.GetBuildFileName(result.BuildTool)
+	var added []string
+	for _, arg := range args {
+		plugin, err := resolvePlugin(arg, result.BuildTool)
+		if err != nil {
+			return output.PluginsOutput{}, err
+		}
+		if !result.Parser.AddPlugin(project, plugin) {
+			return output.PluginsOutput{}, fmt.Errorf("plugin '%s' already exists in %s", plugin.Key(), buildFile)
+		}
+		added = append(added, plugin.Key())
+	}
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.PluginsOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Added = added
+	return out, nil
+}
+
+func removePlugins(fs afero.Fs, dir string, args []string) (output.PluginsOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.PluginsOutput{}, err
+	}
+
+	var removed []string
+	for _, arg := range args {
+		plugin, err := resolvePlugin(arg, result.BuildTool)
+		if err != nil {
+			return output.PluginsOutput{}, err
+		}
+		if !result.Parser.RemovePlugin(project, plugin) {
+			return output.PluginsOutput{}, fmt.Errorf("plugin '%s' not found in %s", plugin.Key(), buildtool.GetBuildFileName(result.BuildTool))
+		}
+		removed = append(removed, plugin.Key())
+	}
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.PluginsOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Removed = removed
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	tagStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:     "plugin",
+		Aliases: []string{"plugins"},
+		Short:   "Manage build plugins",
+		Long: `List, add and remove build plugins such as JaCoCo, Spotless,
+Checkstyle, the versions plugin or the GraalVM native plugin.
+
+Shortcuts from the plugin catalog write a <plugin> entry with sensible
+default configuration to the <build><plugins> section of pom.xml, or a
+plugins { } entry plus its configuration blocks to build.gradle(.kts).
+Plugins outside the catalog can be given as groupId:artifactId[:version]
+for Maven or id[:version] for Gradle.`,
+		Example: `  # List plugins in the build file
+  haft plugin list
+
+  # Show the plugin catalog
+  haft plugin list --available
+
+  # Add code coverage and formatting
+  haft plugin add jacoco spotless
+
+  # Remove a plugin
+  haft plugin remove checkstyle`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newAddCommand())
+	cmd.AddCommand(newRemoveCommand())
+
+	return cmd
+}
+
+func buildOutput(result *buildtool.DetectionResult, project *buildtool.Project) output.PluginsOutput {
+	out := output.PluginsOutput{
+		BuildFile: result.FilePath,
+		Plugins:   []output.PluginInfo{},
+	}
+	for _, plugin := range result.Parser.GetPlugins(project) {
+		out.Plugins = append(out.Plugins, output.PluginInfo{
+			ID:       plugin.Key(),
+			Version:  plugin.Version,
+			Shortcut: shortcutFor(plugin, result.BuildTool),
+		})
+	}
+	return out
+}
+
+func availableOutput() output.PluginsOutput {
+	out := output.PluginsOutput{Plugins: []output.PluginInfo{}}
+	for _, shortcut := range GetAllShortcuts() {
+		entry := pluginCatalog[shortcut]
+		out.Plugins = append(out.Plugins, output.PluginInfo{
+			ID:          entry.Gradle.ID,
+			Shortcut:    shortcut,
+			Name:        entry.Name,
+			Description: entry.Description,
+		})
+	}
+	return out
+}
+
+func printPlugins(result output.PluginsOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Plugins"))
+	fmt.Println(labelStyle.Render(result.BuildFile))
+	fmt.Println()
+
+	if len(result.Plugins) == 0 {
+		fmt.Println(labelStyle.Render("  No plugins declared"))
+		fmt.Println()
+		return
+	}
+
+	width := 0
+	for _, plugin := range result.Plugins {
+		width = max(width, len(plugin.ID))
+	}
+	for _, plugin := range result.Plugins {
+		version := plugin.Version
+		if version == "" {
+			version = "-"
+		}
+		line := fmt.Sprintf("  %s %s", valueStyle.Render(fmt.Sprintf("%-*s", width, plugin.ID)), labelStyle.Render(version))
+		if plugin.Shortcut != "" {
+			line += " " + tagStyle.Render("("+plugin.Shortcut+")")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func printAvailable(result output.PluginsOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Available Plugins"))
+	fmt.Println()
+
+	width := 0
+	for _, plugin := range result.Plugins {
+		width = max(width, len(plugin.Shortcut))
+	}
+	for _, plugin := range result.Plugins {
+		fmt.Printf("  %s %s\n", tagStyle.Render(fmt.Sprintf("%-*s", width, plugin.Shortcut)), labelStyle.Render(plugin.Description))
+	}
+	fmt.Println()
+}
+
+func pluginError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
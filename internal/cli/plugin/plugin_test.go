@@ -0,0 +1,219 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.springframework.boot</groupId>
+                <artifactId>spring-boot-maven-plugin</artifactId>
+            </plugin>
+        </plugins>
+    </build>
+</project>
+`
+
+const gradleBuild = `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.4.1'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+}
+`
+
+const kotlinBuild = `plugins {
+    java
+    id("org.springframework.boot") version "3.4.1"
+}
+
+dependencies {
+    implementation("org.springframework.boot:spring-boot-starter-web")
+}
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "plugin", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "add", "remove"}, names)
+}
+
+func TestCatalog(t *testing.T) {
+	for _, shortcut := range GetAllShortcuts() {
+		entry, ok := GetCatalogEntry(shortcut)
+		require.True(t, ok)
+		assert.NotEmpty(t, entry.Name, shortcut)
+		assert.NotEmpty(t, entry.Description, shortcut)
+		assert.NotEmpty(t, entry.Maven.ArtifactId, shortcut)
+		assert.NotEmpty(t, entry.Maven.Version, shortcut)
+		assert.NotEmpty(t, entry.Gradle.ID, shortcut)
+		assert.Equal(t, entry.Gradle.Script == "", entry.Gradle.KotlinScript == "", shortcut)
+	}
+}
+
+func TestResolvePlugin(t *testing.T) {
+	tests := []struct {
+		arg      string
+		tool     buildtool.Type
+		expected buildtool.Plugin
+		err      bool
+	}{
+		{"jacoco", buildtool.Gradle, pluginCatalog["jacoco"].Gradle, false},
+		{"jacoco", buildtool.Maven, pluginCatalog["jacoco"].Maven, false},
+		{"org.apache.maven.plugins:maven-enforcer-plugin:3.5.0", buildtool.Maven, buildtool.Plugin{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-enforcer-plugin", Version: "3.5.0"}, false},
+		{"maven-enforcer-plugin", buildtool.Maven, buildtool.Plugin{}, true},
+		{"org.openapi.generator:7.10.0", buildtool.Gradle, buildtool.Plugin{ID: "org.openapi.generator", Version: "7.10.0"}, false},
+		{"java-library", buildtool.Gradle, buildtool.Plugin{ID: "java-library"}, false},
+		{"a:b:c", buildtool.Gradle, buildtool.Plugin{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			plugin, err := resolvePlugin(tt.arg, tt.tool)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, plugin)
+		})
+	}
+}
+
+func TestAddAndRemoveMaven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom})
+
+	out, err := addPlugins(fs, "/project", []string{"jacoco"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org.jacoco:jacoco-maven-plugin"}, out.Added)
+	require.Len(t, out.Plugins, 2)
+	assert.Equal(t, "jacoco", out.Plugins[1].Shortcut)
+
+	data, err := afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `            <plugin>
+                <groupId>org.jacoco</groupId>
+                <artifactId>jacoco-maven-plugin</artifactId>
+                <version>0.8.12</version>
+                <executions>
+                    <execution>
+                        <id>prepare-agent</id>
+                        <goals>
+                            <goal>prepare-agent</goal>
+                        </goals>
+                    </execution>
+                    <execution>
+                        <id>report</id>
+                        <phase>test</phase>
+                        <goals>
+                            <goal>report</goal>
+                        </goals>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>`)
+
+	_, err = addPlugins(fs, "/project", []string{"jacoco"})
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = removePlugins(fs, "/project", []string{"org.jacoco:jacoco-maven-plugin"})
+	require.NoError(t, err)
+	data, err = afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, pom, string(data))
+
+	_, err = removePlugins(fs, "/project", []string{"spotless"})
+	assert.ErrorContains(t, err, "plugin 'com.diffplug.spotless:spotless-maven-plugin' not found in pom.xml")
+}
+
+func TestAddAndRemoveGradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"build.gradle": gradleBuild})
+
+	out, err := addPlugins(fs, "/project", []string{"jacoco", "jib"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jacoco", "com.google.cloud.tools.jib"}, out.Added)
+
+	data, err := afero.ReadFile(fs, "/project/build.gradle")
+	require.NoError(t, err)
+	assert.Equal(t, `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.4.1'
+    id 'jacoco'
+    id 'com.google.cloud.tools.jib' version '3.4.4'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+}
+
+jacoco {
+    toolVersion = '0.8.12'
+}
+
+jacocoTestReport {
+    dependsOn test
+    reports {
+        xml.required = true
+        html.required = true
+    }
+}
+
+test.finalizedBy jacocoTestReport
+
+jib {
+    to {
+        image = "${project.name}:${project.version}"
+    }
+}
+`, string(data))
+
+	out, err = removePlugins(fs, "/project", []string{"jacoco", "jib"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jacoco", "com.google.cloud.tools.jib"}, out.Removed)
+
+	data, err = afero.ReadFile(fs, "/project/build.gradle")
+	require.NoError(t, err)
+	assert.Equal(t, gradleBuild, string(data))
+}
+
+func TestCatalogRoundTrip(t *testing.T) {
+	builds := map[string]string{"pom.xml": pom, "build.gradle": gradleBuild, "build.gradle.kts": kotlinBuild}
+	for file, content := range builds {
+		for _, shortcut := range GetAllShortcuts() {
+			t.Run(file+"/"+shortcut, func(t *testing.T) {
+				fs := testutil.MemFs(t, "/project", map[string]string{file: content})
+
+				_, err := addPlugins(fs, "/project", []string{shortcut})
+				require.NoError(t, err)
+				_, err = removePlugins(fs, "/project", []string{shortcut})
+				require.NoError(t, err)
+
+				data, err := afero.ReadFile(fs, "/project/"+file)
+				require.NoError(t, err)
+				assert.Equal(t, content, string(data))
+			})
+		}
+	}
+}
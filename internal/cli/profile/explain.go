@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const scoreBarWidth = 20
+
+func newExplainCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "explain <field>",
+		Short: "Explain how a profile field was detected",
+		Long: `Re-scan the project and explain how a profile field is detected.
+
+Shows the detected value, its confidence and the evidence behind it.
+For architecture, the score of every candidate architecture is listed.
+
+Fields: ` + strings.Join(detector.ProfileFieldNames(), ", "),
+		Example: `  # Why was this architecture chosen?
+  haft profile explain architecture
+
+  # Explain the ID type as JSON
+  haft profile explain id_type --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExplain(cmd, args[0], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runExplain(cmd *cobra.Command, field string, jsonOutput bool) error {
+	dir, err := currentDir(cmd)
+	if err != nil {
+		return profileError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	result, err := explainField(afero.NewOsFs(), dir, field)
+	if err != nil {
+		return profileError(jsonOutput, "EXPLAIN_ERROR", err)
+	}
+
+	if jsonOutput {
+		return output.Success(result)
+	}
+
+	printExplanation(result)
+	return nil
+}
+
+func explainField(fs afero.Fs, dir, field string) (output.ProfileExplainOutput, error) {
+	explanation, err := detector.NewDetector(dir, detector.WithFileSystem(fs)).Explain(field)
+	if err != nil {
+		return output.ProfileExplainOutput{}, err
+	}
+
+	profile, err := loadProfile(fs, dir)
+	if err != nil {
+		return output.ProfileExplainOutput{}, err
+	}
+	value, _ := profile.GetField(field)
+
+	result := output.ProfileExplainOutput{
+		Field:      field,
+		Value:      value,
+		Detected:   fmt.Sprint(explanation.Result.Value),
+		Locked:     profile.IsLocked(field),
+		Confidence: explanation.Result.Confidence,
+		Evidence:   explanation.Result.Evidence,
+	}
+	for _, score := range explanation.Scores {
+		result.Scores = append(result.Scores, output.ArchitectureScore{
+			Architecture: score.Architecture.String(),
+			Score:        score.Score,
+		})
+	}
+	return result, nil
+}
+
+func printExplanation(result output.ProfileExplainOutput) {
+	calc := detector.NewConfidenceCalculator()
+
+	fmt.Println()
+	current := valueStyle.Render(result.Value)
+	if result.Locked {
+		current += " " + lockedStyle.Render("(locked)")
+	}
+	fmt.Printf("%s %s\n", titleStyle.Render(result.Field+":"), current)
+	fmt.Printf("  %s %s %s\n", labelStyle.Render("Detected:"), valueStyle.Render(result.Detected),
+		labelStyle.Render(fmt.Sprintf("(%d%% confidence, %s)", calc.FormatPercentage(result.Confidence), calc.GetConfidenceLevel(result.Confidence))))
+
+	if len(result.Scores) > 0 {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("Scores"))
+		for _, score := range result.Scores {
+			filled := int(score.Score*scoreBarWidth + 0.5)
+			bar := barStyle.Render(strings.Repeat("█", filled)) + labelStyle.Render(strings.Repeat("░", scoreBarWidth-filled))
+			fmt.Printf("  %-10s %s %s\n", score.Architecture, bar, labelStyle.Render(fmt.Sprintf("%.2f", score.Score)))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Evidence"))
+	for _, line := range result.Evidence {
+		fmt.Printf("  • %s\n", line)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,163 @@
+package profile
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valueStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	lockedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	barStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Inspect and correct the detected project profile",
+		Long: `Inspect, explain and correct the project profile Haft detects
+from your source code.
+
+The profile is cached in .haft/profile.json and drives package layout,
+naming and annotations for generated code. When detection guesses wrong,
+set the correct value and lock it so later scans keep it.
+
+Each module of a multi-module project has its own profile. Use --module
+to pick one when running from the project root.`,
+		Example: `  # Show the current profile
+  haft profile show
+
+  # Override and lock the architecture
+  haft profile set architecture=hexagonal --lock
+
+  # Let detection decide the ID type again
+  haft profile unlock id_type
+
+  # See why an architecture was chosen
+  haft profile explain architecture
+
+  # Show the profile of one module
+  haft profile show --module api`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newShowCommand())
+	cmd.AddCommand(newSetCommand())
+	cmd.AddCommand(newUnlockCommand())
+	cmd.AddCommand(newExplainCommand())
+
+	return cmd
+}
+
+func loadProfile(fs afero.Fs, dir string) (*detector.ProjectProfile, error) {
+	cache := detector.NewProfileCacheWithFs(fs, dir)
+	profile, err := cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil {
+		return profile, nil
+	}
+	return detectProfile(fs, dir, nil)
+}
+
+func detectProfile(fs afero.Fs, dir string, previous *detector.ProjectProfile) (*detector.ProjectProfile, error) {
+	d := detector.NewDetector(dir, detector.WithFileSystem(fs))
+	profile, err := d.Detect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect project profile: %w", err)
+	}
+	profile.PreserveLocked(previous)
+
+	scan := d.LastScan()
+	logger.Default().Debug("Project scanned",
+		"files", scan.Stats.Files,
+		"parsed", scan.Stats.Parsed,
+		"cached", scan.Stats.Reused,
+		"workers", scan.Stats.Workers,
+		"duration", scan.Stats.Duration.String())
+
+	cache := detector.NewProfileCacheWithFs(fs, dir)
+	cache.SetSourceChecksum(scan.SourceChecksum)
+	if err := cache.Save(profile); err != nil {
+		return nil, err
+	}
+	_ = d.SaveIndex()
+	return profile, nil
+}
+
+func saveProfile(fs afero.Fs, dir string, profile *detector.ProjectProfile) error {
+	return detector.NewProfileCacheWithFs(fs, dir).Save(profile)
+}
+
+func profilePath(dir string) string {
+	return filepath.Join(dir, detector.CacheDir, detector.ProfileFile)
+}
+
+func buildOutput(dir string, profile *detector.ProjectProfile, updated []string) output.ProfileOutput {
+	result := output.ProfileOutput{
+		Path:       profilePath(dir),
+		DetectedAt: profile.DetectedAt.Format(time.RFC3339),
+		Updated:    updated,
+	}
+
+	for _, name := range detector.ProfileFieldNames() {
+		value, _ := profile.GetField(name)
+		result.Fields = append(result.Fields, output.ProfileField{
+			Name:   name,
+			Value:  value,
+			Locked: profile.IsLocked(name),
+		})
+	}
+	return result
+}
+
+func printProfile(result output.ProfileOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Project Profile"))
+	fmt.Println(labelStyle.Render(result.Path))
+	fmt.Println()
+
+	for _, field := range result.Fields {
+		value := field.Value
+		if value == "" {
+			value = "-"
+		}
+		line := fmt.Sprintf("  %s %s", labelStyle.Render(fmt.Sprintf("%-18s", field.Name)), valueStyle.Render(value))
+		if field.Locked {
+			line += " " + lockedStyle.Render("(locked)")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func profileError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
+
+func currentDir(cmd *cobra.Command) (string, error) {
+	dir, err := buildtool.WorkingDir()
+	if err != nil {
+		return "", err
+	}
+	module, _ := cmd.Flags().GetString("module")
+	return buildtool.SourceModuleDir(afero.NewOsFs(), dir, module)
+}
@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func layeredProject() map[string]string {
+	return map[string]string{
+		"src/main/java/com/example/app/controller/UserController.java": "package com.example.app.controller;\n\n@RestController\npublic class UserController {\n}",
+		"src/main/java/com/example/app/service/UserService.java":       "package com.example.app.service;\n\n@Service\npublic class UserService {\n}",
+		"src/main/java/com/example/app/entity/User.java":               "package com.example.app.entity;\n\n@Entity\npublic class User {\n}",
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "profile", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"show", "set", "unlock", "explain"}, names)
+}
+
+func TestSetFieldsLocksAndPersists(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", layeredProject())
+
+	profile, updated, err := setFields(fs, "/project", []string{"architecture=hexagonal", "id_type=UUID"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"architecture", "id_type"}, updated)
+	assert.True(t, profile.ArchLocked)
+
+	cached, err := detector.NewProfileCacheWithFs(fs, "/project").Load()
+	require.NoError(t, err)
+	assert.Equal(t, detector.ArchHexagonal, cached.Architecture)
+	assert.Equal(t, "UUID", cached.IDType)
+	assert.True(t, cached.IsLocked("id_type"))
+}
+
+func TestSetFieldsRejectsInvalidInput(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", layeredProject())
+
+	_, _, err := setFields(fs, "/project", []string{"architecture"}, false)
+	assert.ErrorContains(t, err, "expected field=value")
+
+	_, _, err = setFields(fs, "/project", []string{"architecture=onion"}, false)
+	assert.ErrorContains(t, err, "invalid architecture")
+}
+
+func TestRefreshKeepsLockedFields(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", layeredProject())
+
+	_, _, err := setFields(fs, "/project", []string{"architecture=clean"}, true)
+	require.NoError(t, err)
+	_, _, err = setFields(fs, "/project", []string{"controller_suffix=Resource"}, false)
+	require.NoError(t, err)
+
+	profile, err := showProfile(fs, "/project", true)
+	require.NoError(t, err)
+	assert.Equal(t, detector.ArchClean, profile.Architecture)
+	assert.Equal(t, "Controller", profile.ControllerSuffix)
+
+	profile, err = unlockFields(fs, "/project", []string{"architecture"})
+	require.NoError(t, err)
+	assert.False(t, profile.IsLocked("architecture"))
+
+	profile, err = showProfile(fs, "/project", true)
+	require.NoError(t, err)
+	assert.Equal(t, detector.ArchLayered, profile.Architecture)
+}
+
+func TestExplainField(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", layeredProject())
+
+	_, _, err := setFields(fs, "/project", []string{"architecture=hexagonal"}, true)
+	require.NoError(t, err)
+
+	result, err := explainField(fs, "/project", "architecture")
+	require.NoError(t, err)
+	assert.Equal(t, "hexagonal", result.Value)
+	assert.Equal(t, "layered", result.Detected)
+	assert.True(t, result.Locked)
+	assert.Len(t, result.Scores, 6)
+	assert.NotEmpty(t, result.Evidence)
+}
+
+func TestBuildOutput(t *testing.T) {
+	profile := detector.NewDefaultProfile()
+	require.NoError(t, profile.Lock("mapper"))
+
+	result := buildOutput("/project", profile, nil)
+
+	assert.Equal(t, "/project/.haft/profile.json", result.Path)
+	assert.Len(t, result.Fields, len(detector.ProfileFieldNames()))
+	for _, field := range result.Fields {
+		assert.Equal(t, field.Name == "mapper", field.Locked, field.Name)
+	}
+}
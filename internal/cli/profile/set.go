@@ -0,0 +1,163 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newSetCommand() *cobra.Command {
+	var lock bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "set <field=value...>",
+		Short: "Override profile fields",
+		Long: `Override one or more profile fields.
+
+Without --lock, the value is replaced the next time the project is
+re-scanned. With --lock, the value is kept across scans until the field
+is unlocked.
+
+Fields: ` + strings.Join(detector.ProfileFieldNames(), ", "),
+		Example: `  # Use hexagonal architecture and keep it
+  haft profile set architecture=hexagonal --lock
+
+  # Set several fields at once
+  haft profile set id_type=UUID mapper=mapstruct --lock`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd, args, lock, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&lock, "lock", false, "Lock the fields so re-detection keeps them")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newUnlockCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "unlock <field...>",
+		Short: "Let detection manage profile fields again",
+		Long: `Unlock profile fields so the next scan can replace their values.
+
+Fields: ` + strings.Join(detector.ProfileFieldNames(), ", "),
+		Example: `  # Unlock the ID type
+  haft profile unlock id_type`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnlock(cmd, args, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runSet(cmd *cobra.Command, assignments []string, lock, jsonOutput bool) error {
+	dir, err := currentDir(cmd)
+	if err != nil {
+		return profileError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	fs := afero.NewOsFs()
+	profile, updated, err := setFields(fs, dir, assignments, lock)
+	if err != nil {
+		return profileError(jsonOutput, "INVALID_FIELD", err)
+	}
+
+	result := buildOutput(dir, profile, updated)
+	if jsonOutput {
+		return output.Success(result)
+	}
+
+	log := logger.Default()
+	for _, name := range updated {
+		value, _ := profile.GetField(name)
+		log.Success("Set", "field", name, "value", value)
+	}
+	if !lock {
+		log.Info("Use --lock to keep these values when the project is re-scanned")
+	}
+	return nil
+}
+
+func runUnlock(cmd *cobra.Command, names []string, jsonOutput bool) error {
+	dir, err := currentDir(cmd)
+	if err != nil {
+		return profileError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	fs := afero.NewOsFs()
+	profile, err := unlockFields(fs, dir, names)
+	if err != nil {
+		return profileError(jsonOutput, "INVALID_FIELD", err)
+	}
+
+	result := buildOutput(dir, profile, names)
+	if jsonOutput {
+		return output.Success(result)
+	}
+
+	log := logger.Default()
+	for _, name := range names {
+		log.Success("Unlocked", "field", name)
+	}
+	return nil
+}
+
+func setFields(fs afero.Fs, dir string, assignments []string, lock bool) (*detector.ProjectProfile, []string, error) {
+	profile, err := loadProfile(fs, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated []string
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid assignment '%s' (expected field=value)", assignment)
+		}
+		name = strings.TrimSpace(name)
+		if err := profile.SetField(name, strings.TrimSpace(value)); err != nil {
+			return nil, nil, err
+		}
+		if lock {
+			_ = profile.Lock(name)
+		}
+		updated = append(updated, name)
+	}
+
+	if err := saveProfile(fs, dir, profile); err != nil {
+		return nil, nil, err
+	}
+	return profile, updated, nil
+}
+
+func unlockFields(fs afero.Fs, dir string, names []string) (*detector.ProjectProfile, error) {
+	profile, err := loadProfile(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if err := profile.Unlock(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := saveProfile(fs, dir, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,71 @@
+package profile
+
+import (
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newShowCommand() *cobra.Command {
+	var refresh bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the detected project profile",
+		Long: `Show the project profile used for code generation.
+
+The cached profile is shown when available; otherwise the project is
+scanned first. Use --refresh to re-scan. Locked fields keep their values.`,
+		Example: `  # Show the profile
+  haft profile show
+
+  # Re-scan the project first
+  haft profile show --refresh
+
+  # Output as JSON
+  haft profile show --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd, refresh, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Re-scan the project before showing the profile")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runShow(cmd *cobra.Command, refresh, jsonOutput bool) error {
+	dir, err := currentDir(cmd)
+	if err != nil {
+		return profileError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	fs := afero.NewOsFs()
+	profile, err := showProfile(fs, dir, refresh)
+	if err != nil {
+		return profileError(jsonOutput, "DETECTION_ERROR", err)
+	}
+
+	result := buildOutput(dir, profile, nil)
+	if jsonOutput {
+		return output.Success(result)
+	}
+
+	printProfile(result)
+	return nil
+}
+
+func showProfile(fs afero.Fs, dir string, refresh bool) (*detector.ProjectProfile, error) {
+	if !refresh {
+		return loadProfile(fs, dir)
+	}
+
+	previous, err := detector.NewProfileCacheWithFs(fs, dir).Load()
+	if err != nil {
+		return nil, err
+	}
+	return detectProfile(fs, dir, previous)
+}
@@ -0,0 +1,201 @@
+package prop
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List build file properties",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return propError(jsonOutput, "CWD_ERROR", err)
+			}
+			result, project, err := buildtool.Load(afero.NewOsFs(), dir)
+			if err != nil {
+				return propError(jsonOutput, "NO_BUILD_FILE", err)
+			}
+
+			out := buildOutput(result, project)
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printProperties(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newGetCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a property",
+		Example: `  # Print the Java version
+  haft prop get java.version`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return propError(jsonOutput, "CWD_ERROR", err)
+			}
+			value, err := getProperty(afero.NewOsFs(), dir, args[0])
+			if err != nil {
+				return propError(jsonOutput, "PROPERTY_NOT_FOUND", err)
+			}
+
+			if jsonOutput {
+				return output.Success(output.PropertyInfo{Key: args[0], Value: value})
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newSetCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Add or update a property",
+		Long: `Add a property to the build file or update its value.
+
+New Maven properties are appended to <properties>. New Gradle properties
+are added next to the existing extra properties, or before the
+repositories and dependencies blocks when there are none.`,
+		Example: `  # Pin the JJWT version
+  haft prop set jjwt.version 0.12.6`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return propError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := setProperty(afero.NewOsFs(), dir, args[0], args[1])
+			if err != nil {
+				return propError(jsonOutput, "WRITE_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			logger.Default().Success("Set", "property", args[0], "value", args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newRemoveCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <key...>",
+		Aliases: []string{"rm"},
+		Short:   "Remove properties",
+		Example: `  # Remove a property
+  haft prop remove jjwt.version`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return propError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := removeProperties(afero.NewOsFs(), dir, args)
+			if err != nil {
+				return propError(jsonOutput, "PROPERTY_NOT_FOUND", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			log := logger.Default()
+			for _, key := range out.Removed {
+				log.Success("Removed", "property", key)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func getProperty(fs afero.Fs, dir, key string) (string, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := result.Parser.GetProperty(project, key)
+	if !ok {
+		return "", fmt.Errorf("property '%s' not found in %s", key, buildtool.GetBuildFileName(result.BuildTool))
+	}
+	return value, nil
+}
+
+func setProperty(fs afero.Fs, dir, key, value string) (output.PropertiesOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.PropertiesOutput{}, err
+	}
+
+	result.Parser.SetProperty(project, key, value)
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.PropertiesOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Updated = []string{key}
+	return out, nil
+}
+
+func removeProperties(fs afero.Fs, dir string, keys []string) (output.PropertiesOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.PropertiesOutput{}, err
+	}
+
+	for _, key := range keys {
+		if !result.Parser.RemoveProperty(project, key) {
+			return output.PropertiesOutput{}, fmt.Errorf("property '%s' not found in %s", key, buildtool.GetBuildFileName(result.BuildTool))
+		}
+	}
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.PropertiesOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Removed = keys
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package prop
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:     "prop",
+		Aliases: []string{"property"},
+		Short:   "Manage build file properties",
+		Long: `List, read and change the properties declared in your build file.
+
+For Maven these are the entries of <properties> in pom.xml. For Gradle
+they are extra properties: ext { } blocks and ext.name assignments in
+build.gradle, extra["name"] and "by extra(...)" in build.gradle.kts.
+
+Edits only touch the property being changed. Comments, ordering and
+formatting of the rest of the file are kept.`,
+		Example: `  # List all properties
+  haft prop list
+
+  # Read a property
+  haft prop get java.version
+
+  # Add or update a property
+  haft prop set jjwt.version 0.12.6
+
+  # Remove a property
+  haft prop remove jjwt.version
+
+  # Work on a module of a multi-module project
+  haft prop list --module api`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newGetCommand())
+	cmd.AddCommand(newSetCommand())
+	cmd.AddCommand(newRemoveCommand())
+
+	return cmd
+}
+
+func buildOutput(result *buildtool.DetectionResult, project *buildtool.Project) output.PropertiesOutput {
+	out := output.PropertiesOutput{
+		BuildFile:  result.FilePath,
+		Properties: []output.PropertyInfo{},
+	}
+	for _, prop := range result.Parser.GetProperties(project) {
+		out.Properties = append(out.Properties, output.PropertyInfo{Key: prop.Key, Value: prop.Value})
+	}
+	return out
+}
+
+func printProperties(result output.PropertiesOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Properties"))
+	fmt.Println(labelStyle.Render(result.BuildFile))
+	fmt.Println()
+
+	if len(result.Properties) == 0 {
+		fmt.Println(labelStyle.Render("  No properties defined"))
+		fmt.Println()
+		return
+	}
+
+	width := 0
+	for _, prop := range result.Properties {
+		width = max(width, len(prop.Key))
+	}
+	for _, prop := range result.Properties {
+		fmt.Printf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-*s", width, prop.Key)), valueStyle.Render(prop.Value))
+	}
+	fmt.Println()
+}
+
+func propError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
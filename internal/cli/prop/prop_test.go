@@ -0,0 +1,90 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+    <version>0.0.1-SNAPSHOT</version>
+
+    <properties>
+        <!-- runtime -->
+        <java.version>21</java.version>
+    </properties>
+</project>
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "prop", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("module"))
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "get", "set", "remove"}, names)
+}
+
+func TestSetGetAndRemoveMaven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom})
+
+	out, err := setProperty(fs, "/project", "jjwt.version", "0.12.6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jjwt.version"}, out.Updated)
+	assert.Len(t, out.Properties, 2)
+
+	value, err := getProperty(fs, "/project", "jjwt.version")
+	require.NoError(t, err)
+	assert.Equal(t, "0.12.6", value)
+
+	data, err := afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "        <!-- runtime -->\n        <java.version>21</java.version>\n        <jjwt.version>0.12.6</jjwt.version>\n    </properties>")
+
+	out, err = removeProperties(fs, "/project", []string{"jjwt.version"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jjwt.version"}, out.Removed)
+
+	data, err = afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, pom, string(data))
+}
+
+func TestSetPropertyGradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"build.gradle.kts": "plugins {\n    java\n}\n\ndependencies {\n}\n"})
+
+	_, err := setProperty(fs, "/project", "jjwt.version", "0.12.6")
+	require.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, "/project/build.gradle.kts")
+	require.NoError(t, err)
+	assert.Equal(t, "plugins {\n    java\n}\n\nextra[\"jjwt.version\"] = \"0.12.6\"\n\ndependencies {\n}\n", string(data))
+}
+
+func TestMissingProperty(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom})
+
+	_, err := getProperty(fs, "/project", "missing")
+	assert.ErrorContains(t, err, "property 'missing' not found in pom.xml")
+
+	_, err = removeProperties(fs, "/project", []string{"missing"})
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestNoBuildFile(t *testing.T) {
+	_, err := getProperty(afero.NewMemMapFs(), "/project", "java.version")
+	assert.ErrorContains(t, err, "could not find build file")
+}
@@ -3,10 +3,12 @@ package remove
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/KashifKhn/haft/internal/buildtool"
 	_ "github.com/KashifKhn/haft/internal/gradle"
+	"github.com/KashifKhn/haft/internal/hooks"
 	"github.com/KashifKhn/haft/internal/logger"
 	_ "github.com/KashifKhn/haft/internal/maven"
 	"github.com/KashifKhn/haft/internal/output"
@@ -38,6 +40,9 @@ The remove command modifies your build file (pom.xml or build.gradle) to remove
   # Remove multiple
   haft remove lombok validation h2
 
+  # Remove from a module of a multi-module project
+  haft remove h2 --module api
+
   # Output as JSON
   haft remove lombok --json`,
 		Aliases: []string{"rm"},
@@ -45,6 +50,7 @@ The remove command modifies your build file (pom.xml or build.gradle) to remove
 		RunE:    runRemove,
 	}
 
+	cmd.Flags().String("module", "", "Target module in a multi-module project")
 	cmd.Flags().Bool("no-interactive", false, "Skip interactive picker (requires dependency argument)")
 	cmd.Flags().Bool("json", false, "Output result as JSON")
 
@@ -55,6 +61,8 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	log := logger.Default()
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 
+	fs := afero.NewOsFs()
+	moduleName, _ := cmd.Flags().GetString("module")
 	cwd, err := os.Getwd()
 	if err != nil {
 		if jsonFlag {
@@ -63,7 +71,14 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fs := afero.NewOsFs()
+	cwd, err = buildtool.ModuleDir(fs, cwd, moduleName)
+	if err != nil {
+		if jsonFlag {
+			return output.Error("MODULE_NOT_FOUND", "could not select module", err.Error())
+		}
+		return err
+	}
+
 	result, err := buildtool.Detect(cwd, fs)
 	if err != nil {
 		if jsonFlag {
@@ -117,7 +132,8 @@ func runRemove(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		if result.Parser.RemoveDependency(project, groupId, artifactId) {
+		removedDependency := result.Parser.RemoveDependency(project, groupId, artifactId)
+		if result.Parser.RemoveManagedDependency(project, groupId, artifactId) || removedDependency {
 			if !jsonFlag {
 				log.Success("Removed", "dependency", fmt.Sprintf("%s:%s", groupId, artifactId))
 			}
@@ -144,6 +160,17 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	files := append([]string{result.FilePath}, project.Files...)
+	rollback := hooks.NewRollback(fs, cwd)
+	for _, file := range files {
+		if err := rollback.TrackModified(file); err != nil {
+			if jsonFlag {
+				return output.Error("READ_ERROR", fmt.Sprintf("could not read %s", file), err.Error())
+			}
+			return err
+		}
+	}
+
 	if err := result.Parser.Write(result.FilePath, project); err != nil {
 		if jsonFlag {
 			return output.Error("WRITE_ERROR", fmt.Sprintf("could not write %s", result.FilePath), err.Error())
@@ -151,16 +178,33 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not write %s: %w", result.FilePath, err)
 	}
 
+	if !jsonFlag {
+		log.Success(fmt.Sprintf("Removed %d dependencies from %s", len(removed), buildtool.GetBuildFileName(result.BuildTool)))
+	}
+
+	var changed []string
+	for _, file := range files {
+		if rel, err := filepath.Rel(cwd, file); err == nil {
+			file = rel
+		}
+		changed = append(changed, file)
+	}
+	report, hookErr := hooks.Execute(fs, cwd, hooks.PostRemove, changed, rollback, jsonFlag)
+
 	if jsonFlag {
-		return output.Success(output.AddRemoveResult{
+		removeResult := output.AddRemoveResult{
 			Action:  "remove",
 			Removed: removed,
 			Skipped: notFound,
-		})
+			Hooks:   report.ToOutput(),
+		}
+		if hookErr != nil {
+			return output.ErrorWithData("HOOK_FAILED", hookErr.Error(), removeResult)
+		}
+		return output.Success(removeResult)
 	}
 
-	log.Success(fmt.Sprintf("Removed %d dependencies from %s", len(removed), buildtool.GetBuildFileName(result.BuildTool)))
-	return nil
+	return hookErr
 }
 
 func resolveInput(input string, project *buildtool.Project) (string, string) {
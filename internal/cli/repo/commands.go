@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List artifact repositories",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return repoError(jsonOutput, "CWD_ERROR", err)
+			}
+			result, project, err := buildtool.Load(afero.NewOsFs(), dir)
+			if err != nil {
+				return repoError(jsonOutput, "NO_BUILD_FILE", err)
+			}
+
+			out := buildOutput(result, project)
+			if jsonOutput {
+				return output.Success(out)
+			}
+			printRepositories(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newAddCommand() *cobra.Command {
+	var name string
+	var plugin bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "add <id> <url>",
+		Short: "Add an artifact repository",
+		Long: `Add a repository to the build file.
+
+The id is written as <id> in pom.xml and as the repository name in
+Gradle, where it also selects the credentials to use. Credentials
+themselves belong in ~/.m2/settings.xml or ~/.gradle/gradle.properties,
+not in the build file.`,
+		Example: `  # Add a company Nexus
+  haft repo add nexus https://nexus.example.com/repository/maven-public/
+
+  # Add with a display name (Maven)
+  haft repo add nexus https://nexus.example.com/repository/maven-public/ --name "Company Nexus"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return repoError(jsonOutput, "CWD_ERROR", err)
+			}
+			repo := buildtool.Repository{ID: args[0], Name: name, URL: args[1], Plugin: plugin}
+			out, err := addRepository(afero.NewOsFs(), dir, repo)
+			if err != nil {
+				return repoError(jsonOutput, "REPOSITORY_ERROR", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			logger.Default().Success("Added", "repository", repo.ID, "url", repo.URL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Display name of the repository (Maven only)")
+	cmd.Flags().BoolVar(&plugin, "plugin", false, "Add as a plugin repository (Maven only)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newRemoveCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <id|url...>",
+		Aliases: []string{"rm"},
+		Short:   "Remove artifact repositories",
+		Example: `  # Remove by id
+  haft repo remove nexus
+
+  # Remove by URL
+  haft repo remove https://repo.spring.io/milestone`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, _ := cmd.Flags().GetString("module")
+			dir, err := buildtool.WorkingModuleDir(afero.NewOsFs(), module)
+			if err != nil {
+				return repoError(jsonOutput, "CWD_ERROR", err)
+			}
+			out, err := removeRepositories(afero.NewOsFs(), dir, args)
+			if err != nil {
+				return repoError(jsonOutput, "REPOSITORY_NOT_FOUND", err)
+			}
+
+			if jsonOutput {
+				return output.Success(out)
+			}
+			log := logger.Default()
+			for _, id := range out.Removed {
+				log.Success("Removed", "repository", id)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func addRepository(fs afero.Fs, dir string, repo buildtool.Repository) (output.RepositoriesOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.RepositoriesOutput{}, err
+	}
+
+	buildFile := buildtool.GetBuildFileName(result.BuildTool)
+	if repo.Plugin && result.BuildTool != buildtool.Maven {
+		return output.RepositoriesOutput{}, fmt.Errorf("plugin repositories are declared in settings.gradle, not %s", buildFile)
+	}
+	if !result.Parser.AddRepository(project, repo) {
+		return output.RepositoriesOutput{}, fmt.Errorf("repository '%s' already exists in %s", repo.ID, buildFile)
+	}
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.RepositoriesOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Added = []string{repo.ID}
+	return out, nil
+}
+
+func removeRepositories(fs afero.Fs, dir string, ids []string) (output.RepositoriesOutput, error) {
+	result, project, err := buildtool.Load(fs, dir)
+	if err != nil {
+		return output.RepositoriesOutput{}, err
+	}
+
+	for _, id := range ids {
+		if !result.Parser.RemoveRepository(project, id) {
+			return output.RepositoriesOutput{}, fmt.Errorf("repository '%s' not found in %s", id, buildtool.GetBuildFileName(result.BuildTool))
+		}
+	}
+	if err := result.Parser.Write(result.FilePath, project); err != nil {
+		return output.RepositoriesOutput{}, fmt.Errorf("could not write %s: %w", result.FilePath, err)
+	}
+
+	out := buildOutput(result, project)
+	out.Removed = ids
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	_ "github.com/KashifKhn/haft/internal/gradle"
+	_ "github.com/KashifKhn/haft/internal/maven"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	labelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	tagStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+)
+
+func NewCommand() *cobra.Command {
+	var module string
+
+	cmd := &cobra.Command{
+		Use:     "repo",
+		Aliases: []string{"repository"},
+		Short:   "Manage artifact repositories",
+		Long: `List, add and remove the artifact repositories your build resolves
+dependencies from, such as a company Nexus or Artifactory.
+
+For Maven these are the <repositories> and <pluginRepositories> sections
+of pom.xml. For Gradle it is the top-level repositories { } block of the
+build file; plugin repositories live in settings.gradle and are not
+managed here.`,
+		Example: `  # List repositories
+  haft repo list
+
+  # Add a company Nexus
+  haft repo add nexus https://nexus.example.com/repository/maven-public/
+
+  # Add a Maven plugin repository
+  haft repo add nexus-plugins https://nexus.example.com/repository/plugins/ --plugin
+
+  # Remove a repository by id or URL
+  haft repo remove nexus`,
+	}
+
+	cmd.PersistentFlags().StringVar(&module, "module", "", "Target module in a multi-module project")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newAddCommand())
+	cmd.AddCommand(newRemoveCommand())
+
+	return cmd
+}
+
+func buildOutput(result *buildtool.DetectionResult, project *buildtool.Project) output.RepositoriesOutput {
+	out := output.RepositoriesOutput{
+		BuildFile:    result.FilePath,
+		Repositories: []output.RepositoryInfo{},
+	}
+	for _, repo := range result.Parser.GetRepositories(project) {
+		out.Repositories = append(out.Repositories, output.RepositoryInfo{
+			ID:     repo.ID,
+			Name:   repo.Name,
+			URL:    repo.URL,
+			Plugin: repo.Plugin,
+		})
+	}
+	return out
+}
+
+func printRepositories(result output.RepositoriesOutput) {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("Repositories"))
+	fmt.Println(labelStyle.Render(result.BuildFile))
+	fmt.Println()
+
+	if len(result.Repositories) == 0 {
+		fmt.Println(labelStyle.Render("  No repositories declared"))
+		fmt.Println()
+		return
+	}
+
+	width := 0
+	for _, repo := range result.Repositories {
+		width = max(width, len(repo.ID))
+	}
+	for _, repo := range result.Repositories {
+		url := repo.URL
+		if url == "" {
+			url = "-"
+		}
+		line := fmt.Sprintf("  %s %s", labelStyle.Render(fmt.Sprintf("%-*s", width, repo.ID)), valueStyle.Render(url))
+		if repo.Plugin {
+			line += " " + tagStyle.Render("(plugins)")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func repoError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
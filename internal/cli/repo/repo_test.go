@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>demo</artifactId>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+        </dependency>
+    </dependencies>
+
+    <build>
+    </build>
+</project>
+`
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand()
+
+	assert.Equal(t, "repo", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "add", "remove"}, names)
+}
+
+func TestAddAndRemoveMaven(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"pom.xml": pom})
+	nexus := buildtool.Repository{ID: "nexus", Name: "Company Nexus", URL: "https://nexus.example.com/repository/maven-public/"}
+
+	out, err := addRepository(fs, "/project", nexus)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nexus"}, out.Added)
+	require.Len(t, out.Repositories, 1)
+	assert.Equal(t, "Company Nexus", out.Repositories[0].Name)
+
+	data, err := afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `    </dependencies>
+
+    <repositories>
+        <repository>
+            <id>nexus</id>
+            <name>Company Nexus</name>
+            <url>https://nexus.example.com/repository/maven-public/</url>
+        </repository>
+    </repositories>
+
+    <build>`)
+
+	_, err = addRepository(fs, "/project", nexus)
+	assert.ErrorContains(t, err, "already exists")
+
+	out, err = removeRepositories(fs, "/project", []string{"https://nexus.example.com/repository/maven-public/"})
+	require.NoError(t, err)
+	assert.Empty(t, out.Repositories)
+
+	_, err = removeRepositories(fs, "/project", []string{"nexus"})
+	assert.ErrorContains(t, err, "repository 'nexus' not found in pom.xml")
+}
+
+func TestAddRepositoryGradle(t *testing.T) {
+	fs := testutil.MemFs(t, "/project", map[string]string{"build.gradle": "repositories {\n    mavenCentral()\n}\n"})
+
+	_, err := addRepository(fs, "/project", buildtool.Repository{ID: "x", URL: "https://x", Plugin: true})
+	assert.ErrorContains(t, err, "settings.gradle")
+
+	out, err := addRepository(fs, "/project", buildtool.Repository{ID: "nexus", URL: "https://nexus"})
+	require.NoError(t, err)
+	require.Len(t, out.Repositories, 2)
+	assert.Equal(t, "mavenCentral", out.Repositories[0].ID)
+
+	data, err := afero.ReadFile(fs, "/project/build.gradle")
+	require.NoError(t, err)
+	assert.Equal(t, "repositories {\n    mavenCentral()\n    maven {\n        name = 'nexus'\n        url = 'https://nexus'\n    }\n}\n", string(data))
+}
@@ -4,14 +4,24 @@ import (
 	"os"
 
 	addcmd "github.com/KashifKhn/haft/internal/cli/add"
+	archcmd "github.com/KashifKhn/haft/internal/cli/arch"
+	bootcmd "github.com/KashifKhn/haft/internal/cli/boot"
 	completioncmd "github.com/KashifKhn/haft/internal/cli/completion"
+	convertcmd "github.com/KashifKhn/haft/internal/cli/convert"
 	devcmd "github.com/KashifKhn/haft/internal/cli/dev"
 	dockercmd "github.com/KashifKhn/haft/internal/cli/docker"
 	doctorcmd "github.com/KashifKhn/haft/internal/cli/doctor"
+	erdcmd "github.com/KashifKhn/haft/internal/cli/erd"
 	generatecmd "github.com/KashifKhn/haft/internal/cli/generate"
+	graphcmd "github.com/KashifKhn/haft/internal/cli/graph"
 	infocmd "github.com/KashifKhn/haft/internal/cli/info"
 	initcmd "github.com/KashifKhn/haft/internal/cli/init"
+	migratecmd "github.com/KashifKhn/haft/internal/cli/migrate"
+	plugincmd "github.com/KashifKhn/haft/internal/cli/plugin"
+	profilecmd "github.com/KashifKhn/haft/internal/cli/profile"
+	propcmd "github.com/KashifKhn/haft/internal/cli/prop"
 	removecmd "github.com/KashifKhn/haft/internal/cli/remove"
+	repocmd "github.com/KashifKhn/haft/internal/cli/repo"
 	routescmd "github.com/KashifKhn/haft/internal/cli/routes"
 	statscmd "github.com/KashifKhn/haft/internal/cli/stats"
 	templatecmd "github.com/KashifKhn/haft/internal/cli/template"
@@ -58,6 +68,22 @@ Features:
   haft remove h2
   haft remove lombok validation
 
+  # Build properties and repositories
+  haft prop set jjwt.version 0.12.6
+  haft repo add nexus https://nexus.example.com/repository/maven-public/
+
+  # Build plugins
+  haft plugin add jacoco spotless
+
+  # Spring Boot upgrade
+  haft boot upgrade --to 3.4.x
+
+  # javax to jakarta migration
+  haft migrate jakarta --dry-run
+
+  # Maven to Gradle conversion
+  haft convert --to gradle-kotlin
+
   # Development workflow
   haft dev serve          # Start with hot-reload
   haft dev build          # Build project
@@ -67,7 +93,10 @@ Features:
   haft info               # Show project info
   haft routes             # List REST endpoints
   haft stats              # Show code statistics
-  haft stats --cocomo     # Include COCOMO estimates`,
+  haft stats --cocomo     # Include COCOMO estimates
+  haft arch check         # Check architecture rules
+  haft graph              # Export dependency graph
+  haft erd                # Render entity relationship diagram`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		initLogger()
 	},
@@ -86,6 +115,8 @@ func Execute() error {
 }
 
 func init() {
+	cobra.EnableTraverseRunHooks = true
+
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
 
@@ -95,13 +126,23 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initcmd.NewCommand())
 	rootCmd.AddCommand(generatecmd.NewCommand())
+	rootCmd.AddCommand(graphcmd.NewCommand())
+	rootCmd.AddCommand(erdcmd.NewCommand())
 	rootCmd.AddCommand(addcmd.NewCommand())
+	rootCmd.AddCommand(archcmd.NewCommand())
 	rootCmd.AddCommand(removecmd.NewCommand())
+	rootCmd.AddCommand(repocmd.NewCommand())
+	rootCmd.AddCommand(plugincmd.NewCommand())
+	rootCmd.AddCommand(bootcmd.NewCommand())
+	rootCmd.AddCommand(migratecmd.NewCommand())
+	rootCmd.AddCommand(convertcmd.NewCommand())
 	rootCmd.AddCommand(completioncmd.NewCommand())
 	rootCmd.AddCommand(devcmd.NewCommand())
 	rootCmd.AddCommand(dockercmd.NewCommand())
 	rootCmd.AddCommand(doctorcmd.NewCommand())
 	rootCmd.AddCommand(infocmd.NewCommand())
+	rootCmd.AddCommand(profilecmd.NewCommand())
+	rootCmd.AddCommand(propcmd.NewCommand())
 	rootCmd.AddCommand(routescmd.NewCommand())
 	rootCmd.AddCommand(statscmd.NewCommand())
 	rootCmd.AddCommand(templatecmd.NewCommand())
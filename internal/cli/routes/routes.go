@@ -1,11 +1,9 @@
 package routes
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
@@ -36,11 +34,13 @@ type Route struct {
 	Handler    string
 	File       string
 	Line       int
+	Module     string
 }
 
 func NewCommand() *cobra.Command {
 	var jsonOutput bool
 	var showFiles bool
+	var module string
 
 	cmd := &cobra.Command{
 		Use:   "routes",
@@ -48,7 +48,10 @@ func NewCommand() *cobra.Command {
 		Long: `Scan the project and list all REST API endpoints.
 
 Parses Java source files to find Spring MVC annotations like
-@GetMapping, @PostMapping, @RequestMapping, etc.`,
+@GetMapping, @PostMapping, @RequestMapping, etc.
+
+At the root of a multi-module Maven or Gradle project, routes from every
+module are listed. Use --module to list the routes of a single module.`,
 		Example: `  # List all routes
   haft routes
 
@@ -56,21 +59,41 @@ Parses Java source files to find Spring MVC annotations like
   haft routes --files
 
   # Output as JSON
-  haft routes --json`,
+  haft routes --json
+
+  # List routes of one module
+  haft routes --module api`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRoutes(jsonOutput, showFiles)
+			return runRoutes(jsonOutput, showFiles, module)
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&showFiles, "files", "f", false, "Show file locations")
+	cmd.Flags().StringVar(&module, "module", "", "Target module in a multi-module project")
 
 	return cmd
 }
 
-func runRoutes(jsonOutput bool, showFiles bool) error {
+func runRoutes(jsonOutput bool, showFiles bool, module string) error {
 	fs := afero.NewOsFs()
-	_, err := buildtool.DetectWithCwd(fs)
+	cwd, err := os.Getwd()
+	if err != nil {
+		if jsonOutput {
+			return output.Error("DIRECTORY_ERROR", "Failed to get current directory", err.Error())
+		}
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	dir, err := buildtool.ModuleDir(fs, cwd, module)
+	if err != nil {
+		if jsonOutput {
+			return output.Error("MODULE_NOT_FOUND", "Could not select module", err.Error())
+		}
+		return err
+	}
+
+	_, err = buildtool.Detect(dir, fs)
 	if err != nil {
 		if jsonOutput {
 			return output.Error("NOT_SPRING_PROJECT", "Not a Spring Boot project", err.Error())
@@ -78,9 +101,7 @@ func runRoutes(jsonOutput bool, showFiles bool) error {
 		return fmt.Errorf("not a Spring Boot project: %w", err)
 	}
 
-	srcDir := findSourceDir()
-
-	routes, err := scanForRoutes(srcDir)
+	routes, err := collectRoutes(fs, dir)
 	if err != nil {
 		if jsonOutput {
 			return output.Error("SCAN_ERROR", "Failed to scan routes", err.Error())
@@ -102,147 +123,48 @@ func runRoutes(jsonOutput bool, showFiles bool) error {
 	return printRoutesFormatted(routes, showFiles)
 }
 
-func findSourceDir() string {
-	possibleDirs := []string{
-		"src/main/java",
-		"src/main/kotlin",
+func collectRoutes(fs afero.Fs, dir string) ([]Route, error) {
+	routes, err := scanForRoutes(findSourceDir(dir))
+	if err != nil {
+		return nil, err
 	}
 
-	for _, dir := range possibleDirs {
-		if _, err := os.Stat(dir); err == nil {
-			return dir
+	workspace, err := buildtool.DetectWorkspace(dir, fs)
+	if err == nil && workspace.IsMultiModule() && workspace.ModuleFor(dir) == nil {
+		for _, module := range workspace.Modules {
+			moduleRoutes, err := scanForRoutes(findSourceDir(module.Dir))
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan module %s: %w", module.Name, err)
+			}
+			for i := range moduleRoutes {
+				moduleRoutes[i].Module = module.Name
+			}
+			routes = append(routes, moduleRoutes...)
 		}
 	}
 
-	return "src/main/java"
-}
-
-func scanForRoutes(srcDir string) ([]Route, error) {
-	var routes []Route
-
-	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
+	for i := range routes {
+		if rel, err := filepath.Rel(dir, routes[i].File); err == nil {
+			routes[i].File = rel
 		}
-
-		if info.IsDir() || (!strings.HasSuffix(path, ".java") && !strings.HasSuffix(path, ".kt")) {
-			return nil
-		}
-
-		fileRoutes, err := parseFileForRoutes(path)
-		if err != nil {
-			return nil
-		}
-
-		routes = append(routes, fileRoutes...)
-		return nil
-	})
-
-	return routes, err
-}
-
-func parseFileForRoutes(filePath string) ([]Route, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = file.Close() }()
-
-	var routes []Route
-	var classPath string
-	var controllerName string
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-
-	requestMappingRe := regexp.MustCompile(`@RequestMapping\s*\(\s*(?:value\s*=\s*)?["']([^"']+)["']`)
-	classMappingRe := regexp.MustCompile(`@RequestMapping\s*\(\s*(?:value\s*=\s*)?["']([^"']+)["']`)
-	classNameRe := regexp.MustCompile(`class\s+(\w+)`)
-
-	getMappingRe := regexp.MustCompile(`@GetMapping\s*(?:\(\s*(?:value\s*=\s*)?["']?([^"'\)]*?)["']?\s*\))?`)
-	postMappingRe := regexp.MustCompile(`@PostMapping\s*(?:\(\s*(?:value\s*=\s*)?["']?([^"'\)]*?)["']?\s*\))?`)
-	putMappingRe := regexp.MustCompile(`@PutMapping\s*(?:\(\s*(?:value\s*=\s*)?["']?([^"'\)]*?)["']?\s*\))?`)
-	patchMappingRe := regexp.MustCompile(`@PatchMapping\s*(?:\(\s*(?:value\s*=\s*)?["']?([^"'\)]*?)["']?\s*\))?`)
-	deleteMappingRe := regexp.MustCompile(`@DeleteMapping\s*(?:\(\s*(?:value\s*=\s*)?["']?([^"'\)]*?)["']?\s*\))?`)
-
-	methodRe := regexp.MustCompile(`(?:public|private|protected)\s+\S+\s+(\w+)\s*\(`)
-
-	var pendingMethod string
-	var pendingPath string
-	var pendingLine int
-
-	isController := false
-	fileContent, _ := os.ReadFile(filePath)
-	fileStr := string(fileContent)
-
-	if strings.Contains(fileStr, "@RestController") || strings.Contains(fileStr, "@Controller") {
-		isController = true
 	}
+	return routes, nil
+}
 
-	if !isController {
-		return nil, nil
+func findSourceDir(baseDir string) string {
+	possibleDirs := []string{
+		"src/main/java",
+		"src/main/kotlin",
 	}
 
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-
-		if matches := classNameRe.FindStringSubmatch(line); matches != nil && controllerName == "" {
-			controllerName = matches[1]
-		}
-
-		if matches := classMappingRe.FindStringSubmatch(line); matches != nil && classPath == "" {
-			classPath = matches[1]
-		}
-
-		if matches := getMappingRe.FindStringSubmatch(line); matches != nil {
-			pendingMethod = "GET"
-			pendingPath = cleanPath(matches[1])
-			pendingLine = lineNum
-		} else if matches := postMappingRe.FindStringSubmatch(line); matches != nil {
-			pendingMethod = "POST"
-			pendingPath = cleanPath(matches[1])
-			pendingLine = lineNum
-		} else if matches := putMappingRe.FindStringSubmatch(line); matches != nil {
-			pendingMethod = "PUT"
-			pendingPath = cleanPath(matches[1])
-			pendingLine = lineNum
-		} else if matches := patchMappingRe.FindStringSubmatch(line); matches != nil {
-			pendingMethod = "PATCH"
-			pendingPath = cleanPath(matches[1])
-			pendingLine = lineNum
-		} else if matches := deleteMappingRe.FindStringSubmatch(line); matches != nil {
-			pendingMethod = "DELETE"
-			pendingPath = cleanPath(matches[1])
-			pendingLine = lineNum
-		}
-
-		if matches := requestMappingRe.FindStringSubmatch(line); matches != nil {
-			methodMatch := regexp.MustCompile(`method\s*=\s*RequestMethod\.(\w+)`)
-			if mm := methodMatch.FindStringSubmatch(line); mm != nil {
-				pendingMethod = mm[1]
-				pendingPath = cleanPath(matches[1])
-				pendingLine = lineNum
-			}
-		}
-
-		if pendingMethod != "" {
-			if matches := methodRe.FindStringSubmatch(line); matches != nil {
-				fullPath := joinPaths(classPath, pendingPath)
-				routes = append(routes, Route{
-					Method:     pendingMethod,
-					Path:       fullPath,
-					Controller: controllerName,
-					Handler:    matches[1],
-					File:       filePath,
-					Line:       pendingLine,
-				})
-				pendingMethod = ""
-				pendingPath = ""
-			}
+	for _, dir := range possibleDirs {
+		path := filepath.Join(baseDir, dir)
+		if _, err := os.Stat(path); err == nil {
+			return path
 		}
 	}
 
-	return routes, nil
+	return filepath.Join(baseDir, "src/main/java")
 }
 
 func cleanPath(path string) string {
@@ -302,7 +224,11 @@ func printRoutesFormatted(routes []Route, showFiles bool) error {
 
 		method := methodStyle.Render(fmt.Sprintf("%-7s", r.Method))
 		path := pathStyle.Render(r.Path)
-		handler := controllerStyle.Render(fmt.Sprintf("%s.%s", r.Controller, r.Handler))
+		handlerName := fmt.Sprintf("%s.%s", r.Controller, r.Handler)
+		if r.Module != "" {
+			handlerName = fmt.Sprintf("[%s] %s", r.Module, handlerName)
+		}
+		handler := controllerStyle.Render(handlerName)
 
 		if showFiles {
 			fmt.Printf("  %s %s\n", method, path)
@@ -328,6 +254,7 @@ func printRoutesJSON(routes []Route) error {
 			Handler:    r.Handler,
 			File:       r.File,
 			Line:       r.Line,
+			Module:     r.Module,
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -658,3 +659,191 @@ public class TestController {
 	assert.Equal(t, 8, getRoute.Line)
 	assert.Equal(t, 13, postRoute.Line)
 }
+
+func TestCollectRoutes_MultiModule(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"pom.xml":       "<project><modules><module>users</module><module>orders</module></modules></project>",
+		"users/pom.xml": "<project></project>",
+		"users/src/main/java/com/example/UserController.java": `@RestController
+@RequestMapping("/api/users")
+public class UserController {
+    @GetMapping
+    public List<User> list() { return null; }
+}
+`,
+		"orders/pom.xml": "<project></project>",
+		"orders/src/main/kotlin/com/example/OrderController.kt": `@RestController
+@RequestMapping("/api/orders")
+class OrderController {
+    @PostMapping
+    public fun create(): Order = Order()
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	routes, err := collectRoutes(afero.NewOsFs(), tmpDir)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+
+	modules := map[string]string{}
+	paths := map[string]string{}
+	for _, r := range routes {
+		modules[r.Path] = r.Module
+		paths[r.Path] = r.File
+	}
+	assert.Equal(t, "users", modules["/api/users"])
+	assert.Equal(t, "orders", modules["/api/orders"])
+	assert.Equal(t, filepath.Join("users", "src", "main", "java", "com", "example", "UserController.java"), paths["/api/users"])
+
+	routes, err = collectRoutes(afero.NewOsFs(), filepath.Join(tmpDir, "users"))
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Empty(t, routes[0].Module)
+	assert.Equal(t, filepath.Join("src", "main", "java", "com", "example", "UserController.java"), routes[0].File)
+}
+
+func TestParseFileForRoutes_MultiLineAnnotationsAndArrays(t *testing.T) {
+	content := `package com.example;
+
+@RestController
+@RequestMapping(
+    value = {"/api/users", "/v2/users"},
+    produces = MediaType.APPLICATION_JSON_VALUE
+)
+public class UserController {
+    private static final String BY_ID = "/{id}";
+
+    // @GetMapping("/commented")
+    @GetMapping(
+        path = BY_ID
+    )
+    public ResponseEntity<Map<String, List<User>>> getById(@PathVariable Long id) {
+        return null;
+    }
+
+    @RequestMapping(value = BY_ID + "/status", method = {RequestMethod.PUT, RequestMethod.PATCH})
+    public void status(@PathVariable Long id) {
+    }
+}
+`
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "UserController.java")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	routes, err := parseFileForRoutes(filePath)
+	require.NoError(t, err)
+
+	var keys []string
+	for _, r := range routes {
+		keys = append(keys, r.Method+" "+r.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"GET /api/users/{id}",
+		"GET /v2/users/{id}",
+		"PUT /api/users/{id}/status",
+		"PATCH /api/users/{id}/status",
+		"PUT /v2/users/{id}/status",
+		"PATCH /v2/users/{id}/status",
+	}, keys)
+	assert.Equal(t, 12, routes[0].Line)
+	assert.Equal(t, "getById", routes[0].Handler)
+}
+
+func TestScanForRoutes_ResolvesConstantsAcrossFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"ApiPaths.java": `package com.example.web;
+
+public final class ApiPaths {
+    public static final String API = "/api";
+    public static final String ORDERS = API + "/orders";
+}
+`,
+		"OrderController.java": `package com.example.web;
+
+import static com.example.web.ApiPaths.API;
+
+@RestController
+@RequestMapping(ApiPaths.ORDERS)
+public class OrderController {
+    @GetMapping(API + "/status")
+    public String status() { return "ok"; }
+}
+`,
+		"Paths.kt": `package com.example.web
+
+const val BASE = "/kt"
+`,
+		"ItemController.kt": `package com.example.web
+
+@RestController
+@RequestMapping("$BASE/items")
+class ItemController {
+    companion object {
+        const val BY_ID = "/{id}"
+    }
+
+    @DeleteMapping(value = [BY_ID, "$BY_ID/force"])
+    fun delete(@PathVariable id: Long) {
+    }
+}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	routes, err := scanForRoutes(tmpDir)
+	require.NoError(t, err)
+
+	var keys []string
+	for _, r := range routes {
+		keys = append(keys, r.Method+" "+r.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"GET /api/orders/api/status",
+		"DELETE /kt/items/{id}",
+		"DELETE /kt/items/{id}/force",
+	}, keys)
+}
+
+func TestScanForRoutes_ResolvesConstantsInDeclaringClass(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Api.java": `package com.example.api;
+
+public final class Api {
+    private static final String BASE = "/v1";
+    public static final String USERS = BASE + "/users";
+}
+`,
+		"UserController.java": `package com.example.web;
+
+import com.example.api.Api;
+
+@RestController
+@RequestMapping(value = Api.USERS)
+public class UserController {
+    private static final String BASE = "/ignored";
+
+    @GetMapping("/a")
+    public String a() { return "a"; }
+}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	routes, err := scanForRoutes(tmpDir)
+	require.NoError(t, err)
+
+	require.Len(t, routes, 1)
+	assert.Equal(t, "/v1/users/a", routes[0].Path)
+}
@@ -0,0 +1,293 @@
+package routes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+const maxConstantDepth = 8
+
+var mappingMethods = map[string]string{
+	"GetMapping":    "GET",
+	"PostMapping":   "POST",
+	"PutMapping":    "PUT",
+	"PatchMapping":  "PATCH",
+	"DeleteMapping": "DELETE",
+}
+
+type parsedFile struct {
+	path string
+	file *source.File
+}
+
+type constant struct {
+	value source.Expr
+	file  *source.File
+	owner string
+}
+
+type constants map[string]constant
+
+func scanForRoutes(srcDir string) ([]Route, error) {
+	var files []parsedFile
+
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info.IsDir() || (!strings.HasSuffix(path, ".java") && !strings.HasSuffix(path, ".kt")) {
+			return nil
+		}
+
+		file, err := readSource(path)
+		if err != nil {
+			return nil
+		}
+
+		files = append(files, parsedFile{path: path, file: file})
+		return nil
+	})
+
+	global := constants{}
+	for _, f := range files {
+		global.collect(f.file)
+	}
+
+	var routes []Route
+	for _, f := range files {
+		routes = append(routes, routesIn(f.path, f.file, global.resolver(f.file))...)
+	}
+
+	return routes, err
+}
+
+func parseFileForRoutes(filePath string) ([]Route, error) {
+	file, err := readSource(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	global := constants{}
+	global.collect(file)
+	return routesIn(filePath, file, global.resolver(file)), nil
+}
+
+func readSource(path string) (*source.File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return source.Parse(string(content), source.LanguageFor(path)), nil
+}
+
+func routesIn(path string, file *source.File, resolve source.Resolver) []Route {
+	var routes []Route
+
+	for _, typ := range file.AllTypes() {
+		if !typ.Annotations.Has("RestController") && !typ.Annotations.Has("Controller") {
+			continue
+		}
+
+		basePaths := []string{""}
+		if mapping, ok := typ.Annotations.Get("RequestMapping"); ok {
+			basePaths = mappingPaths(mapping, resolve)
+		}
+
+		for _, method := range typ.Methods {
+			for _, ann := range method.Annotations {
+				verbs := requestMethods(ann)
+				if len(verbs) == 0 {
+					continue
+				}
+				for _, base := range basePaths {
+					for _, sub := range mappingPaths(ann, resolve) {
+						for _, verb := range verbs {
+							routes = append(routes, Route{
+								Method:     verb,
+								Path:       joinPaths(base, sub),
+								Controller: typ.Name,
+								Handler:    method.Name,
+								File:       path,
+								Line:       ann.Line,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return routes
+}
+
+func requestMethods(ann source.Annotation) []string {
+	if verb, ok := mappingMethods[ann.Name]; ok {
+		return []string{verb}
+	}
+	if ann.Name != "RequestMapping" {
+		return nil
+	}
+
+	arg, ok := ann.Arg("method")
+	if !ok {
+		return nil
+	}
+
+	var verbs []string
+	for _, value := range arg.Values() {
+		ref := value.Ref()
+		if ref == "" {
+			continue
+		}
+		verbs = append(verbs, strings.ToUpper(ref[strings.LastIndex(ref, ".")+1:]))
+	}
+	return verbs
+}
+
+func mappingPaths(ann source.Annotation, resolve source.Resolver) []string {
+	arg, ok := ann.Arg("value", "path")
+	if !ok || len(arg.Values()) == 0 {
+		return []string{""}
+	}
+
+	var paths []string
+	for _, value := range arg.Values() {
+		path, ok := value.StringValue(resolve)
+		if !ok {
+			path = value.Text
+		}
+		paths = append(paths, cleanPath(path))
+	}
+	return paths
+}
+
+func (c constants) collect(file *source.File) {
+	for _, field := range file.Fields {
+		if field.Value != nil && file.Package != "" {
+			c[file.Package+"."+field.Name] = constant{value: *field.Value, file: file}
+		}
+	}
+	for _, typ := range file.Types {
+		c.collectType(file, "", typ)
+	}
+}
+
+func (c constants) collectType(file *source.File, outer string, typ *source.Type) {
+	qualified := qualifiedName(outer, typ)
+	owner := qualified
+	keys := []string{typ.Name, qualified}
+	if file.Package != "" {
+		owner = file.Package + "." + qualified
+		keys = append(keys, owner)
+	}
+	for _, field := range typ.Fields {
+		if field.Value == nil {
+			continue
+		}
+		for _, key := range keys {
+			c[key+"."+field.Name] = constant{value: *field.Value, file: file, owner: owner}
+		}
+	}
+	for _, nested := range typ.Types {
+		c.collectType(file, qualified, nested)
+	}
+}
+
+func qualifiedName(outer string, typ *source.Type) string {
+	if outer == "" {
+		return typ.Name
+	}
+	if typ.Kind == source.KindObject && typ.Name == "Companion" {
+		return outer
+	}
+	return outer + "." + typ.Name
+}
+
+func (c constants) locals(file *source.File) constants {
+	local := constants{}
+	for _, field := range file.Fields {
+		if field.Value != nil {
+			local[field.Name] = constant{value: *field.Value, file: file}
+		}
+	}
+	var walk func(outer string, types []*source.Type)
+	walk = func(outer string, types []*source.Type) {
+		for _, typ := range types {
+			qualified := qualifiedName(outer, typ)
+			owner := qualified
+			if file.Package != "" {
+				owner = file.Package + "." + qualified
+			}
+			for _, field := range typ.Fields {
+				if field.Value != nil {
+					local[field.Name] = constant{value: *field.Value, file: file, owner: owner}
+				}
+			}
+			walk(qualified, typ.Types)
+		}
+	}
+	walk("", file.Types)
+	for _, imp := range file.Imports {
+		if value, ok := c[imp.Path]; ok {
+			name := imp.Alias
+			if name == "" {
+				name = imp.Path[strings.LastIndex(imp.Path, ".")+1:]
+			}
+			local[name] = value
+		}
+	}
+	return local
+}
+
+func (c constants) lookup(ref string, file *source.File, owner string, local constants) (constant, bool) {
+	for scope := owner; scope != "" && scope != file.Package; scope = enclosing(scope) {
+		if value, ok := c[scope+"."+ref]; ok {
+			return value, true
+		}
+	}
+	if value, ok := local[ref]; ok {
+		return value, true
+	}
+	if value, ok := c[ref]; ok {
+		return value, true
+	}
+	if file.Package != "" {
+		value, ok := c[file.Package+"."+ref]
+		return value, ok
+	}
+	return constant{}, false
+}
+
+func enclosing(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+func (c constants) resolver(file *source.File) source.Resolver {
+	depth := 0
+	locals := map[*source.File]constants{}
+	var scoped func(file *source.File, owner string) source.Resolver
+	scoped = func(file *source.File, owner string) source.Resolver {
+		return func(ref string) (string, bool) {
+			local, ok := locals[file]
+			if !ok {
+				local = c.locals(file)
+				locals[file] = local
+			}
+			value, ok := c.lookup(ref, file, owner, local)
+			if !ok || depth >= maxConstantDepth {
+				return "", false
+			}
+			depth++
+			defer func() { depth-- }()
+			return value.value.StringValue(scoped(value.file, value.owner))
+		}
+	}
+	return scoped(file, "")
+}
@@ -25,6 +25,7 @@ var (
 func NewCommand() *cobra.Command {
 	var jsonOutput bool
 	var showCocomo bool
+	var module string
 
 	cmd := &cobra.Command{
 		Use:   "stats",
@@ -32,7 +33,10 @@ func NewCommand() *cobra.Command {
 		Long: `Display code statistics for the current Spring Boot project.
 
 Uses SCC (Sloc Cloc and Code) to count lines of code, comments, blanks,
-and complexity for each language in the project.`,
+and complexity for each language in the project.
+
+At the root of a multi-module project all modules are counted together.
+Use --module to count a single module.`,
 		Example: `  # Show code statistics
   haft stats
 
@@ -40,34 +44,46 @@ and complexity for each language in the project.`,
   haft stats --json
 
   # Include COCOMO cost estimates
-  haft stats --cocomo`,
+  haft stats --cocomo
+
+  # Count a single module
+  haft stats --module api`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStats(jsonOutput, showCocomo)
+			return runStats(jsonOutput, showCocomo, module)
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&showCocomo, "cocomo", false, "Show COCOMO cost estimates")
+	cmd.Flags().StringVar(&module, "module", "", "Target module in a multi-module project")
 
 	return cmd
 }
 
-func runStats(jsonOutput bool, showCocomo bool) error {
+func runStats(jsonOutput bool, showCocomo bool, module string) error {
 	fs := afero.NewOsFs()
-	_, err := buildtool.DetectWithCwd(fs)
+	cwd, err := os.Getwd()
 	if err != nil {
 		if jsonOutput {
-			return output.Error("NOT_SPRING_PROJECT", "Not a Spring Boot project", err.Error())
+			return output.Error("DIRECTORY_ERROR", "Failed to get current directory", err.Error())
 		}
-		return fmt.Errorf("not a Spring Boot project: %w", err)
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	cwd, err := os.Getwd()
+	cwd, err = buildtool.ModuleDir(fs, cwd, module)
 	if err != nil {
 		if jsonOutput {
-			return output.Error("DIRECTORY_ERROR", "Failed to get current directory", err.Error())
+			return output.Error("MODULE_NOT_FOUND", "Could not select module", err.Error())
 		}
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
+	}
+
+	_, err = buildtool.Detect(cwd, fs)
+	if err != nil {
+		if jsonOutput {
+			return output.Error("NOT_SPRING_PROJECT", "Not a Spring Boot project", err.Error())
+		}
+		return fmt.Errorf("not a Spring Boot project: %w", err)
 	}
 
 	projectStats, err := stats.CountProject(cwd)
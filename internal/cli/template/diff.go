@@ -0,0 +1,233 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffStatusCurrent   = "current"
+	diffStatusModified  = "modified"
+	diffStatusOutdated  = "outdated"
+	diffStatusDiverged  = "diverged"
+	diffStatusUntracked = "untracked"
+)
+
+type diffOptions struct {
+	merge      bool
+	global     bool
+	jsonOutput bool
+}
+
+func newDiffCommand() *cobra.Command {
+	var opts diffOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff [template...]",
+		Short: "Compare overrides with the built-in templates",
+		Long: `Compare customized templates with the built-in templates shipped in
+this version of haft.
+
+When a template is copied with 'haft template init', the original
+built-in version is recorded in .haft/template-base/. The diff command
+uses that base to show two things for each override:
+  - Upstream changes: what changed in the built-in template since init
+  - Local changes: what you changed in your override
+
+Use --merge to apply upstream changes to your overrides with a
+three-way merge. Lines changed on both sides are written with conflict
+markers for you to resolve by hand.
+
+Templates copied before base recording existed are reported as
+untracked and compared directly against the built-in template.`,
+		Example: `  # Show the state of all project overrides
+  haft template diff
+
+  # Show one template
+  haft template diff resource/layered/Controller.java.tmpl
+
+  # Merge upstream changes into your overrides
+  haft template diff --merge
+
+  # Output as JSON
+  haft template diff --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.merge, "merge", "m", false, "Three-way merge upstream changes into overrides")
+	cmd.Flags().BoolVarP(&opts.global, "global", "g", false, "Use global ~/.haft/templates directory")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runDiff(filters []string, opts diffOptions) error {
+	root, scope, err := resolveTemplateRoot(opts.global)
+	if err != nil {
+		return packError(opts.jsonOutput, "CWD_ERROR", err)
+	}
+
+	loader := generator.NewTemplateLoader(afero.NewOsFs(), root)
+	templates, err := loader.ListProjectTemplates()
+	if err != nil {
+		return packError(opts.jsonOutput, "LIST_ERROR", fmt.Errorf("could not list templates: %w", err))
+	}
+	templates = filterTemplateNames(templates, filters)
+
+	result, err := diffTemplates(loader, templates, opts.merge)
+	if err != nil {
+		return packError(opts.jsonOutput, "MERGE_ERROR", err)
+	}
+	result.Scope = scope
+
+	if opts.jsonOutput {
+		return output.Success(result)
+	}
+
+	if len(result.Results) == 0 {
+		logger.Default().Info(fmt.Sprintf("No overrides of built-in templates found in %s", loader.GetProjectTemplateDir()))
+		return nil
+	}
+
+	printDiffResult(result)
+
+	if result.Conflicts > 0 {
+		return fmt.Errorf("merge left %d conflict(s), resolve the markers and re-run 'haft template validate'", result.Conflicts)
+	}
+	return nil
+}
+
+func diffTemplates(loader *generator.TemplateLoader, templates []string, merge bool) (output.TemplateDiffOutput, error) {
+	result := output.TemplateDiffOutput{Results: []output.TemplateDiffResult{}}
+
+	for _, name := range templates {
+		embedded, err := generator.LoadEmbeddedTemplate(name)
+		if err != nil {
+			continue
+		}
+
+		entry, err := diffTemplate(loader, name, string(embedded), merge)
+		if err != nil {
+			return result, err
+		}
+
+		if entry.Merged {
+			result.Merged++
+		}
+		result.Conflicts += entry.Conflicts
+		result.Results = append(result.Results, entry)
+	}
+
+	return result, nil
+}
+
+func diffTemplate(loader *generator.TemplateLoader, name, embedded string, merge bool) (output.TemplateDiffResult, error) {
+	override, path, err := loader.LoadProjectOverride(name)
+	if err != nil {
+		return output.TemplateDiffResult{}, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	entry := output.TemplateDiffResult{Template: name}
+
+	base, err := loader.LoadBase(name)
+	if err != nil {
+		entry.Status = diffStatusUntracked
+		entry.Local = generator.UnifiedDiff("embedded/"+name, "override/"+name, embedded, string(override), 3)
+		if entry.Local == "" {
+			entry.Status = diffStatusCurrent
+		}
+		return entry, nil
+	}
+
+	entry.Upstream = generator.UnifiedDiff("base/"+name, "embedded/"+name, string(base), embedded, 3)
+	entry.Local = generator.UnifiedDiff("base/"+name, "override/"+name, string(base), string(override), 3)
+	entry.Status = diffStatus(entry.Upstream != "", entry.Local != "")
+
+	if !merge || entry.Upstream == "" {
+		return entry, nil
+	}
+
+	merged := generator.Merge3(string(base), string(override), embedded)
+	if err := afero.WriteFile(loader.Fs(), path, []byte(merged.Content), 0644); err != nil {
+		return entry, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := loader.RecordBase(name, []byte(embedded)); err != nil {
+		return entry, fmt.Errorf("failed to record base for %s: %w", name, err)
+	}
+
+	entry.Merged = true
+	entry.Conflicts = merged.Conflicts
+	return entry, nil
+}
+
+func diffStatus(upstream, local bool) string {
+	switch {
+	case upstream && local:
+		return diffStatusDiverged
+	case upstream:
+		return diffStatusOutdated
+	case local:
+		return diffStatusModified
+	default:
+		return diffStatusCurrent
+	}
+}
+
+func printDiffResult(result output.TemplateDiffOutput) {
+	fmt.Println()
+	for _, entry := range result.Results {
+		fmt.Printf("  %s %s\n", diffStatusLabel(entry), entry.Template)
+
+		printDiffSection("Upstream changes", entry.Upstream)
+		if entry.Status == diffStatusUntracked {
+			printDiffSection("Differences from built-in (no base recorded, merge unavailable)", entry.Local)
+		} else {
+			printDiffSection("Local changes", entry.Local)
+		}
+
+		if entry.Merged {
+			if entry.Conflicts > 0 {
+				fmt.Printf("      %s\n", warningStyle.Render(fmt.Sprintf("merged with %d conflict(s)", entry.Conflicts)))
+			} else {
+				fmt.Printf("      %s\n", successStyle.Render("merged cleanly"))
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("  %d template(s), %d merged, %d conflict(s)\n\n", len(result.Results), result.Merged, result.Conflicts)
+}
+
+func diffStatusLabel(entry output.TemplateDiffResult) string {
+	switch entry.Status {
+	case diffStatusCurrent:
+		return successStyle.Render("✓")
+	case diffStatusModified:
+		return infoStyle.Render("●") + lineStyle.Render(" modified")
+	case diffStatusOutdated:
+		return warningStyle.Render("↑") + lineStyle.Render(" upstream changed")
+	case diffStatusDiverged:
+		return warningStyle.Render("⇅") + lineStyle.Render(" diverged")
+	default:
+		return lineStyle.Render("? untracked")
+	}
+}
+
+func printDiffSection(title, diff string) {
+	if diff == "" {
+		return
+	}
+	fmt.Printf("    %s\n", headerStyle.Render(title))
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		fmt.Printf("      %s\n", lineStyle.Render(line))
+	}
+}
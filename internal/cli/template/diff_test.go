@@ -0,0 +1,110 @@
+package template
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffTemplateName = "resource/layered/Controller.java.tmpl"
+
+func newDiffLoader(t *testing.T) (afero.Fs, *generator.TemplateLoader, string) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	loader := generator.NewTemplateLoaderWithHome(fs, "/project", "")
+	require.NoError(t, loader.CopyEmbeddedToProject(diffTemplateName))
+	return fs, loader, filepath.Join(loader.GetProjectTemplateDir(), diffTemplateName)
+}
+
+func TestNewDiffCommand(t *testing.T) {
+	cmd := newDiffCommand()
+
+	assert.Equal(t, "diff [template...]", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("merge"))
+	assert.NotNil(t, cmd.Flags().Lookup("global"))
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestDiffStatus(t *testing.T) {
+	assert.Equal(t, diffStatusCurrent, diffStatus(false, false))
+	assert.Equal(t, diffStatusModified, diffStatus(false, true))
+	assert.Equal(t, diffStatusOutdated, diffStatus(true, false))
+	assert.Equal(t, diffStatusDiverged, diffStatus(true, true))
+}
+
+func TestDiffTemplatesCurrent(t *testing.T) {
+	_, loader, _ := newDiffLoader(t)
+
+	result, err := diffTemplates(loader, []string{diffTemplateName}, false)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, diffStatusCurrent, result.Results[0].Status)
+	assert.Empty(t, result.Results[0].Upstream)
+	assert.Empty(t, result.Results[0].Local)
+}
+
+func TestDiffTemplatesMergesUpstreamChanges(t *testing.T) {
+	fs, loader, path := newDiffLoader(t)
+	embedded, err := generator.LoadEmbeddedTemplate(diffTemplateName)
+	require.NoError(t, err)
+
+	require.NoError(t, loader.RecordBase(diffTemplateName, append([]byte("// old header\n"), embedded...)))
+	require.NoError(t, afero.WriteFile(fs, path, append(append([]byte("// old header\n"), embedded...), []byte("// local footer\n")...), 0644))
+
+	result, err := diffTemplates(loader, []string{diffTemplateName}, false)
+	require.NoError(t, err)
+	entry := result.Results[0]
+	assert.Equal(t, diffStatusDiverged, entry.Status)
+	assert.Contains(t, entry.Upstream, "-// old header")
+	assert.Contains(t, entry.Local, "+// local footer")
+
+	result, err = diffTemplates(loader, []string{diffTemplateName}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Merged)
+	assert.Zero(t, result.Conflicts)
+
+	merged, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Equal(t, string(embedded)+"// local footer\n", string(merged))
+
+	base, err := loader.LoadBase(diffTemplateName)
+	require.NoError(t, err)
+	assert.Equal(t, embedded, base)
+}
+
+func TestDiffTemplatesReportsConflicts(t *testing.T) {
+	fs, loader, path := newDiffLoader(t)
+	embedded, err := generator.LoadEmbeddedTemplate(diffTemplateName)
+	require.NoError(t, err)
+
+	require.NoError(t, loader.RecordBase(diffTemplateName, append([]byte("// base\n"), embedded...)))
+	require.NoError(t, afero.WriteFile(fs, path, append([]byte("// mine\n"), embedded...), 0644))
+
+	result, err := diffTemplates(loader, []string{diffTemplateName}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Conflicts)
+
+	merged, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), generator.ConflictOursMarker+"// mine\n")
+}
+
+func TestDiffTemplatesUntracked(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	loader := generator.NewTemplateLoaderWithHome(fs, "/project", "")
+	path := filepath.Join(loader.GetProjectTemplateDir(), diffTemplateName)
+	require.NoError(t, afero.WriteFile(fs, path, []byte("custom\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(loader.GetProjectTemplateDir(), "resource/Custom.java.tmpl"), []byte("x\n"), 0644))
+
+	result, err := diffTemplates(loader, []string{diffTemplateName, "resource/Custom.java.tmpl"}, true)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, diffStatusUntracked, result.Results[0].Status)
+	assert.Contains(t, result.Results[0].Local, "+custom")
+	assert.False(t, result.Results[0].Merged)
+}
@@ -0,0 +1,74 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/cli/generate"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/generator"
+)
+
+const (
+	fixtureResourceName = "Product"
+	fixtureBasePackage  = "com.example.demo"
+)
+
+func testFixtures() []generator.Fixture {
+	var fixtures []generator.Fixture
+	for _, arch := range []detector.ArchitectureType{detector.ArchLayered, detector.ArchFeature} {
+		for _, lombok := range []bool{true, false} {
+			for _, idType := range []string{"Long", "UUID"} {
+				for _, wrapper := range []bool{true, false} {
+					profile := fixtureProfile(arch, lombok, idType, wrapper)
+					fixtures = append(fixtures, generator.Fixture{
+						Name:         fixtureName(arch, lombok, idType, wrapper),
+						Architecture: string(arch),
+						Data:         generate.BuildTemplateContextFromProfile(fixtureResourceName, profile).ToMap(),
+					})
+				}
+			}
+		}
+	}
+	return fixtures
+}
+
+func fixtureName(arch detector.ArchitectureType, lombok bool, idType string, wrapper bool) string {
+	lombokPart := "lombok"
+	if !lombok {
+		lombokPart = "plain"
+	}
+	wrapperPart := "wrapper"
+	if !wrapper {
+		wrapperPart = "nowrapper"
+	}
+	return fmt.Sprintf("%s-%s-%s-%s", arch, lombokPart, strings.ToLower(idType), wrapperPart)
+}
+
+func fixtureProfile(arch detector.ArchitectureType, lombok bool, idType string, wrapper bool) *detector.ProjectProfile {
+	profile := detector.NewDefaultProfile()
+	profile.Architecture = arch
+	profile.BasePackage = fixtureBasePackage
+	profile.IDType = idType
+	profile.HasValidation = true
+	profile.Exceptions.HasGlobalHandler = true
+
+	if arch == detector.ArchFeature {
+		profile.FeatureStyle = detector.FeatureStyleFlat
+	}
+
+	if !lombok {
+		profile.Lombok = detector.LombokProfile{}
+	}
+
+	if wrapper {
+		profile.ResponseWrapper = &detector.WrapperInfo{
+			Name:      "ApiResponse",
+			Package:   fixtureBasePackage + ".common",
+			FullPath:  fixtureBasePackage + ".common.ApiResponse",
+			IsGeneric: true,
+		}
+	}
+
+	return profile
+}
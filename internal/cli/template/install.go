@@ -0,0 +1,157 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newInstallCommand() *cobra.Command {
+	var global bool
+	var force bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "install [source]",
+		Short: "Install a template pack",
+		Long: `Install a template pack into the project or global template directory.
+
+A source can be:
+  - a local directory containing haft-pack.json and templates/
+  - a tarball created with 'haft template pack' (local path or URL)
+  - a git repository URL, optionally pinned with #<ref>
+
+Every installed pack is recorded in .haft/templates-lock.json with its
+version, source and checksum. Commit the lock file so every developer
+renders the same templates. Running install without a source restores
+all packs listed in the lock file and verifies their checksums.
+
+Packs declare the haft versions and architectures they support in their
+manifest. Incompatible packs are rejected unless --force is given.`,
+		Example: `  # Install a pack from a git repository at a tag
+  haft template install https://github.com/acme/haft-templates.git#v1.2.0
+
+  # Install a pack tarball
+  haft template install ./acme-templates-1.2.0.tar.gz
+
+  # Install a pack into ~/.haft/templates
+  haft template install ../acme-templates --global
+
+  # Restore all packs recorded in the lock file
+  haft template install`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return runRestore(global, force, jsonOutput)
+			}
+			return runInstall(args[0], global, force, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&global, "global", "g", false, "Install into the global ~/.haft/templates directory")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite conflicting templates and skip compatibility checks")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runInstall(source string, global, force, jsonOutput bool) error {
+	fs := afero.NewOsFs()
+
+	root, scope, err := resolveTemplateRoot(global)
+	if err != nil {
+		return packError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	pack, err := templatepack.Fetch(fs, source, "")
+	if err != nil {
+		return packError(jsonOutput, "FETCH_ERROR", fmt.Errorf("failed to fetch pack: %w", err))
+	}
+
+	if !force {
+		if err := checkPackCompatibility(fs, pack, root, global); err != nil {
+			return packError(jsonOutput, "INCOMPATIBLE_PACK", fmt.Errorf("%w (use --force to install anyway)", err))
+		}
+	}
+
+	installer := templatepack.NewInstaller(fs, root)
+	recorded := lockSource(source, root)
+
+	result, err := installer.Install(pack, recorded, force)
+	if err != nil {
+		return packError(jsonOutput, "INSTALL_ERROR", err)
+	}
+
+	status := "installed"
+	if result.Previous != "" {
+		status = "updated"
+	}
+	packResult := installResultOutput(result, recorded, status)
+
+	if jsonOutput {
+		return output.Success(output.TemplatePacksOutput{
+			Action:   "install",
+			Scope:    scope,
+			LockFile: installer.LockPath(),
+			Packs:    []output.TemplatePackResult{packResult},
+		})
+	}
+
+	printPackResult(packResult)
+	return nil
+}
+
+func runRestore(global, force, jsonOutput bool) error {
+	log := logger.Default()
+	fs := afero.NewOsFs()
+
+	root, scope, err := resolveTemplateRoot(global)
+	if err != nil {
+		return packError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	installer := templatepack.NewInstaller(fs, root)
+	lock, err := installer.ReadLock()
+	if err != nil {
+		return packError(jsonOutput, "LOCK_ERROR", err)
+	}
+
+	if len(lock.Packs) == 0 && !jsonOutput {
+		log.Info("No template packs recorded in lock file", "file", installer.LockPath())
+		return nil
+	}
+
+	results := []output.TemplatePackResult{}
+	for _, locked := range lock.Packs {
+		pack, err := templatepack.Fetch(fs, locked.Source, root)
+		if err != nil {
+			return packError(jsonOutput, "FETCH_ERROR", fmt.Errorf("failed to fetch pack '%s': %w", locked.Name, err))
+		}
+
+		result, err := installer.InstallLocked(pack, locked, force)
+		if err != nil {
+			return packError(jsonOutput, "INSTALL_ERROR", err)
+		}
+
+		packResult := installResultOutput(result, locked.Source, "installed")
+		results = append(results, packResult)
+		if !jsonOutput {
+			printPackResult(packResult)
+		}
+	}
+
+	if jsonOutput {
+		return output.Success(output.TemplatePacksOutput{
+			Action:   "install",
+			Scope:    scope,
+			LockFile: installer.LockPath(),
+			Packs:    results,
+		})
+	}
+
+	return nil
+}
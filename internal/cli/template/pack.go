@@ -0,0 +1,140 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type packOptions struct {
+	manifest   templatepack.Manifest
+	outputPath string
+	jsonOutput bool
+}
+
+func newPackCommand() *cobra.Command {
+	var opts packOptions
+
+	cmd := &cobra.Command{
+		Use:   "pack [dir]",
+		Short: "Bundle templates into a versioned pack",
+		Long: `Bundle templates into a versioned .tar.gz pack that can be shared with
+'haft template install'.
+
+If the directory contains a haft-pack.json manifest, its templates/
+directory is packed as-is. Otherwise the project's .haft/templates
+directory is packed and the manifest is built from the flags.
+
+The manifest declares which haft versions (--haft) and architectures
+(--arch) the pack supports. Installs that do not match are rejected.`,
+		Example: `  # Pack the project's custom templates
+  haft template pack --name acme-templates --version 1.2.0
+
+  # Declare compatibility
+  haft template pack --name acme-templates --version 1.2.0 \
+    --haft ">=0.6.0 <1.0.0" --arch layered,feature
+
+  # Pack a pack source directory containing haft-pack.json
+  haft template pack ./acme-templates --output dist/acme.tar.gz`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runPack(dir, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.manifest.Name, "name", "", "Pack name")
+	cmd.Flags().StringVar(&opts.manifest.Version, "version", "", "Pack version (semver)")
+	cmd.Flags().StringVar(&opts.manifest.Description, "description", "", "Pack description")
+	cmd.Flags().StringVar(&opts.manifest.Haft, "haft", "", "Compatible haft versions (e.g. \">=0.6.0 <1.0.0\")")
+	cmd.Flags().StringSliceVar(&opts.manifest.Architectures, "arch", nil, "Supported architectures (e.g. layered,feature)")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Output archive path (default: <name>-<version>.tar.gz)")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runPack(dir string, opts packOptions) error {
+	log := logger.Default()
+	fs := afero.NewOsFs()
+
+	pack, err := loadPackSource(fs, dir, opts.manifest)
+	if err != nil {
+		return packError(opts.jsonOutput, "PACK_ERROR", err)
+	}
+
+	archivePath := opts.outputPath
+	if archivePath == "" {
+		archivePath = pack.ArchiveName()
+	}
+
+	if err := writePackArchive(fs, pack, archivePath); err != nil {
+		return packError(opts.jsonOutput, "WRITE_ERROR", err)
+	}
+
+	if opts.jsonOutput {
+		return output.Success(output.TemplatePackOutput{
+			Name:          pack.Manifest.Name,
+			Version:       pack.Manifest.Version,
+			Haft:          pack.Manifest.Haft,
+			Architectures: pack.Manifest.Architectures,
+			Archive:       archivePath,
+			Checksum:      pack.Checksum(),
+			Files:         pack.FileNames(),
+		})
+	}
+
+	log.Success(fmt.Sprintf("Packed %s", pack.Manifest.ID()), "archive", archivePath, "templates", len(pack.Files))
+	return nil
+}
+
+func loadPackSource(fs afero.Fs, dir string, manifest templatepack.Manifest) (*templatepack.Pack, error) {
+	if exists, _ := afero.Exists(fs, filepath.Join(dir, templatepack.ManifestFile)); exists {
+		return templatepack.LoadDir(fs, dir)
+	}
+
+	if manifest.Name == "" || manifest.Version == "" {
+		return nil, fmt.Errorf("no %s found in %s: --name and --version are required", templatepack.ManifestFile, dir)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	pack := templatepack.New(manifest)
+	if err := pack.AddDir(fs, filepath.Join(dir, generator.ProjectTemplateDir)); err != nil {
+		return nil, err
+	}
+	if len(pack.Files) == 0 {
+		return nil, fmt.Errorf("no templates found in %s", filepath.Join(dir, generator.ProjectTemplateDir))
+	}
+	return pack, nil
+}
+
+func writePackArchive(fs afero.Fs, pack *templatepack.Pack, archivePath string) error {
+	if dir := filepath.Dir(archivePath); dir != "." {
+		if err := fs.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	file, err := fs.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", archivePath, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := pack.WriteTarball(file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", archivePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/cli/upgrade"
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+)
+
+func resolveTemplateRoot(global bool) (string, string, error) {
+	if global {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", fmt.Errorf("could not determine home directory: %w", err)
+		}
+		return homeDir, "global", nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine current directory: %w", err)
+	}
+	return cwd, "project", nil
+}
+
+func checkPackCompatibility(fs afero.Fs, pack *templatepack.Pack, root string, global bool) error {
+	manifest := pack.Manifest
+	haftVersion := upgrade.GetCurrentVersion()
+
+	supported, err := manifest.SupportsHaft(haftVersion)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("pack '%s' requires haft %s (current: %s)", manifest.ID(), manifest.Haft, haftVersion)
+	}
+
+	if global {
+		return nil
+	}
+
+	profile, err := detector.NewProfileCacheWithFs(fs, root).Load()
+	if err != nil || profile == nil {
+		return nil
+	}
+
+	arch := profile.Architecture.String()
+	if profile.Architecture != detector.ArchUnknown && !manifest.SupportsArchitecture(arch) {
+		return fmt.Errorf("pack '%s' supports %s architectures, project uses %s",
+			manifest.ID(), strings.Join(manifest.Architectures, ", "), arch)
+	}
+	return nil
+}
+
+func lockSource(source, root string) string {
+	if templatepack.DetectSourceKind(source) == templatepack.SourceGit || strings.Contains(source, "://") {
+		return source
+	}
+
+	abs, err := filepath.Abs(source)
+	if err != nil {
+		return source
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return source
+	}
+	return filepath.ToSlash(rel)
+}
+
+func installResultOutput(result *templatepack.InstallResult, source, status string) output.TemplatePackResult {
+	return output.TemplatePackResult{
+		Name:     result.Name,
+		Version:  result.Version,
+		Previous: result.Previous,
+		Source:   source,
+		Status:   status,
+		Written:  result.Written,
+		Removed:  result.Removed,
+		Kept:     result.Kept,
+	}
+}
+
+func printPackResult(result output.TemplatePackResult) {
+	log := logger.Default()
+
+	switch result.Status {
+	case "up-to-date":
+		log.Info(fmt.Sprintf("%s@%s is up to date", result.Name, result.Version))
+	case "removed":
+		log.Success(fmt.Sprintf("Removed %s@%s", result.Name, result.Version), "files", len(result.Removed))
+	case "updated":
+		log.Success(fmt.Sprintf("Updated %s %s → %s", result.Name, result.Previous, result.Version), "files", len(result.Written))
+	default:
+		log.Success(fmt.Sprintf("Installed %s@%s", result.Name, result.Version), "files", len(result.Written))
+	}
+
+	for _, file := range result.Kept {
+		log.Warning("Kept locally modified template", "template", file)
+	}
+}
+
+func packError(jsonOutput bool, code string, err error) error {
+	if jsonOutput {
+		return output.Error(code, err.Error())
+	}
+	return err
+}
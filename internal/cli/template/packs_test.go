@@ -0,0 +1,165 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/cli/upgrade"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(originalWd) })
+	require.NoError(t, os.Chdir(tmpDir))
+	return tmpDir
+}
+
+func TestPackCommandsHaveDocs(t *testing.T) {
+	for _, cmd := range []*cobra.Command{newPackCommand(), newInstallCommand(), newUpdateCommand(), newRemoveCommand()} {
+		assert.NotEmpty(t, cmd.Short)
+		assert.NotEmpty(t, cmd.Long)
+		assert.NotEmpty(t, cmd.Example)
+	}
+
+	install := newInstallCommand()
+	assert.NotNil(t, install.Flags().Lookup("global"))
+	assert.NotNil(t, install.Flags().Lookup("force"))
+
+	pack := newPackCommand()
+	for _, flag := range []string{"name", "version", "haft", "arch", "output", "json"} {
+		assert.NotNil(t, pack.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestLockSource(t *testing.T) {
+	root := t.TempDir()
+
+	assert.Equal(t, "https://github.com/acme/t.git#v1", lockSource("https://github.com/acme/t.git#v1", root))
+	assert.Equal(t, "packs/acme", lockSource(filepath.Join(root, "packs", "acme"), root))
+
+	outside := filepath.Join(filepath.Dir(root), "elsewhere.tar.gz")
+	assert.Equal(t, "../elsewhere.tar.gz", lockSource(outside, root))
+}
+
+func TestPackInstallRemoveRoundTrip(t *testing.T) {
+	tmpDir := chdirTemp(t)
+
+	source := filepath.Join(tmpDir, "source")
+	templatePath := filepath.Join(source, ".haft", "templates", "resource", "layered", "Controller.java.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(templatePath), 0755))
+	require.NoError(t, os.WriteFile(templatePath, []byte("package ${BasePackage};\n"), 0644))
+
+	archive := filepath.Join(tmpDir, "dist", "acme.tar.gz")
+	err := runPack(source, packOptions{
+		manifest:   templatepack.Manifest{Name: "acme", Version: "1.0.0", Architectures: []string{"layered"}},
+		outputPath: archive,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, runInstall(archive, false, false, false))
+
+	installed := filepath.Join(tmpDir, ".haft", "templates", "resource", "layered", "Controller.java.tmpl")
+	content, err := os.ReadFile(installed)
+	require.NoError(t, err)
+	assert.Equal(t, "package ${BasePackage};\n", string(content))
+
+	lock, err := templatepack.ReadLock(afero.NewOsFs(), filepath.Join(tmpDir, ".haft", templatepack.LockFile))
+	require.NoError(t, err)
+	require.Len(t, lock.Packs, 1)
+	assert.Equal(t, "dist/acme.tar.gz", lock.Packs[0].Source)
+
+	require.NoError(t, runUpdate(nil, false, false, false))
+
+	require.NoError(t, os.Remove(installed))
+	require.NoError(t, runRestore(false, false, false))
+	_, err = os.Stat(installed)
+	assert.NoError(t, err)
+
+	require.NoError(t, runRemove([]string{"acme"}, false, false, false))
+	_, err = os.Stat(installed)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPackRequiresNameAndVersion(t *testing.T) {
+	tmpDir := chdirTemp(t)
+
+	err := runPack(tmpDir, packOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--name and --version")
+}
+
+func TestInstallRejectsIncompatibleHaftVersion(t *testing.T) {
+	tmpDir := chdirTemp(t)
+
+	packDir := filepath.Join(tmpDir, "acme")
+	require.NoError(t, os.MkdirAll(filepath.Join(packDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(packDir, templatepack.ManifestFile),
+		[]byte(`{"name": "acme", "version": "1.0.0", "haft": ">=99.0.0"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(packDir, "templates", "x.tmpl"), []byte("x"), 0644))
+
+	original := upgrade.GetCurrentVersion()
+	upgrade.SetCurrentVersion("v0.1.0")
+	t.Cleanup(func() { upgrade.SetCurrentVersion(original) })
+
+	err := runInstall(packDir, false, false, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires haft")
+
+	assert.NoError(t, runInstall(packDir, false, true, false))
+}
+
+func TestRemoveUnknownPack(t *testing.T) {
+	chdirTemp(t)
+
+	err := runRemove([]string{"missing"}, false, false, false)
+	assert.Error(t, err)
+}
+
+func TestTemplateTestWithEmbeddedTemplates(t *testing.T) {
+	tmpDir := chdirTemp(t)
+	require.NoError(t, runInit("resource", false, false, false))
+
+	err := runTest(nil, testOptions{})
+	require.Error(t, err)
+
+	require.NoError(t, runTest(nil, testOptions{update: true}))
+	require.NoError(t, runTest(nil, testOptions{}))
+
+	golden := filepath.Join(tmpDir, ".haft", "templates", "resource", "layered", "Controller.java.golden", "layered-lombok-uuid-wrapper.golden")
+	content, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "ProductController")
+
+	templatePath := filepath.Join(tmpDir, ".haft", "templates", "resource", "layered", "Controller.java.tmpl")
+	original, err := os.ReadFile(templatePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(templatePath, append([]byte("// changed\n"), original...), 0644))
+
+	err = runTest([]string{"resource/layered/Controller.java.tmpl"}, testOptions{fixture: "lombok"})
+	assert.Error(t, err)
+}
+
+func TestFilterFixtures(t *testing.T) {
+	fixtures := testFixtures()
+	assert.Len(t, fixtures, 16)
+	assert.Len(t, filterFixtures(fixtures, "feature-"), 8)
+	assert.Len(t, filterFixtures(fixtures, "uuid-nowrapper"), 4)
+	assert.Len(t, filterFixtures(fixtures, ""), 16)
+}
+
+func TestFilterTemplateNames(t *testing.T) {
+	templates := []string{"resource/layered/Controller.java.tmpl", "test/layered/ServiceTest.java.tmpl"}
+
+	assert.Equal(t, templates, filterTemplateNames(templates, nil))
+	assert.Equal(t, []string{"test/layered/ServiceTest.java.tmpl"}, filterTemplateNames(templates, []string{"test/"}))
+	assert.Equal(t, []string{"resource/layered/Controller.java.tmpl"},
+		filterTemplateNames(templates, []string{".haft/templates/resource/layered/Controller.java.tmpl"}))
+}
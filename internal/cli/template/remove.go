@@ -0,0 +1,80 @@
+package template
+
+import (
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newRemoveCommand() *cobra.Command {
+	var global bool
+	var force bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "remove <pack...>",
+		Short: "Remove installed template packs",
+		Long: `Remove installed template packs and their entries in the lock file.
+
+Templates you modified locally are kept unless --force is given, so
+customisations are never lost silently.`,
+		Example: `  # Remove a pack from the project
+  haft template remove acme-templates
+
+  # Remove a global pack, including modified templates
+  haft template remove acme-templates --global --force`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(args, global, force, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&global, "global", "g", false, "Remove packs from the global ~/.haft/templates directory")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Also delete templates that were modified locally")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runRemove(names []string, global, force, jsonOutput bool) error {
+	fs := afero.NewOsFs()
+
+	root, scope, err := resolveTemplateRoot(global)
+	if err != nil {
+		return packError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	installer := templatepack.NewInstaller(fs, root)
+	results := []output.TemplatePackResult{}
+
+	for _, name := range names {
+		removed, err := installer.Remove(name, force)
+		if err != nil {
+			return packError(jsonOutput, "REMOVE_ERROR", err)
+		}
+
+		result := output.TemplatePackResult{
+			Name:    removed.Name,
+			Version: removed.Version,
+			Status:  "removed",
+			Removed: removed.Removed,
+			Kept:    removed.Kept,
+		}
+		results = append(results, result)
+		if !jsonOutput {
+			printPackResult(result)
+		}
+	}
+
+	if jsonOutput {
+		return output.Success(output.TemplatePacksOutput{
+			Action:   "remove",
+			Scope:    scope,
+			LockFile: installer.LockPath(),
+			Packs:    results,
+		})
+	}
+
+	return nil
+}
@@ -42,12 +42,30 @@ And comment-based conditionals:
   haft template validate
 
   # Show available template variables
-  haft template validate --vars`,
+  haft template validate --vars
+
+  # Render custom templates against fixture profiles
+  haft template test
+
+  # Merge built-in template updates into your overrides
+  haft template diff --merge
+
+  # Bundle templates into a shareable pack
+  haft template pack --name acme-templates --version 1.0.0
+
+  # Install a pack from git and record it in the lock file
+  haft template install https://github.com/acme/haft-templates.git#v1.0.0`,
 	}
 
 	cmd.AddCommand(newInitCommand())
 	cmd.AddCommand(newListCommand())
 	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newTestCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newPackCommand())
+	cmd.AddCommand(newInstallCommand())
+	cmd.AddCommand(newUpdateCommand())
+	cmd.AddCommand(newRemoveCommand())
 
 	return cmd
 }
@@ -24,7 +24,7 @@ func TestNewCommandHasSubcommands(t *testing.T) {
 	cmd := NewCommand()
 
 	subcommands := cmd.Commands()
-	assert.Len(t, subcommands, 3)
+	assert.Len(t, subcommands, 9)
 
 	subcommandNames := make([]string, len(subcommands))
 	for i, sub := range subcommands {
@@ -34,6 +34,12 @@ func TestNewCommandHasSubcommands(t *testing.T) {
 	assert.Contains(t, subcommandNames, "init")
 	assert.Contains(t, subcommandNames, "list")
 	assert.Contains(t, subcommandNames, "validate [template-path]")
+	assert.Contains(t, subcommandNames, "test [template...]")
+	assert.Contains(t, subcommandNames, "diff [template...]")
+	assert.Contains(t, subcommandNames, "pack [dir]")
+	assert.Contains(t, subcommandNames, "install [source]")
+	assert.Contains(t, subcommandNames, "update [pack...]")
+	assert.Contains(t, subcommandNames, "remove <pack...>")
 }
 
 func TestNewInitCommand(t *testing.T) {
@@ -309,13 +315,13 @@ func TestPrintTemplateInfoAllSources(t *testing.T) {
 
 func TestPrintAvailableVariablesDoesNotPanic(t *testing.T) {
 	assert.NotPanics(t, func() {
-		printAvailableVariables()
+		printAvailableVariables(generator.CustomData{})
 	})
 }
 
 func TestPrintAvailableConditionsDoesNotPanic(t *testing.T) {
 	assert.NotPanics(t, func() {
-		printAvailableConditions()
+		printAvailableConditions(generator.CustomData{})
 	})
 }
 
@@ -590,3 +596,29 @@ func TestInitCommandSkipExisting(t *testing.T) {
 	err = runInit("resource", false, false, false)
 	assert.NoError(t, err)
 }
+
+func TestSchemaFor(t *testing.T) {
+	assert.NotNil(t, schemaFor("/project/.haft/templates/resource/layered/Controller.java.tmpl", generator.CustomData{}))
+	assert.NotNil(t, schemaFor("templates/test/feature/ServiceTest.java.tmpl", generator.CustomData{}))
+	assert.Nil(t, schemaFor("/project/.haft/templates/project/pom.xml.tmpl", generator.CustomData{}))
+}
+
+func TestEmbeddedTemplatesMatchSchema(t *testing.T) {
+	for _, dir := range []string{"resource", "test"} {
+		templates, err := generator.ListEmbeddedTemplates(dir)
+		require.NoError(t, err)
+
+		for _, name := range templates {
+			content, err := generator.LoadEmbeddedTemplate(name)
+			require.NoError(t, err)
+
+			result := generator.ValidateTemplateWithSchema(string(content), name, schemaFor(name, generator.CustomData{}))
+			assert.True(t, result.Valid, "%s: %v", name, result.Errors)
+		}
+	}
+}
+
+func TestFormatPosition(t *testing.T) {
+	assert.Equal(t, "line 3:7", formatPosition(generator.ValidationError{Line: 3, Column: 7}))
+	assert.Equal(t, "line 3", formatPosition(generator.ValidationError{Line: 3}))
+}
@@ -0,0 +1,241 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type testOptions struct {
+	update     bool
+	fixture    string
+	jsonOutput bool
+}
+
+func newTestCommand() *cobra.Command {
+	var opts testOptions
+
+	cmd := &cobra.Command{
+		Use:   "test [template...]",
+		Short: "Render custom templates against fixture profiles",
+		Long: `Render every custom resource and test template against a matrix of
+fixture profiles and compare the output with golden files.
+
+Fixtures cover layered/feature architectures, Lombok on/off, UUID/Long
+IDs and a response wrapper on/off. Golden files live next to each
+template, e.g. Controller.java.tmpl is checked against
+Controller.java.golden/<fixture>.golden.
+
+Rendering happens entirely in memory, so the command is safe to run in
+CI. Use --update to accept the current output as the new golden files.`,
+		Example: `  # Test all project templates
+  haft template test
+
+  # Accept changes after editing a template
+  haft template test --update
+
+  # Test one template against the Lombok fixtures only
+  haft template test resource/layered/Entity.java.tmpl --fixture lombok
+
+  # Output as JSON
+  haft template test --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTest(args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.update, "update", "u", false, "Write rendered output as the new golden files")
+	cmd.Flags().StringVar(&opts.fixture, "fixture", "", "Only run fixtures whose name contains this value")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runTest(filters []string, opts testOptions) error {
+	log := logger.Default()
+	osFs := afero.NewOsFs()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return packError(opts.jsonOutput, "CWD_ERROR", fmt.Errorf("could not determine current directory: %w", err))
+	}
+
+	loader := generator.NewTemplateLoader(osFs, cwd)
+	templates, err := loader.ListProjectTemplates()
+	if err != nil {
+		return packError(opts.jsonOutput, "LIST_ERROR", fmt.Errorf("could not list templates: %w", err))
+	}
+	templates = filterTemplateNames(templates, filters)
+
+	if len(templates) == 0 && !opts.jsonOutput {
+		log.Info("No custom templates found in .haft/templates/")
+		return nil
+	}
+
+	templateDir := loader.GetProjectTemplateDir()
+	memFs := afero.NewMemMapFs()
+	if err := copyTree(osFs, memFs, templateDir); err != nil {
+		return packError(opts.jsonOutput, "READ_ERROR", fmt.Errorf("could not load templates: %w", err))
+	}
+
+	custom, err := generator.LoadCustomData(osFs, cwd)
+	if err != nil {
+		return packError(opts.jsonOutput, "CONFIG_ERROR", err)
+	}
+
+	harness := generator.NewGoldenHarness(memFs, templateDir, filterFixtures(testFixtures(), opts.fixture))
+	harness.SetCustomData(custom)
+	cases := harness.Run(templates, opts.update)
+
+	if opts.update {
+		if err := persistGoldenFiles(osFs, cases); err != nil {
+			return packError(opts.jsonOutput, "WRITE_ERROR", err)
+		}
+	}
+
+	result := summarizeGoldenCases(cases, cwd)
+	if opts.jsonOutput {
+		return output.Success(result)
+	}
+
+	printTestResult(result)
+
+	if failures := result.Failed + result.Missing + result.Errors; failures > 0 {
+		return fmt.Errorf("template tests failed: %d of %d case(s)", failures, result.Total)
+	}
+	return nil
+}
+
+func filterTemplateNames(templates []string, filters []string) []string {
+	if len(filters) == 0 {
+		return templates
+	}
+
+	var filtered []string
+	for _, tmpl := range templates {
+		for _, filter := range filters {
+			filter = strings.TrimPrefix(filepath.ToSlash(filter), generator.ProjectTemplateDir+"/")
+			if strings.HasPrefix(filepath.ToSlash(tmpl), filter) {
+				filtered = append(filtered, tmpl)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func filterFixtures(fixtures []generator.Fixture, filter string) []generator.Fixture {
+	if filter == "" {
+		return fixtures
+	}
+
+	var filtered []generator.Fixture
+	for _, fixture := range fixtures {
+		if strings.Contains(fixture.Name, filter) {
+			filtered = append(filtered, fixture)
+		}
+	}
+	return filtered
+}
+
+func copyTree(src, dst afero.Fs, root string) error {
+	return afero.Walk(src, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return dst.MkdirAll(path, 0755)
+		}
+		content, err := afero.ReadFile(src, path)
+		if err != nil {
+			return err
+		}
+		return afero.WriteFile(dst, path, content, 0644)
+	})
+}
+
+func persistGoldenFiles(fs afero.Fs, cases []generator.GoldenCase) error {
+	for _, c := range cases {
+		if c.Status != generator.GoldenUpdated {
+			continue
+		}
+		if err := fs.MkdirAll(filepath.Dir(c.GoldenPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := afero.WriteFile(fs, c.GoldenPath, []byte(c.Rendered), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", c.GoldenPath, err)
+		}
+	}
+	return nil
+}
+
+func summarizeGoldenCases(cases []generator.GoldenCase, cwd string) output.TemplateTestOutput {
+	result := output.TemplateTestOutput{Cases: []output.TemplateTestCase{}, Total: len(cases)}
+	for _, c := range cases {
+		golden := c.GoldenPath
+		if rel, err := filepath.Rel(cwd, golden); err == nil && golden != "" {
+			golden = rel
+		}
+
+		result.Cases = append(result.Cases, output.TemplateTestCase{
+			Template: c.Template,
+			Fixture:  c.Fixture,
+			Status:   string(c.Status),
+			Golden:   golden,
+			Diff:     c.Diff,
+			Error:    c.Error,
+		})
+
+		switch c.Status {
+		case generator.GoldenPass:
+			result.Passed++
+		case generator.GoldenFail:
+			result.Failed++
+		case generator.GoldenMissing:
+			result.Missing++
+		case generator.GoldenUpdated:
+			result.Updated++
+		default:
+			result.Errors++
+		}
+	}
+	return result
+}
+
+func printTestResult(result output.TemplateTestOutput) {
+	fmt.Println()
+	for _, c := range result.Cases {
+		label := c.Template
+		if c.Fixture != "" {
+			label = fmt.Sprintf("%s [%s]", c.Template, c.Fixture)
+		}
+
+		switch c.Status {
+		case string(generator.GoldenPass):
+			fmt.Printf("  %s %s\n", successStyle.Render("✓"), label)
+		case string(generator.GoldenUpdated):
+			fmt.Printf("  %s %s %s\n", infoStyle.Render("↻"), label, lineStyle.Render("updated"))
+		case string(generator.GoldenMissing):
+			fmt.Printf("  %s %s %s\n", warningStyle.Render("?"), label, lineStyle.Render("missing golden (run with --update)"))
+		case string(generator.GoldenFail):
+			fmt.Printf("  %s %s\n", errorStyle.Render("✗"), label)
+			for _, line := range strings.Split(strings.TrimRight(c.Diff, "\n"), "\n") {
+				fmt.Printf("      %s\n", lineStyle.Render(line))
+			}
+		default:
+			fmt.Printf("  %s %s\n      %s %s\n", errorStyle.Render("✗"), label, errorStyle.Render("error"), c.Error)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("  %d passed, %d failed, %d missing, %d updated, %d errors\n\n",
+		result.Passed, result.Failed, result.Missing, result.Updated, result.Errors)
+}
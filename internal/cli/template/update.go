@@ -0,0 +1,143 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/KashifKhn/haft/internal/templatepack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func newUpdateCommand() *cobra.Command {
+	var global bool
+	var force bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "update [pack...]",
+		Short: "Update installed template packs",
+		Long: `Re-fetch installed template packs from their recorded sources and
+install any changes, updating the lock file.
+
+Templates removed from a pack are deleted. Templates you modified
+locally are never overwritten or deleted unless --force is given.`,
+		Example: `  # Update every installed pack
+  haft template update
+
+  # Update a single pack
+  haft template update acme-templates
+
+  # Update global packs
+  haft template update --global`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(args, global, force, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&global, "global", "g", false, "Update packs in the global ~/.haft/templates directory")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite local modifications and skip compatibility checks")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output result as JSON")
+
+	return cmd
+}
+
+func runUpdate(names []string, global, force, jsonOutput bool) error {
+	log := logger.Default()
+	fs := afero.NewOsFs()
+
+	root, scope, err := resolveTemplateRoot(global)
+	if err != nil {
+		return packError(jsonOutput, "CWD_ERROR", err)
+	}
+
+	installer := templatepack.NewInstaller(fs, root)
+	lock, err := installer.ReadLock()
+	if err != nil {
+		return packError(jsonOutput, "LOCK_ERROR", err)
+	}
+
+	selected, err := selectLockedPacks(lock, names)
+	if err != nil {
+		return packError(jsonOutput, "PACK_NOT_FOUND", err)
+	}
+
+	if len(selected) == 0 && !jsonOutput {
+		log.Info("No template packs installed")
+		return nil
+	}
+
+	results := []output.TemplatePackResult{}
+	for _, locked := range selected {
+		result, err := updatePack(fs, installer, locked, root, global, force)
+		if err != nil {
+			return packError(jsonOutput, "UPDATE_ERROR", err)
+		}
+		results = append(results, result)
+		if !jsonOutput {
+			printPackResult(result)
+		}
+	}
+
+	if jsonOutput {
+		return output.Success(output.TemplatePacksOutput{
+			Action:   "update",
+			Scope:    scope,
+			LockFile: installer.LockPath(),
+			Packs:    results,
+		})
+	}
+
+	return nil
+}
+
+func updatePack(fs afero.Fs, installer *templatepack.Installer, locked templatepack.LockedPack, root string, global, force bool) (output.TemplatePackResult, error) {
+	pack, err := templatepack.Fetch(fs, locked.Source, root)
+	if err != nil {
+		return output.TemplatePackResult{}, fmt.Errorf("failed to fetch pack '%s': %w", locked.Name, err)
+	}
+
+	if pack.Manifest.Name != locked.Name {
+		return output.TemplatePackResult{}, fmt.Errorf("source %s now provides pack '%s', expected '%s'",
+			locked.Source, pack.Manifest.Name, locked.Name)
+	}
+
+	if pack.Checksum() == locked.Checksum {
+		return output.TemplatePackResult{
+			Name:    locked.Name,
+			Version: locked.Version,
+			Source:  locked.Source,
+			Status:  "up-to-date",
+		}, nil
+	}
+
+	if !force {
+		if err := checkPackCompatibility(fs, pack, root, global); err != nil {
+			return output.TemplatePackResult{}, fmt.Errorf("%w (use --force to update anyway)", err)
+		}
+	}
+
+	result, err := installer.Install(pack, locked.Source, force)
+	if err != nil {
+		return output.TemplatePackResult{}, err
+	}
+
+	return installResultOutput(result, locked.Source, "updated"), nil
+}
+
+func selectLockedPacks(lock *templatepack.Lock, names []string) ([]templatepack.LockedPack, error) {
+	if len(names) == 0 {
+		return lock.Packs, nil
+	}
+
+	var selected []templatepack.LockedPack
+	for _, name := range names {
+		locked := lock.Find(name)
+		if locked == nil {
+			return nil, fmt.Errorf("pack '%s' is not installed", name)
+		}
+		selected = append(selected, *locked)
+	}
+	return selected, nil
+}
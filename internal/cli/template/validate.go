@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/KashifKhn/haft/internal/cli/generate"
+	"github.com/KashifKhn/haft/internal/detector"
 	"github.com/KashifKhn/haft/internal/generator"
 	"github.com/KashifKhn/haft/internal/logger"
 	"github.com/KashifKhn/haft/internal/output"
@@ -37,6 +39,9 @@ This command checks for:
   - Unmatched @if/@endif directives
   - Unknown variables (warnings)
   - Go template syntax errors
+  - Field references, placeholders and @if conditions in resource and
+    test templates, checked against the real generation context with
+    line, column and "did you mean" suggestions
 
 You can validate a single template file or all templates in a directory.`,
 		Example: `  # Validate all project templates
@@ -58,11 +63,11 @@ You can validate a single template file or all templates in a directory.`,
   haft template validate --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if showVars {
-				printAvailableVariables()
+				printAvailableVariables(loadCustomData())
 				return nil
 			}
 			if showConditions {
-				printAvailableConditions()
+				printAvailableConditions(loadCustomData())
 				return nil
 			}
 			return runValidate(args, jsonOutput)
@@ -88,6 +93,14 @@ func runValidate(args []string, jsonOutput bool) error {
 		return fmt.Errorf("could not determine current directory: %w", err)
 	}
 
+	custom, err := generator.LoadCustomData(fs, cwd)
+	if err != nil {
+		if jsonOutput {
+			return output.Error("CONFIG_ERROR", "could not load template variables", err.Error())
+		}
+		return err
+	}
+
 	var templatePaths []string
 
 	if len(args) == 0 {
@@ -194,7 +207,7 @@ func runValidate(args []string, jsonOutput bool) error {
 			relPath = templatePath
 		}
 
-		result := generator.ValidateTemplate(string(content), filepath.Base(templatePath))
+		result := generator.ValidateTemplateWithSchema(string(content), filepath.Base(templatePath), schemaFor(templatePath, custom))
 
 		var validationErrors []output.TemplateValidationError
 		var validationWarnings []output.TemplateValidationError
@@ -202,12 +215,14 @@ func runValidate(args []string, jsonOutput bool) error {
 		for _, e := range result.Errors {
 			validationErrors = append(validationErrors, output.TemplateValidationError{
 				Line:    e.Line,
+				Column:  e.Column,
 				Message: e.Message,
 			})
 		}
 		for _, w := range result.Warnings {
 			validationWarnings = append(validationWarnings, output.TemplateValidationError{
 				Line:    w.Line,
+				Column:  w.Column,
 				Message: w.Message,
 			})
 		}
@@ -228,9 +243,9 @@ func runValidate(args []string, jsonOutput bool) error {
 			if !jsonOutput {
 				fmt.Printf("  %s %s\n", warningStyle.Render("⚠"), relPath)
 				for _, warn := range result.Warnings {
-					fmt.Printf("      %s line %d: %s\n",
+					fmt.Printf("      %s %s: %s\n",
 						warningStyle.Render("warning"),
-						warn.Line,
+						formatPosition(warn),
 						warn.Message)
 				}
 			}
@@ -240,15 +255,15 @@ func runValidate(args []string, jsonOutput bool) error {
 			if !jsonOutput {
 				fmt.Printf("  %s %s\n", errorStyle.Render("✗"), relPath)
 				for _, err := range result.Errors {
-					fmt.Printf("      %s line %d: %s\n",
+					fmt.Printf("      %s %s: %s\n",
 						errorStyle.Render("error"),
-						err.Line,
+						formatPosition(err),
 						err.Message)
 				}
 				for _, warn := range result.Warnings {
-					fmt.Printf("      %s line %d: %s\n",
+					fmt.Printf("      %s %s: %s\n",
 						warningStyle.Render("warning"),
-						warn.Line,
+						formatPosition(warn),
 						warn.Message)
 				}
 			}
@@ -296,7 +311,42 @@ func runValidate(args []string, jsonOutput bool) error {
 	return nil
 }
 
-func printAvailableVariables() {
+func schemaFor(templatePath string, custom generator.CustomData) *generator.TemplateSchema {
+	path := filepath.ToSlash(templatePath)
+	if idx := strings.LastIndex(path, generator.ProjectTemplateDir+"/"); idx >= 0 {
+		path = path[idx+len(generator.ProjectTemplateDir)+1:]
+	}
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "resource" || segment == "test" {
+			profile := detector.NewDefaultProfile()
+			data := generate.BuildTemplateContextFromProfile(fixtureResourceName, profile).ToMap()
+			return generator.NewTemplateSchema(custom.SchemaSample(data))
+		}
+	}
+	return nil
+}
+
+func formatPosition(e generator.ValidationError) string {
+	if e.Column > 0 {
+		return fmt.Sprintf("line %d:%d", e.Line, e.Column)
+	}
+	return fmt.Sprintf("line %d", e.Line)
+}
+
+func loadCustomData() generator.CustomData {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return generator.CustomData{}
+	}
+	custom, err := generator.LoadCustomData(afero.NewOsFs(), cwd)
+	if err != nil {
+		logger.Default().Warning("Could not load project template variables", "error", err.Error())
+	}
+	return custom
+}
+
+func printAvailableVariables(custom generator.CustomData) {
 	fmt.Println()
 	fmt.Println(infoStyle.Render("  Available Template Variables"))
 	fmt.Println(strings.Repeat("─", 60))
@@ -309,11 +359,20 @@ func printAvailableVariables() {
 		fmt.Println()
 	}
 
+	if len(custom.Variables) > 0 {
+		fmt.Println(infoStyle.Render("  Project Variables (.haft.json)"))
+		fmt.Println()
+		for _, name := range custom.VariableNames() {
+			fmt.Printf("  %-20s %s\n", successStyle.Render("${"+name+"}"), lineStyle.Render(custom.Variables[name]))
+		}
+		fmt.Println()
+	}
+
 	fmt.Println(lineStyle.Render("  Usage: ${VariableName} in your template"))
 	fmt.Println()
 }
 
-func printAvailableConditions() {
+func printAvailableConditions(custom generator.CustomData) {
 	fmt.Println()
 	fmt.Println(infoStyle.Render("  Available Conditions for @if Directives"))
 	fmt.Println(strings.Repeat("─", 60))
@@ -323,6 +382,9 @@ func printAvailableConditions() {
 	for _, c := range conditions {
 		fmt.Printf("  %-20s %s\n", successStyle.Render(c.Name), c.Description)
 	}
+	for _, name := range custom.ConditionNames() {
+		fmt.Printf("  %-20s %s\n", successStyle.Render(name), lineStyle.Render(custom.Conditions[name]+" (.haft.json)"))
+	}
 
 	fmt.Println()
 	fmt.Println(lineStyle.Render("  Usage:"))
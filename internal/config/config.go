@@ -23,6 +23,8 @@ type ProjectConfig struct {
 	Architecture ArchSettings      `json:"architecture"`
 	Database     DatabaseSettings  `json:"database"`
 	Generators   GeneratorSettings `json:"generators"`
+	Templates    TemplateSettings  `json:"templates,omitzero"`
+	Hooks        HookSettings      `json:"hooks,omitzero"`
 }
 
 type ProjectSettings struct {
@@ -46,7 +48,17 @@ type BuildSettings struct {
 }
 
 type ArchSettings struct {
-	Style string `json:"style"`
+	Style         string     `json:"style"`
+	Rules         []ArchRule `json:"rules,omitempty"`
+	DisabledRules []string   `json:"disabledRules,omitempty"`
+}
+
+type ArchRule struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	From        []string `json:"from"`
+	Forbid      []string `json:"forbid"`
+	Severity    string   `json:"severity,omitempty"`
 }
 
 type DatabaseSettings struct {
@@ -66,6 +78,24 @@ type TestSettings struct {
 	Enabled bool `json:"enabled"`
 }
 
+type TemplateSettings struct {
+	Variables  map[string]string `json:"variables,omitempty"`
+	Conditions map[string]string `json:"conditions,omitempty"`
+}
+
+type HookSettings struct {
+	PostGenerate []HookConfig `json:"postGenerate,omitempty"`
+	PostAdd      []HookConfig `json:"postAdd,omitempty"`
+	PostRemove   []HookConfig `json:"postRemove,omitempty"`
+}
+
+type HookConfig struct {
+	Name      string `json:"name,omitempty"`
+	Command   string `json:"command"`
+	Timeout   string `json:"timeout,omitempty"`
+	OnFailure string `json:"onFailure,omitempty"`
+}
+
 type GlobalConfig struct {
 	Defaults DefaultSettings `json:"defaults"`
 	Output   OutputSettings  `json:"output"`
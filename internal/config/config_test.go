@@ -54,6 +54,27 @@ func TestSaveAndLoadProjectConfig(t *testing.T) {
 	assert.Equal(t, config.Build.Tool, loaded.Build.Tool)
 }
 
+func TestProjectConfigTemplateSettings(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cm := NewConfigManager(fs, "/project", "/home/user")
+
+	require.NoError(t, cm.SaveProjectConfig(DefaultProjectConfig()))
+	data, err := afero.ReadFile(fs, filepath.Join("/project", ProjectConfigFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "templates")
+
+	config := DefaultProjectConfig()
+	config.Templates = TemplateSettings{
+		Variables:  map[string]string{"Author": "Platform Team"},
+		Conditions: map[string]string{"UsesTenantId": ".HasJpa"},
+	}
+	require.NoError(t, cm.SaveProjectConfig(config))
+
+	loaded, err := cm.LoadProjectConfig()
+	require.NoError(t, err)
+	assert.Equal(t, config.Templates, loaded.Templates)
+}
+
 func TestLoadProjectConfigNotExists(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	cm := NewConfigManager(fs, "/project", "/home/user")
@@ -19,9 +19,10 @@ const (
 )
 
 type ProfileCache struct {
-	fs         afero.Fs
-	projectDir string
-	maxAge     time.Duration
+	fs             afero.Fs
+	projectDir     string
+	maxAge         time.Duration
+	sourceChecksum string
 }
 
 func NewProfileCache(projectDir string) *ProfileCache {
@@ -44,6 +45,10 @@ func (c *ProfileCache) SetMaxAge(maxAge time.Duration) {
 	c.maxAge = maxAge
 }
 
+func (c *ProfileCache) SetSourceChecksum(checksum string) {
+	c.sourceChecksum = checksum
+}
+
 func (c *ProfileCache) getCacheDir() string {
 	return filepath.Join(c.projectDir, CacheDir)
 }
@@ -73,9 +78,12 @@ func (c *ProfileCache) Save(profile *ProjectProfile) error {
 		return fmt.Errorf("failed to write profile: %w", err)
 	}
 
-	checksum, err := c.computeSourceChecksum()
-	if err != nil {
-		return nil
+	checksum := c.sourceChecksum
+	if checksum == "" {
+		checksum, err = c.computeSourceChecksum()
+		if err != nil {
+			return nil
+		}
 	}
 
 	if err := afero.WriteFile(c.fs, c.getChecksumPath(), []byte(checksum), 0644); err != nil {
@@ -148,34 +156,50 @@ func (c *ProfileCache) hasSourceChanged() bool {
 }
 
 func (c *ProfileCache) computeSourceChecksum() (string, error) {
-	srcDir := filepath.Join(c.projectDir, "src", "main", "java")
-
-	exists, err := afero.DirExists(c.fs, srcDir)
-	if err != nil || !exists {
-		return "", fmt.Errorf("source directory not found")
-	}
-
 	var files []string
-	err = afero.Walk(c.fs, srcDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
+	found := false
+
+	for _, root := range []string{"src/main/java", KotlinSourceRoot} {
+		srcDir := filepath.Join(c.projectDir, root)
+		exists, err := afero.DirExists(c.fs, srcDir)
+		if err != nil || !exists {
+			continue
 		}
-		if !info.IsDir() && filepath.Ext(path) == ".java" {
-			relPath, _ := filepath.Rel(c.projectDir, path)
-			files = append(files, fmt.Sprintf("%s:%d:%d", relPath, info.Size(), info.ModTime().Unix()))
+		found = true
+
+		err = afero.Walk(c.fs, srcDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			ext := filepath.Ext(path)
+			if !info.IsDir() && (ext == ".java" || ext == ".kt") {
+				relPath, _ := filepath.Rel(c.projectDir, path)
+				files = append(files, checksumEntry(relPath, info.Size(), info.ModTime()))
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
 		}
-		return nil
-	})
-	if err != nil {
-		return "", err
 	}
 
-	hash := md5.New()
-	for _, f := range files {
-		hash.Write([]byte(f))
+	if !found {
+		return "", fmt.Errorf("source directory not found")
 	}
 
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return checksumOf(files), nil
+}
+
+func checksumEntry(relPath string, size int64, modTime time.Time) string {
+	return fmt.Sprintf("%s:%d:%d", relPath, size, modTime.Unix())
+}
+
+func checksumOf(entries []string) string {
+	hash := md5.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry))
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
 }
 
 func (c *ProfileCache) Clear() error {
@@ -0,0 +1,364 @@
+package detector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	autowiredFieldRegex  = regexp.MustCompile(`@Autowired\s+(?:(?:private|protected|public)\s+)?(?:lateinit\s+var\b|[\w.]+(?:<[^;(){}=]*>)?\s+\w+\s*;)`)
+	finalFieldRegex      = regexp.MustCompile(`(?m)^\s*(?:private|protected)?\s*final\s+[\w<>,.?\[\] ]+\s+\w+\s*;`)
+	kotlinCtorFieldRegex = regexp.MustCompile(`class\s+\w+\s*(?:@\w+\s+)?(?:constructor\s*)?\([^)]*\bval\s`)
+	optionalReturnRegex  = regexp.MustCompile(`(?m)^\s*(?:public\s+)?Optional<[\w<>,.? ]+>\s+\w+\s*\(`)
+	throwNotFoundRegex   = regexp.MustCompile(`orElseThrow\(|throw\s+new\s+\w*NotFound\w*\(`)
+	classMappingRegex    = regexp.MustCompile(`@RequestMapping\(\s*(?:(?:value|path)\s*=\s*)?\{?\s*"([^"]*)"`)
+	localDeclRegex       = regexp.MustCompile(`^(final\s+)?(?:var|[A-Z][\w.]*(?:<[\w<>,.? ]*>)?(?:\[\])*)\s+[a-z]\w*\s*(?:=|;|:)`)
+	stringLiteralRegex   = regexp.MustCompile(`"(?:\\.|[^"\\])*"`)
+	testMethodRegex      = regexp.MustCompile(`(?s)@(?:Test|ParameterizedTest)\b[^{;]*?(?:void|fun)\s+(\w+)\s*\(`)
+	apiVersionRegex      = regexp.MustCompile(`^v\d+$`)
+)
+
+type conventionTally struct {
+	counts   map[string]int
+	examples map[string][]string
+	total    int
+}
+
+type conventionScan struct {
+	injection   *conventionTally
+	dtoStyle    *conventionTally
+	lookup      *conventionTally
+	restPrefix  *conventionTally
+	restPlural  *conventionTally
+	restKebab   *conventionTally
+	finalLocals *conventionTally
+	testNaming  *conventionTally
+}
+
+func newConventionTally() *conventionTally {
+	return &conventionTally{counts: make(map[string]int), examples: make(map[string][]string)}
+}
+
+func (t *conventionTally) add(value, example string) {
+	t.counts[value]++
+	t.total++
+	for _, existing := range t.examples[value] {
+		if existing == example {
+			return
+		}
+	}
+	if example != "" && len(t.examples[value]) < maxEvidenceFiles {
+		t.examples[value] = append(t.examples[value], example)
+	}
+}
+
+func (t *conventionTally) winner(preferred ...string) (string, bool) {
+	others := make([]string, 0, len(t.counts))
+	for value := range t.counts {
+		others = append(others, value)
+	}
+	sort.Strings(others)
+
+	best := ""
+	for _, value := range append(preferred, others...) {
+		if t.counts[value] > t.counts[best] {
+			best = value
+		}
+	}
+	return best, best != ""
+}
+
+func (t *conventionTally) confidence(calc *ConfidenceCalculator, value string) float64 {
+	return calc.CalculateFromCounts(t.counts[value], t.total)
+}
+
+func (t *conventionTally) evidence() []string {
+	values := make([]string, 0, len(t.counts))
+	for value := range t.counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if t.counts[values[i]] != t.counts[values[j]] {
+			return t.counts[values[i]] > t.counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+
+	var evidence []string
+	for _, value := range values {
+		line := fmt.Sprintf("%s: %d", value, t.counts[value])
+		if len(t.examples[value]) > 0 {
+			line += " (" + strings.Join(t.examples[value], ", ") + ")"
+		}
+		evidence = append(evidence, line)
+	}
+	return evidence
+}
+
+func (d *Detector) detectConventions(scan *ScanResult, profile *ProjectProfile) {
+	cs := scanConventions(scan)
+	calc := d.confidenceCalculator
+	conv := &profile.Conventions
+
+	if value, ok := cs.injection.winner(string(InjectionConstructor), string(InjectionLombok), string(InjectionField)); ok {
+		conv.Injection = InjectionStyle(value)
+		conv.InjectionConfidence = cs.injection.confidence(calc, value)
+	}
+	if value, ok := cs.dtoStyle.winner(string(DTOStyleClass), string(DTOStyleRecord)); ok {
+		conv.DTOStyle = DTOStyle(value)
+		conv.DTOStyleConfidence = cs.dtoStyle.confidence(calc, value)
+	}
+	if value, ok := cs.lookup.winner(string(LookupException), string(LookupOptional)); ok {
+		conv.Lookup = LookupStyle(value)
+		conv.LookupConfidence = cs.lookup.confidence(calc, value)
+	}
+	if value, ok := cs.restPrefix.winner("/api", "none"); ok {
+		plural, _ := cs.restPlural.winner("plural", "singular")
+		kebab, _ := cs.restKebab.winner("kebab", "joined", "camel")
+		conv.RestPaths = RestPathStyle{
+			Prefix:     strings.TrimPrefix(value, "none"),
+			Plural:     plural != "singular",
+			KebabCase:  kebab == "kebab",
+			Confidence: cs.restPrefix.confidence(calc, value),
+		}
+	}
+	if value, ok := cs.finalLocals.winner("plain", "final"); ok {
+		conv.FinalLocals = value == "final"
+		conv.FinalLocalsConfidence = cs.finalLocals.confidence(calc, value)
+	}
+	if value, ok := cs.testNaming.winner(string(TestNamingShould), string(TestNamingGivenWhenThen), string(TestNamingSnakeCase), string(TestNamingTestPrefix)); ok {
+		conv.TestNaming = TestNamingStyle(value)
+		conv.TestNamingConfidence = cs.testNaming.confidence(calc, value)
+	}
+}
+
+func scanConventions(scan *ScanResult) *conventionScan {
+	cs := &conventionScan{
+		injection:   newConventionTally(),
+		dtoStyle:    newConventionTally(),
+		lookup:      newConventionTally(),
+		restPrefix:  newConventionTally(),
+		restPlural:  newConventionTally(),
+		restKebab:   newConventionTally(),
+		finalLocals: newConventionTally(),
+		testNaming:  newConventionTally(),
+	}
+
+	for _, file := range scan.SourceFiles {
+		if file.FileType == FileTypeDTO && !file.IsInterface && !isKotlinFile(file) {
+			style := DTOStyleClass
+			if file.IsRecord {
+				style = DTOStyleRecord
+			}
+			cs.dtoStyle.add(string(style), file.ClassName)
+		}
+		if file.Injection != "" {
+			cs.injection.add(string(file.Injection), file.ClassName)
+		}
+		for _, style := range file.LookupStyles {
+			cs.lookup.add(string(style), file.ClassName)
+		}
+		if file.RequestMapping != "" {
+			tallyRestPath(cs, file)
+		}
+		for i := 0; i < file.FinalLocals; i++ {
+			cs.finalLocals.add("final", file.ClassName)
+		}
+		for i := 0; i < file.PlainLocals; i++ {
+			cs.finalLocals.add("plain", file.ClassName)
+		}
+	}
+
+	for _, file := range scan.TestFiles {
+		for _, name := range file.TestMethods {
+			if style := classifyTestName(name); style != "" {
+				cs.testNaming.add(string(style), name)
+			}
+		}
+	}
+
+	return cs
+}
+
+func collectConventions(jf *JavaFile, text string) {
+	if jf.FileType == FileTypeTest {
+		for _, match := range testMethodRegex.FindAllStringSubmatch(text, -1) {
+			jf.TestMethods = append(jf.TestMethods, match[1])
+		}
+		return
+	}
+
+	switch jf.FileType {
+	case FileTypeController:
+		jf.Injection = injectionStyle(jf, text)
+		if match := classMappingRegex.FindStringSubmatch(text); match != nil {
+			jf.RequestMapping = match[1]
+		}
+	case FileTypeService:
+		jf.Injection = injectionStyle(jf, text)
+		jf.LookupStyles = lookupStyles(jf, text)
+	}
+	if !isKotlinFile(jf) && !jf.IsInterface {
+		jf.FinalLocals, jf.PlainLocals = countLocals(text)
+	}
+}
+
+func (d *Detector) conventionResult(field, value string, scan *ScanResult, profile *ProjectProfile) DetectionResult {
+	cs := scanConventions(scan)
+	conv := profile.Conventions
+	result := DetectionResult{Value: value}
+
+	switch field {
+	case "injection":
+		result.Confidence, result.Evidence = conv.InjectionConfidence, cs.injection.evidence()
+	case "dto_style":
+		result.Confidence, result.Evidence = conv.DTOStyleConfidence, cs.dtoStyle.evidence()
+	case "lookup_style":
+		result.Confidence, result.Evidence = conv.LookupConfidence, cs.lookup.evidence()
+	case "rest_prefix":
+		result.Confidence, result.Evidence = conv.RestPaths.Confidence, cs.restPrefix.evidence()
+	case "rest_plural":
+		result.Confidence, result.Evidence = conv.RestPaths.Confidence, cs.restPlural.evidence()
+	case "rest_kebab_case":
+		result.Confidence, result.Evidence = conv.RestPaths.Confidence, cs.restKebab.evidence()
+	case "final_locals":
+		result.Confidence, result.Evidence = conv.FinalLocalsConfidence, cs.finalLocals.evidence()
+	case "test_naming":
+		result.Confidence, result.Evidence = conv.TestNamingConfidence, cs.testNaming.evidence()
+	}
+	return result
+}
+
+func injectionStyle(file *JavaFile, text string) InjectionStyle {
+	if file.IsInterface {
+		return ""
+	}
+	switch {
+	case autowiredFieldRegex.MatchString(text):
+		return InjectionField
+	case isKotlinFile(file) && kotlinCtorFieldRegex.MatchString(text):
+		return InjectionConstructor
+	case !finalFieldRegex.MatchString(text):
+		return ""
+	case containsAnnotation(file, "RequiredArgsConstructor") || containsAnnotation(file, "AllArgsConstructor"):
+		return InjectionLombok
+	default:
+		return InjectionConstructor
+	}
+}
+
+func lookupStyles(file *JavaFile, text string) []LookupStyle {
+	var styles []LookupStyle
+	if optionalReturnRegex.MatchString(text) {
+		styles = append(styles, LookupOptional)
+	}
+	if !file.IsInterface && throwNotFoundRegex.MatchString(text) {
+		styles = append(styles, LookupException)
+	}
+	return styles
+}
+
+func tallyRestPath(cs *conventionScan, file *JavaFile) {
+	mapping := file.RequestMapping
+
+	var segments []string
+	for _, segment := range strings.Split(mapping, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	prefix := "none"
+	rest := segments
+	switch {
+	case len(segments) > 1 && segments[0] == "api" && apiVersionRegex.MatchString(segments[1]):
+		prefix, rest = "/api/"+segments[1], segments[2:]
+	case len(segments) > 0 && segments[0] == "api":
+		prefix, rest = "/api", segments[1:]
+	case len(segments) > 0 && apiVersionRegex.MatchString(segments[0]):
+		prefix, rest = "/"+segments[0], segments[1:]
+	}
+	cs.restPrefix.add(prefix, mapping)
+
+	if len(rest) == 0 || strings.Contains(rest[0], "{") {
+		return
+	}
+	resource := rest[0]
+	if strings.HasSuffix(resource, "s") {
+		cs.restPlural.add("plural", mapping)
+	} else {
+		cs.restPlural.add("singular", mapping)
+	}
+
+	switch {
+	case strings.Contains(resource, "-"):
+		cs.restKebab.add("kebab", mapping)
+	case strings.ToLower(resource) != resource:
+		cs.restKebab.add("camel", mapping)
+	case len(splitCamel(strings.TrimSuffix(file.ClassName, "Controller"))) > 1:
+		cs.restKebab.add("joined", mapping)
+	}
+}
+
+func countLocals(text string) (final, plain int) {
+	depth := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(stringLiteralRegex.ReplaceAllString(line, `""`))
+		if depth >= 2 && !strings.HasPrefix(trimmed, "return") {
+			if match := localDeclRegex.FindStringSubmatch(trimmed); match != nil {
+				if match[1] != "" {
+					final++
+				} else {
+					plain++
+				}
+			}
+		}
+		depth += strings.Count(trimmed, "{") - strings.Count(trimmed, "}")
+	}
+	return final, plain
+}
+
+func classifyTestName(name string) TestNamingStyle {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(lower, "given") || (strings.Contains(name, "_") && strings.Contains(lower, "when") && strings.Contains(lower, "then")):
+		return TestNamingGivenWhenThen
+	case strings.HasPrefix(name, "should_") || (strings.Contains(name, "_") && lower == name):
+		return TestNamingSnakeCase
+	case strings.HasPrefix(name, "should"):
+		return TestNamingShould
+	case strings.HasPrefix(name, "test"):
+		return TestNamingTestPrefix
+	}
+	return ""
+}
+
+func containsAnnotation(file *JavaFile, annotation string) bool {
+	for _, ann := range file.Annotations {
+		if ann == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+func isKotlinFile(file *JavaFile) bool {
+	return strings.HasSuffix(file.Path, ".kt")
+}
+
+func splitCamel(name string) []string {
+	var words []string
+	start := 0
+	for i := 1; i < len(name); i++ {
+		if name[i] >= 'A' && name[i] <= 'Z' {
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+	if name != "" {
+		words = append(words, name[start:])
+	}
+	return words
+}
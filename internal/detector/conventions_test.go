@@ -0,0 +1,161 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conventionProjectFS(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"/project/src/main/java/com/example/app/order/OrderItemController.java": `package com.example.app.order;
+
+@RestController
+@RequestMapping("/api/v1/order-items")
+@RequiredArgsConstructor
+public class OrderItemController {
+    private final OrderItemService orderItemService;
+
+    @GetMapping("/{id}")
+    public ResponseEntity<OrderItemResponse> getById(@PathVariable Long id) {
+        final var result = orderItemService.findById(id);
+        return result.map(ResponseEntity::ok).orElseGet(() -> ResponseEntity.notFound().build());
+    }
+}`,
+		"/project/src/main/java/com/example/app/user/UserController.java": `package com.example.app.user;
+
+@RestController
+@RequestMapping(path = "/api/v1/users")
+@RequiredArgsConstructor
+public class UserController {
+    private final UserService userService;
+}`,
+		"/project/src/main/java/com/example/app/user/UserService.java": `package com.example.app.user;
+
+@Service
+@RequiredArgsConstructor
+public class UserService {
+    private final UserRepository userRepository;
+
+    public Optional<UserResponse> findById(Long id) {
+        final User user = userRepository.findById(id).orElse(null);
+        final String label = "a { b";
+        return Optional.ofNullable(user).map(UserResponse::from);
+    }
+}`,
+		"/project/src/main/java/com/example/app/user/dto/UserResponse.java": "package com.example.app.user.dto;\n\npublic record UserResponse(Long id, String name) {\n}",
+		"/project/src/main/java/com/example/app/user/dto/UserRequest.java":  "package com.example.app.user.dto;\n\npublic record UserRequest(String name) {\n}",
+		"/project/src/test/java/com/example/app/user/UserServiceTest.java": `package com.example.app.user;
+
+class UserServiceTest {
+    @Test
+    void givenExistingUser_whenFindById_thenReturnsUser() {
+    }
+
+    @Test
+    @DisplayName("missing")
+    void givenMissingUser_whenFindById_thenEmpty() {
+    }
+
+    @Test
+    void shouldCreateUser() {
+    }
+}`,
+	}
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func TestDetectConventions(t *testing.T) {
+	profile, err := NewDetector("/project", WithFileSystem(conventionProjectFS(t))).Detect()
+	require.NoError(t, err)
+
+	conv := profile.Conventions
+	assert.Equal(t, InjectionLombok, conv.Injection)
+	assert.Greater(t, conv.InjectionConfidence, 0.0)
+	assert.Equal(t, DTOStyleRecord, conv.DTOStyle)
+	assert.Equal(t, LookupOptional, conv.Lookup)
+	assert.Equal(t, "/api/v1", conv.RestPaths.Prefix)
+	assert.True(t, conv.RestPaths.Plural)
+	assert.True(t, conv.RestPaths.KebabCase)
+	assert.Greater(t, conv.RestPaths.Confidence, 0.0)
+	assert.True(t, conv.FinalLocals)
+	assert.Equal(t, TestNamingGivenWhenThen, conv.TestNaming)
+}
+
+func TestDetectConventionsEmptyProject(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/src/main/java/com/example/app/App.java", []byte("package com.example.app;\n\npublic class App {\n}"), 0644))
+
+	profile, err := NewDetector("/project", WithFileSystem(fs)).Detect()
+	require.NoError(t, err)
+
+	assert.Empty(t, profile.Conventions.Injection)
+	assert.Empty(t, profile.Conventions.TestNaming)
+	assert.Zero(t, profile.Conventions.RestPaths.Confidence)
+}
+
+func TestDetectorExplainConventions(t *testing.T) {
+	d := NewDetector("/project", WithFileSystem(conventionProjectFS(t)))
+
+	explanation, err := d.Explain("injection")
+	require.NoError(t, err)
+	assert.Equal(t, "lombok", explanation.Result.Value)
+	assert.Contains(t, explanation.Result.Evidence, "lombok: 3 (OrderItemController, UserController, UserService)")
+
+	explanation, err = d.Explain("rest_prefix")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1", explanation.Result.Value)
+	assert.Contains(t, explanation.Result.Evidence, "/api/v1: 2 (/api/v1/order-items, /api/v1/users)")
+}
+
+func TestInjectionDetection(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected InjectionStyle
+	}{
+		{"field", "/A.java", "class A {\n    @Autowired\n    private UserService userService;\n}", InjectionField},
+		{"kotlin lateinit", "/A.kt", "class A {\n    @Autowired lateinit var userService: UserService\n}", InjectionField},
+		{"constructor", "/A.java", "class A {\n    private final UserService userService;\n    A(UserService s) { this.userService = s; }\n}", InjectionConstructor},
+		{"kotlin constructor", "/A.kt", "class A(private val userService: UserService)", InjectionConstructor},
+		{"lombok", "/A.java", "@RequiredArgsConstructor\nclass A {\n    private final UserService userService;\n}", InjectionLombok},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := &JavaFile{Path: tt.path, ClassName: "A"}
+			if tt.expected == InjectionLombok {
+				file.Annotations = []string{"RequiredArgsConstructor"}
+			}
+			assert.Equal(t, tt.expected, injectionStyle(file, tt.content))
+		})
+	}
+}
+
+func TestClassifyTestName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected TestNamingStyle
+	}{
+		{"shouldReturnUser", TestNamingShould},
+		{"should_return_user", TestNamingSnakeCase},
+		{"returns_user_when_found", TestNamingSnakeCase},
+		{"givenUser_whenFind_thenFound", TestNamingGivenWhenThen},
+		{"find_whenMissing_thenThrows", TestNamingGivenWhenThen},
+		{"testFindUser", TestNamingTestPrefix},
+		{"findsUser", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyTestName(tt.name))
+		})
+	}
+}
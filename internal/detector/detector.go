@@ -10,12 +10,20 @@ const (
 	DefaultCacheMaxAge = 24 * time.Hour
 )
 
+var (
+	hexagonalMarkers     = []string{"domain", "application", "infrastructure", "adapter", "port"}
+	cleanSpecificMarkers = []string{"usecase", "gateway", "presenter", "interactor"}
+	cleanSupportMarkers  = []string{"domain", "application", "infrastructure"}
+	modularMarkers       = []string{"api", "internal", "module"}
+)
+
 type Detector struct {
 	fs                   afero.Fs
 	projectDir           string
 	scanner              *Scanner
 	confidenceCalculator *ConfidenceCalculator
 	cacheMaxAge          time.Duration
+	lastScan             *ScanResult
 }
 
 type DetectorOption func(*Detector)
@@ -55,7 +63,20 @@ func (d *Detector) Detect() (*ProjectProfile, error) {
 	if err != nil {
 		return nil, err
 	}
+	d.lastScan = scanResult
+
+	return d.profileFromScan(scanResult), nil
+}
+
+func (d *Detector) SaveIndex() error {
+	return d.scanner.SaveIndex()
+}
+
+func (d *Detector) LastScan() *ScanResult {
+	return d.lastScan
+}
 
+func (d *Detector) profileFromScan(scanResult *ScanResult) *ProjectProfile {
 	profile := NewEmptyProfile()
 	profile.ProjectRoot = d.projectDir
 	profile.BasePackage = scanResult.BasePackage
@@ -74,8 +95,9 @@ func (d *Detector) Detect() (*ProjectProfile, error) {
 	d.detectValidation(scanResult, profile)
 	d.detectDatabase(scanResult, profile)
 	d.detectTestingProfile(scanResult, profile)
+	d.detectConventions(scanResult, profile)
 
-	return profile, nil
+	return profile
 }
 
 func (d *Detector) detectArchitecture(scan *ScanResult, profile *ProjectProfile) {
@@ -201,7 +223,6 @@ func (d *Detector) calculateFeatureScore(scan *ScanResult) float64 {
 }
 
 func (d *Detector) calculateHexagonalScore(scan *ScanResult) float64 {
-	hexagonalMarkers := []string{"domain", "application", "infrastructure", "adapter", "port"}
 	foundMarkers := 0
 
 	packages := d.scanner.GetUniquePackages(scan.SourceFiles)
@@ -227,8 +248,6 @@ func (d *Detector) calculateHexagonalScore(scan *ScanResult) float64 {
 }
 
 func (d *Detector) calculateCleanScore(scan *ScanResult) float64 {
-	cleanSpecificMarkers := []string{"usecase", "gateway", "presenter", "interactor"}
-	cleanSupportMarkers := []string{"domain", "application", "infrastructure"}
 	foundSpecific := 0
 	foundSupport := 0
 
@@ -277,7 +296,6 @@ func (d *Detector) calculateCleanScore(scan *ScanResult) float64 {
 }
 
 func (d *Detector) calculateModularScore(scan *ScanResult) float64 {
-	modularMarkers := []string{"api", "internal", "module"}
 	foundMarkers := 0
 
 	packages := d.scanner.GetUniquePackages(scan.SourceFiles)
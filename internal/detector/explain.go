@@ -0,0 +1,232 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxEvidenceFiles = 5
+
+type ArchitectureScore struct {
+	Architecture ArchitectureType
+	Score        float64
+}
+
+type Explanation struct {
+	Field  string
+	Result DetectionResult
+	Scores []ArchitectureScore
+}
+
+func (d *Detector) Explain(field string) (*Explanation, error) {
+	if _, err := lookupField(field); err != nil {
+		return nil, err
+	}
+
+	scan, err := d.scanner.Scan()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := d.profileFromScan(scan)
+	value, _ := profile.GetField(field)
+	explanation := &Explanation{
+		Field:  field,
+		Result: DetectionResult{Value: value, Confidence: 1.0},
+	}
+
+	switch field {
+	case "architecture":
+		explanation.Scores = d.architectureScores(scan)
+		explanation.Result.Confidence = profile.ArchConfidence
+		explanation.Result.Evidence = d.architectureEvidence(scan)
+	case "base_package":
+		explanation.Result.Evidence = []string{
+			fmt.Sprintf("shortest common package of %d source files is '%s'", len(scan.SourceFiles), scan.BasePackage),
+		}
+	case "feature_style":
+		explanation.Result.Evidence = featureStyleEvidence(profile)
+	case "dto_naming":
+		explanation.Result = d.suffixResult(value, d.scanner.GetFilesByType(scan.SourceFiles, FileTypeDTO), "DTO",
+			[]string{"Request", "Response"}, []string{"DTO"}, []string{"Dto"})
+	case "controller_suffix":
+		explanation.Result = d.suffixResult(value, d.scanner.GetFilesByType(scan.SourceFiles, FileTypeController), "controller",
+			[]string{"Controller"}, []string{"Resource"})
+	case "service_suffix":
+		explanation.Result.Evidence = []string{"not detected; defaults to 'Service'"}
+	case "id_type":
+		explanation.Result.Evidence = d.idTypeEvidence(scan)
+	case "mapper":
+		explanation.Result.Evidence = importEvidence(scan.SourceFiles, "Mapper", "mapstruct", "modelmapper")
+	case "database":
+		explanation.Result.Evidence = importEvidence(scan.SourceFiles, "Entity", "cassandra", "r2dbc", "mongodb")
+	case "swagger_style":
+		explanation.Result.Evidence = importEvidence(scan.SourceFiles, "Operation", "io.swagger", "springdoc")
+	case "validation_style":
+		explanation.Result.Evidence = importEvidence(scan.SourceFiles, "Valid", "jakarta.validation", "javax.validation")
+	default:
+		explanation.Result = d.conventionResult(field, value, scan, profile)
+	}
+
+	if len(explanation.Result.Evidence) == 0 {
+		explanation.Result.Evidence = []string{"no signals found; using default"}
+	}
+	return explanation, nil
+}
+
+func (d *Detector) architectureScores(scan *ScanResult) []ArchitectureScore {
+	if len(scan.SourceFiles) == 0 {
+		return []ArchitectureScore{{Architecture: ArchLayered, Score: 1.0}}
+	}
+
+	scores := []ArchitectureScore{
+		{ArchLayered, d.calculateLayeredScore(scan)},
+		{ArchFeature, d.calculateFeatureScore(scan)},
+		{ArchHexagonal, d.calculateHexagonalScore(scan)},
+		{ArchClean, d.calculateCleanScore(scan)},
+		{ArchModular, d.calculateModularScore(scan)},
+		{ArchFlat, d.calculateFlatScore(scan)},
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
+
+func (d *Detector) architectureEvidence(scan *ScanResult) []string {
+	if len(scan.SourceFiles) == 0 {
+		return []string{"no source files found; defaulting to layered"}
+	}
+
+	packages := d.scanner.GetUniquePackages(scan.SourceFiles)
+	evidence := []string{
+		fmt.Sprintf("scanned %d source files in %d packages", len(scan.SourceFiles), len(packages)),
+	}
+
+	var layers []string
+	for _, layer := range []string{"controller", "service", "repository", "entity", "dto", "model"} {
+		for _, file := range scan.SourceFiles {
+			if isDirectLayerPackage(file.Package, scan.BasePackage, layer) {
+				layers = append(layers, layer)
+				break
+			}
+		}
+	}
+	evidence = appendFound(evidence, "layer packages", layers)
+
+	modules := d.extractFeatureModules(scan, scan.BasePackage)
+	sort.Strings(modules)
+	evidence = appendFound(evidence, "feature modules", modules)
+	evidence = appendFound(evidence, "hexagonal markers", markersFound(packages, hexagonalMarkers))
+	evidence = appendFound(evidence, "clean markers", markersFound(packages, append(cleanSpecificMarkers, cleanSupportMarkers...)))
+	evidence = appendFound(evidence, "modular markers", markersFound(packages, modularMarkers))
+	return evidence
+}
+
+func featureStyleEvidence(profile *ProjectProfile) []string {
+	if profile.Architecture != ArchFeature {
+		return []string{fmt.Sprintf("only applies to feature architecture (detected: %s)", profile.Architecture)}
+	}
+	return []string{fmt.Sprintf("feature modules: %s", strings.Join(profile.FeatureModules, ", "))}
+}
+
+func (d *Detector) suffixResult(value string, files []*JavaFile, kind string, groups ...[]string) DetectionResult {
+	result := DetectionResult{Value: value}
+	if len(files) == 0 {
+		result.Confidence = 1.0
+		return result
+	}
+
+	best := 0
+	for _, suffixes := range groups {
+		count := 0
+		for _, file := range files {
+			if endsWithAny(file.ClassName, suffixes...) {
+				count++
+			}
+		}
+		best = max(best, count)
+		result.Evidence = append(result.Evidence,
+			fmt.Sprintf("%d of %d %s classes end with %s", count, len(files), kind, strings.Join(suffixes, "/")))
+	}
+	result.Confidence = d.confidenceCalculator.CalculateFromCounts(best, len(files))
+	return result
+}
+
+func (d *Detector) idTypeEvidence(scan *ScanResult) []string {
+	entities := d.scanner.GetFilesByType(scan.SourceFiles, FileTypeEntity)
+	if len(entities) == 0 {
+		return nil
+	}
+
+	var matches []string
+	for _, entity := range entities {
+		for _, imp := range entity.Imports {
+			if endsWith(imp, "UUID") {
+				matches = append(matches, fmt.Sprintf("%s imports %s", entity.ClassName, imp))
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return []string{fmt.Sprintf("none of %d entities import UUID", len(entities))}
+	}
+	return limitEvidence(matches)
+}
+
+func importEvidence(files []*JavaFile, annotation string, imports ...string) []string {
+	var evidence []string
+	for _, file := range files {
+		for _, ann := range file.Annotations {
+			if ann == annotation {
+				evidence = append(evidence, fmt.Sprintf("%s is annotated with @%s", file.ClassName, annotation))
+				break
+			}
+		}
+		for _, imp := range file.Imports {
+			if matchAny(imp, imports) {
+				evidence = append(evidence, fmt.Sprintf("%s imports %s", file.ClassName, imp))
+				break
+			}
+		}
+	}
+	return limitEvidence(evidence)
+}
+
+func matchAny(value string, substrings []string) bool {
+	for _, substr := range substrings {
+		if containsString(value, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func markersFound(packages, markers []string) []string {
+	var found []string
+	for _, marker := range markers {
+		for _, pkg := range packages {
+			if containsPackagePart(pkg, marker) {
+				found = append(found, marker)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func appendFound(evidence []string, label string, found []string) []string {
+	if len(found) == 0 {
+		return append(evidence, fmt.Sprintf("%s: none", label))
+	}
+	return append(evidence, fmt.Sprintf("%s: %s", label, strings.Join(found, ", ")))
+}
+
+func limitEvidence(evidence []string) []string {
+	if len(evidence) <= maxEvidenceFiles {
+		return evidence
+	}
+	more := len(evidence) - maxEvidenceFiles
+	return append(evidence[:maxEvidenceFiles:maxEvidenceFiles], fmt.Sprintf("... and %d more", more))
+}
@@ -0,0 +1,345 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type profileField struct {
+	get  func(*ProjectProfile) string
+	set  func(*ProjectProfile, string) error
+	flag func(*ProjectProfile) *bool
+}
+
+var profileFields = map[string]profileField{
+	"architecture": {
+		get: func(p *ProjectProfile) string { return string(p.Architecture) },
+		set: func(p *ProjectProfile, v string) error {
+			arch := ParseArchitectureType(v)
+			if !arch.IsValid() {
+				return invalidValue("architecture", v, "layered", "feature", "hexagonal", "clean", "modular", "flat")
+			}
+			p.Architecture = arch
+			p.ArchConfidence = 1.0
+			return nil
+		},
+		flag: func(p *ProjectProfile) *bool { return &p.ArchLocked },
+	},
+	"feature_style": {
+		get: func(p *ProjectProfile) string { return string(p.FeatureStyle) },
+		set: func(p *ProjectProfile, v string) error {
+			switch FeatureStyle(v) {
+			case FeatureStyleFlat, FeatureStyleNested:
+				p.FeatureStyle = FeatureStyle(v)
+				return nil
+			}
+			return invalidValue("feature_style", v, "flat", "nested")
+		},
+	},
+	"base_package": {
+		get: func(p *ProjectProfile) string { return p.BasePackage },
+		set: func(p *ProjectProfile, v string) error {
+			if v == "" || strings.HasPrefix(v, ".") || strings.HasSuffix(v, ".") {
+				return fmt.Errorf("invalid base_package '%s'", v)
+			}
+			p.BasePackage = v
+			return nil
+		},
+	},
+	"dto_naming": {
+		get: func(p *ProjectProfile) string { return string(p.DTONaming) },
+		set: func(p *ProjectProfile, v string) error {
+			naming := ParseDTONamingStyle(v)
+			if naming == DTONamingUnknown {
+				return invalidValue("dto_naming", v, "request_response", "dto_upper", "dto_lower")
+			}
+			p.DTONaming = naming
+			return nil
+		},
+		flag: func(p *ProjectProfile) *bool { return &p.DTONamingLocked },
+	},
+	"controller_suffix": {
+		get: func(p *ProjectProfile) string { return p.ControllerSuffix },
+		set: func(p *ProjectProfile, v string) error {
+			return setIdentifier(&p.ControllerSuffix, "controller_suffix", v)
+		},
+	},
+	"service_suffix": {
+		get: func(p *ProjectProfile) string { return p.ServiceSuffix },
+		set: func(p *ProjectProfile, v string) error {
+			return setIdentifier(&p.ServiceSuffix, "service_suffix", v)
+		},
+	},
+	"id_type": {
+		get: func(p *ProjectProfile) string { return p.IDType },
+		set: func(p *ProjectProfile, v string) error {
+			switch v {
+			case "Long":
+				p.IDAnnotation = "@GeneratedValue(strategy = GenerationType.IDENTITY)"
+			case "UUID":
+				p.IDAnnotation = "@GeneratedValue(strategy = GenerationType.UUID)"
+			default:
+				return invalidValue("id_type", v, "Long", "UUID")
+			}
+			p.IDType = v
+			return nil
+		},
+		flag: func(p *ProjectProfile) *bool { return &p.IDLocked },
+	},
+	"mapper": {
+		get: func(p *ProjectProfile) string { return string(p.Mapper) },
+		set: func(p *ProjectProfile, v string) error {
+			mapper := ParseMapperType(v)
+			if mapper == MapperNone && v != string(MapperNone) {
+				return invalidValue("mapper", v, "mapstruct", "modelmapper", "manual", "none")
+			}
+			p.Mapper = mapper
+			return nil
+		},
+		flag: func(p *ProjectProfile) *bool { return &p.MapperLocked },
+	},
+	"database": {
+		get: func(p *ProjectProfile) string { return string(p.Database) },
+		set: func(p *ProjectProfile, v string) error {
+			db := ParseDatabaseType(v)
+			if db == DatabaseUnknown {
+				return invalidValue("database", v, "jpa", "mongo", "cassandra", "r2dbc", "multi")
+			}
+			p.Database = db
+			return nil
+		},
+		flag: func(p *ProjectProfile) *bool { return &p.DatabaseLocked },
+	},
+	"swagger_style": {
+		get: func(p *ProjectProfile) string { return string(p.SwaggerStyle) },
+		set: func(p *ProjectProfile, v string) error {
+			switch SwaggerStyle(v) {
+			case SwaggerOpenAPI3, SwaggerV2, SwaggerNone:
+				p.SwaggerStyle = SwaggerStyle(v)
+				p.HasSwagger = p.SwaggerStyle != SwaggerNone
+				return nil
+			}
+			return invalidValue("swagger_style", v, "openapi3", "swagger2", "none")
+		},
+	},
+	"validation_style": {
+		get: func(p *ProjectProfile) string { return string(p.ValidationStyle) },
+		set: func(p *ProjectProfile, v string) error {
+			switch ValidationStyle(v) {
+			case ValidationJakarta, ValidationJavax, ValidationNone:
+				p.ValidationStyle = ValidationStyle(v)
+				p.HasValidation = p.ValidationStyle != ValidationNone
+				return nil
+			}
+			return invalidValue("validation_style", v, "jakarta", "javax", "none")
+		},
+	},
+	"injection": {
+		get: func(p *ProjectProfile) string { return string(p.Conventions.Injection) },
+		set: func(p *ProjectProfile, v string) error {
+			switch InjectionStyle(v) {
+			case InjectionConstructor, InjectionLombok, InjectionField:
+				p.Conventions.Injection = InjectionStyle(v)
+				p.Conventions.InjectionConfidence = 1.0
+				return nil
+			}
+			return invalidValue("injection", v, "constructor", "lombok", "field")
+		},
+	},
+	"dto_style": {
+		get: func(p *ProjectProfile) string { return string(p.Conventions.DTOStyle) },
+		set: func(p *ProjectProfile, v string) error {
+			switch DTOStyle(v) {
+			case DTOStyleClass, DTOStyleRecord:
+				p.Conventions.DTOStyle = DTOStyle(v)
+				p.Conventions.DTOStyleConfidence = 1.0
+				return nil
+			}
+			return invalidValue("dto_style", v, "class", "record")
+		},
+	},
+	"lookup_style": {
+		get: func(p *ProjectProfile) string { return string(p.Conventions.Lookup) },
+		set: func(p *ProjectProfile, v string) error {
+			switch LookupStyle(v) {
+			case LookupException, LookupOptional:
+				p.Conventions.Lookup = LookupStyle(v)
+				p.Conventions.LookupConfidence = 1.0
+				return nil
+			}
+			return invalidValue("lookup_style", v, "exception", "optional")
+		},
+	},
+	"rest_prefix": {
+		get: func(p *ProjectProfile) string { return p.Conventions.RestPaths.Prefix },
+		set: func(p *ProjectProfile, v string) error {
+			if v != "" && (!strings.HasPrefix(v, "/") || strings.HasSuffix(v, "/") || strings.ContainsAny(v, " {}")) {
+				return fmt.Errorf("invalid rest_prefix '%s' (expected a path like /api/v1, or empty)", v)
+			}
+			paths := explicitRestPaths(p)
+			paths.Prefix = v
+			return nil
+		},
+	},
+	"rest_plural": {
+		get: func(p *ProjectProfile) string { return strconv.FormatBool(p.Conventions.RestPaths.Plural) },
+		set: func(p *ProjectProfile, v string) error {
+			return setBool(&explicitRestPaths(p).Plural, "rest_plural", v)
+		},
+	},
+	"rest_kebab_case": {
+		get: func(p *ProjectProfile) string { return strconv.FormatBool(p.Conventions.RestPaths.KebabCase) },
+		set: func(p *ProjectProfile, v string) error {
+			return setBool(&explicitRestPaths(p).KebabCase, "rest_kebab_case", v)
+		},
+	},
+	"final_locals": {
+		get: func(p *ProjectProfile) string { return strconv.FormatBool(p.Conventions.FinalLocals) },
+		set: func(p *ProjectProfile, v string) error {
+			if err := setBool(&p.Conventions.FinalLocals, "final_locals", v); err != nil {
+				return err
+			}
+			p.Conventions.FinalLocalsConfidence = 1.0
+			return nil
+		},
+	},
+	"test_naming": {
+		get: func(p *ProjectProfile) string { return string(p.Conventions.TestNaming) },
+		set: func(p *ProjectProfile, v string) error {
+			switch TestNamingStyle(v) {
+			case TestNamingShould, TestNamingSnakeCase, TestNamingGivenWhenThen, TestNamingTestPrefix:
+				p.Conventions.TestNaming = TestNamingStyle(v)
+				p.Conventions.TestNamingConfidence = 1.0
+				return nil
+			}
+			return invalidValue("test_naming", v, "should", "snake_case", "given_when_then", "test_prefix")
+		},
+	},
+}
+
+func ProfileFieldNames() []string {
+	names := make([]string, 0, len(profileFields))
+	for name := range profileFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupField(name string) (profileField, error) {
+	field, ok := profileFields[name]
+	if !ok {
+		return profileField{}, fmt.Errorf("unknown profile field '%s' (available: %s)", name, strings.Join(ProfileFieldNames(), ", "))
+	}
+	return field, nil
+}
+
+func (p *ProjectProfile) GetField(name string) (string, error) {
+	field, err := lookupField(name)
+	if err != nil {
+		return "", err
+	}
+	return field.get(p), nil
+}
+
+func (p *ProjectProfile) SetField(name, value string) error {
+	field, err := lookupField(name)
+	if err != nil {
+		return err
+	}
+	return field.set(p, value)
+}
+
+func (p *ProjectProfile) Lock(name string) error {
+	field, err := lookupField(name)
+	if err != nil {
+		return err
+	}
+	if field.flag != nil {
+		*field.flag(p) = true
+	}
+	p.LockField(name)
+	return nil
+}
+
+func (p *ProjectProfile) Unlock(name string) error {
+	field, err := lookupField(name)
+	if err != nil {
+		return err
+	}
+	if field.flag != nil {
+		*field.flag(p) = false
+	}
+	p.UnlockField(name)
+	return nil
+}
+
+func (p *ProjectProfile) IsLocked(name string) bool {
+	field, ok := profileFields[name]
+	if !ok {
+		return false
+	}
+	if field.flag != nil && *field.flag(p) {
+		return true
+	}
+	return p.IsFieldLocked(name)
+}
+
+func (p *ProjectProfile) PreserveLocked(previous *ProjectProfile) {
+	if previous == nil {
+		return
+	}
+
+	for _, name := range ProfileFieldNames() {
+		if !previous.IsLocked(name) {
+			continue
+		}
+		field := profileFields[name]
+		if err := field.set(p, field.get(previous)); err == nil {
+			preserveRelated(p, previous, name)
+		}
+		_ = p.Lock(name)
+	}
+}
+
+func preserveRelated(p, previous *ProjectProfile, name string) {
+	switch name {
+	case "architecture":
+		p.ArchConfidence = previous.ArchConfidence
+		p.FeatureModules = previous.FeatureModules
+	case "id_type":
+		p.IDAnnotation = previous.IDAnnotation
+	}
+}
+
+func setIdentifier(target *string, name, value string) error {
+	if value == "" || strings.ContainsAny(value, " .") {
+		return fmt.Errorf("invalid %s '%s'", name, value)
+	}
+	*target = value
+	return nil
+}
+
+func explicitRestPaths(p *ProjectProfile) *RestPathStyle {
+	paths := &p.Conventions.RestPaths
+	if paths.Confidence == 0 {
+		*paths = RestPathStyle{Prefix: "/api", Plural: true}
+	}
+	paths.Confidence = 1.0
+	return paths
+}
+
+func setBool(target *bool, name, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return invalidValue(name, value, "true", "false")
+	}
+	*target = parsed
+	return nil
+}
+
+func invalidValue(name, value string, allowed ...string) error {
+	return fmt.Errorf("invalid %s '%s' (allowed: %s)", name, value, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,143 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileSetField(t *testing.T) {
+	profile := NewEmptyProfile()
+
+	require.NoError(t, profile.SetField("architecture", "ports-and-adapters"))
+	assert.Equal(t, ArchHexagonal, profile.Architecture)
+	assert.Equal(t, 1.0, profile.ArchConfidence)
+
+	require.NoError(t, profile.SetField("id_type", "UUID"))
+	assert.Equal(t, "@GeneratedValue(strategy = GenerationType.UUID)", profile.IDAnnotation)
+
+	require.NoError(t, profile.SetField("swagger_style", "openapi3"))
+	assert.True(t, profile.HasSwagger)
+
+	value, err := profile.GetField("architecture")
+	require.NoError(t, err)
+	assert.Equal(t, "hexagonal", value)
+}
+
+func TestProfileSetConventionFields(t *testing.T) {
+	profile := NewEmptyProfile()
+
+	require.NoError(t, profile.SetField("injection", "field"))
+	assert.Equal(t, InjectionField, profile.Conventions.Injection)
+	assert.Equal(t, 1.0, profile.Conventions.InjectionConfidence)
+
+	require.NoError(t, profile.SetField("rest_prefix", "/api/v2"))
+	require.NoError(t, profile.SetField("rest_kebab_case", "true"))
+	assert.Equal(t, RestPathStyle{Prefix: "/api/v2", Plural: true, KebabCase: true, Confidence: 1.0}, profile.Conventions.RestPaths)
+
+	require.NoError(t, profile.SetField("final_locals", "true"))
+	assert.True(t, profile.Conventions.FinalLocals)
+
+	assert.Error(t, profile.SetField("rest_prefix", "api/"))
+	assert.Error(t, profile.SetField("rest_plural", "maybe"))
+	assert.Error(t, profile.SetField("test_naming", "camel"))
+}
+
+func TestProfileSetFieldInvalid(t *testing.T) {
+	profile := NewEmptyProfile()
+
+	err := profile.SetField("architecture", "onion")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowed: layered")
+
+	err = profile.SetField("mapper", "dozer")
+	require.Error(t, err)
+
+	err = profile.SetField("colour", "blue")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown profile field 'colour'")
+}
+
+func TestProfileLockAndUnlock(t *testing.T) {
+	profile := NewEmptyProfile()
+
+	require.NoError(t, profile.Lock("id_type"))
+	assert.True(t, profile.IDLocked)
+	assert.True(t, profile.IsLocked("id_type"))
+	assert.Equal(t, []string{"id_type"}, profile.LockedFields)
+
+	require.NoError(t, profile.Lock("controller_suffix"))
+	assert.True(t, profile.IsLocked("controller_suffix"))
+
+	require.NoError(t, profile.Unlock("id_type"))
+	assert.False(t, profile.IDLocked)
+	assert.False(t, profile.IsLocked("id_type"))
+	assert.Equal(t, []string{"controller_suffix"}, profile.LockedFields)
+}
+
+func TestProfileIsLockedHonoursLegacyFlags(t *testing.T) {
+	profile := NewEmptyProfile()
+	profile.ArchLocked = true
+
+	assert.True(t, profile.IsLocked("architecture"))
+	assert.False(t, profile.IsLocked("unknown"))
+}
+
+func TestProfilePreserveLocked(t *testing.T) {
+	previous := NewEmptyProfile()
+	require.NoError(t, previous.SetField("architecture", "hexagonal"))
+	require.NoError(t, previous.Lock("architecture"))
+	require.NoError(t, previous.SetField("mapper", "mapstruct"))
+
+	detected := NewEmptyProfile()
+	detected.Architecture = ArchLayered
+	detected.ArchConfidence = 0.6
+	detected.Mapper = MapperManual
+
+	detected.PreserveLocked(previous)
+
+	assert.Equal(t, ArchHexagonal, detected.Architecture)
+	assert.Equal(t, 1.0, detected.ArchConfidence)
+	assert.True(t, detected.ArchLocked)
+	assert.Equal(t, MapperManual, detected.Mapper)
+	assert.False(t, detected.IsLocked("mapper"))
+}
+
+func TestProfilePreserveLockedNil(t *testing.T) {
+	detected := NewEmptyProfile()
+	detected.PreserveLocked(nil)
+
+	assert.Empty(t, detected.LockedFields)
+}
+
+func TestDetectorExplainArchitecture(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"/project/src/main/java/com/example/app/controller/UserController.java": "package com.example.app.controller;\n\n@RestController\npublic class UserController {\n}",
+		"/project/src/main/java/com/example/app/service/UserService.java":       "package com.example.app.service;\n\n@Service\npublic class UserService {\n}",
+		"/project/src/main/java/com/example/app/repository/UserRepository.java": "package com.example.app.repository;\n\npublic interface UserRepository extends JpaRepository<User, Long> {\n}",
+		"/project/src/main/java/com/example/app/entity/User.java":               "package com.example.app.entity;\n\nimport java.util.UUID;\n\n@Entity\npublic class User {\n}",
+	}
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+
+	d := NewDetector("/project", WithFileSystem(fs))
+
+	explanation, err := d.Explain("architecture")
+	require.NoError(t, err)
+	assert.Equal(t, "layered", explanation.Result.Value)
+	require.Len(t, explanation.Scores, 6)
+	assert.Equal(t, ArchLayered, explanation.Scores[0].Architecture)
+	assert.Contains(t, explanation.Result.Evidence, "layer packages: controller, service, repository, entity")
+
+	explanation, err = d.Explain("id_type")
+	require.NoError(t, err)
+	assert.Equal(t, "UUID", explanation.Result.Value)
+	assert.Equal(t, []string{"User imports java.util.UUID"}, explanation.Result.Evidence)
+
+	_, err = d.Explain("colour")
+	assert.Error(t, err)
+}
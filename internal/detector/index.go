@@ -0,0 +1,160 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	IndexFile    = "index.json"
+	indexVersion = 2
+)
+
+type FileIndex struct {
+	Version int                   `json:"version"`
+	Files   map[string]IndexEntry `json:"files"`
+	changed bool
+}
+
+type IndexEntry struct {
+	Size    int64     `json:"size"`
+	ModTime int64     `json:"mod_time"`
+	Test    bool      `json:"test"`
+	File    *JavaFile `json:"file"`
+}
+
+type sourceFile struct {
+	path    string
+	rootDir string
+	isTest  bool
+	size    int64
+	modTime time.Time
+}
+
+func newFileIndex() *FileIndex {
+	return &FileIndex{Version: indexVersion, Files: make(map[string]IndexEntry)}
+}
+
+func (s *Scanner) indexPath() string {
+	return filepath.Join(s.projectDir, CacheDir, IndexFile)
+}
+
+func (s *Scanner) loadIndex() *FileIndex {
+	if !s.useIndex {
+		return newFileIndex()
+	}
+
+	data, err := afero.ReadFile(s.fs, s.indexPath())
+	if err != nil {
+		return newFileIndex()
+	}
+
+	var index FileIndex
+	if err := json.Unmarshal(data, &index); err != nil || index.Version != indexVersion || index.Files == nil {
+		return newFileIndex()
+	}
+	return &index
+}
+
+func (s *Scanner) saveIndex(index *FileIndex) error {
+	if err := s.fs.MkdirAll(filepath.Join(s.projectDir, CacheDir), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	if err := afero.WriteFile(s.fs, s.indexPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return nil
+}
+
+func (s *Scanner) relativePath(path string) string {
+	rel, err := filepath.Rel(s.projectDir, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (s *Scanner) parseFiles(files []sourceFile, index *FileIndex) ([]*JavaFile, ScanStats) {
+	parsed := make([]*JavaFile, len(files))
+	stats := ScanStats{Files: len(files)}
+
+	var pending []int
+	for i, file := range files {
+		entry, ok := index.Files[s.relativePath(file.path)]
+		if ok && entry.File != nil && entry.Size == file.size && entry.ModTime == file.modTime.UnixNano() && entry.Test == file.isTest {
+			reused := *entry.File
+			reused.Path = file.path
+			parsed[i] = &reused
+			stats.Reused++
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	stats.Parsed = len(pending)
+	stats.Workers = min(s.workers, len(pending))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < stats.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				parsed[i] = s.parseFile(files[i])
+			}
+		}()
+	}
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	entries := make(map[string]IndexEntry, len(files))
+	for i, file := range files {
+		if parsed[i] == nil {
+			continue
+		}
+		entries[s.relativePath(file.path)] = IndexEntry{
+			Size:    file.size,
+			ModTime: file.modTime.UnixNano(),
+			Test:    file.isTest,
+			File:    parsed[i],
+		}
+	}
+	index.changed = len(pending) > 0 || len(entries) != len(index.Files)
+	index.Files = entries
+
+	return parsed, stats
+}
+
+func compactFiles(files []*JavaFile) []*JavaFile {
+	var result []*JavaFile
+	for _, file := range files {
+		if file != nil {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+func (s *Scanner) sourceChecksum(files []sourceFile) string {
+	entries := make([]string, 0, len(files))
+	for _, file := range files {
+		relPath, _ := filepath.Rel(s.projectDir, file.path)
+		entries = append(entries, checksumEntry(relPath, file.size, file.modTime))
+	}
+	return checksumOf(entries)
+}
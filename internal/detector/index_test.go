@@ -0,0 +1,114 @@
+package detector
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func indexedProjectFS(t *testing.T, count int) afero.Fs {
+	fs := afero.NewMemMapFs()
+	for i := 0; i < count; i++ {
+		path := fmt.Sprintf("/project/src/main/java/com/example/app/feature%d/Feature%dService.java", i, i)
+		content := fmt.Sprintf("package com.example.app.feature%d;\n\n@Service\npublic class Feature%dService {\n}", i, i)
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	require.NoError(t, afero.WriteFile(fs, "/project/src/test/java/com/example/app/AppTest.java", []byte("package com.example.app;\n\nclass AppTest {\n    @Test\n    void shouldStart() {\n    }\n}"), 0644))
+	return fs
+}
+
+func TestScannerIndexReusesUnchangedFiles(t *testing.T) {
+	fs := indexedProjectFS(t, 5)
+	scanner := NewScanner(fs, "/project")
+
+	first, err := scanner.Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 6, first.Stats.Files)
+	assert.Equal(t, 6, first.Stats.Parsed)
+	assert.Equal(t, 0, first.Stats.Reused)
+	require.NoError(t, scanner.SaveIndex())
+
+	exists, err := afero.Exists(fs, filepath.Join("/project", CacheDir, IndexFile))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	second, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 0, second.Stats.Parsed)
+	assert.Equal(t, 6, second.Stats.Reused)
+	assert.Equal(t, first.SourceFiles, second.SourceFiles)
+	assert.Equal(t, []string{"shouldStart"}, second.TestFiles[0].TestMethods)
+
+	changed := "/project/src/main/java/com/example/app/feature1/Feature1Service.java"
+	require.NoError(t, afero.WriteFile(fs, changed, []byte("package com.example.app.feature1;\n\n@RestController\npublic class Feature1Controller {\n}"), 0644))
+	require.NoError(t, fs.Remove("/project/src/main/java/com/example/app/feature2/Feature2Service.java"))
+
+	third, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 5, third.Stats.Files)
+	assert.Equal(t, 1, third.Stats.Parsed)
+	assert.Equal(t, 4, third.Stats.Reused)
+	assert.Equal(t, "Feature1Controller", third.SourceFiles[1].ClassName)
+	assert.Equal(t, FileTypeController, third.SourceFiles[1].FileType)
+}
+
+func TestScannerScanDoesNotWriteIndex(t *testing.T) {
+	fs := indexedProjectFS(t, 2)
+
+	_, err := NewDetector("/project", WithFileSystem(fs)).Detect()
+	require.NoError(t, err)
+
+	exists, err := afero.Exists(fs, filepath.Join("/project", CacheDir))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestScannerIndexIgnoresCorruptIndex(t *testing.T) {
+	fs := indexedProjectFS(t, 2)
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("/project", CacheDir, IndexFile), []byte("{not json"), 0644))
+
+	result, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Stats.Parsed)
+	assert.Len(t, result.SourceFiles, 2)
+}
+
+func TestScannerParallelMatchesSerial(t *testing.T) {
+	fs := indexedProjectFS(t, 40)
+
+	serial := NewScanner(fs, "/project")
+	serial.SetIndexEnabled(false)
+	serial.SetWorkers(1)
+	expected, err := serial.Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expected.Stats.Workers)
+
+	parallel := NewScanner(fs, "/project")
+	parallel.SetIndexEnabled(false)
+	parallel.SetWorkers(8)
+	actual, err := parallel.Scan()
+	require.NoError(t, err)
+	assert.Equal(t, 8, actual.Stats.Workers)
+
+	assert.Equal(t, expected.SourceFiles, actual.SourceFiles)
+	assert.Equal(t, expected.BasePackage, actual.BasePackage)
+
+	exists, err := afero.Exists(fs, filepath.Join("/project", CacheDir, IndexFile))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestScannerSourceChecksumMatchesProfileCache(t *testing.T) {
+	fs := indexedProjectFS(t, 3)
+
+	result, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+
+	checksum, err := NewProfileCacheWithFs(fs, "/project").computeSourceChecksum()
+	require.NoError(t, err)
+	assert.Equal(t, checksum, result.SourceChecksum)
+}
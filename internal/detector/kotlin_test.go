@@ -0,0 +1,181 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScannerParseKotlinFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		className   string
+		fileType    JavaFileType
+		annotations []string
+		extends     string
+		implements  []string
+		isInterface bool
+		isAbstract  bool
+	}{
+		{
+			name: "data class entity with multiline constructor",
+			content: `package com.example.app.user.entity
+
+import jakarta.persistence.Entity
+import jakarta.persistence.Id
+import java.util.UUID
+
+@Entity
+@Table(name = "users")
+data class User(
+    @Id val id: UUID,
+    val name: String,
+) : BaseEntity<UUID>(), Auditable
+`,
+			className:   "User",
+			fileType:    FileTypeEntity,
+			annotations: []string{"Entity", "Table"},
+			extends:     "BaseEntity",
+			implements:  []string{"Auditable"},
+		},
+		{
+			name: "repository interface",
+			content: `package com.example.app.user.repository
+
+interface UserRepository : JpaRepository<User, Long>, CustomUserRepository {
+    fun findByName(name: String): User?
+}
+`,
+			className:   "UserRepository",
+			fileType:    FileTypeRepository,
+			implements:  []string{"JpaRepository", "CustomUserRepository"},
+			isInterface: true,
+		},
+		{
+			name: "annotations on declaration line",
+			content: `package com.example.app.user.controller
+
+@RestController @RequestMapping("/users") class UserController(private val service: UserService) {
+}
+`,
+			className:   "UserController",
+			fileType:    FileTypeController,
+			annotations: []string{"RestController", "RequestMapping"},
+		},
+		{
+			name: "abstract class with type parameters",
+			content: `package com.example.app.common
+
+/*
+ * class Ignored
+ */
+@MappedSuperclass
+abstract class BaseEntity<ID : Serializable> {
+}
+`,
+			className:   "BaseEntity",
+			annotations: []string{"MappedSuperclass"},
+			isAbstract:  true,
+			fileType:    FileTypeEntity,
+		},
+		{
+			name: "qualified annotation and constructor keyword",
+			content: `package com.example.app.user.service
+
+@org.springframework.stereotype.Service
+class UserService @Autowired constructor(
+    private val repository: UserRepository
+) : UserUseCase {
+}
+`,
+			className:   "UserService",
+			fileType:    FileTypeService,
+			annotations: []string{"Service"},
+			implements:  []string{"UserUseCase"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := createTestFS()
+			path := "/project/src/main/kotlin/File.kt"
+			require.NoError(t, createJavaFile(fs, path, tt.content))
+
+			jf, err := NewScanner(fs, "/project").parseSourceFile(path, false)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.className, jf.ClassName)
+			assert.Equal(t, tt.fileType, jf.FileType)
+			assert.Equal(t, tt.annotations, jf.Annotations)
+			assert.Equal(t, tt.extends, jf.ExtendsClass)
+			assert.Equal(t, tt.implements, jf.ImplementsInterfaces)
+			assert.Equal(t, tt.isInterface, jf.IsInterface)
+			assert.Equal(t, tt.isAbstract, jf.IsAbstract)
+		})
+	}
+}
+
+func TestScannerParseKotlinFilePackageAndImports(t *testing.T) {
+	fs := createTestFS()
+	path := "/project/src/main/kotlin/com/example/Application.kt"
+	content := "@file:JvmName(\"App\")\npackage com.example.`demo`\n\nimport org.springframework.boot.runApplication\nimport java.util.UUID as Id\n\n@SpringBootApplication\nclass Application\n\nfun main(args: Array<String>) {\n    runApplication<Application>(*args)\n}\n"
+	require.NoError(t, createJavaFile(fs, path, content))
+
+	jf, err := NewScanner(fs, "/project").parseSourceFile(path, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "com.example.demo", jf.Package)
+	assert.Equal(t, []string{"org.springframework.boot.runApplication", "java.util.UUID"}, jf.Imports)
+	assert.Equal(t, []int{4, 5}, jf.ImportLines)
+	assert.Equal(t, []string{"SpringBootApplication"}, jf.Annotations)
+	assert.Equal(t, "Application", jf.ClassName)
+}
+
+func TestScannerScanKotlinProject(t *testing.T) {
+	fs := createTestFS()
+	files := map[string]string{
+		"/project/src/main/kotlin/com/example/app/controller/UserController.kt": "package com.example.app.controller\n\n@RestController\nclass UserController\n",
+		"/project/src/main/kotlin/com/example/app/service/UserService.kt":       "package com.example.app.service\n\n@Service\nclass UserService\n",
+		"/project/src/main/kotlin/com/example/app/entity/User.kt":               "package com.example.app.entity\n\nimport java.util.UUID\n\n@Entity\nclass User(val id: UUID)\n",
+		"/project/src/main/kotlin/com/example/app/repository/UserRepository.kt": "package com.example.app.repository\n\ninterface UserRepository : JpaRepository<User, UUID>\n",
+		"/project/src/test/kotlin/com/example/app/service/UserServiceTest.kt":   "package com.example.app.service\n\nimport io.mockk.mockk\n\nclass UserServiceTest\n",
+		"/project/build.gradle.kts":                                             "",
+	}
+	for path, content := range files {
+		require.NoError(t, createJavaFile(fs, path, content))
+	}
+
+	result, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+
+	assert.Len(t, result.SourceFiles, 4)
+	assert.Len(t, result.TestFiles, 1)
+	assert.Equal(t, "com.example.app", result.BasePackage)
+	assert.Equal(t, KotlinSourceRoot, result.SourceRoot)
+	assert.Equal(t, KotlinTestRoot, result.TestRoot)
+
+	profile, err := NewDetector("/project", WithFileSystem(fs)).Detect()
+	require.NoError(t, err)
+	assert.Equal(t, ArchLayered, profile.Architecture)
+	assert.Equal(t, "UUID", profile.IDType)
+}
+
+func TestScannerScanMixedProject(t *testing.T) {
+	fs := createTestFS()
+	files := map[string]string{
+		"/project/src/main/java/com/example/app/user/UserController.java": "package com.example.app.user;\n\n@RestController\npublic class UserController {\n}",
+		"/project/src/main/kotlin/com/example/app/user/UserService.kt":    "package com.example.app.user\n\n@Service\nclass UserService\n",
+	}
+	for path, content := range files {
+		require.NoError(t, createJavaFile(fs, path, content))
+	}
+
+	result, err := NewScanner(fs, "/project").Scan()
+	require.NoError(t, err)
+
+	assert.Len(t, result.SourceFiles, 2)
+	assert.Equal(t, "src/main/java", result.SourceRoot)
+	assert.Equal(t, "com.example.app.user", result.BasePackage)
+}
@@ -46,6 +46,8 @@ type ProjectProfile struct {
 
 	Testing TestProfile `json:"testing"`
 
+	Conventions ConventionProfile `json:"conventions"`
+
 	Database       DatabaseType `json:"database"`
 	DatabaseLocked bool         `json:"database_locked"`
 
@@ -1,31 +1,50 @@
 package detector
 
 import (
-	"bufio"
 	"os"
 	"path/filepath"
-	"regexp"
+	"runtime"
 	"strings"
+	"time"
 
+	"github.com/KashifKhn/haft/internal/source"
 	"github.com/spf13/afero"
 )
 
+const (
+	KotlinSourceRoot = "src/main/kotlin"
+	KotlinTestRoot   = "src/test/kotlin"
+)
+
 type Scanner struct {
 	fs         afero.Fs
 	projectDir string
 	sourceRoot string
 	testRoot   string
+	workers    int
+	useIndex   bool
+	index      *FileIndex
 }
 
 type ScanResult struct {
-	SourceFiles []*JavaFile
-	TestFiles   []*JavaFile
-	BasePackage string
-	SourceRoot  string
-	TestRoot    string
-	BuildTool   string
-	HasGradle   bool
-	HasMaven    bool
+	SourceFiles    []*JavaFile
+	TestFiles      []*JavaFile
+	BasePackage    string
+	SourceRoot     string
+	TestRoot       string
+	BuildTool      string
+	HasGradle      bool
+	HasMaven       bool
+	SourceChecksum string
+	Stats          ScanStats
+}
+
+type ScanStats struct {
+	Files    int
+	Parsed   int
+	Reused   int
+	Workers  int
+	Duration time.Duration
 }
 
 func NewScanner(fs afero.Fs, projectDir string) *Scanner {
@@ -34,10 +53,34 @@ func NewScanner(fs afero.Fs, projectDir string) *Scanner {
 		projectDir: projectDir,
 		sourceRoot: "src/main/java",
 		testRoot:   "src/test/java",
+		workers:    runtime.GOMAXPROCS(0),
+		useIndex:   true,
+	}
+}
+
+func (s *Scanner) SetWorkers(workers int) {
+	if workers > 0 {
+		s.workers = workers
+	}
+}
+
+func (s *Scanner) SetIndexEnabled(enabled bool) {
+	s.useIndex = enabled
+}
+
+func (s *Scanner) SaveIndex() error {
+	if !s.useIndex || s.index == nil || !s.index.changed {
+		return nil
+	}
+	if err := s.saveIndex(s.index); err != nil {
+		return err
 	}
+	s.index.changed = false
+	return nil
 }
 
 func (s *Scanner) Scan() (*ScanResult, error) {
+	start := time.Now()
 	result := &ScanResult{
 		SourceRoot: s.sourceRoot,
 		TestRoot:   s.testRoot,
@@ -45,29 +88,63 @@ func (s *Scanner) Scan() (*ScanResult, error) {
 
 	s.detectBuildTool(result)
 
-	sourcePath := filepath.Join(s.projectDir, s.sourceRoot)
-	if exists, _ := afero.DirExists(s.fs, sourcePath); exists {
-		files, err := s.scanDirectory(sourcePath, false)
-		if err != nil {
-			return nil, err
-		}
-		result.SourceFiles = files
+	sources, sourceRoot, err := s.collectRoots(s.sourceRoot, KotlinSourceRoot, false)
+	if err != nil {
+		return nil, err
 	}
+	result.SourceRoot = sourceRoot
 
-	testPath := filepath.Join(s.projectDir, s.testRoot)
-	if exists, _ := afero.DirExists(s.fs, testPath); exists {
-		files, err := s.scanDirectory(testPath, true)
-		if err != nil {
-			return nil, err
-		}
-		result.TestFiles = files
+	tests, testRoot, err := s.collectRoots(s.testRoot, KotlinTestRoot, true)
+	if err != nil {
+		return nil, err
+	}
+	result.TestRoot = testRoot
+
+	if len(sources) > 0 {
+		result.SourceChecksum = s.sourceChecksum(sources)
 	}
 
+	index := s.loadIndex()
+	files, stats := s.parseFiles(append(sources, tests...), index)
+	result.SourceFiles = compactFiles(files[:len(sources)])
+	result.TestFiles = compactFiles(files[len(sources):])
+
+	s.index = index
+
 	result.BasePackage = s.detectBasePackage(result.SourceFiles)
 
+	stats.Duration = time.Since(start)
+	result.Stats = stats
+
 	return result, nil
 }
 
+func (s *Scanner) collectRoots(javaRoot, kotlinRoot string, isTest bool) ([]sourceFile, string, error) {
+	var files []sourceFile
+	root := javaRoot
+	javaExists := false
+
+	for _, candidate := range []string{javaRoot, kotlinRoot} {
+		path := filepath.Join(s.projectDir, candidate)
+		if exists, _ := afero.DirExists(s.fs, path); !exists {
+			continue
+		}
+		if candidate == javaRoot {
+			javaExists = true
+		} else if !javaExists {
+			root = kotlinRoot
+		}
+
+		found, err := s.collectDirectory(path, isTest)
+		if err != nil {
+			return nil, "", err
+		}
+		files = append(files, found...)
+	}
+
+	return files, root, nil
+}
+
 func (s *Scanner) detectBuildTool(result *ScanResult) {
 	pomPath := filepath.Join(s.projectDir, "pom.xml")
 	if exists, _ := afero.Exists(s.fs, pomPath); exists {
@@ -86,8 +163,8 @@ func (s *Scanner) detectBuildTool(result *ScanResult) {
 	}
 }
 
-func (s *Scanner) scanDirectory(dir string, isTest bool) ([]*JavaFile, error) {
-	var files []*JavaFile
+func (s *Scanner) collectDirectory(dir string, isTest bool) ([]sourceFile, error) {
+	var files []sourceFile
 
 	err := afero.Walk(s.fs, dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -98,28 +175,35 @@ func (s *Scanner) scanDirectory(dir string, isTest bool) ([]*JavaFile, error) {
 			return nil
 		}
 
-		if !strings.HasSuffix(path, ".java") {
-			return nil
-		}
-
-		javaFile, err := s.parseJavaFile(path, dir, isTest)
-		if err != nil {
-			return nil
+		switch filepath.Ext(path) {
+		case ".java", ".kt":
+			files = append(files, sourceFile{
+				path:    path,
+				rootDir: dir,
+				isTest:  isTest,
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			})
 		}
-
-		files = append(files, javaFile)
 		return nil
 	})
 
 	return files, err
 }
 
-func (s *Scanner) parseJavaFile(path, rootDir string, isTest bool) (*JavaFile, error) {
-	file, err := s.fs.Open(path)
+func (s *Scanner) parseFile(file sourceFile) *JavaFile {
+	javaFile, err := s.parseSourceFile(file.path, file.isTest)
+	if err != nil {
+		return nil
+	}
+	return javaFile
+}
+
+func (s *Scanner) parseSourceFile(path string, isTest bool) (*JavaFile, error) {
+	content, err := afero.ReadFile(s.fs, path)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = file.Close() }()
 
 	jf := &JavaFile{
 		Path:     path,
@@ -130,63 +214,40 @@ func (s *Scanner) parseJavaFile(path, rootDir string, isTest bool) (*JavaFile, e
 		jf.FileType = FileTypeTest
 	}
 
-	scanner := bufio.NewScanner(file)
-	inBlockComment := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if strings.HasPrefix(line, "/*") {
-			inBlockComment = true
-		}
-		if strings.Contains(line, "*/") {
-			inBlockComment = false
-			continue
-		}
-		if inBlockComment || strings.HasPrefix(line, "//") {
-			continue
-		}
-
-		if strings.HasPrefix(line, "package ") {
-			jf.Package = extractPackage(line)
-			continue
-		}
-
-		if strings.HasPrefix(line, "import ") {
-			jf.Imports = append(jf.Imports, extractImport(line))
-			continue
-		}
-
-		if strings.HasPrefix(line, "@") {
-			annotation := extractAnnotation(line)
-			if annotation != "" {
-				jf.Annotations = append(jf.Annotations, annotation)
-			}
-			continue
-		}
-
-		if isClassDeclaration(line) {
-			parseClassDeclaration(line, jf)
-			break
-		}
-
-		if isInterfaceDeclaration(line) {
-			jf.IsInterface = true
-			jf.ClassName = extractInterfaceName(line)
-			parseInterfaceExtends(line, jf)
-			break
-		}
+	parsed := source.Parse(string(content), source.LanguageFor(path))
+	jf.Package = parsed.Package
+	for _, imp := range parsed.Imports {
+		jf.Imports = append(jf.Imports, imp.Path)
+		jf.ImportLines = append(jf.ImportLines, imp.Line)
 	}
 
-	if jf.ClassName == "" {
+	if typ := parsed.PrimaryType(path); typ != nil {
+		applyType(jf, typ)
+	} else {
 		jf.ClassName = extractClassNameFromPath(path)
 	}
 
 	if !isTest {
 		jf.FileType = classifyJavaFile(jf)
 	}
+	collectConventions(jf, string(content))
+
+	return jf, nil
+}
 
-	return jf, scanner.Err()
+func applyType(jf *JavaFile, typ *source.Type) {
+	jf.ClassName = typ.Name
+	jf.Annotations = typ.Annotations.Names()
+	jf.IsInterface = typ.Kind == source.KindInterface || typ.Kind == source.KindAnnotation
+	jf.IsRecord = typ.Kind == source.KindRecord
+	jf.IsAbstract = typ.Modifiers.Has("abstract") || (isKotlinFile(jf) && typ.Modifiers.Has("sealed"))
+
+	if typ.Extends != "" {
+		jf.ExtendsClass = source.SimpleName(typ.Extends)
+	}
+	for _, iface := range typ.Interfaces {
+		jf.ImplementsInterfaces = append(jf.ImplementsInterfaces, source.SimpleName(iface))
+	}
 }
 
 func (s *Scanner) detectBasePackage(files []*JavaFile) string {
@@ -272,102 +333,9 @@ func commonPrefix(a, b string) string {
 	return strings.Join(common, ".")
 }
 
-var (
-	packageRegex     = regexp.MustCompile(`^package\s+([a-zA-Z0-9_.]+)\s*;`)
-	importRegex      = regexp.MustCompile(`^import\s+(?:static\s+)?([a-zA-Z0-9_.]+)\s*;`)
-	annotationRegex  = regexp.MustCompile(`^@(\w+)`)
-	classRegex       = regexp.MustCompile(`(?:public\s+)?(?:abstract\s+)?class\s+(\w+)`)
-	extendsRegex     = regexp.MustCompile(`extends\s+(\w+)`)
-	implementsRegex  = regexp.MustCompile(`implements\s+([\w\s,<>]+)`)
-	interfaceRegex   = regexp.MustCompile(`(?:public\s+)?interface\s+(\w+)`)
-	interfaceExtends = regexp.MustCompile(`extends\s+([\w\s,<>]+)`)
-)
-
-func extractPackage(line string) string {
-	matches := packageRegex.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-	return ""
-}
-
-func extractImport(line string) string {
-	matches := importRegex.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-	return ""
-}
-
-func extractAnnotation(line string) string {
-	matches := annotationRegex.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-	return ""
-}
-
-func isClassDeclaration(line string) bool {
-	return strings.Contains(line, "class ") && !strings.HasPrefix(line, "//")
-}
-
-func isInterfaceDeclaration(line string) bool {
-	return strings.Contains(line, "interface ") && !strings.HasPrefix(line, "//")
-}
-
-func parseClassDeclaration(line string, jf *JavaFile) {
-	if strings.Contains(line, "abstract ") {
-		jf.IsAbstract = true
-	}
-
-	classMatches := classRegex.FindStringSubmatch(line)
-	if len(classMatches) > 1 {
-		jf.ClassName = classMatches[1]
-	}
-
-	extendsMatches := extendsRegex.FindStringSubmatch(line)
-	if len(extendsMatches) > 1 {
-		jf.ExtendsClass = extendsMatches[1]
-	}
-
-	implementsMatches := implementsRegex.FindStringSubmatch(line)
-	if len(implementsMatches) > 1 {
-		interfaces := strings.Split(implementsMatches[1], ",")
-		for _, iface := range interfaces {
-			iface = strings.TrimSpace(iface)
-			iface = strings.Split(iface, "<")[0]
-			if iface != "" {
-				jf.ImplementsInterfaces = append(jf.ImplementsInterfaces, iface)
-			}
-		}
-	}
-}
-
-func extractInterfaceName(line string) string {
-	matches := interfaceRegex.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		return matches[1]
-	}
-	return ""
-}
-
-func parseInterfaceExtends(line string, jf *JavaFile) {
-	matches := interfaceExtends.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		interfaces := strings.Split(matches[1], ",")
-		for _, iface := range interfaces {
-			iface = strings.TrimSpace(iface)
-			iface = strings.Split(iface, "<")[0]
-			if iface != "" {
-				jf.ImplementsInterfaces = append(jf.ImplementsInterfaces, iface)
-			}
-		}
-	}
-}
-
 func extractClassNameFromPath(path string) string {
 	base := filepath.Base(path)
-	return strings.TrimSuffix(base, ".java")
+	return strings.TrimSuffix(base, filepath.Ext(base))
 }
 
 func classifyJavaFile(jf *JavaFile) JavaFileType {
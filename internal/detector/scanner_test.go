@@ -107,7 +107,7 @@ public class MyClass {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.Equal(t, "com.example.app", jf.Package)
@@ -184,7 +184,7 @@ public interface UserMapper {
 			require.NoError(t, createJavaFile(fs, path, tt.content))
 
 			scanner := NewScanner(fs, "/project")
-			jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+			jf, err := scanner.parseSourceFile(path, false)
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.expectedAnn, jf.Annotations)
@@ -204,7 +204,7 @@ public class User extends BaseEntity implements Auditable, Serializable {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.Equal(t, "User", jf.ClassName)
@@ -224,7 +224,7 @@ public abstract class BaseEntity {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.True(t, jf.IsAbstract)
@@ -242,7 +242,7 @@ public interface UserRepository extends JpaRepository<User, Long> {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.True(t, jf.IsInterface)
@@ -267,13 +267,14 @@ public class MyService {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.Contains(t, jf.Imports, "java.util.List")
 	assert.Contains(t, jf.Imports, "java.util.UUID")
 	assert.Contains(t, jf.Imports, "org.springframework.stereotype.Service")
 	assert.Contains(t, jf.Imports, "org.junit.Assert.assertEquals")
+	assert.Equal(t, []int{3, 4, 5, 6}, jf.ImportLines)
 }
 
 func TestScannerParseJavaFileWithComments(t *testing.T) {
@@ -295,7 +296,7 @@ public class MyService {
 	require.NoError(t, createJavaFile(fs, path, content))
 
 	scanner := NewScanner(fs, "/project")
-	jf, err := scanner.parseJavaFile(path, "/project/src/main/java", false)
+	jf, err := scanner.parseSourceFile(path, false)
 
 	require.NoError(t, err)
 	assert.Equal(t, "MyService", jf.ClassName)
@@ -532,62 +533,3 @@ func TestScannerEmptyProject(t *testing.T) {
 	assert.Equal(t, 0, len(result.SourceFiles))
 	assert.Equal(t, "", result.BasePackage)
 }
-
-func TestExtractPackageRegex(t *testing.T) {
-	tests := []struct {
-		line     string
-		expected string
-	}{
-		{"package com.example.app;", "com.example.app"},
-		{"package com.example;", "com.example"},
-		{"package myapp;", "myapp"},
-		{"  package com.example.app;  ", ""},
-		{"", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.line, func(t *testing.T) {
-			result := extractPackage(tt.line)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestExtractImportRegex(t *testing.T) {
-	tests := []struct {
-		line     string
-		expected string
-	}{
-		{"import java.util.List;", "java.util.List"},
-		{"import static org.junit.Assert.assertEquals;", "org.junit.Assert.assertEquals"},
-		{"import com.example.MyClass;", "com.example.MyClass"},
-		{"", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.line, func(t *testing.T) {
-			result := extractImport(tt.line)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestExtractAnnotationRegex(t *testing.T) {
-	tests := []struct {
-		line     string
-		expected string
-	}{
-		{"@Service", "Service"},
-		{"@RestController", "RestController"},
-		{"@RequestMapping(\"/api\")", "RequestMapping"},
-		{"@Entity(name = \"user\")", "Entity"},
-		{"", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.line, func(t *testing.T) {
-			result := extractAnnotation(tt.line)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
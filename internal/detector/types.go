@@ -64,6 +64,37 @@ const (
 	ValidationNone    ValidationStyle = "none"
 )
 
+type InjectionStyle string
+
+const (
+	InjectionConstructor InjectionStyle = "constructor"
+	InjectionLombok      InjectionStyle = "lombok"
+	InjectionField       InjectionStyle = "field"
+)
+
+type DTOStyle string
+
+const (
+	DTOStyleClass  DTOStyle = "class"
+	DTOStyleRecord DTOStyle = "record"
+)
+
+type LookupStyle string
+
+const (
+	LookupException LookupStyle = "exception"
+	LookupOptional  LookupStyle = "optional"
+)
+
+type TestNamingStyle string
+
+const (
+	TestNamingShould        TestNamingStyle = "should"
+	TestNamingSnakeCase     TestNamingStyle = "snake_case"
+	TestNamingGivenWhenThen TestNamingStyle = "given_when_then"
+	TestNamingTestPrefix    TestNamingStyle = "test_prefix"
+)
+
 type JavaFileType string
 
 const (
@@ -133,6 +164,27 @@ type TestProfile struct {
 	StructureMirror   bool   `json:"structure_mirror"`
 }
 
+type RestPathStyle struct {
+	Prefix     string  `json:"prefix"`
+	Plural     bool    `json:"plural"`
+	KebabCase  bool    `json:"kebab_case"`
+	Confidence float64 `json:"confidence"`
+}
+
+type ConventionProfile struct {
+	Injection             InjectionStyle  `json:"injection,omitempty"`
+	InjectionConfidence   float64         `json:"injection_confidence"`
+	DTOStyle              DTOStyle        `json:"dto_style,omitempty"`
+	DTOStyleConfidence    float64         `json:"dto_style_confidence"`
+	Lookup                LookupStyle     `json:"lookup,omitempty"`
+	LookupConfidence      float64         `json:"lookup_confidence"`
+	RestPaths             RestPathStyle   `json:"rest_paths"`
+	FinalLocals           bool            `json:"final_locals"`
+	FinalLocalsConfidence float64         `json:"final_locals_confidence"`
+	TestNaming            TestNamingStyle `json:"test_naming,omitempty"`
+	TestNamingConfidence  float64         `json:"test_naming_confidence"`
+}
+
 type JavaFile struct {
 	Path                 string
 	Package              string
@@ -142,8 +194,16 @@ type JavaFile struct {
 	ExtendsClass         string
 	ImplementsInterfaces []string
 	Imports              []string
+	ImportLines          []int
 	IsAbstract           bool
 	IsInterface          bool
+	IsRecord             bool
+	Injection            InjectionStyle
+	LookupStyles         []LookupStyle
+	RequestMapping       string
+	FinalLocals          int
+	PlainLocals          int
+	TestMethods          []string
 }
 
 type DetectionResult struct {
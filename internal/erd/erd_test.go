@@ -0,0 +1,285 @@
+package erd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseEntitySource = `package com.shop.common;
+
+import jakarta.persistence.*;
+import java.time.Instant;
+
+@MappedSuperclass
+public abstract class BaseEntity {
+    @Id
+    @GeneratedValue(strategy = GenerationType.IDENTITY)
+    private Long id;
+
+    @Column(name = "created_at", nullable = false, updatable = false)
+    private Instant createdAt;
+
+    public Long getId() {
+        return id;
+    }
+}`
+
+const customerSource = `package com.shop.customer;
+
+import jakarta.persistence.*;
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * A customer of the shop; private String ignored;
+ */
+@Entity
+@Table(name = "customers")
+public class Customer extends BaseEntity {
+    @Column(name = "email_address", unique = true)
+    private String email;
+
+    private String fullName;
+
+    // private String commented;
+    @OneToMany(mappedBy = "customer", cascade = CascadeType.ALL)
+    private List<Order> orders = new ArrayList<>();
+
+    @Transient
+    private String displayName;
+
+    private static final long serialVersionUID = 1L;
+
+    public String getEmail() {
+        return email;
+    }
+}`
+
+const orderSource = `package com.shop.order;
+
+import jakarta.persistence.*;
+import java.math.BigDecimal;
+
+@Entity(name = "PurchaseOrder")
+public class Order extends BaseEntity {
+    @ManyToOne(fetch = FetchType.LAZY, optional = false)
+    @JoinColumn(name = "customer_ref")
+    private Customer customer;
+
+    @ManyToOne
+    private Customer referrer;
+
+    private BigDecimal totalAmount;
+}`
+
+const productSource = `package com.shop.catalog;
+
+import org.springframework.data.annotation.Id;
+import org.springframework.data.mongodb.core.mapping.*;
+
+@Document(collection = "products")
+public class Product {
+    @Id
+    private String id;
+
+    @Field("product_name")
+    private String name;
+
+    private Double price;
+}`
+
+const invoiceSource = `package com.shop.billing
+
+import jakarta.persistence.*
+
+@Entity
+@Table(name = "invoices")
+class Invoice(
+    @Id @GeneratedValue
+    val id: Long? = null,
+
+    @Column(name = "invoice_no")
+    val number: String,
+
+    @ManyToOne
+    @JoinColumn(name = "order_id")
+    val order: Order? = null,
+) {
+    @Transient
+    var cachedTotal: Int = 0
+
+    val status: String = "DRAFT"
+}`
+
+func sampleDiagram() *Diagram {
+	return Build([]Source{
+		{Path: "src/main/java/com/shop/common/BaseEntity.java", Content: baseEntitySource},
+		{Path: "src/main/java/com/shop/customer/Customer.java", Content: customerSource},
+		{Path: "src/main/java/com/shop/order/Order.java", Content: orderSource},
+		{Path: "src/main/java/com/shop/catalog/Product.java", Content: productSource},
+		{Path: "src/main/kotlin/com/shop/billing/Invoice.kt", Content: invoiceSource},
+		{Path: "src/main/java/com/shop/customer/CustomerService.java", Content: "package com.shop.customer;\n\n@Service\npublic class CustomerService {\n    private final CustomerRepository repository;\n}"},
+	})
+}
+
+func findEntity(t *testing.T, d *Diagram, name string) Entity {
+	for _, entity := range d.Entities {
+		if entity.Name == name {
+			return entity
+		}
+	}
+	t.Fatalf("entity %s not found", name)
+	return Entity{}
+}
+
+func columnNames(entity Entity) []string {
+	var names []string
+	for _, column := range entity.Columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+func TestBuildEntities(t *testing.T) {
+	d := sampleDiagram()
+
+	var names []string
+	for _, entity := range d.Entities {
+		names = append(names, entity.Name)
+	}
+	assert.Equal(t, []string{"Customer", "Invoice", "Order", "Product"}, names)
+}
+
+func TestBuildTableAndColumnNames(t *testing.T) {
+	d := sampleDiagram()
+
+	customer := findEntity(t, d, "Customer")
+	assert.Equal(t, "customers", customer.Table)
+	assert.Equal(t, KindEntity, customer.Kind)
+	assert.Equal(t, "BaseEntity", customer.Extends)
+	assert.Equal(t, []string{"id", "created_at", "email_address", "full_name"}, columnNames(customer))
+	assert.True(t, customer.Columns[2].Unique)
+
+	order := findEntity(t, d, "Order")
+	assert.Equal(t, "purchase_order", order.Table)
+}
+
+func TestBuildInheritedFields(t *testing.T) {
+	customer := findEntity(t, sampleDiagram(), "Customer")
+
+	id := customer.Columns[0]
+	assert.True(t, id.PrimaryKey)
+	assert.True(t, id.Inherited)
+	assert.Equal(t, "Long", id.Type)
+	assert.True(t, customer.Columns[1].Inherited)
+	assert.False(t, customer.Columns[2].Inherited)
+}
+
+func TestBuildForeignKeys(t *testing.T) {
+	order := findEntity(t, sampleDiagram(), "Order")
+
+	assert.Equal(t, []string{"id", "created_at", "customer_ref", "referrer_id", "total_amount"}, columnNames(order))
+	fk := order.Columns[2]
+	assert.True(t, fk.ForeignKey)
+	assert.Equal(t, "customer", fk.Field)
+	assert.Equal(t, "Long", fk.Type)
+}
+
+func TestBuildRelations(t *testing.T) {
+	d := sampleDiagram()
+
+	var keys []string
+	for _, r := range d.Relations {
+		keys = append(keys, r.From+"."+r.Field+"->"+r.To+" "+string(r.Kind))
+	}
+	assert.ElementsMatch(t, []string{
+		"Order.customer->Customer ManyToOne",
+		"Order.referrer->Customer ManyToOne",
+		"Invoice.order->Order ManyToOne",
+	}, keys)
+
+	for _, r := range d.Relations {
+		assert.Equal(t, r.Field == "customer", r.Required, r.Field)
+	}
+}
+
+func TestBuildKeepsInverseSideWithoutOwner(t *testing.T) {
+	d := Build([]Source{
+		{Path: "Author.java", Content: "@Entity\npublic class Author {\n    @Id private Long id;\n    @OneToMany(mappedBy = \"author\")\n    private Set<Book> books;\n}"},
+		{Path: "Book.java", Content: "@Entity\npublic class Book {\n    @Id private Long id;\n}"},
+	})
+
+	require.Len(t, d.Relations, 1)
+	assert.Equal(t, OneToMany, d.Relations[0].Kind)
+	assert.Equal(t, "Author", d.Relations[0].From)
+	assert.Equal(t, []string{"id"}, columnNames(findEntity(t, d, "Book")))
+}
+
+func TestBuildDocument(t *testing.T) {
+	product := findEntity(t, sampleDiagram(), "Product")
+
+	assert.Equal(t, KindDocument, product.Kind)
+	assert.Equal(t, "products", product.Table)
+	assert.Equal(t, []string{"_id", "product_name", "price"}, columnNames(product))
+	assert.True(t, product.Columns[0].PrimaryKey)
+}
+
+func TestBuildKotlinEntity(t *testing.T) {
+	invoice := findEntity(t, sampleDiagram(), "Invoice")
+
+	assert.Equal(t, "invoices", invoice.Table)
+	assert.Equal(t, []string{"id", "invoice_no", "order_id", "status"}, columnNames(invoice))
+	assert.True(t, invoice.Columns[0].PrimaryKey)
+	assert.Equal(t, "Long", invoice.Columns[0].Type)
+	assert.True(t, invoice.Columns[2].ForeignKey)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	mermaid := RenderMermaid(sampleDiagram())
+
+	assert.Contains(t, mermaid, "erDiagram\n")
+	assert.Contains(t, mermaid, "  customers {\n    Long id PK \"inherited\"\n")
+	assert.Contains(t, mermaid, "    String email_address UK\n")
+	assert.Contains(t, mermaid, "    Long customer_ref FK\n")
+	assert.Contains(t, mermaid, "  purchase_order }o--|| customers : customer\n")
+	assert.Contains(t, mermaid, "  purchase_order }o--o| customers : referrer\n")
+	assert.Contains(t, mermaid, "  invoices }o--o| purchase_order : order\n")
+}
+
+func TestRenderPlantUML(t *testing.T) {
+	plantuml := RenderPlantUML(sampleDiagram())
+
+	assert.Contains(t, plantuml, "@startuml\n")
+	assert.Contains(t, plantuml, "entity \"customers\" as customers {\n  * id : Long <<PK>>\n  --\n")
+	assert.Contains(t, plantuml, "  customer_ref : Long <<FK>>\n")
+	assert.Contains(t, plantuml, "purchase_order }o--|| customers : customer\n")
+	assert.True(t, strings.HasSuffix(plantuml, "@enduml\n"))
+}
+
+func TestRenderDOT(t *testing.T) {
+	dot := RenderDOT(sampleDiagram())
+
+	assert.Contains(t, dot, "digraph erd {")
+	assert.Contains(t, dot, `"products" [label="{products|_id : String (PK)\l`)
+	assert.Contains(t, dot, `"purchase_order" -> "customers" [label="customer (N:1)"];`)
+}
+
+func TestMermaidGenericTypes(t *testing.T) {
+	d := Build([]Source{
+		{Path: "Setting.java", Content: "@Entity\npublic class Setting {\n    @Id private Long id;\n    @ElementCollection\n    private Map<String, Integer> limits;\n}"},
+	})
+
+	assert.Contains(t, RenderMermaid(d), "    Map~String_Integer~ limits\n")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("puml")
+	require.NoError(t, err)
+	assert.Equal(t, FormatPlantUML, format)
+
+	_, err = ParseFormat("svg")
+	assert.ErrorContains(t, err, "expected mermaid, plantuml, dot or json")
+}
@@ -0,0 +1,330 @@
+package erd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+type EntityKind string
+
+const (
+	KindEntity   EntityKind = "entity"
+	KindDocument EntityKind = "document"
+)
+
+type RelationKind string
+
+const (
+	OneToOne   RelationKind = "OneToOne"
+	OneToMany  RelationKind = "OneToMany"
+	ManyToOne  RelationKind = "ManyToOne"
+	ManyToMany RelationKind = "ManyToMany"
+)
+
+type Column struct {
+	Name       string `json:"name"`
+	Field      string `json:"field"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primaryKey,omitempty"`
+	ForeignKey bool   `json:"foreignKey,omitempty"`
+	Unique     bool   `json:"unique,omitempty"`
+	Inherited  bool   `json:"inherited,omitempty"`
+}
+
+type Entity struct {
+	Name    string     `json:"name"`
+	Table   string     `json:"table"`
+	Kind    EntityKind `json:"kind"`
+	Extends string     `json:"extends,omitempty"`
+	Path    string     `json:"path"`
+	Columns []Column   `json:"columns"`
+}
+
+type Relation struct {
+	From     string       `json:"from"`
+	To       string       `json:"to"`
+	Field    string       `json:"field"`
+	Kind     RelationKind `json:"kind"`
+	Required bool         `json:"required,omitempty"`
+	mappedBy string
+}
+
+type Diagram struct {
+	Entities  []Entity   `json:"entities"`
+	Relations []Relation `json:"relations"`
+}
+
+type Source struct {
+	Path    string
+	Content string
+}
+
+type class struct {
+	*source.Type
+	Path string
+}
+
+var relationKinds = map[string]RelationKind{
+	"OneToOne":   OneToOne,
+	"OneToMany":  OneToMany,
+	"ManyToOne":  ManyToOne,
+	"ManyToMany": ManyToMany,
+}
+
+func Build(sources []Source) *Diagram {
+	classes := make(map[string]*class)
+	var order []string
+	for _, src := range sources {
+		typ := source.Parse(src.Content, source.LanguageFor(src.Path)).PrimaryType(src.Path)
+		if typ == nil {
+			continue
+		}
+		c := &class{Type: typ, Path: src.Path}
+		if _, exists := classes[c.Name]; !exists {
+			order = append(order, c.Name)
+		}
+		classes[c.Name] = c
+	}
+
+	b := &builder{classes: classes, entities: make(map[string]*class)}
+	for _, name := range order {
+		c := classes[name]
+		if c.Annotations.Has("Entity") || c.Annotations.Has("Document") {
+			b.entities[name] = c
+		}
+	}
+
+	diagram := &Diagram{Entities: []Entity{}, Relations: []Relation{}}
+	for _, name := range order {
+		if c, ok := b.entities[name]; ok {
+			entity, relations := b.entity(c)
+			diagram.Entities = append(diagram.Entities, entity)
+			diagram.Relations = append(diagram.Relations, relations...)
+		}
+	}
+
+	sort.Slice(diagram.Entities, func(i, j int) bool {
+		return diagram.Entities[i].Name < diagram.Entities[j].Name
+	})
+	diagram.Relations = dedupeRelations(diagram.Relations)
+	return diagram
+}
+
+type builder struct {
+	classes  map[string]*class
+	entities map[string]*class
+}
+
+type inheritedMember struct {
+	source.Field
+	inherited bool
+}
+
+func (b *builder) entity(c *class) (Entity, []Relation) {
+	kind := KindEntity
+	if c.Annotations.Has("Document") {
+		kind = KindDocument
+	}
+
+	entity := Entity{Name: c.Name, Table: tableName(c, kind), Kind: kind, Extends: source.SimpleName(c.Extends), Path: c.Path, Columns: []Column{}}
+	var relations []Relation
+
+	for _, m := range b.members(c) {
+		if m.Modifiers.Has("static") || m.Modifiers.Has("transient") || m.Annotations.Has("Transient") {
+			continue
+		}
+
+		if relation, ok := b.relation(c.Name, m.Field); ok {
+			relations = append(relations, relation)
+			if column, owning := b.foreignKey(relation, m); owning {
+				entity.Columns = append(entity.Columns, column)
+			}
+			continue
+		}
+
+		entity.Columns = append(entity.Columns, Column{
+			Name:       columnName(m.Field, kind),
+			Field:      m.Name,
+			Type:       fieldType(m.Field),
+			PrimaryKey: isID(m.Field),
+			Unique:     argument(m.Annotations, "Column", "unique") == "true",
+			Inherited:  m.inherited,
+		})
+	}
+	return entity, relations
+}
+
+func (b *builder) members(c *class) []inheritedMember {
+	var chain []*class
+	seen := map[string]bool{c.Name: true}
+	for parent := b.classes[source.SimpleName(c.Extends)]; parent != nil && !seen[parent.Name]; parent = b.classes[source.SimpleName(parent.Extends)] {
+		seen[parent.Name] = true
+		chain = append([]*class{parent}, chain...)
+	}
+
+	var result []inheritedMember
+	for _, parent := range chain {
+		for _, m := range parent.Fields {
+			result = append(result, inheritedMember{Field: m, inherited: true})
+		}
+	}
+	for _, m := range c.Fields {
+		result = append(result, inheritedMember{Field: m})
+	}
+	return result
+}
+
+func (b *builder) relation(owner string, m source.Field) (Relation, bool) {
+	for _, a := range m.Annotations {
+		kind, ok := relationKinds[a.Name]
+		if a.Name == "DBRef" || a.Name == "DocumentReference" {
+			kind, ok = ManyToOne, true
+			if isCollection(fieldType(m)) {
+				kind = OneToMany
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		target := argValue(a, "targetEntity")
+		if target == "" {
+			target = elementType(fieldType(m))
+		}
+		target = source.SimpleName(target)
+		if _, known := b.entities[target]; !known {
+			return Relation{}, false
+		}
+
+		required := argValue(a, "optional") == "false" || argument(m.Annotations, "JoinColumn", "nullable") == "false"
+		return Relation{
+			From:     owner,
+			To:       target,
+			Field:    m.Name,
+			Kind:     kind,
+			Required: required,
+			mappedBy: argValue(a, "mappedBy"),
+		}, true
+	}
+	return Relation{}, false
+}
+
+func (b *builder) foreignKey(relation Relation, m inheritedMember) (Column, bool) {
+	if relation.mappedBy != "" || (relation.Kind != ManyToOne && relation.Kind != OneToOne) {
+		return Column{}, false
+	}
+
+	target := b.entities[relation.To]
+	idColumn, idType := "id", "Long"
+	for _, tm := range b.members(target) {
+		if isID(tm.Field) {
+			idColumn, idType = columnName(tm.Field, KindEntity), fieldType(tm.Field)
+			break
+		}
+	}
+
+	name := argument(m.Annotations, "JoinColumn", "name")
+	if name == "" {
+		name = generator.SnakeCase(m.Name) + "_" + idColumn
+	}
+	return Column{Name: name, Field: m.Name, Type: idType, ForeignKey: true, Inherited: m.inherited}, true
+}
+
+func dedupeRelations(relations []Relation) []Relation {
+	owning := make(map[string]bool)
+	for _, r := range relations {
+		if r.mappedBy == "" {
+			owning[r.From+"."+r.Field+"->"+r.To] = true
+		}
+	}
+
+	result := []Relation{}
+	for _, r := range relations {
+		if r.mappedBy != "" && owning[r.To+"."+r.mappedBy+"->"+r.From] {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func tableName(c *class, kind EntityKind) string {
+	if kind == KindDocument {
+		for _, key := range []string{"collection", "value"} {
+			if name := argument(c.Annotations, "Document", key); name != "" {
+				return name
+			}
+		}
+		return strings.ToLower(c.Name[:1]) + c.Name[1:]
+	}
+
+	if name := argument(c.Annotations, "Table", "name"); name != "" {
+		return name
+	}
+	if name := argument(c.Annotations, "Entity", "name"); name != "" {
+		return generator.SnakeCase(name)
+	}
+	return generator.SnakeCase(c.Name)
+}
+
+func columnName(m source.Field, kind EntityKind) string {
+	if kind == KindDocument {
+		if isID(m) {
+			return "_id"
+		}
+		for _, key := range []string{"name", "value"} {
+			if name := argument(m.Annotations, "Field", key); name != "" {
+				return name
+			}
+		}
+		return m.Name
+	}
+
+	if name := argument(m.Annotations, "Column", "name"); name != "" {
+		return name
+	}
+	return generator.SnakeCase(m.Name)
+}
+
+func isID(m source.Field) bool {
+	return m.Annotations.Has("Id") || m.Annotations.Has("EmbeddedId")
+}
+
+func fieldType(m source.Field) string {
+	return strings.ReplaceAll(m.Type, "?", "")
+}
+
+func isCollection(typ string) bool {
+	return strings.Contains(typ, "<") || strings.HasSuffix(typ, "[]")
+}
+
+func elementType(typ string) string {
+	start := strings.LastIndex(typ, "<")
+	end := strings.Index(typ, ">")
+	if start >= 0 && end > start {
+		parts := strings.Split(typ[start+1:end], ",")
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+	return strings.TrimSuffix(typ, "[]")
+}
+
+func argument(annotations source.Annotations, name, key string) string {
+	if a, ok := annotations.Get(name); ok {
+		return argValue(a, key)
+	}
+	return ""
+}
+
+func argValue(a source.Annotation, key string) string {
+	value, ok := a.Arg(key)
+	if !ok {
+		return ""
+	}
+	if text, ok := value.StringValue(nil); ok {
+		return text
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(value.Text, ".class"), "::class")
+}
@@ -0,0 +1,198 @@
+package erd
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatMermaid  Format = "mermaid"
+	FormatPlantUML Format = "plantuml"
+	FormatDOT      Format = "dot"
+	FormatJSON     Format = "json"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case FormatMermaid, FormatPlantUML, FormatDOT, FormatJSON:
+		return Format(value), nil
+	case "puml":
+		return FormatPlantUML, nil
+	default:
+		return "", fmt.Errorf("invalid format '%s' (expected mermaid, plantuml, dot or json)", value)
+	}
+}
+
+func Render(d *Diagram, format Format) string {
+	switch format {
+	case FormatPlantUML:
+		return RenderPlantUML(d)
+	case FormatDOT:
+		return RenderDOT(d)
+	default:
+		return RenderMermaid(d)
+	}
+}
+
+func RenderMermaid(d *Diagram) string {
+	var sb strings.Builder
+	sb.WriteString("erDiagram\n")
+
+	for _, entity := range d.Entities {
+		fmt.Fprintf(&sb, "  %s {\n", entity.Table)
+		for _, column := range entity.Columns {
+			line := fmt.Sprintf("    %s %s", mermaidType(column.Type), column.Name)
+			if keys := columnKeys(column); len(keys) > 0 {
+				line += " " + strings.Join(keys, ",")
+			}
+			if column.Inherited {
+				line += ` "inherited"`
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("  }\n")
+	}
+
+	tables := tableNames(d)
+	for _, relation := range d.Relations {
+		fmt.Fprintf(&sb, "  %s %s %s : %s\n", tables[relation.From], cardinality(relation), tables[relation.To], relation.Field)
+	}
+	return sb.String()
+}
+
+func RenderPlantUML(d *Diagram) string {
+	var sb strings.Builder
+	sb.WriteString("@startuml\n")
+	sb.WriteString("hide circle\n")
+	sb.WriteString("skinparam linetype ortho\n\n")
+
+	for _, entity := range d.Entities {
+		fmt.Fprintf(&sb, "entity \"%s\" as %s {\n", entity.Table, plantID(entity.Table))
+		var keys, others []Column
+		for _, column := range entity.Columns {
+			if column.PrimaryKey {
+				keys = append(keys, column)
+			} else {
+				others = append(others, column)
+			}
+		}
+		for _, column := range keys {
+			fmt.Fprintf(&sb, "  * %s : %s <<PK>>\n", column.Name, column.Type)
+		}
+		if len(keys) > 0 {
+			sb.WriteString("  --\n")
+		}
+		for _, column := range others {
+			line := fmt.Sprintf("  %s : %s", column.Name, column.Type)
+			for _, key := range columnKeys(column) {
+				line += " <<" + key + ">>"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	tables := tableNames(d)
+	for _, relation := range d.Relations {
+		fmt.Fprintf(&sb, "%s %s %s : %s\n", plantID(tables[relation.From]), cardinality(relation), plantID(tables[relation.To]), relation.Field)
+	}
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
+
+func RenderDOT(d *Diagram) string {
+	var sb strings.Builder
+	sb.WriteString("digraph erd {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=record, fontname=\"Helvetica\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, entity := range d.Entities {
+		rows := []string{recordEscape(entity.Table)}
+		var columns []string
+		for _, column := range entity.Columns {
+			row := column.Name + " : " + column.Type
+			if keys := columnKeys(column); len(keys) > 0 {
+				row += " (" + strings.Join(keys, ", ") + ")"
+			}
+			columns = append(columns, recordEscape(row)+`\l`)
+		}
+		rows = append(rows, strings.Join(columns, ""))
+		fmt.Fprintf(&sb, "  %q [label=\"{%s}\"];\n", entity.Table, strings.Join(rows, "|"))
+	}
+
+	tables := tableNames(d)
+	for _, relation := range d.Relations {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", tables[relation.From], tables[relation.To], relation.Field+" ("+ratio(relation.Kind)+")")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func cardinality(r Relation) string {
+	switch r.Kind {
+	case ManyToOne:
+		if r.Required {
+			return "}o--||"
+		}
+		return "}o--o|"
+	case OneToMany:
+		return "||--o{"
+	case OneToOne:
+		if r.Required {
+			return "||--||"
+		}
+		return "||--o|"
+	default:
+		return "}o--o{"
+	}
+}
+
+func ratio(kind RelationKind) string {
+	switch kind {
+	case ManyToOne:
+		return "N:1"
+	case OneToMany:
+		return "1:N"
+	case OneToOne:
+		return "1:1"
+	default:
+		return "N:M"
+	}
+}
+
+func columnKeys(column Column) []string {
+	var keys []string
+	if column.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if column.ForeignKey {
+		keys = append(keys, "FK")
+	}
+	if column.Unique {
+		keys = append(keys, "UK")
+	}
+	return keys
+}
+
+func tableNames(d *Diagram) map[string]string {
+	tables := make(map[string]string, len(d.Entities))
+	for _, entity := range d.Entities {
+		tables[entity.Name] = entity.Table
+	}
+	return tables
+}
+
+func mermaidType(typ string) string {
+	return strings.NewReplacer("<", "~", ">", "~", ",", "_", " ", "", "?", "").Replace(typ)
+}
+
+func plantID(name string) string {
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(name)
+}
+
+func recordEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/spf13/afero"
+)
+
+type CustomData struct {
+	Variables  map[string]string
+	Conditions map[string]string
+}
+
+func LoadCustomData(fs afero.Fs, projectRoot string) (CustomData, error) {
+	if projectRoot == "" {
+		return CustomData{}, nil
+	}
+
+	cm := config.NewConfigManager(fs, projectRoot, "")
+	if !cm.ProjectConfigExists() {
+		return CustomData{}, nil
+	}
+
+	cfg, err := cm.LoadProjectConfig()
+	if err != nil {
+		return CustomData{}, fmt.Errorf("failed to load %s: %w", config.ProjectConfigFile, err)
+	}
+
+	return CustomData{
+		Variables:  cfg.Templates.Variables,
+		Conditions: cfg.Templates.Conditions,
+	}, nil
+}
+
+func (c CustomData) IsEmpty() bool {
+	return len(c.Variables) == 0 && len(c.Conditions) == 0
+}
+
+func (c CustomData) VariableNames() []string {
+	return sortedKeys(c.Variables)
+}
+
+func (c CustomData) ConditionNames() []string {
+	return sortedKeys(c.Conditions)
+}
+
+func (c CustomData) Apply(data map[string]any, funcMap template.FuncMap) (map[string]any, error) {
+	if c.IsEmpty() {
+		return data, nil
+	}
+
+	merged := make(map[string]any, len(data)+len(c.Variables)+len(c.Conditions))
+	for key, value := range data {
+		merged[key] = value
+	}
+
+	if err := c.resolveVariables(data, merged, funcMap); err != nil {
+		return nil, err
+	}
+
+	for _, name := range c.ConditionNames() {
+		if _, exists := data[name]; exists {
+			continue
+		}
+		value, err := renderCustomValue(name, "{{if "+c.Conditions[name]+"}}true{{end}}", merged, funcMap)
+		if err != nil {
+			return nil, fmt.Errorf("custom condition %s: %w", name, err)
+		}
+		merged[name] = value == "true"
+	}
+
+	return merged, nil
+}
+
+func (c CustomData) resolveVariables(data, merged map[string]any, funcMap template.FuncMap) error {
+	var pending []string
+	for _, name := range c.VariableNames() {
+		if _, exists := data[name]; !exists {
+			pending = append(pending, name)
+		}
+	}
+
+	for len(pending) > 0 {
+		var unresolved []string
+		var lastErr error
+		for _, name := range pending {
+			value, err := renderCustomValue(name, c.Variables[name], merged, funcMap)
+			if err != nil {
+				unresolved = append(unresolved, name)
+				lastErr = fmt.Errorf("custom variable %s: %w", name, err)
+				continue
+			}
+			merged[name] = value
+		}
+		if len(unresolved) == len(pending) {
+			return lastErr
+		}
+		pending = unresolved
+	}
+	return nil
+}
+
+func (c CustomData) SchemaSample(data map[string]any) map[string]any {
+	sample := make(map[string]any, len(data)+len(c.Variables)+len(c.Conditions))
+	for key, value := range data {
+		sample[key] = value
+	}
+	for name := range c.Variables {
+		sample[name] = ""
+	}
+	for name := range c.Conditions {
+		sample[name] = false
+	}
+	return sample
+}
+
+func renderCustomValue(name, value string, data map[string]any, funcMap template.FuncMap) (string, error) {
+	tmpl, err := template.New(name).Funcs(funcMap).Option("missingkey=error").Parse(PreprocessTemplate(value))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customConfig = `{
+  "version": "1",
+  "templates": {
+    "variables": {
+      "Author": "Platform Team",
+      "ApiPrefix": "/api/v1",
+      "ApiPath": "${ApiPrefix}/${namePlural}"
+    },
+    "conditions": {
+      "UsesTenantId": "and .HasJpa (eq .Architecture \"feature\")"
+    }
+  }
+}`
+
+func TestLoadCustomData(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft.json", []byte(customConfig), 0644))
+
+	custom, err := LoadCustomData(fs, "/project")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ApiPath", "ApiPrefix", "Author"}, custom.VariableNames())
+	assert.Equal(t, []string{"UsesTenantId"}, custom.ConditionNames())
+}
+
+func TestLoadCustomDataWithoutConfig(t *testing.T) {
+	custom, err := LoadCustomData(afero.NewMemMapFs(), "/project")
+	require.NoError(t, err)
+	assert.True(t, custom.IsEmpty())
+}
+
+func TestLoadCustomDataInvalidConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft.json", []byte("{"), 0644))
+
+	_, err := LoadCustomData(fs, "/project")
+	assert.ErrorContains(t, err, ".haft.json")
+}
+
+func TestCustomDataApply(t *testing.T) {
+	custom := CustomData{
+		Variables: map[string]string{
+			"ApiPrefix": "/api/v1",
+			"ApiPath":   "{{.ApiPrefix}}/{{plural .NameLower}}",
+			"Name":      "Ignored",
+		},
+		Conditions: map[string]string{
+			"UsesTenantId": "and .HasJpa (eq .Architecture \"feature\")",
+			"IsLayered":    "eq .Architecture \"layered\"",
+		},
+	}
+	data := map[string]any{"Name": "Product", "NameLower": "product", "HasJpa": true, "Architecture": "feature"}
+
+	merged, err := custom.Apply(data, defaultFuncMap())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Product", merged["Name"])
+	assert.Equal(t, "/api/v1", merged["ApiPrefix"])
+	assert.Equal(t, "/api/v1/products", merged["ApiPath"])
+	assert.Equal(t, true, merged["UsesTenantId"])
+	assert.Equal(t, false, merged["IsLayered"])
+	assert.NotContains(t, data, "ApiPrefix")
+}
+
+func TestCustomDataApplyErrors(t *testing.T) {
+	custom := CustomData{Variables: map[string]string{"Broken": "{{.Missing}}"}}
+
+	_, err := custom.Apply(map[string]any{}, defaultFuncMap())
+	assert.ErrorContains(t, err, "custom variable Broken")
+
+	custom = CustomData{Conditions: map[string]string{"Bad": "eq .Missing"}}
+	_, err = custom.Apply(map[string]any{}, defaultFuncMap())
+	assert.ErrorContains(t, err, "custom condition Bad")
+}
+
+func TestEngineAppliesProjectVariables(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft.json", []byte(customConfig), 0644))
+	engine := NewEngineWithLoader(fs, "/project")
+
+	data := map[string]any{"Name": "Order", "NameLower": "order", "HasJpa": true, "Architecture": "feature"}
+	content := "// ${Author}\n// @if UsesTenantId\ntenant\n// @endif\n@RequestMapping(\"${ApiPath}\")\n"
+
+	rendered, err := engine.RenderString(content, data)
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "// Platform Team\n")
+	assert.Contains(t, rendered, "tenant\n")
+	assert.Contains(t, rendered, `@RequestMapping("/api/v1/orders")`)
+}
+
+func TestEngineReportsInvalidProjectConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft.json", []byte("{"), 0644))
+	engine := NewEngineWithLoader(fs, "/project")
+
+	_, err := engine.RenderString("x", map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestCustomDataSchemaSample(t *testing.T) {
+	custom := CustomData{Variables: map[string]string{"Author": "x"}, Conditions: map[string]string{"UsesTenantId": "true"}}
+	schema := NewTemplateSchema(custom.SchemaSample(map[string]any{"Name": "Product"}))
+
+	result := ValidateTemplateWithSchema("${Author} {{.Name}}\n// @if UsesTenantId\n// @endif\n", "test.tmpl", schema)
+	assert.True(t, result.Valid, result.Errors)
+}
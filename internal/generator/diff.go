@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+func SplitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func DiffLines(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []DiffLine
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		result = append(result, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		result = append(result, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+	return result
+}
+
+func UnifiedDiff(fromName, toName, from, to string, context int) string {
+	diff := DiffLines(SplitLines(from), SplitLines(to))
+	hunks := buildHunks(diff, context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks {
+		h.write(&sb, diff)
+	}
+	return sb.String()
+}
+
+type diffHunk struct {
+	start, end     int
+	fromLine, from int
+	toLine, to     int
+}
+
+func buildHunks(diff []DiffLine, context int) []diffHunk {
+	var hunks []diffHunk
+	fromLine, toLine := 1, 1
+	lastChange := -1
+
+	for idx, line := range diff {
+		if line.Op != DiffEqual {
+			start := max(idx-context, 0)
+			if len(hunks) > 0 && start <= lastChange+context+1 {
+				hunks[len(hunks)-1].end = idx + 1
+			} else {
+				back := idx - start
+				hunks = append(hunks, diffHunk{start: start, end: idx + 1, fromLine: fromLine - back, toLine: toLine - back})
+			}
+			lastChange = idx
+		}
+		if line.Op != DiffInsert {
+			fromLine++
+		}
+		if line.Op != DiffDelete {
+			toLine++
+		}
+	}
+
+	for i := range hunks {
+		hunks[i].end = min(hunks[i].end+context, len(diff))
+		if i+1 < len(hunks) && hunks[i].end > hunks[i+1].start {
+			hunks[i].end = hunks[i+1].start
+		}
+		for _, line := range diff[hunks[i].start:hunks[i].end] {
+			if line.Op != DiffInsert {
+				hunks[i].from++
+			}
+			if line.Op != DiffDelete {
+				hunks[i].to++
+			}
+		}
+	}
+	return hunks
+}
+
+func (h diffHunk) write(sb *strings.Builder, diff []DiffLine) {
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", h.fromLine, h.from, h.toLine, h.to)
+	for _, line := range diff[h.start:h.end] {
+		prefix := " "
+		switch line.Op {
+		case DiffDelete:
+			prefix = "-"
+		case DiffInsert:
+			prefix = "+"
+		}
+		sb.WriteString(prefix + line.Text)
+		if !strings.HasSuffix(line.Text, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
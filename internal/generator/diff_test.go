@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitLines(t *testing.T) {
+	assert.Nil(t, SplitLines(""))
+	assert.Equal(t, []string{"a\n", "b\n"}, SplitLines("a\nb\n"))
+	assert.Equal(t, []string{"a\n", "b"}, SplitLines("a\nb"))
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines(SplitLines("a\nb\nc\n"), SplitLines("a\nx\nc\n"))
+
+	assert.Equal(t, []DiffLine{
+		{Op: DiffEqual, Text: "a\n"},
+		{Op: DiffDelete, Text: "b\n"},
+		{Op: DiffInsert, Text: "x\n"},
+		{Op: DiffEqual, Text: "c\n"},
+	}, diff)
+}
+
+func TestDiffLinesInsertAndDeleteAtEnds(t *testing.T) {
+	diff := DiffLines(SplitLines("a\nb\n"), SplitLines("b\nc\n"))
+
+	assert.Equal(t, []DiffLine{
+		{Op: DiffDelete, Text: "a\n"},
+		{Op: DiffEqual, Text: "b\n"},
+		{Op: DiffInsert, Text: "c\n"},
+	}, diff)
+}
+
+func TestUnifiedDiffIdentical(t *testing.T) {
+	assert.Empty(t, UnifiedDiff("a", "b", "same\n", "same\n", 3))
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	to := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n"
+
+	diff := UnifiedDiff("old", "new", from, to, 2)
+
+	expected := strings.Join([]string{
+		"--- old",
+		"+++ new",
+		"@@ -3,5 +3,5 @@",
+		" 3",
+		" 4",
+		"-5",
+		"+five",
+		" 6",
+		" 7",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, diff)
+}
+
+func TestUnifiedDiffSeparateHunks(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	to := "A\nb\nc\nd\ne\nf\ng\nh\ni\nJ\n"
+
+	diff := UnifiedDiff("old", "new", from, to, 1)
+
+	assert.Equal(t, 2, strings.Count(diff, "@@ -"))
+	assert.Contains(t, diff, "@@ -1,2 +1,2 @@")
+	assert.Contains(t, diff, "@@ -9,2 +9,2 @@")
+}
+
+func TestUnifiedDiffMissingTrailingNewline(t *testing.T) {
+	diff := UnifiedDiff("old", "new", "a\n", "a\nb", 3)
+
+	assert.Contains(t, diff, "+b\n\\ No newline at end of file\n")
+}
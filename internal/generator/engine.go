@@ -19,6 +19,8 @@ type Engine struct {
 	fs             afero.Fs
 	funcMap        template.FuncMap
 	templateLoader *TemplateLoader
+	custom         CustomData
+	customErr      error
 }
 
 func NewEngine(filesystem afero.Fs) *Engine {
@@ -35,9 +37,31 @@ func NewEngineWithLoader(filesystem afero.Fs, projectRoot string) *Engine {
 		funcMap:        defaultFuncMap(),
 		templateLoader: NewTemplateLoader(filesystem, projectRoot),
 	}
+	e.custom, e.customErr = LoadCustomData(filesystem, projectRoot)
 	return e
 }
 
+func (e *Engine) SetCustomData(custom CustomData) {
+	e.custom = custom
+	e.customErr = nil
+}
+
+func (e *Engine) GetCustomData() CustomData {
+	return e.custom
+}
+
+func (e *Engine) prepareData(data any) (any, error) {
+	if e.customErr != nil {
+		return nil, e.customErr
+	}
+
+	values, ok := data.(map[string]any)
+	if !ok {
+		return data, nil
+	}
+	return e.custom.Apply(values, e.funcMap)
+}
+
 func (e *Engine) SetTemplateLoader(loader *TemplateLoader) {
 	e.templateLoader = loader
 }
@@ -59,6 +83,7 @@ func defaultFuncMap() template.FuncMap {
 		"plural":     pluralize,
 		"singular":   singularize,
 		"package":    toPackagePath,
+		"testName":   toTestName,
 	}
 }
 
@@ -83,6 +108,11 @@ func (e *Engine) RenderTemplate(name string, data any) (string, error) {
 		}
 	}
 
+	data, err = e.prepareData(data)
+	if err != nil {
+		return "", err
+	}
+
 	preprocessed := PreprocessTemplate(string(content))
 
 	tmpl, err := template.New(name).Funcs(e.funcMap).Parse(preprocessed)
@@ -99,6 +129,11 @@ func (e *Engine) RenderTemplate(name string, data any) (string, error) {
 }
 
 func (e *Engine) RenderString(content string, data any) (string, error) {
+	data, err := e.prepareData(data)
+	if err != nil {
+		return "", err
+	}
+
 	preprocessed := PreprocessTemplate(content)
 
 	tmpl, err := template.New("inline").Funcs(e.funcMap).Parse(preprocessed)
@@ -262,6 +297,31 @@ func toKebabCase(s string) string {
 	return strings.Join(words, "-")
 }
 
+func toTestName(style, shouldName, givenWhenThenName string) string {
+	switch style {
+	case "given_when_then":
+		return givenWhenThenName
+	case "snake_case":
+		return toSnakeCase(shouldName)
+	case "test_prefix":
+		return "test" + strings.TrimPrefix(shouldName, "should")
+	default:
+		return shouldName
+	}
+}
+
+func Pluralize(s string) string {
+	return pluralize(s)
+}
+
+func KebabCase(s string) string {
+	return toKebabCase(s)
+}
+
+func SnakeCase(s string) string {
+	return toSnakeCase(s)
+}
+
 func splitWords(s string) []string {
 	s = strings.ReplaceAll(s, "-", " ")
 	s = strings.ReplaceAll(s, "_", " ")
@@ -257,6 +257,25 @@ func TestToKebabCase(t *testing.T) {
 	}
 }
 
+func TestToTestName(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{"", "shouldFindById"},
+		{"should", "shouldFindById"},
+		{"snake_case", "should_find_by_id"},
+		{"test_prefix", "testFindById"},
+		{"given_when_then", "givenId_whenFind_thenFound"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toTestName(tt.style, "shouldFindById", "givenId_whenFind_thenFound"))
+		})
+	}
+}
+
 func TestPluralize(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	GoldenDirSuffix  = ".golden"
+	GoldenFileSuffix = ".golden"
+)
+
+type GoldenStatus string
+
+const (
+	GoldenPass    GoldenStatus = "pass"
+	GoldenFail    GoldenStatus = "fail"
+	GoldenMissing GoldenStatus = "missing"
+	GoldenUpdated GoldenStatus = "updated"
+	GoldenError   GoldenStatus = "error"
+)
+
+type Fixture struct {
+	Name         string
+	Architecture string
+	Data         map[string]any
+}
+
+type GoldenCase struct {
+	Template   string
+	Fixture    string
+	GoldenPath string
+	Status     GoldenStatus
+	Rendered   string
+	Diff       string
+	Error      string
+}
+
+type GoldenHarness struct {
+	fs          afero.Fs
+	templateDir string
+	fixtures    []Fixture
+	engine      *Engine
+}
+
+func NewGoldenHarness(fs afero.Fs, templateDir string, fixtures []Fixture) *GoldenHarness {
+	return &GoldenHarness{
+		fs:          fs,
+		templateDir: templateDir,
+		fixtures:    fixtures,
+		engine:      NewEngine(fs),
+	}
+}
+
+func GoldenPath(templateDir, templateName, fixture string) string {
+	base := strings.TrimSuffix(templateName, ".tmpl")
+	return filepath.Join(templateDir, base+GoldenDirSuffix, fixture+GoldenFileSuffix)
+}
+
+func (h *GoldenHarness) SetCustomData(custom CustomData) {
+	h.engine.SetCustomData(custom)
+}
+
+func (h *GoldenHarness) FixturesFor(templateName string) []Fixture {
+	parts := strings.Split(filepath.ToSlash(templateName), "/")
+	if len(parts) == 0 || (parts[0] != "resource" && parts[0] != "test") {
+		return nil
+	}
+
+	var matched []Fixture
+	for _, fixture := range h.fixtures {
+		if templateArchitecture(parts) == "" || templateArchitecture(parts) == fixture.Architecture {
+			matched = append(matched, fixture)
+		}
+	}
+	return matched
+}
+
+func templateArchitecture(parts []string) string {
+	for _, part := range parts {
+		if part == "layered" || part == "feature" {
+			return part
+		}
+	}
+	return ""
+}
+
+func (h *GoldenHarness) Run(templates []string, update bool) []GoldenCase {
+	var cases []GoldenCase
+	for _, tmpl := range templates {
+		content, err := afero.ReadFile(h.fs, filepath.Join(h.templateDir, tmpl))
+		if err != nil {
+			cases = append(cases, GoldenCase{Template: tmpl, Status: GoldenError, Error: err.Error()})
+			continue
+		}
+		for _, fixture := range h.FixturesFor(tmpl) {
+			cases = append(cases, h.runCase(tmpl, string(content), fixture, update))
+		}
+	}
+	return cases
+}
+
+func (h *GoldenHarness) runCase(tmpl, content string, fixture Fixture, update bool) GoldenCase {
+	result := GoldenCase{
+		Template:   tmpl,
+		Fixture:    fixture.Name,
+		GoldenPath: GoldenPath(h.templateDir, tmpl, fixture.Name),
+	}
+
+	rendered, err := h.engine.RenderString(content, fixture.Data)
+	if err != nil {
+		result.Status = GoldenError
+		result.Error = err.Error()
+		return result
+	}
+	result.Rendered = rendered
+
+	if strings.Contains(rendered, "<no value>") {
+		result.Status = GoldenError
+		result.Error = "rendered output contains <no value>; a variable is missing from the template data"
+		return result
+	}
+
+	golden, readErr := afero.ReadFile(h.fs, result.GoldenPath)
+	switch {
+	case readErr == nil && string(golden) == rendered:
+		result.Status = GoldenPass
+	case update:
+		if err := h.engine.WriteFile(result.GoldenPath, rendered); err != nil {
+			result.Status = GoldenError
+			result.Error = fmt.Sprintf("failed to write golden file: %v", err)
+			return result
+		}
+		result.Status = GoldenUpdated
+	case readErr != nil:
+		result.Status = GoldenMissing
+	default:
+		result.Status = GoldenFail
+		result.Diff = UnifiedDiff("golden", "rendered", string(golden), rendered, 3)
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goldenTemplateDir = "/project/.haft/templates"
+
+func goldenFixtures() []Fixture {
+	return []Fixture{
+		{Name: "layered-lombok", Architecture: "layered", Data: map[string]any{"Name": "Product", "HasLombok": true}},
+		{Name: "layered-plain", Architecture: "layered", Data: map[string]any{"Name": "Product", "HasLombok": false}},
+		{Name: "feature-lombok", Architecture: "feature", Data: map[string]any{"Name": "Product", "HasLombok": true}},
+	}
+}
+
+func writeGoldenTemplate(t *testing.T, fs afero.Fs, name, content string) {
+	t.Helper()
+	require.NoError(t, afero.WriteFile(fs, goldenTemplateDir+"/"+name, []byte(content), 0644))
+}
+
+func TestGoldenPath(t *testing.T) {
+	path := GoldenPath(goldenTemplateDir, "resource/layered/Controller.java.tmpl", "layered-lombok")
+	assert.Equal(t, goldenTemplateDir+"/resource/layered/Controller.java.golden/layered-lombok.golden", path)
+}
+
+func TestGoldenHarnessFixturesFor(t *testing.T) {
+	harness := NewGoldenHarness(afero.NewMemMapFs(), goldenTemplateDir, goldenFixtures())
+
+	assert.Len(t, harness.FixturesFor("resource/layered/Controller.java.tmpl"), 2)
+	assert.Len(t, harness.FixturesFor("test/feature/ServiceTest.java.tmpl"), 1)
+	assert.Len(t, harness.FixturesFor("resource/Custom.java.tmpl"), 3)
+	assert.Empty(t, harness.FixturesFor("project/pom.xml.tmpl"))
+}
+
+func TestGoldenHarnessMissingThenUpdateThenPass(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeGoldenTemplate(t, fs, "resource/layered/Entity.java.tmpl", "// @if HasLombok\n@Data\n// @endif\nclass ${Name} {}\n")
+	harness := NewGoldenHarness(fs, goldenTemplateDir, goldenFixtures())
+	templates := []string{"resource/layered/Entity.java.tmpl"}
+
+	cases := harness.Run(templates, false)
+	require.Len(t, cases, 2)
+	for _, c := range cases {
+		assert.Equal(t, GoldenMissing, c.Status)
+	}
+
+	cases = harness.Run(templates, true)
+	for _, c := range cases {
+		assert.Equal(t, GoldenUpdated, c.Status)
+	}
+
+	golden, err := afero.ReadFile(fs, GoldenPath(goldenTemplateDir, templates[0], "layered-lombok"))
+	require.NoError(t, err)
+	assert.Contains(t, string(golden), "@Data\n")
+	assert.Contains(t, string(golden), "class Product {}\n")
+
+	cases = harness.Run(templates, false)
+	for _, c := range cases {
+		assert.Equal(t, GoldenPass, c.Status, c.Fixture)
+	}
+}
+
+func TestGoldenHarnessDetectsChanges(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeGoldenTemplate(t, fs, "resource/layered/Entity.java.tmpl", "class ${Name} {}\n")
+	harness := NewGoldenHarness(fs, goldenTemplateDir, goldenFixtures()[:1])
+	templates := []string{"resource/layered/Entity.java.tmpl"}
+
+	harness.Run(templates, true)
+	writeGoldenTemplate(t, fs, "resource/layered/Entity.java.tmpl", "public class ${Name} {}\n")
+
+	cases := harness.Run(templates, false)
+	require.Len(t, cases, 1)
+	assert.Equal(t, GoldenFail, cases[0].Status)
+	assert.Contains(t, cases[0].Diff, "-class Product {}")
+	assert.Contains(t, cases[0].Diff, "+public class Product {}")
+}
+
+func TestGoldenHarnessReportsRenderErrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeGoldenTemplate(t, fs, "resource/layered/Broken.java.tmpl", "{{.Name\n")
+	writeGoldenTemplate(t, fs, "resource/layered/Missing.java.tmpl", "${Unknown}\n")
+	harness := NewGoldenHarness(fs, goldenTemplateDir, goldenFixtures()[:1])
+
+	cases := harness.Run([]string{
+		"resource/layered/Broken.java.tmpl",
+		"resource/layered/Missing.java.tmpl",
+		"resource/layered/Absent.java.tmpl",
+	}, true)
+
+	require.Len(t, cases, 3)
+	for _, c := range cases {
+		assert.Equal(t, GoldenError, c.Status, c.Template)
+	}
+	assert.Contains(t, cases[1].Error, "<no value>")
+}
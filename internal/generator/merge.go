@@ -0,0 +1,167 @@
+package generator
+
+import "strings"
+
+const (
+	ConflictOursMarker   = "<<<<<<< override\n"
+	ConflictBaseMarker   = "||||||| base\n"
+	ConflictSplitMarker  = "=======\n"
+	ConflictTheirsMarker = ">>>>>>> embedded\n"
+)
+
+type MergeResult struct {
+	Content   string
+	Conflicts int
+}
+
+type changeHunk struct {
+	start int
+	end   int
+	lines []string
+}
+
+func Merge3(base, ours, theirs string) MergeResult {
+	baseLines := SplitLines(base)
+	oursHunks := changeHunks(baseLines, SplitLines(ours))
+	theirsHunks := changeHunks(baseLines, SplitLines(theirs))
+
+	var sb strings.Builder
+	result := MergeResult{}
+	pos, a, b := 0, 0, 0
+
+	for a < len(oursHunks) || b < len(theirsHunks) {
+		start, end, groupA, groupB := nextMergeGroup(oursHunks[a:], theirsHunks[b:])
+		a += len(groupA)
+		b += len(groupB)
+
+		writeLines(&sb, baseLines[pos:start])
+		region := baseLines[start:end]
+		oursRegion := applyHunks(region, start, groupA)
+		theirsRegion := applyHunks(region, start, groupB)
+
+		switch {
+		case len(groupB) == 0:
+			writeLines(&sb, oursRegion)
+		case len(groupA) == 0, sameLines(oursRegion, theirsRegion):
+			writeLines(&sb, theirsRegion)
+		default:
+			result.Conflicts++
+			writeConflict(&sb, oursRegion, region, theirsRegion)
+		}
+		pos = end
+	}
+
+	writeLines(&sb, baseLines[pos:])
+	result.Content = sb.String()
+	return result
+}
+
+func changeHunks(base, other []string) []changeHunk {
+	var hunks []changeHunk
+	var current *changeHunk
+	pos := 0
+
+	for _, line := range DiffLines(base, other) {
+		if line.Op == DiffEqual {
+			if current != nil {
+				hunks = append(hunks, *current)
+				current = nil
+			}
+			pos++
+			continue
+		}
+		if current == nil {
+			current = &changeHunk{start: pos, end: pos}
+		}
+		if line.Op == DiffDelete {
+			pos++
+			current.end = pos
+		} else {
+			current.lines = append(current.lines, line.Text)
+		}
+	}
+	if current != nil {
+		hunks = append(hunks, *current)
+	}
+	return hunks
+}
+
+func nextMergeGroup(ours, theirs []changeHunk) (int, int, []changeHunk, []changeHunk) {
+	var start int
+	switch {
+	case len(ours) == 0:
+		start = theirs[0].start
+	case len(theirs) == 0:
+		start = ours[0].start
+	default:
+		start = min(ours[0].start, theirs[0].start)
+	}
+
+	end := start
+	a, b := 0, 0
+	for {
+		switch {
+		case a < len(ours) && hunkTouches(ours[a], start, end):
+			end = max(end, ours[a].end)
+			a++
+		case b < len(theirs) && hunkTouches(theirs[b], start, end):
+			end = max(end, theirs[b].end)
+			b++
+		default:
+			return start, end, ours[:a], theirs[:b]
+		}
+	}
+}
+
+func hunkTouches(h changeHunk, start, end int) bool {
+	if h.start == start {
+		return true
+	}
+	return h.start < end
+}
+
+func applyHunks(region []string, offset int, hunks []changeHunk) []string {
+	var result []string
+	pos := offset
+	for _, h := range hunks {
+		result = append(result, region[pos-offset:h.start-offset]...)
+		result = append(result, h.lines...)
+		pos = h.end
+	}
+	return append(result, region[pos-offset:]...)
+}
+
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+}
+
+func writeConflict(sb *strings.Builder, ours, base, theirs []string) {
+	sb.WriteString(ConflictOursMarker)
+	writeConflictSide(sb, ours)
+	sb.WriteString(ConflictBaseMarker)
+	writeConflictSide(sb, base)
+	sb.WriteString(ConflictSplitMarker)
+	writeConflictSide(sb, theirs)
+	sb.WriteString(ConflictTheirsMarker)
+}
+
+func writeConflictSide(sb *strings.Builder, lines []string) {
+	writeLines(sb, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge3NoChanges(t *testing.T) {
+	result := Merge3("a\nb\n", "a\nb\n", "a\nb\n")
+
+	assert.Equal(t, "a\nb\n", result.Content)
+	assert.Zero(t, result.Conflicts)
+}
+
+func TestMerge3CleanMerge(t *testing.T) {
+	base := "1\n2\n3\n4\n5\n"
+	ours := "1\ntwo\n3\n4\n5\n"
+	theirs := "1\n2\n3\n4\nfive\n"
+
+	result := Merge3(base, ours, theirs)
+
+	assert.Equal(t, "1\ntwo\n3\n4\nfive\n", result.Content)
+	assert.Zero(t, result.Conflicts)
+}
+
+func TestMerge3OnlyUpstreamChanged(t *testing.T) {
+	result := Merge3("a\nb\n", "a\nb\n", "a\nb\nc\n")
+
+	assert.Equal(t, "a\nb\nc\n", result.Content)
+	assert.Zero(t, result.Conflicts)
+}
+
+func TestMerge3IdenticalChange(t *testing.T) {
+	result := Merge3("a\nb\nc\n", "a\nB\nc\n", "a\nB\nc\n")
+
+	assert.Equal(t, "a\nB\nc\n", result.Content)
+	assert.Zero(t, result.Conflicts)
+}
+
+func TestMerge3Conflict(t *testing.T) {
+	result := Merge3("a\nb\nc\n", "a\nours\nc\n", "a\ntheirs\nc\n")
+
+	expected := "a\n" +
+		ConflictOursMarker + "ours\n" +
+		ConflictBaseMarker + "b\n" +
+		ConflictSplitMarker + "theirs\n" +
+		ConflictTheirsMarker + "c\n"
+	assert.Equal(t, expected, result.Content)
+	assert.Equal(t, 1, result.Conflicts)
+}
+
+func TestMerge3InsertionsAtSamePosition(t *testing.T) {
+	result := Merge3("a\nb\n", "a\nx\nb\n", "a\ny\nb\n")
+
+	assert.Equal(t, 1, result.Conflicts)
+	assert.Contains(t, result.Content, ConflictOursMarker+"x\n")
+}
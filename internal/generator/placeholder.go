@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -29,6 +30,8 @@ var (
 		"tableName":   "{{.TableName}}",
 	}
 
+	templateErrorLineRegex = regexp.MustCompile(`^template: [^:]*:(\d+):`)
+
 	conditionalStartRegex = regexp.MustCompile(`(?m)^\s*//\s*@if\s+(\w+)\s*$`)
 	conditionalElseRegex  = regexp.MustCompile(`(?m)^\s*//\s*@else\s*$`)
 	conditionalEndRegex   = regexp.MustCompile(`(?m)^\s*//\s*@endif\s*$`)
@@ -102,6 +105,10 @@ type ValidationResult struct {
 }
 
 func ValidateTemplate(content string, templateName string) ValidationResult {
+	return validateTemplate(content, templateName, true)
+}
+
+func validateTemplate(content string, templateName string, warnUnknown bool) ValidationResult {
 	result := ValidationResult{Valid: true}
 
 	lines := strings.Split(content, "\n")
@@ -118,8 +125,9 @@ func ValidateTemplate(content string, templateName string) ValidationResult {
 		result.Valid = false
 	}
 
-	unknownVars := findUnknownVariables(lines)
-	result.Warnings = append(result.Warnings, unknownVars...)
+	if warnUnknown {
+		result.Warnings = append(result.Warnings, findUnknownVariables(lines)...)
+	}
 
 	preprocessed := PreprocessTemplate(content)
 	goTemplateErrors := validateGoTemplate(preprocessed, templateName)
@@ -253,8 +261,12 @@ func validateGoTemplate(content string, name string) []ValidationError {
 	funcMap := defaultFuncMap()
 	_, err := template.New(name).Funcs(funcMap).Parse(content)
 	if err != nil {
+		line := 1
+		if match := templateErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
 		errors = append(errors, ValidationError{
-			Line:    1,
+			Line:    line,
 			Column:  1,
 			Message: fmt.Sprintf("template syntax error: %v", err),
 		})
@@ -284,9 +296,7 @@ func GetAvailableVariables() []VariableInfo {
 		{Name: "${namePlural}", Description: "Pluralized lowercase name", Example: "users"},
 		{Name: "${NamePlural}", Description: "Pluralized PascalCase name", Example: "Users"},
 		{Name: "${BasePackage}", Description: "Base package path", Example: "com.example.app"},
-		{Name: "${Package}", Description: "Full package path for current file", Example: "com.example.app.controller"},
 		{Name: "${IDType}", Description: "Entity ID type", Example: "Long or UUID"},
-		{Name: "${TableName}", Description: "Database table name", Example: "users"},
 	}
 }
 
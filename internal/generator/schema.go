@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type TemplateSchema struct {
+	fields map[string]*TemplateSchema
+}
+
+func NewTemplateSchema(sample map[string]any) *TemplateSchema {
+	schema := &TemplateSchema{fields: make(map[string]*TemplateSchema)}
+	for name, value := range sample {
+		schema.fields[name] = schemaFromValue(reflect.ValueOf(value))
+	}
+	return schema
+}
+
+func schemaFromValue(v reflect.Value) *TemplateSchema {
+	if !v.IsValid() {
+		return &TemplateSchema{}
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return &TemplateSchema{}
+		}
+		v = v.Elem()
+	}
+
+	schema := &TemplateSchema{}
+	switch v.Kind() {
+	case reflect.Struct:
+		schema.fields = make(map[string]*TemplateSchema)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				schema.fields[t.Field(i).Name] = schemaFromValue(v.Field(i))
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return schema
+		}
+		schema.fields = make(map[string]*TemplateSchema)
+		for _, key := range v.MapKeys() {
+			schema.fields[key.String()] = schemaFromValue(v.MapIndex(key))
+		}
+	}
+
+	for i := 0; i < v.Type().NumMethod(); i++ {
+		if schema.fields == nil {
+			schema.fields = make(map[string]*TemplateSchema)
+		}
+		schema.fields[v.Type().Method(i).Name] = &TemplateSchema{}
+	}
+	return schema
+}
+
+func (s *TemplateSchema) Has(name string) bool {
+	_, ok := s.fields[name]
+	return ok
+}
+
+func (s *TemplateSchema) Names() []string {
+	names := make([]string, 0, len(s.fields))
+	for name := range s.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *TemplateSchema) Resolve(path []string) (int, *TemplateSchema) {
+	current := s
+	for i, name := range path {
+		next, ok := current.fields[name]
+		if !ok {
+			return i, current
+		}
+		current = next
+	}
+	return -1, current
+}
+
+func ClosestName(name string, candidates []string) string {
+	best := ""
+	bestDistance := max(2, len(name)/3) + 1
+	lower := strings.ToLower(name)
+
+	for _, candidate := range candidates {
+		distance := levenshtein(lower, strings.ToLower(candidate))
+		if distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -11,6 +11,7 @@ import (
 const (
 	ProjectTemplateDir = ".haft/templates"
 	GlobalTemplateDir  = ".haft/templates"
+	TemplateBaseDir    = ".haft/template-base"
 )
 
 type TemplateSource int
@@ -61,6 +62,10 @@ func NewTemplateLoaderWithHome(fs afero.Fs, projectRoot, homeDir string) *Templa
 	}
 }
 
+func (l *TemplateLoader) Fs() afero.Fs {
+	return l.fs
+}
+
 func (l *TemplateLoader) LoadTemplate(name string) (*LoadedTemplate, error) {
 	if content, path, err := l.loadFromProject(name); err == nil {
 		return &LoadedTemplate{Content: content, Source: SourceProject, Path: path}, nil
@@ -205,7 +210,38 @@ func (l *TemplateLoader) CopyEmbeddedToProject(templateName string) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	return afero.WriteFile(l.fs, destPath, content, 0644)
+	if err := afero.WriteFile(l.fs, destPath, content, 0644); err != nil {
+		return err
+	}
+
+	return l.RecordBase(templateName, content)
+}
+
+func (l *TemplateLoader) GetBaseDir() string {
+	if l.projectRoot == "" {
+		return ""
+	}
+	return filepath.Join(l.projectRoot, TemplateBaseDir)
+}
+
+func (l *TemplateLoader) RecordBase(templateName string, content []byte) error {
+	basePath := filepath.Join(l.GetBaseDir(), templateName)
+	if err := l.fs.MkdirAll(filepath.Dir(basePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return afero.WriteFile(l.fs, basePath, content, 0644)
+}
+
+func (l *TemplateLoader) LoadBase(templateName string) ([]byte, error) {
+	return afero.ReadFile(l.fs, filepath.Join(l.GetBaseDir(), templateName))
+}
+
+func (l *TemplateLoader) LoadProjectOverride(templateName string) ([]byte, string, error) {
+	return l.loadFromProject(templateName)
+}
+
+func LoadEmbeddedTemplate(templateName string) ([]byte, error) {
+	return templateFS.ReadFile("templates/" + templateName)
 }
 
 func (l *TemplateLoader) CopyAllEmbeddedToProject(templateDir string) error {
@@ -261,6 +261,38 @@ func TestTemplateLoader_CopyEmbeddedToProject(t *testing.T) {
 	assert.NotEmpty(t, content)
 }
 
+func TestTemplateLoader_CopyEmbeddedToProjectRecordsBase(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	loader := NewTemplateLoaderWithHome(fs, "/project", "/home/user")
+	name := "resource/layered/Controller.java.tmpl"
+
+	require.NoError(t, loader.CopyEmbeddedToProject(name))
+
+	base, err := loader.LoadBase(name)
+	require.NoError(t, err)
+	embedded, err := LoadEmbeddedTemplate(name)
+	require.NoError(t, err)
+	assert.Equal(t, embedded, base)
+
+	templates, err := loader.ListProjectTemplates()
+	require.NoError(t, err)
+	assert.Equal(t, []string{name}, templates)
+}
+
+func TestTemplateLoader_RecordBase(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	loader := NewTemplateLoaderWithHome(fs, "/project", "/home/user")
+
+	require.NoError(t, loader.RecordBase("resource/Custom.java.tmpl", []byte("base")))
+
+	content, err := afero.ReadFile(fs, filepath.Join("/project", TemplateBaseDir, "resource/Custom.java.tmpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "base", string(content))
+
+	_, err = loader.LoadBase("resource/Other.java.tmpl")
+	assert.Error(t, err)
+}
+
 func TestTemplateLoader_CopyEmbeddedToProject_NonExistent(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	loader := NewTemplateLoaderWithHome(fs, "/project", "/home/user")
@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+var mappedFieldRegex = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+func ValidateTemplateWithSchema(content string, templateName string, schema *TemplateSchema) ValidationResult {
+	result := validateTemplate(content, templateName, schema == nil)
+	if schema == nil {
+		return result
+	}
+
+	lines := strings.Split(content, "\n")
+	var schemaErrors []ValidationError
+	schemaErrors = append(schemaErrors, checkPlaceholders(lines, schema)...)
+	schemaErrors = append(schemaErrors, checkConditions(lines, schema)...)
+	if result.Valid {
+		schemaErrors = append(schemaErrors, checkFieldReferences(content, templateName, schema)...)
+	}
+
+	sort.SliceStable(schemaErrors, func(i, j int) bool {
+		if schemaErrors[i].Line != schemaErrors[j].Line {
+			return schemaErrors[i].Line < schemaErrors[j].Line
+		}
+		return schemaErrors[i].Column < schemaErrors[j].Column
+	})
+
+	if len(schemaErrors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, schemaErrors...)
+	}
+	return result
+}
+
+func checkPlaceholders(lines []string, schema *TemplateSchema) []ValidationError {
+	var errors []ValidationError
+	candidates := placeholderNames(schema)
+
+	for i, line := range lines {
+		for _, loc := range simplePlaceholderRegex.FindAllStringSubmatchIndex(line, -1) {
+			name := line[loc[2]:loc[3]]
+			column := loc[0] + 1
+
+			mapped, ok := simpleVarMappings[name]
+			if !ok {
+				if !schema.Has(name) {
+					errors = append(errors, ValidationError{
+						Line:    i + 1,
+						Column:  column,
+						Message: withSuggestion(fmt.Sprintf("unknown placeholder '${%s}'", name), "${", ClosestName(name, candidates), "}"),
+					})
+				}
+				continue
+			}
+
+			for _, field := range mappedFieldRegex.FindAllStringSubmatch(mapped, -1) {
+				if !schema.Has(field[1]) {
+					errors = append(errors, ValidationError{
+						Line:    i + 1,
+						Column:  column,
+						Message: fmt.Sprintf("placeholder '${%s}' maps to '.%s', which is not set by the template context", name, field[1]),
+					})
+				}
+			}
+		}
+	}
+	return errors
+}
+
+func checkConditions(lines []string, schema *TemplateSchema) []ValidationError {
+	var errors []ValidationError
+	candidates := append(sortedKeys(conditionMappings), schema.Names()...)
+
+	for i, line := range lines {
+		match := conditionalStartRegex.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		name := line[match[2]:match[3]]
+		column := match[2] + 1
+
+		mapped, ok := conditionMappings[name]
+		if !ok {
+			if !schema.Has(name) {
+				errors = append(errors, ValidationError{
+					Line:    i + 1,
+					Column:  column,
+					Message: withSuggestion(fmt.Sprintf("unknown condition '%s'", name), "", ClosestName(name, candidates), ""),
+				})
+			}
+			continue
+		}
+
+		for _, field := range mappedFieldRegex.FindAllStringSubmatch(mapped, -1) {
+			if !schema.Has(field[1]) {
+				errors = append(errors, ValidationError{
+					Line:    i + 1,
+					Column:  column,
+					Message: fmt.Sprintf("condition '%s' maps to '.%s', which is not set by the template context", name, field[1]),
+				})
+			}
+		}
+	}
+	return errors
+}
+
+func checkFieldReferences(content, templateName string, schema *TemplateSchema) []ValidationError {
+	masked := maskSimpleSyntax(content)
+	tmpl, err := template.New(templateName).Funcs(defaultFuncMap()).Parse(masked)
+	if err != nil || tmpl.Tree == nil {
+		return nil
+	}
+
+	checker := &fieldChecker{content: masked, schema: schema}
+	checker.walk(tmpl.Tree.Root, true)
+	return checker.errors
+}
+
+func maskSimpleSyntax(content string) string {
+	blank := func(match string) string {
+		return strings.Repeat(" ", len(match))
+	}
+	masked := simplePlaceholderRegex.ReplaceAllStringFunc(content, blank)
+	masked = conditionalStartRegex.ReplaceAllStringFunc(masked, blankKeepingNewlines)
+	masked = conditionalElseRegex.ReplaceAllStringFunc(masked, blankKeepingNewlines)
+	return conditionalEndRegex.ReplaceAllStringFunc(masked, blankKeepingNewlines)
+}
+
+func blankKeepingNewlines(match string) string {
+	var sb strings.Builder
+	for _, r := range match {
+		if r == '\n' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteString(strings.Repeat(" ", len(string(r))))
+		}
+	}
+	return sb.String()
+}
+
+type fieldChecker struct {
+	content string
+	schema  *TemplateSchema
+	errors  []ValidationError
+}
+
+func (c *fieldChecker) walk(node parse.Node, rootDot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, rootDot)
+		}
+	case *parse.ActionNode:
+		c.walk(n.Pipe, rootDot)
+	case *parse.IfNode:
+		c.walkBranch(&n.BranchNode, rootDot, rootDot)
+	case *parse.RangeNode:
+		c.walkBranch(&n.BranchNode, rootDot, false)
+	case *parse.WithNode:
+		c.walkBranch(&n.BranchNode, rootDot, false)
+	case *parse.TemplateNode:
+		c.walk(n.Pipe, rootDot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			c.walk(cmd, rootDot)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			c.walk(arg, rootDot)
+		}
+	case *parse.FieldNode:
+		if rootDot {
+			c.check(n.Ident, "", n.Pos)
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			c.check(n.Ident[1:], "$", n.Pos)
+		}
+	case *parse.ChainNode:
+		c.walk(n.Node, rootDot)
+	}
+}
+
+func (c *fieldChecker) walkBranch(n *parse.BranchNode, rootDot, bodyRootDot bool) {
+	c.walk(n.Pipe, rootDot)
+	c.walk(n.List, bodyRootDot)
+	c.walk(n.ElseList, rootDot)
+}
+
+func (c *fieldChecker) check(path []string, prefix string, pos parse.Pos) {
+	failed, parent := c.schema.Resolve(path)
+	if failed < 0 {
+		return
+	}
+
+	line, column := offsetToLineColumn(c.content, int(pos))
+	known := prefix + "." + strings.Join(path[:failed], ".")
+	if failed > 0 {
+		known += "."
+	}
+	name := known + path[failed]
+
+	suggestion := ClosestName(path[failed], parent.Names())
+	if suggestion != "" {
+		suggestion = known + suggestion
+	}
+
+	c.errors = append(c.errors, ValidationError{
+		Line:    line,
+		Column:  column,
+		Message: withSuggestion(fmt.Sprintf("unknown field '%s'", name), "", suggestion, ""),
+	})
+}
+
+func offsetToLineColumn(content string, offset int) (int, int) {
+	offset = min(offset, len(content))
+	before := content[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	return line, column
+}
+
+func withSuggestion(message, prefix, suggestion, suffix string) string {
+	if suggestion == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (did you mean '%s%s%s'?)", message, prefix, suggestion, suffix)
+}
+
+func placeholderNames(schema *TemplateSchema) []string {
+	return append(sortedKeys(simpleVarMappings), schema.Names()...)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
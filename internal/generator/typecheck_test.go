@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLombok struct {
+	UseData    bool
+	UseBuilder bool
+}
+
+func testSchema() *TemplateSchema {
+	return NewTemplateSchema(map[string]any{
+		"Name":        "Product",
+		"NameLower":   "product",
+		"NameCamel":   "product",
+		"BasePackage": "com.example",
+		"IDType":      "Long",
+		"HasLombok":   true,
+		"HasJpa":      true,
+		"Lombok":      testLombok{},
+		"Items":       []string{},
+	})
+}
+
+func TestTemplateSchemaResolve(t *testing.T) {
+	schema := testSchema()
+
+	failed, _ := schema.Resolve([]string{"Lombok", "UseData"})
+	assert.Equal(t, -1, failed)
+
+	failed, parent := schema.Resolve([]string{"Lombok", "UseDta"})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []string{"UseBuilder", "UseData"}, parent.Names())
+
+	failed, _ = schema.Resolve([]string{"Name", "Length"})
+	assert.Equal(t, 1, failed)
+}
+
+func TestClosestName(t *testing.T) {
+	candidates := []string{"Name", "NameCamel", "NameLower", "BasePackage"}
+
+	assert.Equal(t, "NameCamel", ClosestName("NameCamle", candidates))
+	assert.Equal(t, "BasePackage", ClosestName("basepackage", candidates))
+	assert.Empty(t, ClosestName("Completely", candidates))
+}
+
+func TestValidateTemplateWithSchemaValid(t *testing.T) {
+	content := "package ${BasePackage};\n// @if HasLombok\n@Data\n// @endif\n{{if .Lombok.UseBuilder}}@Builder{{end}}\nclass {{.Name}} { {{- range .Items}}{{.Anything}}{{end}} }\n"
+
+	result := ValidateTemplateWithSchema(content, "test.tmpl", testSchema())
+
+	assert.True(t, result.Valid, result.Errors)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestValidateTemplateWithSchemaUnknownField(t *testing.T) {
+	content := "class {{.Name}} {\n    private {{ .NameCamle }} value;\n}\n"
+
+	result := ValidateTemplateWithSchema(content, "test.tmpl", testSchema())
+
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 2, result.Errors[0].Line)
+	assert.Equal(t, 16, result.Errors[0].Column)
+	assert.Equal(t, "unknown field '.NameCamle' (did you mean '.NameCamel'?)", result.Errors[0].Message)
+}
+
+func TestValidateTemplateWithSchemaNestedField(t *testing.T) {
+	result := ValidateTemplateWithSchema("{{if .Lombok.UseDta}}x{{end}}", "test.tmpl", testSchema())
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "unknown field '.Lombok.UseDta' (did you mean '.Lombok.UseData'?)", result.Errors[0].Message)
+}
+
+func TestValidateTemplateWithSchemaRootVariable(t *testing.T) {
+	result := ValidateTemplateWithSchema("{{range .Items}}{{$.Nme}}{{end}}", "test.tmpl", testSchema())
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "unknown field '$.Nme' (did you mean '$.Name'?)", result.Errors[0].Message)
+}
+
+func TestValidateTemplateWithSchemaPlaceholders(t *testing.T) {
+	content := "package ${BasePackage};\nclass ${nameCamle} {}\nimport ${Package}.Foo;\n"
+
+	result := ValidateTemplateWithSchema(content, "test.tmpl", testSchema())
+
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, ValidationError{Line: 2, Column: 7, Message: "unknown placeholder '${nameCamle}' (did you mean '${nameCamel}'?)"}, result.Errors[0])
+	assert.Equal(t, 3, result.Errors[1].Line)
+	assert.Contains(t, result.Errors[1].Message, "maps to '.Package'")
+	assert.Empty(t, result.Warnings)
+}
+
+func TestValidateTemplateWithSchemaConditions(t *testing.T) {
+	content := "// @if HasLombk\n@Data\n// @endif\n// @if HasSwagger\n@Schema\n// @endif\n"
+
+	result := ValidateTemplateWithSchema(content, "test.tmpl", testSchema())
+
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, ValidationError{Line: 1, Column: 8, Message: "unknown condition 'HasLombk' (did you mean 'HasLombok'?)"}, result.Errors[0])
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.Contains(t, result.Errors[1].Message, "maps to '.HasSwagger'")
+}
+
+func TestValidateTemplateWithSchemaNil(t *testing.T) {
+	result := ValidateTemplateWithSchema("${unknownVar}", "test.tmpl", nil)
+
+	assert.True(t, result.Valid)
+	assert.NotEmpty(t, result.Warnings)
+}
+
+func TestValidateTemplateSyntaxErrorLine(t *testing.T) {
+	result := ValidateTemplate("line one\nline two\n{{end}}\n", "test.tmpl")
+
+	require.NotEmpty(t, result.Errors)
+	assert.Equal(t, 3, result.Errors[len(result.Errors)-1].Line)
+}
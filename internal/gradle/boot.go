@@ -0,0 +1,144 @@
+package gradle
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+const (
+	bootPlugin       = "org.springframework.boot"
+	bootGradlePlugin = "org.springframework.boot:spring-boot-gradle-plugin:"
+)
+
+func (p *Parser) SetSpringBootVersion(project *buildtool.Project, version string) bool {
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return false
+	}
+	content := gradleProject.Content
+	properties := versionProperties(content)
+
+	var edits []span
+	var catalogAlias bool
+	target := func(tok source.Token, value string) {
+		if match := templatePattern.FindStringSubmatch(value); match != nil && match[0] == value {
+			for _, entry := range properties {
+				if entry.Key == strings.TrimSpace(match[1]+match[2]) {
+					edits = append(edits, entry.value)
+				}
+			}
+			return
+		}
+		if at := strings.LastIndex(tok.Text, value); at >= 0 {
+			edits = append(edits, span{start: tok.Offset + at, end: tok.Offset + at + len(value)})
+		}
+	}
+
+	if plugins, ok := findBlock(content, 0, len(content), "plugins"); ok {
+		from, to := plugins.inner()
+		for _, stmt := range statements(content, from, to) {
+			entry, ok := pluginDeclaration(stmt.tokens, gradleProject.Catalog)
+			if !ok || entry.ID != bootPlugin {
+				continue
+			}
+			if entry.accessor != "" {
+				catalogAlias = true
+				continue
+			}
+			if tok, ok := versionToken(stmt.tokens); ok {
+				target(tok, tok.Value)
+			}
+		}
+	}
+
+	if buildscript, ok := findBlock(content, 0, len(content), "buildscript"); ok {
+		from, to := buildscript.inner()
+		if deps, ok := findBlock(content, from, to, "dependencies"); ok {
+			from, to := deps.inner()
+			for _, stmt := range statements(content, from, to) {
+				name, args, ok := configurationCall(stmt.tokens)
+				if !ok || name != "classpath" || len(args) == 0 || len(args[0]) != 1 || !isLiteral(args[0][0]) {
+					continue
+				}
+				if coords := args[0][0].Value; strings.HasPrefix(coords, bootGradlePlugin) {
+					target(args[0][0], strings.TrimPrefix(coords, bootGradlePlugin))
+				}
+			}
+		}
+	}
+
+	updated := len(edits) > 0
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for i, edit := range edits {
+		if i > 0 && edit == edits[i-1] {
+			continue
+		}
+		content = content[:edit.start] + version + content[edit.end:]
+	}
+	gradleProject.Content = content
+
+	if catalogAlias && gradleProject.Catalog != nil {
+		if ok, err := gradleProject.Catalog.SetPluginVersion(bootPlugin, version); err == nil && ok {
+			updated = true
+		}
+	}
+	if !updated {
+		return false
+	}
+
+	project.SpringBootVersion = version
+	for i := range project.Plugins {
+		if project.Plugins[i].ID == bootPlugin {
+			project.Plugins[i].Version = version
+		}
+	}
+	project.Properties = p.extractProperties(gradleProject.Content)
+	return true
+}
+
+func versionToken(tokens []source.Token) (source.Token, bool) {
+	for i := 1; i < len(tokens)-1; i++ {
+		if tokens[i].Kind != source.TokenIdent || tokens[i].Text != "version" {
+			continue
+		}
+		rest := tokens[i+1:]
+		if len(rest) >= 3 && isPunct(rest[0], "(") {
+			rest = rest[1:]
+		}
+		if isLiteral(rest[0]) {
+			return rest[0], true
+		}
+	}
+	return source.Token{}, false
+}
+
+func versionProperties(content string) []propertyEntry {
+	entries := parseProperties(content)
+	buildscript, ok := findBlock(content, 0, len(content), "buildscript")
+	if !ok {
+		return entries
+	}
+	from, to := buildscript.inner()
+	ext, ok := findBlock(content, from, to, "ext")
+	if !ok {
+		return entries
+	}
+	from, to = ext.inner()
+	for _, stmt := range statements(content, from, to) {
+		text := content[stmt.start:stmt.end]
+		for _, pattern := range extBlockPropertyPatterns {
+			if match := pattern.FindStringSubmatchIndex(text); match != nil {
+				entries = append(entries, propertyEntry{
+					Property: buildtool.Property{Key: text[match[2]:match[3]], Value: text[match[4]:match[5]]},
+					stmt:     stmt.span,
+					value:    span{start: stmt.start + match[4], end: stmt.start + match[5]},
+				})
+				break
+			}
+		}
+	}
+	return entries
+}
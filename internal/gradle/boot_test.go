@@ -0,0 +1,76 @@
+package gradle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_SetSpringBootVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		isKotlin bool
+	}{
+		{"groovy", `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.3.5'
+    id 'io.spring.dependency-management' version '1.1.7'
+}
+`, false},
+		{"kotlin", `plugins {
+    java
+    id("org.springframework.boot") version "3.3.5"
+}
+`, true},
+		{"buildscript", `buildscript {
+    ext {
+        springBootVersion = '3.3.5'
+    }
+    dependencies {
+        classpath "org.springframework.boot:spring-boot-gradle-plugin:${springBootVersion}"
+    }
+}
+
+apply plugin: 'org.springframework.boot'
+`, false},
+		{"classpath", `buildscript {
+    dependencies {
+        classpath 'org.springframework.boot:spring-boot-gradle-plugin:3.3.5'
+    }
+}
+`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, project := parseContent(t, tt.content, tt.isKotlin)
+			assert.Equal(t, "3.3.5", parser.GetSpringBootVersion(project))
+
+			assert.True(t, parser.SetSpringBootVersion(project, "3.4.1"))
+			assert.Equal(t, "3.4.1", parser.GetSpringBootVersion(project))
+			assert.Equal(t, strings.ReplaceAll(tt.content, "3.3.5", "3.4.1"), content(project))
+		})
+	}
+}
+
+func TestParser_SetSpringBootVersion_Catalog(t *testing.T) {
+	fs, parser, project := setupCatalogProject(t, "build.gradle.kts", `plugins {
+    alias(libs.plugins.spring.boot)
+}
+`)
+
+	assert.True(t, parser.SetSpringBootVersion(project, "3.5.0"))
+	require.NoError(t, parser.Write("/project/api/build.gradle.kts", project))
+
+	data, err := afero.ReadFile(fs, "/project/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(versionCatalog, `spring-boot = "3.4.1"`, `spring-boot = "3.5.0"`, 1), string(data))
+}
+
+func TestParser_SetSpringBootVersion_NoBootPlugin(t *testing.T) {
+	parser, project := parseContent(t, "plugins {\n    id 'java'\n}\n", false)
+	assert.False(t, parser.SetSpringBootVersion(project, "3.4.1"))
+}
@@ -0,0 +1,466 @@
+package gradle
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+)
+
+const CatalogFile = "libs.versions.toml"
+
+var catalogSectionOrder = []string{"versions", "libraries", "bundles", "plugins"}
+
+type CatalogLibrary struct {
+	Alias      string
+	Group      string
+	Name       string
+	Version    string
+	VersionRef string
+}
+
+type Catalog struct {
+	Path      string
+	Versions  map[string]string
+	Libraries []CatalogLibrary
+	Bundles   map[string][]string
+	Plugins   map[string]string
+	doc       *tomlDocument
+	modified  bool
+}
+
+func ParseCatalog(path string, data []byte) (*Catalog, error) {
+	catalog := &Catalog{Path: path}
+	if err := catalog.load(string(data)); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return catalog, nil
+}
+
+func FindCatalog(fs afero.Fs, buildFile string) string {
+	for _, dir := range projectDirs(fs, buildFile) {
+		candidate := filepath.Join(dir, "gradle", CatalogFile)
+		if _, err := fs.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (c *Catalog) Bytes() []byte {
+	return []byte(c.doc.content)
+}
+
+func (c *Catalog) Modified() bool {
+	return c.modified
+}
+
+func (c *Catalog) load(content string) error {
+	doc, err := parseTOML(content)
+	if err != nil {
+		return err
+	}
+	c.doc = doc
+	c.Versions = make(map[string]string)
+	c.Bundles = make(map[string][]string)
+	c.Plugins = make(map[string]string)
+	c.Libraries = nil
+
+	if s := doc.section("versions"); s != nil {
+		for _, entry := range s.entries {
+			c.Versions[entry.key] = versionOf(entry.value)
+		}
+	}
+	if s := doc.section("libraries"); s != nil {
+		for _, entry := range s.entries {
+			if lib, ok := c.parseLibrary(entry); ok {
+				c.Libraries = append(c.Libraries, lib)
+			}
+		}
+	}
+	if s := doc.section("bundles"); s != nil {
+		for _, entry := range s.entries {
+			for _, item := range entry.value.array {
+				c.Bundles[entry.key] = append(c.Bundles[entry.key], item.str)
+			}
+		}
+	}
+	if s := doc.section("plugins"); s != nil {
+		for _, entry := range s.entries {
+			id, version := pluginOf(entry.value, c.Versions)
+			if id != "" {
+				c.Plugins[id] = version
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) parseLibrary(entry tomlEntry) (CatalogLibrary, bool) {
+	lib := CatalogLibrary{Alias: entry.key}
+	value := entry.value
+
+	if value.table == nil {
+		parts := strings.Split(value.str, ":")
+		if len(parts) < 2 {
+			return lib, false
+		}
+		lib.Group, lib.Name = parts[0], parts[1]
+		if len(parts) > 2 {
+			lib.Version = parts[2]
+		}
+		return lib, true
+	}
+
+	if module, ok := value.table["module"]; ok {
+		lib.Group, lib.Name, _ = strings.Cut(module.str, ":")
+	} else {
+		lib.Group, lib.Name = value.table["group"].str, value.table["name"].str
+	}
+	if lib.Group == "" || lib.Name == "" {
+		return lib, false
+	}
+
+	lib.VersionRef = versionRefOf(value.table)
+	if lib.VersionRef != "" {
+		lib.Version = c.Versions[lib.VersionRef]
+	} else {
+		lib.Version = versionOf(value.table["version"])
+	}
+	return lib, true
+}
+
+func versionOf(value tomlValue) string {
+	if value.table == nil {
+		return value.str
+	}
+	for _, key := range []string{"strictly", "require", "prefer"} {
+		if v, ok := value.table[key]; ok {
+			return v.str
+		}
+	}
+	return ""
+}
+
+func versionRefOf(table map[string]tomlValue) string {
+	if ref, ok := table["version.ref"]; ok {
+		return ref.str
+	}
+	return table["version"].table["ref"].str
+}
+
+func pluginOf(value tomlValue, versions map[string]string) (string, string) {
+	if value.table == nil {
+		id, version, _ := strings.Cut(value.str, ":")
+		return id, version
+	}
+	if ref := versionRefOf(value.table); ref != "" {
+		return value.table["id"].str, versions[ref]
+	}
+	return value.table["id"].str, versionOf(value.table["version"])
+}
+
+func Accessor(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
+
+func (c *Catalog) Find(groupId, artifactId string) []CatalogLibrary {
+	var libs []CatalogLibrary
+	for _, lib := range c.Libraries {
+		if lib.Group == groupId && lib.Name == artifactId {
+			libs = append(libs, lib)
+		}
+	}
+	return libs
+}
+
+func (c *Catalog) Resolve(accessor string) []CatalogLibrary {
+	if bundle, ok := strings.CutPrefix(accessor, "bundles."); ok {
+		var libs []CatalogLibrary
+		for alias, members := range c.Bundles {
+			if Accessor(alias) != bundle {
+				continue
+			}
+			for _, member := range members {
+				libs = append(libs, c.Resolve(Accessor(member))...)
+			}
+		}
+		return libs
+	}
+	for _, lib := range c.Libraries {
+		if Accessor(lib.Alias) == accessor {
+			return []CatalogLibrary{lib}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) InBundle(alias string) bool {
+	for _, members := range c.Bundles {
+		for _, member := range members {
+			if member == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Catalog) AddLibrary(dep buildtool.Dependency) (string, error) {
+	if existing := c.Find(dep.GroupId, dep.ArtifactId); len(existing) > 0 {
+		return existing[0].Alias, nil
+	}
+
+	alias := c.newAlias(dep)
+	fields := fmt.Sprintf(`module = "%s:%s"`, dep.GroupId, dep.ArtifactId)
+	if dep.Version != "" {
+		current, exists := c.Versions[alias]
+		switch {
+		case !exists:
+			if err := c.insert("versions", fmt.Sprintf(`%s = "%s"`, alias, dep.Version)); err != nil {
+				return "", err
+			}
+			fields += fmt.Sprintf(`, version.ref = "%s"`, alias)
+		case current == dep.Version:
+			fields += fmt.Sprintf(`, version.ref = "%s"`, alias)
+		default:
+			fields += fmt.Sprintf(`, version = "%s"`, dep.Version)
+		}
+	}
+
+	if err := c.insert("libraries", fmt.Sprintf("%s = { %s }", alias, fields)); err != nil {
+		return "", err
+	}
+	return alias, nil
+}
+
+func (c *Catalog) RemoveLibrary(alias string) error {
+	return c.removeEntry("libraries", alias)
+}
+
+func (c *Catalog) ResolvePlugin(accessor string) (string, string, bool) {
+	if s := c.doc.section("plugins"); s != nil {
+		for _, entry := range s.entries {
+			if Accessor(entry.key) == accessor {
+				id, version := pluginOf(entry.value, c.Versions)
+				return id, version, id != ""
+			}
+		}
+	}
+	return "", "", false
+}
+
+func (c *Catalog) AddPlugin(id, version string) (string, error) {
+	if s := c.doc.section("plugins"); s != nil {
+		for _, entry := range s.entries {
+			if existing, _ := pluginOf(entry.value, c.Versions); existing == id {
+				return entry.key, nil
+			}
+		}
+	}
+
+	taken := func(alias string) bool {
+		_, _, exists := c.ResolvePlugin(Accessor(alias))
+		return exists
+	}
+	alias := aliasFor(id[strings.LastIndex(id, ".")+1:])
+	if taken(alias) {
+		alias = aliasFor(strings.ReplaceAll(id, ".", "-"))
+	}
+
+	fields := fmt.Sprintf(`id = "%s"`, id)
+	if version != "" {
+		if _, exists := c.Versions[alias]; !exists {
+			if err := c.insert("versions", fmt.Sprintf(`%s = "%s"`, alias, version)); err != nil {
+				return "", err
+			}
+		}
+		if c.Versions[alias] == version {
+			fields += fmt.Sprintf(`, version.ref = "%s"`, alias)
+		} else {
+			fields += fmt.Sprintf(`, version = "%s"`, version)
+		}
+	}
+	if err := c.insert("plugins", fmt.Sprintf("%s = { %s }", alias, fields)); err != nil {
+		return "", err
+	}
+	return alias, nil
+}
+
+func (c *Catalog) SetPluginVersion(id, version string) (bool, error) {
+	section := c.doc.section("plugins")
+	if section == nil {
+		return false, nil
+	}
+	for _, entry := range section.entries {
+		existing, current := pluginOf(entry.value, c.Versions)
+		if existing != id || current == "" {
+			continue
+		}
+		if entry.value.table == nil {
+			return true, c.replaceValue(entry, id+":"+current, id+":"+version)
+		}
+		if ref := versionRefOf(entry.value.table); ref != "" {
+			for _, v := range c.doc.section("versions").entries {
+				if v.key == ref {
+					return true, c.replaceValue(v, current, version)
+				}
+			}
+			return false, nil
+		}
+		return true, c.replaceValue(entry, current, version)
+	}
+	return false, nil
+}
+
+func (c *Catalog) replaceValue(entry tomlEntry, old, value string) error {
+	if old == value {
+		return nil
+	}
+	text := c.doc.content[entry.start:entry.end]
+	for _, quote := range []string{`"`, "'"} {
+		if at := strings.Index(text, quote+old+quote); at >= 0 {
+			start := entry.start + at + len(quote)
+			return c.replace(start, start+len(old), value)
+		}
+	}
+	return fmt.Errorf("could not find version %s of %s", old, entry.key)
+}
+
+func (c *Catalog) RemovePlugin(alias string) error {
+	return c.removeEntry("plugins", alias)
+}
+
+func (c *Catalog) removeEntry(name, alias string) error {
+	section := c.doc.section(name)
+	if section == nil {
+		return nil
+	}
+	for _, entry := range section.entries {
+		if entry.key != alias {
+			continue
+		}
+		ref := ""
+		if entry.value.table != nil {
+			ref = versionRefOf(entry.value.table)
+		}
+		if err := c.replace(entry.start, entry.end, ""); err != nil {
+			return err
+		}
+		if ref != "" && !c.versionInUse(ref) {
+			return c.removeVersion(ref)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (c *Catalog) versionInUse(ref string) bool {
+	for _, name := range []string{"libraries", "plugins"} {
+		if s := c.doc.section(name); s != nil {
+			for _, entry := range s.entries {
+				if entry.value.table != nil && versionRefOf(entry.value.table) == ref {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (c *Catalog) removeVersion(key string) error {
+	if s := c.doc.section("versions"); s != nil {
+		for _, entry := range s.entries {
+			if entry.key == key {
+				return c.replace(entry.start, entry.end, "")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) newAlias(dep buildtool.Dependency) string {
+	taken := func(alias string) bool {
+		for _, lib := range c.Libraries {
+			if Accessor(lib.Alias) == Accessor(alias) {
+				return true
+			}
+		}
+		first, _, _ := strings.Cut(Accessor(alias), ".")
+		return first == "bundles" || first == "versions" || first == "plugins"
+	}
+
+	alias := aliasFor(dep.ArtifactId)
+	if taken(alias) {
+		group := dep.GroupId[strings.LastIndex(dep.GroupId, ".")+1:]
+		alias = aliasFor(group + "-" + dep.ArtifactId)
+	}
+	for i, base := 2, alias; taken(alias); i++ {
+		alias = fmt.Sprintf("%s-v%d", base, i)
+	}
+	return alias
+}
+
+var invalidAliasChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+func aliasFor(name string) string {
+	alias := strings.Trim(invalidAliasChars.ReplaceAllString(name, "-"), "-_")
+	if alias == "" || alias[0] < 'a' || alias[0] > 'z' {
+		alias = "lib-" + alias
+	}
+	return alias
+}
+
+func (c *Catalog) insert(name, line string) error {
+	content := c.doc.content
+	text := line + "\n"
+	at := len(content)
+
+	if section := c.doc.section(name); section != nil {
+		at = section.body
+		if len(section.entries) > 0 {
+			at = section.entries[len(section.entries)-1].end
+		}
+	} else {
+		text = "[" + name + "]\n" + text
+		if next := c.nextSection(name); next != nil {
+			at = next.start
+			text += "\n"
+		} else if strings.TrimSpace(content) != "" {
+			text = "\n" + text
+		}
+	}
+
+	if at > 0 && content[at-1] != '\n' {
+		text = "\n" + text
+	}
+	return c.replace(at, at, text)
+}
+
+func (c *Catalog) nextSection(name string) *tomlSection {
+	var next *tomlSection
+	after := false
+	for _, candidate := range catalogSectionOrder {
+		if candidate == name {
+			after = true
+			continue
+		}
+		if s := c.doc.section(candidate); after && s != nil && (next == nil || s.start < next.start) {
+			next = s
+		}
+	}
+	return next
+}
+
+func (c *Catalog) replace(start, end int, text string) error {
+	original := c.doc.content
+	if err := c.load(original[:start] + text + original[end:]); err != nil {
+		_ = c.load(original)
+		return err
+	}
+	c.modified = true
+	return nil
+}
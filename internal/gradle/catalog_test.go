@@ -0,0 +1,217 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const versionCatalog = `# Shared versions
+[versions]
+spring-boot = "3.4.1"
+jjwt = "0.12.5"
+mapstruct = { strictly = "1.5.5.Final" }
+
+[libraries]
+spring-boot-starter-web = { module = "org.springframework.boot:spring-boot-starter-web" }
+jjwt-api = { group = "io.jsonwebtoken", name = "jjwt-api", version.ref = "jjwt" }
+jjwt-impl = { module = "io.jsonwebtoken:jjwt-impl", version = { ref = "jjwt" } }
+mapstruct = { module = "org.mapstruct:mapstruct", version.ref = "mapstruct" }
+lombok = "org.projectlombok:lombok:1.18.30" # inline
+
+[bundles]
+jjwt = [
+    "jjwt-api",
+    "jjwt-impl", # runtime
+]
+
+[plugins]
+spring-boot = { id = "org.springframework.boot", version.ref = "spring-boot" }
+`
+
+func TestParseCatalog(t *testing.T) {
+	catalog, err := ParseCatalog("/project/gradle/libs.versions.toml", []byte(versionCatalog))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"spring-boot": "3.4.1", "jjwt": "0.12.5", "mapstruct": "1.5.5.Final"}, catalog.Versions)
+	assert.Equal(t, []CatalogLibrary{
+		{Alias: "spring-boot-starter-web", Group: "org.springframework.boot", Name: "spring-boot-starter-web"},
+		{Alias: "jjwt-api", Group: "io.jsonwebtoken", Name: "jjwt-api", Version: "0.12.5", VersionRef: "jjwt"},
+		{Alias: "jjwt-impl", Group: "io.jsonwebtoken", Name: "jjwt-impl", Version: "0.12.5", VersionRef: "jjwt"},
+		{Alias: "mapstruct", Group: "org.mapstruct", Name: "mapstruct", Version: "1.5.5.Final", VersionRef: "mapstruct"},
+		{Alias: "lombok", Group: "org.projectlombok", Name: "lombok", Version: "1.18.30"},
+	}, catalog.Libraries)
+	assert.Equal(t, []string{"jjwt-api", "jjwt-impl"}, catalog.Bundles["jjwt"])
+	assert.Equal(t, "3.4.1", catalog.Plugins["org.springframework.boot"])
+}
+
+func TestParseCatalog_Invalid(t *testing.T) {
+	_, err := ParseCatalog("/libs.versions.toml", []byte("[libraries]\nweb = { module = \"a:b\"\n"))
+	assert.ErrorContains(t, err, "failed to parse libs.versions.toml: line 2")
+}
+
+func TestCatalog_Resolve(t *testing.T) {
+	catalog, err := ParseCatalog("/libs.versions.toml", []byte(versionCatalog))
+	require.NoError(t, err)
+
+	assert.Equal(t, "spring.boot.starter.web", Accessor("spring-boot-starter-web"))
+	assert.Equal(t, "spring-boot-starter-web", catalog.Resolve("spring.boot.starter.web")[0].Name)
+	assert.Len(t, catalog.Resolve("bundles.jjwt"), 2)
+	assert.Empty(t, catalog.Resolve("spring.boot"))
+}
+
+func TestCatalog_AddLibrary(t *testing.T) {
+	catalog, err := ParseCatalog("/libs.versions.toml", []byte(versionCatalog))
+	require.NoError(t, err)
+
+	alias, err := catalog.AddLibrary(buildtool.Dependency{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt-api"})
+	require.NoError(t, err)
+	assert.Equal(t, "jjwt-api", alias)
+	assert.False(t, catalog.Modified())
+
+	alias, err = catalog.AddLibrary(buildtool.Dependency{GroupId: "org.springdoc", ArtifactId: "springdoc-openapi-starter-webmvc-ui", Version: "2.7.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "springdoc-openapi-starter-webmvc-ui", alias)
+
+	alias, err = catalog.AddLibrary(buildtool.Dependency{GroupId: "org.mapstruct.extensions", ArtifactId: "mapstruct", Version: "2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "extensions-mapstruct", alias)
+
+	assert.True(t, catalog.Modified())
+	assert.Equal(t, `# Shared versions
+[versions]
+spring-boot = "3.4.1"
+jjwt = "0.12.5"
+mapstruct = { strictly = "1.5.5.Final" }
+springdoc-openapi-starter-webmvc-ui = "2.7.0"
+extensions-mapstruct = "2.0"
+
+[libraries]
+spring-boot-starter-web = { module = "org.springframework.boot:spring-boot-starter-web" }
+jjwt-api = { group = "io.jsonwebtoken", name = "jjwt-api", version.ref = "jjwt" }
+jjwt-impl = { module = "io.jsonwebtoken:jjwt-impl", version = { ref = "jjwt" } }
+mapstruct = { module = "org.mapstruct:mapstruct", version.ref = "mapstruct" }
+lombok = "org.projectlombok:lombok:1.18.30" # inline
+springdoc-openapi-starter-webmvc-ui = { module = "org.springdoc:springdoc-openapi-starter-webmvc-ui", version.ref = "springdoc-openapi-starter-webmvc-ui" }
+extensions-mapstruct = { module = "org.mapstruct.extensions:mapstruct", version.ref = "extensions-mapstruct" }
+
+[bundles]
+jjwt = [
+    "jjwt-api",
+    "jjwt-impl", # runtime
+]
+
+[plugins]
+spring-boot = { id = "org.springframework.boot", version.ref = "spring-boot" }
+`, string(catalog.Bytes()))
+}
+
+func TestCatalog_AddLibraryCreatesSections(t *testing.T) {
+	catalog, err := ParseCatalog("/libs.versions.toml", []byte("[plugins]\nspring-boot = \"org.springframework.boot:3.4.1\""))
+	require.NoError(t, err)
+
+	_, err = catalog.AddLibrary(buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2", Version: "2.3.232"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[versions]\nh2 = \"2.3.232\"\n\n[libraries]\nh2 = { module = \"com.h2database:h2\", version.ref = \"h2\" }\n\n[plugins]\nspring-boot = \"org.springframework.boot:3.4.1\"", string(catalog.Bytes()))
+}
+
+func TestCatalog_RemoveLibrary(t *testing.T) {
+	catalog, err := ParseCatalog("/libs.versions.toml", []byte(versionCatalog))
+	require.NoError(t, err)
+
+	require.NoError(t, catalog.RemoveLibrary("mapstruct"))
+	require.NoError(t, catalog.RemoveLibrary("jjwt-api"))
+
+	content := string(catalog.Bytes())
+	assert.NotContains(t, content, "mapstruct")
+	assert.NotContains(t, content, "jjwt-api = ")
+	assert.Contains(t, content, "jjwt = \"0.12.5\"")
+	assert.True(t, catalog.InBundle("jjwt-impl"))
+}
+
+func setupCatalogProject(t *testing.T, buildFile, build string) (afero.Fs, *Parser, *buildtool.Project) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	isKotlin := buildFile == "build.gradle.kts"
+	require.NoError(t, afero.WriteFile(fs, "/project/settings.gradle", []byte("include 'api'"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/project/gradle/libs.versions.toml", []byte(versionCatalog), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/project/api/"+buildFile, []byte(build), 0644))
+
+	parser := NewParserWithFs(fs, isKotlin)
+	project, err := parser.Parse("/project/api/" + buildFile)
+	require.NoError(t, err)
+	return fs, parser, project
+}
+
+func TestParser_Parse_VersionCatalog(t *testing.T) {
+	_, _, project := setupCatalogProject(t, "build.gradle.kts", `plugins {
+    alias(libs.plugins.spring.boot)
+}
+
+dependencies {
+    implementation(libs.spring.boot.starter.web)
+    implementation(libs.bundles.jjwt)
+    compileOnly(libs.lombok)
+    "integrationTestImplementation"(libs.mapstruct)
+}
+`)
+
+	assert.Equal(t, "3.4.1", project.SpringBootVersion)
+	assert.Equal(t, []string{"/project/gradle/libs.versions.toml"}, project.Files)
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web", Scope: "compile"},
+		{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt-api", Version: "0.12.5", Scope: "compile"},
+		{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt-impl", Version: "0.12.5", Scope: "compile"},
+		{GroupId: "org.projectlombok", ArtifactId: "lombok", Version: "1.18.30", Scope: "provided"},
+	}, project.Dependencies)
+	require.Len(t, project.Profiles, 1)
+	assert.Equal(t, "mapstruct", project.Profiles[0].Dependencies[0].ArtifactId)
+}
+
+func TestParser_AddDependency_VersionCatalog(t *testing.T) {
+	fs, parser, project := setupCatalogProject(t, "build.gradle", "dependencies {\n    implementation libs.spring.boot.starter.web\n}\n")
+
+	parser.AddDependency(project, buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2", Version: "2.3.232", Scope: "runtime"})
+	parser.AddDependency(project, buildtool.Dependency{GroupId: "org.projectlombok", ArtifactId: "lombok", Scope: "provided"})
+	require.NoError(t, parser.Write("/project/api/build.gradle", project))
+
+	build, err := afero.ReadFile(fs, "/project/api/build.gradle")
+	require.NoError(t, err)
+	assert.Equal(t, "dependencies {\n    implementation libs.spring.boot.starter.web\n    runtimeOnly libs.h2\n    compileOnly libs.lombok\n}\n", string(build))
+
+	catalog, err := afero.ReadFile(fs, "/project/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	assert.Contains(t, string(catalog), "mapstruct = { strictly = \"1.5.5.Final\" }\nh2 = \"2.3.232\"\n")
+	assert.Contains(t, string(catalog), "lombok = \"org.projectlombok:lombok:1.18.30\" # inline\nh2 = { module = \"com.h2database:h2\", version.ref = \"h2\" }\n")
+}
+
+func TestParser_RemoveDependency_VersionCatalog(t *testing.T) {
+	fs, parser, project := setupCatalogProject(t, "build.gradle.kts", `dependencies {
+    implementation(libs.spring.boot.starter.web)
+    implementation(libs.mapstruct)
+    compileOnly(libs.lombok)
+    annotationProcessor(libs.lombok)
+}
+`)
+	require.NoError(t, afero.WriteFile(fs, "/project/web/build.gradle.kts", []byte("dependencies {\n    implementation(libs.spring.boot.starter.web)\n}\n"), 0644))
+
+	assert.True(t, parser.RemoveDependency(project, "org.projectlombok", "lombok"))
+	assert.True(t, parser.RemoveDependency(project, "org.mapstruct", "mapstruct"))
+	assert.True(t, parser.RemoveDependency(project, "org.springframework.boot", "spring-boot-starter-web"))
+	require.NoError(t, parser.Write("/project/api/build.gradle.kts", project))
+
+	build, err := afero.ReadFile(fs, "/project/api/build.gradle.kts")
+	require.NoError(t, err)
+	assert.Equal(t, "dependencies {\n}\n", string(build))
+
+	data, err := afero.ReadFile(fs, "/project/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	catalog := string(data)
+	assert.NotContains(t, catalog, "lombok")
+	assert.NotContains(t, catalog, "mapstruct")
+	assert.Contains(t, catalog, "spring-boot-starter-web = ")
+}
@@ -0,0 +1,377 @@
+package gradle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+var sourceSetConfigurationPattern = regexp.MustCompile(`^([a-z]\w*?)(Implementation|CompileOnly|RuntimeOnly|AnnotationProcessor)$`)
+
+type declaration struct {
+	configuration string
+	sourceSet     string
+	dep           buildtool.Dependency
+	accessor      string
+	stmt          span
+}
+
+type dependencyBlock struct {
+	block
+	container string
+}
+
+type notation struct {
+	dep      buildtool.Dependency
+	accessor string
+	platform bool
+}
+
+func dependencyBlocks(content string) []dependencyBlock {
+	var blocks []dependencyBlock
+	for _, stmt := range statements(content, 0, len(content)) {
+		head, body := trailingBlock(stmt)
+		if body == nil || len(head) != 1 || head[0].Kind != source.TokenIdent {
+			continue
+		}
+		switch name := head[0].Text; name {
+		case "dependencies":
+			blocks = append(blocks, dependencyBlock{block: *body})
+		case "subprojects", "allprojects":
+			from, to := body.inner()
+			if deps, ok := findBlock(content, from, to, "dependencies"); ok {
+				blocks = append(blocks, dependencyBlock{block: deps, container: name})
+			}
+		}
+	}
+	return blocks
+}
+
+func (p *Parser) parseDeclarations(content string, catalog *Catalog, vars map[string]string) []declaration {
+	var decls []declaration
+	for _, deps := range dependencyBlocks(content) {
+		decls = append(decls, p.parseBlock(content, deps, catalog, vars)...)
+	}
+	return decls
+}
+
+func (p *Parser) parseBlock(content string, deps dependencyBlock, catalog *Catalog, vars map[string]string) []declaration {
+	var decls []declaration
+	from, to := deps.inner()
+	for _, stmt := range statements(content, from, to) {
+		head, body := trailingBlock(stmt)
+		configuration, args, ok := configurationCall(head)
+		if !ok {
+			continue
+		}
+		n, ok := p.parseNotation(args, vars)
+		if !ok {
+			continue
+		}
+
+		targets := []buildtool.Dependency{n.dep}
+		if n.accessor != "" {
+			if catalog == nil {
+				continue
+			}
+			targets = nil
+			for _, lib := range catalog.Resolve(n.accessor) {
+				targets = append(targets, buildtool.Dependency{GroupId: lib.Group, ArtifactId: lib.Name, Version: lib.Version})
+			}
+		}
+
+		var exclusions []buildtool.Exclusion
+		if body != nil {
+			exclusions = parseExclusions(content, *body, vars)
+		}
+		sourceSet, scope := p.configurationScope(configuration)
+		for _, dep := range targets {
+			dep.Scope = scope
+			if n.platform {
+				dep.Type, dep.Scope = "pom", "import"
+			}
+			dep.Exclusions = exclusions
+			decls = append(decls, declaration{
+				configuration: configuration,
+				sourceSet:     sourceSet,
+				dep:           dep,
+				accessor:      n.accessor,
+				stmt:          stmt.span,
+			})
+		}
+	}
+	return decls
+}
+
+func (p *Parser) extractDependencies(decls []declaration) []buildtool.Dependency {
+	var deps []buildtool.Dependency
+	seen := make(map[string]bool)
+	for _, decl := range decls {
+		key := decl.dep.GroupId + ":" + decl.dep.ArtifactId
+		if decl.sourceSet != "" || isPlatform(decl.dep) || seen[key] {
+			continue
+		}
+		seen[key] = true
+		deps = append(deps, decl.dep)
+	}
+	return deps
+}
+
+func (p *Parser) configurationScope(configuration string) (string, string) {
+	if match := sourceSetConfigurationPattern.FindStringSubmatch(configuration); match != nil && match[1] != "test" {
+		return match[1], p.mapGradleScope(lowerFirst(match[2]))
+	}
+	return "", p.mapGradleScope(configuration)
+}
+
+func configurationCall(head []source.Token) (string, [][]source.Token, bool) {
+	if len(head) < 2 {
+		return "", nil, false
+	}
+
+	var configuration string
+	switch head[0].Kind {
+	case source.TokenIdent:
+		configuration = head[0].Text
+	case source.TokenString, source.TokenChar:
+		configuration = head[0].Value
+	default:
+		return "", nil, false
+	}
+
+	rest := head[1:]
+	if isPunct(rest[0], "(") {
+		if matching(rest, 0) != len(rest)-1 {
+			return "", nil, false
+		}
+		rest = rest[1 : len(rest)-1]
+	}
+	return configuration, splitArgs(rest), true
+}
+
+func splitArgs(tokens []source.Token) [][]source.Token {
+	var args [][]source.Token
+	depth, begin := 0, 0
+	for i, tok := range tokens {
+		if tok.Kind != source.TokenPunct {
+			continue
+		}
+		switch tok.Text {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			depth--
+		case ",":
+			if depth == 0 {
+				args = append(args, tokens[begin:i])
+				begin = i + 1
+			}
+		}
+	}
+	if begin < len(tokens) {
+		args = append(args, tokens[begin:])
+	}
+	return args
+}
+
+func namedArgs(args [][]source.Token, vars map[string]string) (map[string]string, [][]source.Token) {
+	named := make(map[string]string)
+	var positional [][]source.Token
+	for _, arg := range args {
+		if len(arg) == 3 && arg[0].Kind == source.TokenIdent && (isPunct(arg[1], ":") || isPunct(arg[1], "=")) && isLiteral(arg[2]) {
+			named[arg[0].Text] = literalValue(arg[2], vars)
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	return named, positional
+}
+
+func (p *Parser) parseNotation(args [][]source.Token, vars map[string]string) (notation, bool) {
+	named, positional := namedArgs(args, vars)
+	if len(positional) == 0 {
+		if named["group"] == "" || named["name"] == "" {
+			return notation{}, false
+		}
+		return notation{dep: buildtool.Dependency{
+			GroupId:    named["group"],
+			ArtifactId: named["name"],
+			Version:    named["version"],
+			Classifier: named["classifier"],
+			Type:       named["ext"],
+		}}, true
+	}
+
+	arg := positional[0]
+	switch {
+	case len(arg) == 1 && isLiteral(arg[0]):
+		dep := p.parseCoordinates(literalValue(arg[0], vars))
+		return notation{dep: dep}, dep.GroupId != "" && dep.ArtifactId != ""
+	case len(arg) > 2 && arg[0].Kind == source.TokenIdent && isPunct(arg[1], "(") && matching(arg, 1) == len(arg)-1:
+		inner := splitArgs(arg[2 : len(arg)-1])
+		switch arg[0].Text {
+		case "platform", "enforcedPlatform":
+			n, ok := p.parseNotation(inner, vars)
+			n.platform = true
+			return n, ok
+		case "kotlin":
+			_, values := namedArgs(inner, vars)
+			if len(values) == 0 || len(values[0]) != 1 || !isLiteral(values[0][0]) {
+				return notation{}, false
+			}
+			dep := buildtool.Dependency{GroupId: "org.jetbrains.kotlin", ArtifactId: "kotlin-" + literalValue(values[0][0], vars)}
+			if len(values) > 1 && len(values[1]) == 1 && isLiteral(values[1][0]) {
+				dep.Version = literalValue(values[1][0], vars)
+			}
+			return notation{dep: dep}, true
+		}
+	case len(arg) > 2 && arg[0].Kind == source.TokenIdent && arg[0].Text == "libs":
+		accessor, ok := catalogAccessor(arg[1:])
+		return notation{accessor: accessor}, ok
+	}
+	return notation{}, false
+}
+
+func catalogAccessor(tokens []source.Token) (string, bool) {
+	var parts []string
+	for i := 0; i+1 < len(tokens); i += 2 {
+		if !isPunct(tokens[i], ".") || tokens[i+1].Kind != source.TokenIdent {
+			return "", false
+		}
+		parts = append(parts, tokens[i+1].Text)
+	}
+	if len(tokens)%2 != 0 || len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "."), true
+}
+
+func parseExclusions(content string, body block, vars map[string]string) []buildtool.Exclusion {
+	var exclusions []buildtool.Exclusion
+	from, to := body.inner()
+	for _, stmt := range statements(content, from, to) {
+		name, args, ok := configurationCall(stmt.tokens)
+		if !ok || name != "exclude" {
+			continue
+		}
+		named, _ := namedArgs(args, vars)
+		if named["group"] != "" || named["module"] != "" {
+			exclusions = append(exclusions, buildtool.Exclusion{GroupId: named["group"], ArtifactId: named["module"]})
+		}
+	}
+	return exclusions
+}
+
+func isLiteral(tok source.Token) bool {
+	return tok.Kind == source.TokenString || tok.Kind == source.TokenChar
+}
+
+func literalValue(tok source.Token, vars map[string]string) string {
+	if tok.Kind == source.TokenString {
+		return resolveTemplates(tok.Value, vars)
+	}
+	return tok.Value
+}
+
+func (p *Parser) declarationLines(gradleProject *GradleProject, dep buildtool.Dependency, configuration string) []string {
+	quote := "'"
+	if gradleProject.IsKotlin {
+		quote = `"`
+	}
+
+	coords := dep.GroupId + ":" + dep.ArtifactId
+	if dep.Version != "" {
+		coords += ":" + dep.Version
+	}
+	value := quote + coords + quote
+	if gradleProject.Catalog != nil {
+		if alias, err := gradleProject.Catalog.AddLibrary(dep); err == nil {
+			value = "libs." + Accessor(alias)
+		}
+	}
+	if dep.Type == "pom" && dep.Scope == "import" {
+		value = "platform(" + value + ")"
+	}
+
+	if len(dep.Exclusions) == 0 {
+		if gradleProject.IsKotlin {
+			return []string{fmt.Sprintf("%s(%s)", configuration, value)}
+		}
+		return []string{fmt.Sprintf("%s %s", configuration, value)}
+	}
+
+	unit := indentUnit(gradleProject.Content)
+	lines := []string{fmt.Sprintf("%s(%s) {", configuration, value)}
+	for _, exclusion := range dep.Exclusions {
+		var fields []string
+		for _, field := range [][2]string{{"group", exclusion.GroupId}, {"module", exclusion.ArtifactId}} {
+			if field[1] == "" {
+				continue
+			}
+			if gradleProject.IsKotlin {
+				fields = append(fields, fmt.Sprintf(`%s = "%s"`, field[0], field[1]))
+			} else {
+				fields = append(fields, fmt.Sprintf(`%s: '%s'`, field[0], field[1]))
+			}
+		}
+		if gradleProject.IsKotlin {
+			lines = append(lines, unit+"exclude("+strings.Join(fields, ", ")+")")
+		} else {
+			lines = append(lines, unit+"exclude "+strings.Join(fields, ", "))
+		}
+	}
+	return append(lines, "}")
+}
+
+func (p *Parser) AddDeclaration(project *buildtool.Project, configuration string, dep buildtool.Dependency) {
+	if !p.HasDependency(project, dep.GroupId, dep.ArtifactId) {
+		dep.Scope = p.mapGradleScope(configuration)
+		project.Dependencies = append(project.Dependencies, dep)
+	}
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return
+	}
+	lines := p.declarationLines(gradleProject, dep, configuration)
+	gradleProject.Content = p.insertDependency(gradleProject.Content, lines)
+}
+
+func (p *Parser) insertDependency(content string, lines []string) string {
+	blocks := dependencyBlocks(content)
+	for _, deps := range blocks {
+		if deps.container == "" {
+			return appendToBlock(content, deps.block, lines)
+		}
+	}
+	if len(blocks) > 0 {
+		return appendToBlock(content, blocks[0].block, lines)
+	}
+
+	unit := indentUnit(content)
+	section := "dependencies {\n"
+	for _, line := range lines {
+		section += unit + line + "\n"
+	}
+	return appendTopLevel(content, section+"}")
+}
+
+func (p *Parser) removeDeclarations(gradleProject *GradleProject, sourceSet, groupId, artifactId string) bool {
+	found := false
+	decls := p.parseDeclarations(gradleProject.Content, gradleProject.Catalog, nil)
+	for i := len(decls) - 1; i >= 0; i-- {
+		decl := decls[i]
+		if decl.sourceSet != sourceSet || decl.dep.GroupId != groupId || decl.dep.ArtifactId != artifactId {
+			continue
+		}
+		if strings.HasPrefix(decl.accessor, "bundles.") {
+			continue
+		}
+		found = true
+		gradleProject.Content = removeSpan(gradleProject.Content, decl.stmt)
+	}
+	return found
+}
@@ -0,0 +1,320 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Dependencies_Groovy(t *testing.T) {
+	_, project := parseContent(t, `ext {
+    jjwtVersion = '0.12.5'
+}
+
+subprojects {
+    dependencies {
+        implementation 'org.example:nested:1.0'
+    }
+}
+
+dependencies {
+    implementation platform("org.springframework.cloud:spring-cloud-dependencies:2024.0.0")
+    implementation('org.springframework.boot:spring-boot-starter-web') {
+        exclude group: 'org.springframework.boot', module: 'spring-boot-starter-tomcat'
+    }
+    implementation "io.jsonwebtoken:jjwt-api:$jjwtVersion"
+    runtimeOnly group: 'io.jsonwebtoken', name: 'jjwt-impl', version: "${jjwtVersion}"
+    // implementation 'org.example:commented:1.0' }
+    compileOnly(
+        'org.projectlombok:lombok'
+    )
+    implementation project(':core')
+    implementation files('libs/a.jar')
+}
+`, false)
+
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-dependencies", Version: "2024.0.0", Scope: "import", Type: "pom"},
+	}, project.ManagedDependencies)
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.example", ArtifactId: "nested", Version: "1.0", Scope: "compile"},
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web", Scope: "compile", Exclusions: []buildtool.Exclusion{
+			{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-tomcat"},
+		}},
+		{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt-api", Version: "0.12.5", Scope: "compile"},
+		{GroupId: "io.jsonwebtoken", ArtifactId: "jjwt-impl", Version: "0.12.5", Scope: "runtime"},
+		{GroupId: "org.projectlombok", ArtifactId: "lombok", Scope: "provided"},
+	}, project.Dependencies)
+}
+
+func TestParser_Dependencies_Kotlin(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/settings.gradle.kts", []byte(`rootProject.name = "demo"`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/project/gradle.properties", []byte("# versions\nmapstructVersion=1.5.5.Final\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/project/build.gradle.kts", []byte(`val testcontainersVersion = "1.20.4"
+
+dependencies {
+    implementation(enforcedPlatform("org.testcontainers:testcontainers-bom:$testcontainersVersion"))
+    implementation("org.mapstruct:mapstruct:${property("mapstructVersion")}")
+    implementation(kotlin("reflect"))
+    implementation(group = "com.google.guava", name = "guava", version = "33.0.0-jre")
+    implementation("org.unknown:lib:${rootProject.extra["missing"]}")
+    "developmentOnly"("org.springframework.boot:spring-boot-devtools")
+    testImplementation("org.springframework.boot:spring-boot-starter-test") {
+        exclude(group = "org.junit.vintage")
+    }
+}
+`), 0644))
+
+	project, err := NewParserWithFs(fs, true).Parse("/project/build.gradle.kts")
+	require.NoError(t, err)
+
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.testcontainers", ArtifactId: "testcontainers-bom", Version: "1.20.4", Scope: "import", Type: "pom"},
+	}, project.ManagedDependencies)
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.mapstruct", ArtifactId: "mapstruct", Version: "1.5.5.Final", Scope: "compile"},
+		{GroupId: "org.jetbrains.kotlin", ArtifactId: "kotlin-reflect", Scope: "compile"},
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "33.0.0-jre", Scope: "compile"},
+		{GroupId: "org.unknown", ArtifactId: "lib", Version: `${rootProject.extra["missing"]}`, Scope: "compile"},
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-devtools", Scope: "runtime"},
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-test", Scope: "test", Exclusions: []buildtool.Exclusion{
+			{GroupId: "org.junit.vintage"},
+		}},
+	}, project.Dependencies)
+}
+
+func TestParser_AddDependency_TopLevelBlock(t *testing.T) {
+	parser, project := parseContent(t, `buildscript {
+  dependencies {
+    classpath 'org.example:plugin:1.0'
+  }
+}
+
+dependencies {
+  // closing } in a comment
+  implementation 'org.springframework.boot:spring-boot-starter-web'
+}
+`, false)
+
+	parser.AddDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.boot",
+		ArtifactId: "spring-boot-starter-data-jpa",
+		Exclusions: []buildtool.Exclusion{{GroupId: "org.hibernate", ArtifactId: "hibernate-core"}},
+	})
+	parser.AddDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.cloud",
+		ArtifactId: "spring-cloud-dependencies",
+		Version:    "2024.0.0",
+		Type:       "pom",
+		Scope:      "import",
+	})
+
+	assert.Equal(t, `buildscript {
+  dependencies {
+    classpath 'org.example:plugin:1.0'
+  }
+}
+
+dependencies {
+  // closing } in a comment
+  implementation 'org.springframework.boot:spring-boot-starter-web'
+  implementation('org.springframework.boot:spring-boot-starter-data-jpa') {
+    exclude group: 'org.hibernate', module: 'hibernate-core'
+  }
+  implementation platform('org.springframework.cloud:spring-cloud-dependencies:2024.0.0')
+}
+`, content(project))
+}
+
+func TestParser_AddDependency_Kotlin_Exclusions(t *testing.T) {
+	parser, project := parseContent(t, "dependencies {\n}\n", true)
+
+	parser.AddDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.boot",
+		ArtifactId: "spring-boot-starter-web",
+		Exclusions: []buildtool.Exclusion{{ArtifactId: "spring-boot-starter-tomcat"}},
+	})
+
+	assert.Equal(t, "dependencies {\n\timplementation(\"org.springframework.boot:spring-boot-starter-web\") {\n\t\texclude(module = \"spring-boot-starter-tomcat\")\n\t}\n}\n", content(project))
+}
+
+func TestParser_AddDeclaration(t *testing.T) {
+	parser, project := parseContent(t, "dependencies {\n    implementation 'org.springframework.boot:spring-boot-starter-web'\n}\n", false)
+
+	lombok := buildtool.Dependency{GroupId: "org.projectlombok", ArtifactId: "lombok"}
+	parser.AddDeclaration(project, "compileOnly", lombok)
+	parser.AddDeclaration(project, "annotationProcessor", lombok)
+
+	assert.Equal(t, "dependencies {\n    implementation 'org.springframework.boot:spring-boot-starter-web'\n    compileOnly 'org.projectlombok:lombok'\n    annotationProcessor 'org.projectlombok:lombok'\n}\n", content(project))
+	require.Len(t, project.Dependencies, 2)
+	assert.Equal(t, "provided", project.Dependencies[1].Scope)
+}
+
+func TestParser_RemoveDependency_MultiLine(t *testing.T) {
+	parser, project := parseContent(t, `subprojects {
+    dependencies {
+        implementation("org.springframework.boot:spring-boot-starter-web")
+    }
+}
+
+dependencies {
+    implementation(
+        "org.springframework.boot:spring-boot-starter-web"
+    ) {
+        exclude(group = "org.springframework.boot", module = "spring-boot-starter-tomcat") // }
+    }
+    implementation(platform("org.springframework.cloud:spring-cloud-dependencies:2024.0.0"))
+    runtimeOnly("org.postgresql:postgresql")
+}
+`, true)
+
+	assert.True(t, parser.RemoveDependency(project, "org.springframework.boot", "spring-boot-starter-web"))
+	assert.True(t, parser.RemoveDependency(project, "org.springframework.cloud", "spring-cloud-dependencies"))
+
+	assert.Equal(t, `subprojects {
+    dependencies {
+    }
+}
+
+dependencies {
+    runtimeOnly("org.postgresql:postgresql")
+}
+`, content(project))
+	assert.Equal(t, []buildtool.Dependency{{GroupId: "org.postgresql", ArtifactId: "postgresql", Scope: "runtime"}}, project.Dependencies)
+}
+
+func TestParser_SharedDependencyBlocks(t *testing.T) {
+	parser, project := parseContent(t, `buildscript {
+    dependencies {
+        classpath 'org.example:plugin:1.0'
+    }
+}
+
+allprojects {
+    repositories {
+        mavenCentral()
+    }
+}
+
+subprojects {
+    apply plugin: 'java'
+
+    dependencies {
+        implementation 'org.springframework.boot:spring-boot-starter-web'
+        testImplementation 'org.springframework.boot:spring-boot-starter-test'
+    }
+}
+`, false)
+
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-web", Scope: "compile"},
+		{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-test", Scope: "test"},
+	}, project.Dependencies)
+
+	parser.AddDependency(project, buildtool.Dependency{GroupId: "org.projectlombok", ArtifactId: "lombok", Scope: "provided"})
+	assert.True(t, parser.RemoveDependency(project, "org.springframework.boot", "spring-boot-starter-test"))
+
+	assert.Equal(t, `buildscript {
+    dependencies {
+        classpath 'org.example:plugin:1.0'
+    }
+}
+
+allprojects {
+    repositories {
+        mavenCentral()
+    }
+}
+
+subprojects {
+    apply plugin: 'java'
+
+    dependencies {
+        implementation 'org.springframework.boot:spring-boot-starter-web'
+        compileOnly 'org.projectlombok:lombok'
+    }
+}
+`, content(project))
+}
+
+func TestParser_AddDependency_PrefersTopLevelBlock(t *testing.T) {
+	parser, project := parseContent(t, `allprojects {
+    dependencies {
+        implementation("org.slf4j:slf4j-api")
+    }
+}
+
+dependencies {
+    implementation("org.springframework.boot:spring-boot-starter-web")
+}
+`, true)
+
+	parser.AddDependency(project, buildtool.Dependency{GroupId: "org.postgresql", ArtifactId: "postgresql", Scope: "runtime"})
+
+	assert.Equal(t, `allprojects {
+    dependencies {
+        implementation("org.slf4j:slf4j-api")
+    }
+}
+
+dependencies {
+    implementation("org.springframework.boot:spring-boot-starter-web")
+    runtimeOnly("org.postgresql:postgresql")
+}
+`, content(project))
+}
+
+func TestDependencyBlocks(t *testing.T) {
+	src := `buildscript {
+  dependencies { classpath 'a:b:1' }
+}
+subprojects {
+  // dependencies { }
+  dependencies { implementation 'c:d' }
+}
+allprojects { }
+dependencies { }
+`
+
+	var found []string
+	for _, deps := range dependencyBlocks(src) {
+		found = append(found, deps.container+":"+src[deps.start:deps.end])
+	}
+
+	assert.Equal(t, []string{"subprojects:dependencies { implementation 'c:d' }", ":dependencies { }"}, found)
+}
+
+func TestStatements_MultiLineContinuations(t *testing.T) {
+	src := "implementation(\n  'a:b:1'\n)\nfoo = bar +\n  baz\ntasks.named('x')\n  .configure { }\nlast"
+	var texts []string
+	for _, s := range statements(src, 0, len(src)) {
+		texts = append(texts, src[s.start:s.end])
+	}
+
+	assert.Equal(t, []string{"implementation(\n  'a:b:1'\n)", "foo = bar +\n  baz", "tasks.named('x')\n  .configure { }", "last"}, texts)
+}
+
+func TestResolveTemplates(t *testing.T) {
+	vars := map[string]string{"jjwtVersion": "0.12.5", "boot.version": "3.4.1"}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"a:b:$jjwtVersion", "a:b:0.12.5"},
+		{"a:b:${jjwtVersion}", "a:b:0.12.5"},
+		{"a:b:${project.jjwtVersion}", "a:b:0.12.5"},
+		{`a:b:${property("boot.version")}`, "a:b:3.4.1"},
+		{`a:b:${rootProject.extra["jjwtVersion"]}`, "a:b:0.12.5"},
+		{"a:b:$unknown", "a:b:$unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveTemplates(tt.value, vars))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+const dependencyManagementPlugin = "io.spring.dependency-management"
+
+type bomImport struct {
+	dep  buildtool.Dependency
+	stmt span
+}
+
+func isPlatform(dep buildtool.Dependency) bool {
+	return dep.Type == "pom" && dep.Scope == "import"
+}
+
+func (p *Parser) parseBomImports(content string, vars map[string]string) []bomImport {
+	management, ok := findBlock(content, 0, len(content), "dependencyManagement")
+	if !ok {
+		return nil
+	}
+	from, to := management.inner()
+	imports, ok := findBlock(content, from, to, "imports")
+	if !ok {
+		return nil
+	}
+
+	var result []bomImport
+	from, to = imports.inner()
+	for _, stmt := range statements(content, from, to) {
+		name, args, ok := configurationCall(stmt.tokens)
+		if !ok || name != "mavenBom" || len(args) == 0 || len(args[0]) != 1 || !isLiteral(args[0][0]) {
+			continue
+		}
+		dep := p.parseCoordinates(literalValue(args[0][0], vars))
+		if dep.GroupId == "" || dep.ArtifactId == "" {
+			continue
+		}
+		dep.Type, dep.Scope = "pom", "import"
+		result = append(result, bomImport{dep: dep, stmt: stmt.span})
+	}
+	return result
+}
+
+func (p *Parser) extractManagedDependencies(content string, decls []declaration, vars map[string]string) []buildtool.Dependency {
+	var deps []buildtool.Dependency
+	seen := make(map[string]bool)
+	add := func(dep buildtool.Dependency) {
+		key := dep.GroupId + ":" + dep.ArtifactId
+		if !seen[key] {
+			seen[key] = true
+			deps = append(deps, dep)
+		}
+	}
+	for _, bom := range p.parseBomImports(content, vars) {
+		add(bom.dep)
+	}
+	for _, decl := range decls {
+		if decl.sourceSet == "" && isPlatform(decl.dep) {
+			add(decl.dep)
+		}
+	}
+	return deps
+}
+
+func (p *Parser) GetManagedDependencies(project *buildtool.Project) []buildtool.Dependency {
+	return project.ManagedDependencies
+}
+
+func (p *Parser) AddManagedDependency(project *buildtool.Project, dep buildtool.Dependency) bool {
+	if !project.AddManagedDependency(dep) {
+		return false
+	}
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return true
+	}
+	dep.Type, dep.Scope = "pom", "import"
+
+	content := gradleProject.Content
+	_, hasPlugin := project.Plugin(buildtool.Plugin{ID: dependencyManagementPlugin})
+	if _, hasBlock := findBlock(content, 0, len(content), "dependencyManagement"); !hasPlugin && !hasBlock {
+		gradleProject.Content = p.insertDependency(content, p.declarationLines(gradleProject, dep, "implementation"))
+		return true
+	}
+
+	gradleProject.Content = insertBomImport(content, bomImportLine(gradleProject, dep))
+	return true
+}
+
+func (p *Parser) RemoveManagedDependency(project *buildtool.Project, groupId, artifactId string) bool {
+	found := project.RemoveManagedDependency(groupId, artifactId)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return found
+	}
+
+	imports := p.parseBomImports(gradleProject.Content, nil)
+	for i := len(imports) - 1; i >= 0; i-- {
+		if imports[i].dep.GroupId == groupId && imports[i].dep.ArtifactId == artifactId {
+			found = true
+			gradleProject.Content = removeSpan(gradleProject.Content, imports[i].stmt)
+		}
+	}
+	if p.removeDeclarations(gradleProject, "", groupId, artifactId) {
+		found = true
+	}
+	if gradleProject.Catalog != nil {
+		p.pruneCatalog(gradleProject, groupId, artifactId)
+	}
+	return found
+}
+
+func bomImportLine(gradleProject *GradleProject, dep buildtool.Dependency) string {
+	coords := dep.GroupId + ":" + dep.ArtifactId
+	if dep.Version != "" {
+		coords += ":" + dep.Version
+	}
+	if gradleProject.IsKotlin {
+		return fmt.Sprintf(`mavenBom("%s")`, coords)
+	}
+	return fmt.Sprintf("mavenBom '%s'", coords)
+}
+
+func insertBomImport(content, line string) string {
+	unit := indentUnit(content)
+	management, ok := findBlock(content, 0, len(content), "dependencyManagement")
+	if !ok {
+		section := strings.Join([]string{"dependencyManagement {", unit + "imports {", unit + unit + line, unit + "}", "}"}, "\n")
+		if deps, ok := findBlock(content, 0, len(content), "dependencies"); ok {
+			return content[:deps.end] + "\n\n" + section + content[deps.end:]
+		}
+		return appendTopLevel(content, section)
+	}
+
+	from, to := management.inner()
+	if imports, ok := findBlock(content, from, to, "imports"); ok {
+		return appendToBlock(content, imports, []string{line})
+	}
+	return appendToBlock(content, management, []string{"imports {", unit + line, "}"})
+}
@@ -0,0 +1,107 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/stretchr/testify/assert"
+)
+
+var springCloudBom = buildtool.Dependency{
+	GroupId:    "org.springframework.cloud",
+	ArtifactId: "spring-cloud-dependencies",
+	Version:    "2024.0.1",
+	Type:       "pom",
+	Scope:      "import",
+}
+
+func TestParser_ManagedDependencies_MavenBom(t *testing.T) {
+	_, project := parseContent(t, `plugins {
+    id 'org.springframework.boot' version '3.4.1'
+    id 'io.spring.dependency-management' version '1.1.7'
+}
+
+ext {
+    set('springCloudVersion', "2024.0.0")
+}
+
+dependencies {
+    implementation 'org.springframework.cloud:spring-cloud-starter-openfeign'
+}
+
+dependencyManagement {
+    imports {
+        mavenBom "org.springframework.cloud:spring-cloud-dependencies:${springCloudVersion}"
+    }
+}
+`, false)
+
+	assert.Equal(t, []buildtool.Dependency{
+		{GroupId: "org.springframework.cloud", ArtifactId: "spring-cloud-dependencies", Version: "2024.0.0", Type: "pom", Scope: "import"},
+	}, project.ManagedDependencies)
+}
+
+func TestParser_AddManagedDependency_DependencyManagementPlugin(t *testing.T) {
+	parser, project := parseContent(t, `plugins {
+    id("io.spring.dependency-management") version "1.1.7"
+}
+
+dependencies {
+    implementation("org.springframework.cloud:spring-cloud-starter-openfeign")
+}
+
+tasks.withType<Test> {
+    useJUnitPlatform()
+}
+`, true)
+
+	assert.True(t, parser.AddManagedDependency(project, springCloudBom))
+	assert.False(t, parser.AddManagedDependency(project, springCloudBom))
+	assert.True(t, parser.AddManagedDependency(project, buildtool.Dependency{GroupId: "software.amazon.awssdk", ArtifactId: "bom", Version: "2.25.0"}))
+
+	assert.Equal(t, `plugins {
+    id("io.spring.dependency-management") version "1.1.7"
+}
+
+dependencies {
+    implementation("org.springframework.cloud:spring-cloud-starter-openfeign")
+}
+
+dependencyManagement {
+    imports {
+        mavenBom("org.springframework.cloud:spring-cloud-dependencies:2024.0.1")
+        mavenBom("software.amazon.awssdk:bom:2.25.0")
+    }
+}
+
+tasks.withType<Test> {
+    useJUnitPlatform()
+}
+`, content(project))
+
+	assert.True(t, parser.RemoveManagedDependency(project, "software.amazon.awssdk", "bom"))
+	assert.NotContains(t, content(project), "awssdk")
+}
+
+func TestParser_AddManagedDependency_Platform(t *testing.T) {
+	parser, project := parseContent(t, `dependencies {
+    implementation 'org.springframework.cloud:spring-cloud-starter-openfeign'
+}
+`, false)
+
+	assert.True(t, parser.AddManagedDependency(project, springCloudBom))
+
+	assert.Equal(t, `dependencies {
+    implementation 'org.springframework.cloud:spring-cloud-starter-openfeign'
+    implementation platform('org.springframework.cloud:spring-cloud-dependencies:2024.0.1')
+}
+`, content(project))
+	assert.Empty(t, project.Dependencies[1:])
+
+	assert.True(t, parser.RemoveManagedDependency(project, "org.springframework.cloud", "spring-cloud-dependencies"))
+	assert.Equal(t, `dependencies {
+    implementation 'org.springframework.cloud:spring-cloud-starter-openfeign'
+}
+`, content(project))
+	assert.Empty(t, project.ManagedDependencies)
+}
@@ -34,6 +34,7 @@ type GradleProject struct {
 	SourceCompat string
 	TargetCompat string
 	JavaVersion  string
+	Catalog      *Catalog
 }
 
 func NewParser(isKotlin bool) *Parser {
@@ -99,12 +100,36 @@ func (p *Parser) Parse(path string) (*buildtool.Project, error) {
 		Raw:       gradleProject,
 	}
 
+	if catalogPath := FindCatalog(p.fs, path); catalogPath != "" {
+		data, err := afero.ReadFile(p.fs, catalogPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read version catalog: %w", err)
+		}
+		catalog, err := ParseCatalog(catalogPath, data)
+		if err != nil {
+			return nil, err
+		}
+		gradleProject.Catalog = catalog
+		project.Files = []string{catalogPath}
+	}
+
 	project.GroupId = p.extractGroup(content, isKotlin)
 	project.Version = p.extractVersion(content, isKotlin)
+	project.Description = p.extractDescription(content)
 	project.ArtifactId = p.extractArtifactName(path)
 	project.JavaVersion = p.extractJavaVersion(content, isKotlin)
 	project.SpringBootVersion = p.extractSpringBootVersion(content, isKotlin)
-	project.Dependencies = p.extractDependencies(content, isKotlin)
+	if catalog := gradleProject.Catalog; catalog != nil && project.SpringBootVersion == "" {
+		project.SpringBootVersion = catalog.Plugins["org.springframework.boot"]
+	}
+	vars := p.variables(path, content)
+	decls := p.parseDeclarations(content, gradleProject.Catalog, vars)
+	project.Dependencies = p.extractDependencies(decls)
+	project.ManagedDependencies = p.extractManagedDependencies(content, decls, vars)
+	project.Properties = p.extractProperties(content)
+	project.Repositories = p.extractRepositories(content)
+	project.Profiles = p.extractSourceSets(decls)
+	project.Plugins = p.extractPlugins(content, gradleProject.Catalog)
 
 	gradleProject.Group = project.GroupId
 	gradleProject.Version = project.Version
@@ -118,7 +143,15 @@ func (p *Parser) Write(path string, project *buildtool.Project) error {
 	if gradleProject == nil {
 		return fmt.Errorf("invalid gradle project")
 	}
-	return afero.WriteFile(p.fs, path, []byte(gradleProject.Content), 0644)
+	if err := afero.WriteFile(p.fs, path, []byte(gradleProject.Content), 0644); err != nil {
+		return err
+	}
+	if catalog := gradleProject.Catalog; catalog != nil && catalog.Modified() {
+		if err := afero.WriteFile(p.fs, catalog.Path, catalog.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write version catalog: %w", err)
+		}
+	}
+	return nil
 }
 
 func (p *Parser) getGradleProject(project *buildtool.Project) *GradleProject {
@@ -172,6 +205,14 @@ func (p *Parser) extractVersion(content string, isKotlin bool) string {
 	return ""
 }
 
+func (p *Parser) extractDescription(content string) string {
+	pattern := regexp.MustCompile(`(?m)^description\s*=?\s*(?:"([^"]*)"|'([^']*)')`)
+	if match := pattern.FindStringSubmatch(content); match != nil {
+		return match[1] + match[2]
+	}
+	return ""
+}
+
 func (p *Parser) extractArtifactName(path string) string {
 	dir := filepath.Dir(path)
 	settingsFile := filepath.Join(dir, "settings.gradle")
@@ -219,6 +260,7 @@ func (p *Parser) extractSpringBootVersion(content string, isKotlin bool) string
 		regexp.MustCompile(`id\s*\(?["']org\.springframework\.boot["']\)?\s*version\s*["']([^"']+)["']`),
 		regexp.MustCompile(`org\.springframework\.boot["']?\)?\s*version\s*["']([^"']+)["']`),
 		regexp.MustCompile(`springBootVersion\s*=\s*["']([^"']+)["']`),
+		regexp.MustCompile(`spring-boot-gradle-plugin:([^"'$]+)["']`),
 	}
 
 	for _, pattern := range patterns {
@@ -229,50 +271,12 @@ func (p *Parser) extractSpringBootVersion(content string, isKotlin bool) string
 	return ""
 }
 
-func (p *Parser) extractDependencies(content string, isKotlin bool) []buildtool.Dependency {
-	var deps []buildtool.Dependency
-
-	var patterns []*regexp.Regexp
-	if isKotlin {
-		patterns = []*regexp.Regexp{
-			regexp.MustCompile(`(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor|kapt)\s*\(\s*"([^"]+)"\s*\)`),
-			regexp.MustCompile(`(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor|kapt)\s*\(\s*'([^']+)'\s*\)`),
-		}
-	} else {
-		patterns = []*regexp.Regexp{
-			regexp.MustCompile(`(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor)\s+['"]([^'"]+)['"]`),
-			regexp.MustCompile(`(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor)\s*\(\s*['"]([^'"]+)['"]\s*\)`),
-		}
-	}
-
-	seen := make(map[string]bool)
-
-	for _, pattern := range patterns {
-		matches := pattern.FindAllStringSubmatch(content, -1)
-		for _, match := range matches {
-			if len(match) < 3 {
-				continue
-			}
-			scope := match[1]
-			coords := match[2]
-
-			if seen[coords] {
-				continue
-			}
-			seen[coords] = true
-
-			dep := p.parseCoordinates(coords)
-			dep.Scope = p.mapGradleScope(scope)
-			deps = append(deps, dep)
-		}
-	}
-
-	return deps
-}
-
 func (p *Parser) parseCoordinates(coords string) buildtool.Dependency {
-	parts := strings.Split(coords, ":")
 	dep := buildtool.Dependency{}
+	if at := strings.LastIndex(coords, "@"); at >= 0 {
+		coords, dep.Type = coords[:at], coords[at+1:]
+	}
+	parts := strings.Split(coords, ":")
 
 	if len(parts) >= 1 {
 		dep.GroupId = parts[0]
@@ -283,23 +287,26 @@ func (p *Parser) parseCoordinates(coords string) buildtool.Dependency {
 	if len(parts) >= 3 {
 		dep.Version = parts[2]
 	}
+	if len(parts) >= 4 {
+		dep.Classifier = parts[3]
+	}
 
 	return dep
 }
 
 func (p *Parser) mapGradleScope(gradleScope string) string {
 	switch gradleScope {
-	case "implementation":
+	case "implementation", "api":
 		return "compile"
-	case "compileOnly":
+	case "compileOnly", "compileOnlyApi":
 		return "provided"
-	case "runtimeOnly":
+	case "runtimeOnly", "developmentOnly":
 		return "runtime"
 	case "testImplementation", "testCompileOnly":
 		return "test"
-	case "testRuntimeOnly":
+	case "testRuntimeOnly", "testAnnotationProcessor":
 		return "test"
-	case "annotationProcessor", "kapt":
+	case "annotationProcessor", "kapt", "ksp":
 		return "provided"
 	default:
 		return "compile"
@@ -343,32 +350,9 @@ func (p *Parser) AddDependency(project *buildtool.Project, dep buildtool.Depende
 	}
 
 	gradleScope := p.mapScopeToGradle(dep.Scope, gradleProject.IsKotlin)
-	depLine := p.formatDependencyLine(dep, gradleScope, gradleProject.IsKotlin)
-
-	gradleProject.Content = p.insertDependency(gradleProject.Content, depLine, gradleProject.IsKotlin)
-}
-
-func (p *Parser) formatDependencyLine(dep buildtool.Dependency, scope string, isKotlin bool) string {
-	coords := dep.GroupId + ":" + dep.ArtifactId
-	if dep.Version != "" {
-		coords += ":" + dep.Version
-	}
-
-	if isKotlin {
-		return fmt.Sprintf("\t%s(\"%s\")", scope, coords)
-	}
-	return fmt.Sprintf("\t%s '%s'", scope, coords)
-}
-
-func (p *Parser) insertDependency(content, depLine string, isKotlin bool) string {
-	depBlockRegex := regexp.MustCompile(`(?s)(dependencies\s*\{)([^}]*)(\})`)
-
-	if match := depBlockRegex.FindStringSubmatchIndex(content); match != nil {
-		closingBracePos := match[5]
-		return content[:closingBracePos] + depLine + "\n" + content[closingBracePos:]
-	}
+	lines := p.declarationLines(gradleProject, dep, gradleScope)
 
-	return content + "\n\ndependencies {\n" + depLine + "\n}\n"
+	gradleProject.Content = p.insertDependency(gradleProject.Content, lines)
 }
 
 func (p *Parser) RemoveDependency(project *buildtool.Project, groupId, artifactId string) bool {
@@ -388,32 +372,14 @@ func (p *Parser) RemoveDependency(project *buildtool.Project, groupId, artifactI
 		return found
 	}
 
-	gradleProject.Content = p.removeDependencyFromContent(gradleProject.Content, groupId, artifactId, gradleProject.IsKotlin)
-
-	return found
-}
-
-func (p *Parser) removeDependencyFromContent(content, groupId, artifactId string, isKotlin bool) string {
-	coords := groupId + ":" + artifactId
-
-	var patterns []*regexp.Regexp
-	if isKotlin {
-		patterns = []*regexp.Regexp{
-			regexp.MustCompile(`(?m)^\s*(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor|kapt)\s*\(\s*"` + regexp.QuoteMeta(coords) + `[^"]*"\s*\)\s*\n?`),
-			regexp.MustCompile(`(?m)^\s*(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor|kapt)\s*\(\s*'` + regexp.QuoteMeta(coords) + `[^']*'\s*\)\s*\n?`),
-		}
-	} else {
-		patterns = []*regexp.Regexp{
-			regexp.MustCompile(`(?m)^\s*(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor)\s+['"]` + regexp.QuoteMeta(coords) + `[^'"]*['"]\s*\n?`),
-			regexp.MustCompile(`(?m)^\s*(implementation|compileOnly|runtimeOnly|testImplementation|testCompileOnly|testRuntimeOnly|annotationProcessor)\s*\(\s*['"]` + regexp.QuoteMeta(coords) + `[^'"]*['"]\s*\)\s*\n?`),
-		}
+	if p.removeDeclarations(gradleProject, "", groupId, artifactId) {
+		found = true
 	}
-
-	for _, pattern := range patterns {
-		content = pattern.ReplaceAllString(content, "")
+	if gradleProject.Catalog != nil {
+		p.pruneCatalog(gradleProject, groupId, artifactId)
 	}
 
-	return content
+	return found
 }
 
 func (p *Parser) GetDependencies(project *buildtool.Project) []buildtool.Dependency {
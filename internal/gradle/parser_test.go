@@ -476,6 +476,26 @@ func TestParser_ExtractSpringBootVersion(t *testing.T) {
 	}
 }
 
+func TestParser_ExtractDescription(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "Groovy DSL", content: "group = 'com.example'\ndescription = 'Demo project'", expected: "Demo project"},
+		{name: "Kotlin DSL", content: `description = "Demo project"`, expected: "Demo project"},
+		{name: "Groovy method call", content: `description 'Demo project'`, expected: "Demo project"},
+		{name: "Nested task description", content: "tasks.register('hello') {\n    description = 'Says hello'\n}", expected: ""},
+	}
+
+	parser := NewParser(false)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parser.extractDescription(tc.content))
+		})
+	}
+}
+
 func TestParser_DependencyWithVersion(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	parser := NewParserWithFs(fs, false)
@@ -641,3 +661,21 @@ func TestParser_Write_InvalidProject(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestTopLevelStatements(t *testing.T) {
+	content := `plugins {
+    id 'java'
+}
+
+group = 'com.example'
+
+tasks.named('test') {
+    useJUnitPlatform()
+}
+`
+	assert.Equal(t, []string{
+		"plugins {\n    id 'java'\n}",
+		"group = 'com.example'",
+		"tasks.named('test') {\n    useJUnitPlatform()\n}",
+	}, TopLevelStatements(content))
+}
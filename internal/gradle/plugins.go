@@ -0,0 +1,257 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+type pluginEntry struct {
+	buildtool.Plugin
+	accessor string
+	stmt     span
+}
+
+func parsePlugins(content string, catalog *Catalog) []pluginEntry {
+	var entries []pluginEntry
+	if plugins, ok := findBlock(content, 0, len(content), "plugins"); ok {
+		from, to := plugins.inner()
+		for _, stmt := range statements(content, from, to) {
+			if entry, ok := pluginDeclaration(stmt.tokens, catalog); ok {
+				entry.stmt = stmt.span
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	for _, stmt := range statements(content, 0, len(content)) {
+		name, args, ok := configurationCall(stmt.tokens)
+		if !ok || name != "apply" {
+			continue
+		}
+		if named, _ := namedArgs(args, nil); named["plugin"] != "" {
+			entries = append(entries, pluginEntry{Plugin: buildtool.Plugin{ID: named["plugin"]}, stmt: stmt.span})
+		}
+	}
+	return entries
+}
+
+func pluginDeclaration(tokens []source.Token, catalog *Catalog) (pluginEntry, bool) {
+	var entry pluginEntry
+	head := tokens
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i].Kind == source.TokenIdent && (tokens[i].Text == "version" || tokens[i].Text == "apply") {
+			head = tokens[:i]
+			if tokens[i].Text == "version" {
+				entry.Version = pluginArgument(tokens[i+1:])
+			}
+			break
+		}
+	}
+
+	if len(head) == 1 && head[0].Kind == source.TokenIdent {
+		entry.ID = head[0].Value
+		return entry, true
+	}
+	if len(head) < 2 || head[0].Kind != source.TokenIdent {
+		return entry, false
+	}
+
+	switch head[0].Text {
+	case "id":
+		entry.ID = pluginArgument(head[1:])
+	case "kotlin":
+		if module := pluginArgument(head[1:]); module != "" {
+			entry.ID = "org.jetbrains.kotlin." + module
+		}
+	case "alias":
+		if catalog == nil || len(head) < 4 || !isPunct(head[1], "(") || !isPunct(head[len(head)-1], ")") || head[2].Text != "libs" {
+			return entry, false
+		}
+		accessor, ok := catalogAccessor(head[3 : len(head)-1])
+		if !ok || !strings.HasPrefix(accessor, "plugins.") {
+			return entry, false
+		}
+		entry.accessor = accessor
+		entry.ID, entry.Version, ok = catalog.ResolvePlugin(strings.TrimPrefix(accessor, "plugins."))
+		return entry, ok
+	}
+	return entry, entry.ID != ""
+}
+
+func pluginArgument(tokens []source.Token) string {
+	if len(tokens) >= 3 && isPunct(tokens[0], "(") && isPunct(tokens[2], ")") {
+		tokens = tokens[1:2]
+	}
+	if len(tokens) == 0 || !isLiteral(tokens[0]) {
+		return ""
+	}
+	return tokens[0].Value
+}
+
+func (p *Parser) extractPlugins(content string, catalog *Catalog) []buildtool.Plugin {
+	var plugins []buildtool.Plugin
+	for _, entry := range parsePlugins(content, catalog) {
+		plugins = append(plugins, entry.Plugin)
+	}
+	return plugins
+}
+
+func (p *Parser) GetPlugins(project *buildtool.Project) []buildtool.Plugin {
+	return project.Plugins
+}
+
+func (p *Parser) AddPlugin(project *buildtool.Project, plugin buildtool.Plugin) bool {
+	if plugin.ID == "" || !project.AddPlugin(plugin) {
+		return false
+	}
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return true
+	}
+	content := gradleProject.Content
+	line := pluginLine(gradleProject, plugin)
+
+	if plugins, ok := findBlock(content, 0, len(content), "plugins"); ok {
+		content = appendToBlock(content, plugins, []string{line})
+	} else {
+		section := "plugins {\n" + indentUnit(content) + line + "\n}\n"
+		if buildscript, ok := findBlock(content, 0, len(content), "buildscript"); ok {
+			content = content[:buildscript.end] + "\n\n" + strings.TrimSuffix(section, "\n") + content[buildscript.end:]
+		} else if strings.TrimSpace(content) == "" {
+			content = section
+		} else {
+			content = section + "\n" + content
+		}
+	}
+
+	var missing []string
+	for _, stmt := range scriptStatements(pluginScript(gradleProject, plugin), indentUnit(content)) {
+		if !hasStatement(content, stmt) {
+			missing = append(missing, stmt)
+		}
+	}
+	if len(missing) > 0 {
+		content = appendTopLevel(content, strings.Join(missing, "\n\n"))
+	}
+
+	gradleProject.Content = content
+	return true
+}
+
+func (p *Parser) RemovePlugin(project *buildtool.Project, plugin buildtool.Plugin) bool {
+	found := project.RemovePlugin(plugin)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return found
+	}
+
+	var accessors []string
+	entries := parsePlugins(gradleProject.Content, gradleProject.Catalog)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].ID != plugin.ID {
+			continue
+		}
+		found = true
+		gradleProject.Content = removeSpan(gradleProject.Content, entries[i].stmt)
+		if entries[i].accessor != "" {
+			accessors = append(accessors, entries[i].accessor)
+		}
+	}
+
+	script := pluginScript(gradleProject, plugin)
+	for _, text := range scriptStatements(script, indentUnit(gradleProject.Content)) {
+		wanted := normalizeStatement(text)
+		stmts := statements(gradleProject.Content, 0, len(gradleProject.Content))
+		for i := len(stmts) - 1; i >= 0; i-- {
+			if normalizeStatement(gradleProject.Content[stmts[i].start:stmts[i].end]) == wanted {
+				gradleProject.Content = removeSection(gradleProject.Content, stmts[i].span)
+			}
+		}
+	}
+
+	for _, accessor := range accessors {
+		if p.catalogReferenced(gradleProject, accessor) {
+			continue
+		}
+		alias := strings.TrimPrefix(accessor, "plugins.")
+		if s := gradleProject.Catalog.doc.section("plugins"); s != nil {
+			for _, entry := range s.entries {
+				if Accessor(entry.key) == alias {
+					_ = gradleProject.Catalog.RemovePlugin(entry.key)
+					break
+				}
+			}
+		}
+	}
+	return found
+}
+
+func pluginLine(gradleProject *GradleProject, plugin buildtool.Plugin) string {
+	if gradleProject.Catalog != nil && plugin.Version != "" {
+		if alias, err := gradleProject.Catalog.AddPlugin(plugin.ID, plugin.Version); err == nil {
+			return fmt.Sprintf("alias(libs.plugins.%s)", Accessor(alias))
+		}
+	}
+	if gradleProject.IsKotlin {
+		if plugin.Version == "" {
+			return fmt.Sprintf(`id("%s")`, plugin.ID)
+		}
+		return fmt.Sprintf(`id("%s") version "%s"`, plugin.ID, plugin.Version)
+	}
+	if plugin.Version == "" {
+		return fmt.Sprintf("id '%s'", plugin.ID)
+	}
+	return fmt.Sprintf("id '%s' version '%s'", plugin.ID, plugin.Version)
+}
+
+func pluginScript(gradleProject *GradleProject, plugin buildtool.Plugin) string {
+	if gradleProject.IsKotlin {
+		return plugin.KotlinScript
+	}
+	return plugin.Script
+}
+
+func scriptStatements(script, unit string) []string {
+	var result []string
+	for _, stmt := range statements(script, 0, len(script)) {
+		lines := strings.Split(script[stmt.start:stmt.end], "\n")
+		for i, line := range lines {
+			trimmed := strings.TrimLeft(line, "\t")
+			lines[i] = strings.Repeat(unit, len(line)-len(trimmed)) + trimmed
+		}
+		result = append(result, strings.Join(lines, "\n"))
+	}
+	return result
+}
+
+func hasStatement(content, text string) bool {
+	wanted := normalizeStatement(text)
+	for _, stmt := range statements(content, 0, len(content)) {
+		if normalizeStatement(content[stmt.start:stmt.end]) == wanted {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeStatement(text string) string {
+	var parts []string
+	for _, tok := range source.Tokenize(text) {
+		parts = append(parts, tok.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+func removeSection(content string, s span) string {
+	at := strings.LastIndexByte(content[:s.start], '\n') + 1
+	content = removeSpan(content, s)
+	if strings.HasSuffix(content[:at], "\n\n") && (at == len(content) || content[at] == '\n') {
+		return content[:at-1] + content[at:]
+	}
+	return content
+}
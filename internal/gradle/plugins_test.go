@@ -0,0 +1,201 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jacocoPlugin = buildtool.Plugin{
+	ID:           "jacoco",
+	Script:       "jacoco {\n\ttoolVersion = '0.8.12'\n}",
+	KotlinScript: "jacoco {\n\ttoolVersion = \"0.8.12\"\n}",
+}
+
+func TestParser_Plugins(t *testing.T) {
+	_, project := parseContent(t, `plugins {
+    java
+    `+"`java-library`"+`
+    id("org.springframework.boot") version "3.4.1"
+    id("io.spring.dependency-management") version("1.1.7") apply false
+    kotlin("jvm") version "1.9.25"
+}
+
+apply(plugin = "checkstyle")
+`, true)
+
+	assert.Equal(t, []buildtool.Plugin{
+		{ID: "java"},
+		{ID: "java-library"},
+		{ID: "org.springframework.boot", Version: "3.4.1"},
+		{ID: "io.spring.dependency-management", Version: "1.1.7"},
+		{ID: "org.jetbrains.kotlin.jvm", Version: "1.9.25"},
+		{ID: "checkstyle"},
+	}, project.Plugins)
+}
+
+func TestParser_Plugins_GroovyApply(t *testing.T) {
+	_, project := parseContent(t, `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.4.1'
+}
+
+apply plugin: 'jacoco'
+`, false)
+
+	assert.Equal(t, []buildtool.Plugin{
+		{ID: "java"},
+		{ID: "org.springframework.boot", Version: "3.4.1"},
+		{ID: "jacoco"},
+	}, project.Plugins)
+}
+
+func TestParser_AddPlugin_Groovy(t *testing.T) {
+	parser, project := parseContent(t, `plugins {
+    id 'java'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+}
+`, false)
+
+	assert.True(t, parser.AddPlugin(project, jacocoPlugin))
+	assert.True(t, parser.AddPlugin(project, buildtool.Plugin{ID: "com.diffplug.spotless", Version: "7.0.2"}))
+	assert.False(t, parser.AddPlugin(project, buildtool.Plugin{ID: "java"}))
+
+	assert.Equal(t, `plugins {
+    id 'java'
+    id 'jacoco'
+    id 'com.diffplug.spotless' version '7.0.2'
+}
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+}
+
+jacoco {
+    toolVersion = '0.8.12'
+}
+`, content(project))
+}
+
+func TestParser_AddPlugin_CreatesBlock(t *testing.T) {
+	parser, project := parseContent(t, `buildscript {
+	repositories {
+		mavenCentral()
+	}
+}
+
+dependencies {
+}
+`, true)
+
+	assert.True(t, parser.AddPlugin(project, jacocoPlugin))
+	assert.True(t, parser.AddPlugin(project, buildtool.Plugin{ID: "com.google.cloud.tools.jib", Version: "3.4.4"}))
+
+	assert.Equal(t, `buildscript {
+	repositories {
+		mavenCentral()
+	}
+}
+
+plugins {
+	id("jacoco")
+	id("com.google.cloud.tools.jib") version "3.4.4"
+}
+
+dependencies {
+}
+
+jacoco {
+	toolVersion = "0.8.12"
+}
+`, content(project))
+}
+
+func TestParser_RemovePlugin(t *testing.T) {
+	parser, project := parseContent(t, `plugins {
+    id("java")
+    id("jacoco")
+}
+
+jacoco {
+    toolVersion = "0.8.12"
+}
+
+tasks.test {
+    useJUnitPlatform()
+}
+`, true)
+
+	assert.True(t, parser.RemovePlugin(project, jacocoPlugin))
+	assert.False(t, parser.RemovePlugin(project, jacocoPlugin))
+
+	assert.Equal(t, `plugins {
+    id("java")
+}
+
+tasks.test {
+    useJUnitPlatform()
+}
+`, content(project))
+	assert.Equal(t, []buildtool.Plugin{{ID: "java"}}, project.Plugins)
+}
+
+func TestParser_RemovePlugin_KeepsCustomizedConfiguration(t *testing.T) {
+	parser, project := parseContent(t, `plugins {
+    id 'jacoco'
+}
+
+jacoco {
+    toolVersion = '0.8.11'
+}
+`, false)
+
+	assert.True(t, parser.RemovePlugin(project, jacocoPlugin))
+
+	assert.Equal(t, `plugins {
+}
+
+jacoco {
+    toolVersion = '0.8.11'
+}
+`, content(project))
+}
+
+func TestParser_Plugins_VersionCatalog(t *testing.T) {
+	fs, parser, project := setupCatalogProject(t, "build.gradle.kts", `plugins {
+    alias(libs.plugins.spring.boot)
+}
+`)
+
+	assert.Equal(t, []buildtool.Plugin{{ID: "org.springframework.boot", Version: "3.4.1"}}, project.Plugins)
+
+	spotless := buildtool.Plugin{ID: "com.diffplug.spotless", Version: "7.0.2"}
+	assert.True(t, parser.AddPlugin(project, spotless))
+	require.NoError(t, parser.Write("/project/api/build.gradle.kts", project))
+
+	build, err := afero.ReadFile(fs, "/project/api/build.gradle.kts")
+	require.NoError(t, err)
+	assert.Equal(t, `plugins {
+    alias(libs.plugins.spring.boot)
+    alias(libs.plugins.spotless)
+}
+`, string(build))
+
+	catalog, err := afero.ReadFile(fs, "/project/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	assert.Contains(t, string(catalog), `spotless = "7.0.2"`)
+	assert.Contains(t, string(catalog), `spotless = { id = "com.diffplug.spotless", version.ref = "spotless" }`)
+
+	assert.True(t, parser.RemovePlugin(project, spotless))
+	require.NoError(t, parser.Write("/project/api/build.gradle.kts", project))
+
+	catalog, err = afero.ReadFile(fs, "/project/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	assert.Equal(t, versionCatalog, string(catalog))
+}
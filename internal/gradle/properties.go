@@ -0,0 +1,172 @@
+package gradle
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+var (
+	topLevelPropertyPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^ext\.(\w+)\s*=\s*['"]([^'"]*)['"]$`),
+		regexp.MustCompile(`^(?:project\.)?(?:ext|extra)\[\s*['"]([^'"]+)['"]\s*\]\s*=\s*['"]([^'"]*)['"]$`),
+		regexp.MustCompile(`^(?:project\.)?(?:ext|extra)\.set\(\s*['"]([^'"]+)['"]\s*,\s*['"]([^'"]*)['"]\s*\)$`),
+		regexp.MustCompile(`^val\s+(\w+)(?:\s*:\s*\w+)?\s+by\s+extra\(\s*"([^"]*)"\s*\)$`),
+	}
+	extBlockPropertyPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^(\w+)\s*=\s*['"]([^'"]*)['"]$`),
+		regexp.MustCompile(`^set\(\s*['"]([^'"]+)['"]\s*,\s*['"]([^'"]*)['"]\s*\)$`),
+	}
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+type propertyEntry struct {
+	buildtool.Property
+	stmt     span
+	value    span
+	topLevel bool
+}
+
+func parseProperties(content string) []propertyEntry {
+	var entries []propertyEntry
+	collect := func(from, to int, patterns []*regexp.Regexp, topLevel bool) {
+		for _, stmt := range statements(content, from, to) {
+			text := content[stmt.start:stmt.end]
+			for _, pattern := range patterns {
+				match := pattern.FindStringSubmatchIndex(text)
+				if match == nil {
+					continue
+				}
+				entries = append(entries, propertyEntry{
+					Property: buildtool.Property{Key: text[match[2]:match[3]], Value: text[match[4]:match[5]]},
+					stmt:     stmt.span,
+					value:    span{start: stmt.start + match[4], end: stmt.start + match[5]},
+					topLevel: topLevel,
+				})
+				break
+			}
+		}
+	}
+
+	collect(0, len(content), topLevelPropertyPatterns, true)
+	if ext, ok := findBlock(content, 0, len(content), "ext"); ok {
+		from, to := ext.inner()
+		collect(from, to, extBlockPropertyPatterns, false)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].stmt.start < entries[j].stmt.start })
+	return entries
+}
+
+func (p *Parser) extractProperties(content string) []buildtool.Property {
+	var props []buildtool.Property
+	seen := make(map[string]bool)
+	for _, entry := range parseProperties(content) {
+		if seen[entry.Key] {
+			continue
+		}
+		seen[entry.Key] = true
+		props = append(props, entry.Property)
+	}
+	return props
+}
+
+func (p *Parser) GetProperties(project *buildtool.Project) []buildtool.Property {
+	return project.Properties
+}
+
+func (p *Parser) GetProperty(project *buildtool.Project, key string) (string, bool) {
+	return project.Property(key)
+}
+
+func (p *Parser) SetProperty(project *buildtool.Project, key, value string) {
+	project.SetProperty(key, value)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return
+	}
+	content := gradleProject.Content
+
+	for _, entry := range parseProperties(content) {
+		if entry.Key == key {
+			gradleProject.Content = content[:entry.value.start] + value + content[entry.value.end:]
+			return
+		}
+	}
+
+	if gradleProject.IsKotlin {
+		gradleProject.Content = insertTopLevel(content, fmt.Sprintf("extra[\"%s\"] = \"%s\"", key, value))
+		return
+	}
+
+	if ext, ok := findBlock(content, 0, len(content), "ext"); ok {
+		line := fmt.Sprintf("%s = '%s'", key, value)
+		if !identifierPattern.MatchString(key) {
+			line = fmt.Sprintf("set('%s', '%s')", key, value)
+		}
+		gradleProject.Content = appendToBlock(content, ext, []string{line})
+		return
+	}
+
+	line := fmt.Sprintf("ext.%s = '%s'", key, value)
+	if !identifierPattern.MatchString(key) {
+		line = fmt.Sprintf("ext['%s'] = '%s'", key, value)
+	}
+	gradleProject.Content = insertTopLevel(content, line)
+}
+
+func (p *Parser) RemoveProperty(project *buildtool.Project, key string) bool {
+	found := project.RemoveProperty(key)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return found
+	}
+
+	entries := parseProperties(gradleProject.Content)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Key == key {
+			gradleProject.Content = removeSpan(gradleProject.Content, entries[i].stmt)
+		}
+	}
+	return found
+}
+
+func insertTopLevel(content, line string) string {
+	var last *span
+	for _, entry := range parseProperties(content) {
+		if entry.topLevel && (last == nil || entry.stmt.end > last.end) {
+			stmt := entry.stmt
+			last = &stmt
+		}
+	}
+	if last != nil {
+		return content[:last.end] + "\n" + indentOf(content, last.start) + line + content[last.end:]
+	}
+
+	if at, ok := firstBlock(content, "repositories", "dependencies"); ok {
+		return content[:at] + line + "\n\n" + content[at:]
+	}
+	return appendTopLevel(content, line)
+}
+
+func firstBlock(content string, names ...string) (int, bool) {
+	at, found := len(content), false
+	for _, name := range names {
+		if b, ok := findBlock(content, 0, len(content), name); ok && b.start < at {
+			at, found = b.start, true
+		}
+	}
+	return at, found
+}
+
+func appendTopLevel(content, text string) string {
+	trimmed := strings.TrimRight(content, " \t\r\n")
+	if trimmed == "" {
+		return text + "\n"
+	}
+	return trimmed + "\n\n" + text + "\n"
+}
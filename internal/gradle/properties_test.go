@@ -0,0 +1,154 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseContent(t *testing.T, content string, isKotlin bool) (*Parser, *buildtool.Project) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs, isKotlin)
+	path := "/project/build.gradle"
+	if isKotlin {
+		path += ".kts"
+	}
+	require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	project, err := parser.Parse(path)
+	require.NoError(t, err)
+	return parser, project
+}
+
+func content(project *buildtool.Project) string {
+	return project.Raw.(*GradleProject).Content
+}
+
+func TestParser_Properties_Groovy(t *testing.T) {
+	parser, project := parseContent(t, `ext {
+    mapstructVersion = '1.5.5.Final'
+    set('testcontainers.version', "1.19.3")
+    // ignored = 'x'
+}
+ext.jjwtVersion = '0.12.5'
+ext['springCloudVersion'] = '2023.0.0'
+`, false)
+
+	assert.Equal(t, []buildtool.Property{
+		{Key: "mapstructVersion", Value: "1.5.5.Final"},
+		{Key: "testcontainers.version", Value: "1.19.3"},
+		{Key: "jjwtVersion", Value: "0.12.5"},
+		{Key: "springCloudVersion", Value: "2023.0.0"},
+	}, parser.GetProperties(project))
+
+	value, ok := parser.GetProperty(project, "jjwtVersion")
+	assert.True(t, ok)
+	assert.Equal(t, "0.12.5", value)
+}
+
+func TestParser_Properties_Kotlin(t *testing.T) {
+	parser, project := parseContent(t, `val mapstructVersion by extra("1.5.5.Final")
+val lombokVersion: String by extra("1.18.30")
+extra["jjwt.version"] = "0.12.5"
+extra.set("springCloudVersion", "2023.0.0")
+`, true)
+
+	assert.Equal(t, []buildtool.Property{
+		{Key: "mapstructVersion", Value: "1.5.5.Final"},
+		{Key: "lombokVersion", Value: "1.18.30"},
+		{Key: "jjwt.version", Value: "0.12.5"},
+		{Key: "springCloudVersion", Value: "2023.0.0"},
+	}, parser.GetProperties(project))
+}
+
+func TestParser_SetProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		isKotlin bool
+		content  string
+		key      string
+		value    string
+		expected string
+	}{
+		{
+			name:     "updates ext block entry",
+			content:  "ext {\n    jjwtVersion = '0.12.5'\n}\n",
+			key:      "jjwtVersion",
+			value:    "0.12.6",
+			expected: "ext {\n    jjwtVersion = '0.12.6'\n}\n",
+		},
+		{
+			name:     "appends to ext block",
+			content:  "ext {\n    jjwtVersion = '0.12.5'\n}\n",
+			key:      "jjwt.version",
+			value:    "0.12.6",
+			expected: "ext {\n    jjwtVersion = '0.12.5'\n    set('jjwt.version', '0.12.6')\n}\n",
+		},
+		{
+			name:     "inserts after last ext statement",
+			content:  "ext.a = '1'\n\nrepositories {\n    mavenCentral()\n}\n",
+			key:      "b",
+			value:    "2",
+			expected: "ext.a = '1'\next.b = '2'\n\nrepositories {\n    mavenCentral()\n}\n",
+		},
+		{
+			name:     "inserts before repositories",
+			content:  "group = 'com.example'\n\nrepositories {\n    mavenCentral()\n}\n",
+			key:      "jjwt.version",
+			value:    "0.12.6",
+			expected: "group = 'com.example'\n\next['jjwt.version'] = '0.12.6'\n\nrepositories {\n    mavenCentral()\n}\n",
+		},
+		{
+			name:     "updates kotlin delegate",
+			isKotlin: true,
+			content:  "val jjwtVersion by extra(\"0.12.5\")\n",
+			key:      "jjwtVersion",
+			value:    "0.12.6",
+			expected: "val jjwtVersion by extra(\"0.12.6\")\n",
+		},
+		{
+			name:     "inserts kotlin extra before dependencies",
+			isKotlin: true,
+			content:  "group = \"com.example\"\n\ndependencies {\n}\n",
+			key:      "jjwt.version",
+			value:    "0.12.6",
+			expected: "group = \"com.example\"\n\nextra[\"jjwt.version\"] = \"0.12.6\"\n\ndependencies {\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, project := parseContent(t, tt.content, tt.isKotlin)
+
+			parser.SetProperty(project, tt.key, tt.value)
+
+			assert.Equal(t, tt.expected, content(project))
+			value, _ := parser.GetProperty(project, tt.key)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestParser_RemoveProperty(t *testing.T) {
+	parser, project := parseContent(t, "ext {\n    a = '1'\n    b = '2'\n}\next.c = '3'\n", false)
+
+	assert.True(t, parser.RemoveProperty(project, "a"))
+	assert.True(t, parser.RemoveProperty(project, "c"))
+	assert.False(t, parser.RemoveProperty(project, "missing"))
+
+	assert.Equal(t, "ext {\n    b = '2'\n}\n", content(project))
+	assert.Equal(t, []buildtool.Property{{Key: "b", Value: "2"}}, project.Properties)
+}
+
+func TestStatements_SkipsStringsAndComments(t *testing.T) {
+	src := "a('}') // {\n/* b\n { */ c {\n  d \"x;y\"\n}; e"
+	var texts []string
+	for _, s := range statements(src, 0, len(src)) {
+		texts = append(texts, src[s.start:s.end])
+	}
+
+	assert.Equal(t, []string{"a('}')", "c {\n  d \"x;y\"\n}", "e"}, texts)
+}
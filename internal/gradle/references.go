@@ -0,0 +1,51 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+func (p *Parser) pruneCatalog(gradleProject *GradleProject, groupId, artifactId string) {
+	catalog := gradleProject.Catalog
+	for _, lib := range catalog.Find(groupId, artifactId) {
+		if catalog.InBundle(lib.Alias) || p.catalogReferenced(gradleProject, Accessor(lib.Alias)) {
+			continue
+		}
+		_ = catalog.RemoveLibrary(lib.Alias)
+	}
+}
+
+func (p *Parser) catalogReferenced(gradleProject *GradleProject, accessor string) bool {
+	pattern := regexp.MustCompile(`libs\.` + regexp.QuoteMeta(accessor) + `(?:[^\w.]|\.get\(|$)`)
+	if pattern.MatchString(gradleProject.Content) {
+		return true
+	}
+
+	root := filepath.Dir(filepath.Dir(gradleProject.Catalog.Path))
+	current := filepath.Clean(gradleProject.FilePath)
+	referenced := false
+	_ = afero.Walk(p.fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || referenced {
+			return nil
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "build" || name == "target" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Clean(path) == current || (info.Name() != "build.gradle" && info.Name() != "build.gradle.kts") {
+			return nil
+		}
+		if data, err := afero.ReadFile(p.fs, path); err == nil && pattern.Match(data) {
+			referenced = true
+		}
+		return nil
+	})
+	return referenced
+}
@@ -0,0 +1,137 @@
+package gradle
+
+import (
+	"regexp"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+var (
+	wellKnownRepositories = map[string]string{
+		"mavenCentral":       "https://repo.maven.apache.org/maven2/",
+		"google":             "https://dl.google.com/dl/android/maven2/",
+		"gradlePluginPortal": "https://plugins.gradle.org/m2/",
+		"mavenLocal":         "",
+	}
+	wellKnownRepositoryPattern = regexp.MustCompile(`^(mavenCentral|google|gradlePluginPortal|mavenLocal)\s*\(\s*\)$`)
+	mavenCallPattern           = regexp.MustCompile(`^maven\s*\(\s*(?:url\s*[=:]\s*)?(?:uri\(\s*)?['"]([^'"]+)['"]`)
+	mavenURLPattern            = regexp.MustCompile(`(?m)^\s*url\s*(?:=\s*)?(?:uri\(\s*)?['"]([^'"]+)['"]`)
+	mavenNamePattern           = regexp.MustCompile(`(?m)^\s*name\s*(?:=\s*)?['"]([^'"]+)['"]`)
+)
+
+type repositoryEntry struct {
+	buildtool.Repository
+	stmt span
+}
+
+func parseRepositories(content string) []repositoryEntry {
+	repos, ok := findBlock(content, 0, len(content), "repositories")
+	if !ok {
+		return nil
+	}
+
+	var entries []repositoryEntry
+	from, to := repos.inner()
+	for _, stmt := range statements(content, from, to) {
+		text := content[stmt.start:stmt.end]
+		var repo buildtool.Repository
+
+		if match := wellKnownRepositoryPattern.FindStringSubmatch(text); match != nil {
+			repo = buildtool.Repository{ID: match[1], URL: wellKnownRepositories[match[1]]}
+		} else if match := mavenCallPattern.FindStringSubmatch(text); match != nil {
+			repo = buildtool.Repository{ID: match[1], URL: match[1]}
+		} else if maven, ok := findBlock(content, stmt.start, stmt.end, "maven"); ok {
+			inner := content[maven.open+1 : maven.close]
+			if match := mavenURLPattern.FindStringSubmatch(inner); match != nil {
+				repo.URL = match[1]
+			}
+			repo.ID = repo.URL
+			if match := mavenNamePattern.FindStringSubmatch(inner); match != nil {
+				repo.ID = match[1]
+			}
+		}
+
+		if repo.ID != "" {
+			entries = append(entries, repositoryEntry{Repository: repo, stmt: stmt.span})
+		}
+	}
+	return entries
+}
+
+func (p *Parser) extractRepositories(content string) []buildtool.Repository {
+	var repos []buildtool.Repository
+	for _, entry := range parseRepositories(content) {
+		repos = append(repos, entry.Repository)
+	}
+	return repos
+}
+
+func (p *Parser) GetRepositories(project *buildtool.Project) []buildtool.Repository {
+	return project.Repositories
+}
+
+func (p *Parser) AddRepository(project *buildtool.Project, repo buildtool.Repository) bool {
+	if repo.Plugin {
+		return false
+	}
+	repo.Name = ""
+	if repo.ID == "" {
+		repo.ID = repo.URL
+	}
+	if !project.AddRepository(repo) {
+		return false
+	}
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return true
+	}
+	content := gradleProject.Content
+
+	quote := "'"
+	url := "'" + repo.URL + "'"
+	if gradleProject.IsKotlin {
+		quote = `"`
+		url = `uri("` + repo.URL + `")`
+	}
+	unit := indentUnit(content)
+	lines := []string{"maven {"}
+	if repo.ID != repo.URL {
+		lines = append(lines, unit+"name = "+quote+repo.ID+quote)
+	}
+	lines = append(lines, unit+"url = "+url, "}")
+
+	if repos, ok := findBlock(content, 0, len(content), "repositories"); ok {
+		gradleProject.Content = appendToBlock(content, repos, lines)
+		return true
+	}
+
+	section := "repositories {\n"
+	for _, line := range lines {
+		section += unit + line + "\n"
+	}
+	section += "}"
+	if deps, ok := findBlock(content, 0, len(content), "dependencies"); ok {
+		gradleProject.Content = content[:deps.start] + section + "\n\n" + content[deps.start:]
+	} else {
+		gradleProject.Content = appendTopLevel(content, section)
+	}
+	return true
+}
+
+func (p *Parser) RemoveRepository(project *buildtool.Project, id string) bool {
+	found := project.RemoveRepository(id)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return found
+	}
+
+	entries := parseRepositories(gradleProject.Content)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].ID == id || entries[i].URL == id {
+			gradleProject.Content = removeSpan(gradleProject.Content, entries[i].stmt)
+		}
+	}
+	return found
+}
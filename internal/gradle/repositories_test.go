@@ -0,0 +1,173 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Repositories(t *testing.T) {
+	parser, project := parseContent(t, `repositories {
+    mavenCentral()
+    maven { url 'https://repo.spring.io/milestone' }
+    maven {
+        name = 'nexus'
+        url = "https://nexus.example.com/repository/maven-public/"
+        credentials {
+            username = nexusUser
+        }
+    }
+}
+`, false)
+
+	assert.Equal(t, []buildtool.Repository{
+		{ID: "mavenCentral", URL: "https://repo.maven.apache.org/maven2/"},
+		{ID: "https://repo.spring.io/milestone", URL: "https://repo.spring.io/milestone"},
+		{ID: "nexus", URL: "https://nexus.example.com/repository/maven-public/"},
+	}, parser.GetRepositories(project))
+}
+
+func TestParser_Repositories_Kotlin(t *testing.T) {
+	parser, project := parseContent(t, `repositories {
+    mavenLocal()
+    maven("https://jitpack.io")
+    maven(url = uri("https://repo.spring.io/snapshot"))
+}
+`, true)
+
+	var ids []string
+	for _, repo := range parser.GetRepositories(project) {
+		ids = append(ids, repo.ID)
+	}
+	assert.Equal(t, []string{"mavenLocal", "https://jitpack.io", "https://repo.spring.io/snapshot"}, ids)
+}
+
+func TestParser_AddRepository(t *testing.T) {
+	nexus := buildtool.Repository{ID: "nexus", Name: "Company Nexus", URL: "https://nexus.example.com/maven"}
+
+	t.Run("groovy appends to block", func(t *testing.T) {
+		parser, project := parseContent(t, "repositories {\n    mavenCentral()\n}\n", false)
+
+		assert.True(t, parser.AddRepository(project, nexus))
+		assert.False(t, parser.AddRepository(project, nexus))
+
+		assert.Equal(t, "repositories {\n    mavenCentral()\n    maven {\n        name = 'nexus'\n        url = 'https://nexus.example.com/maven'\n    }\n}\n", content(project))
+	})
+
+	t.Run("kotlin creates block", func(t *testing.T) {
+		parser, project := parseContent(t, "plugins {\n\tjava\n}\n\ndependencies {\n}\n", true)
+
+		assert.True(t, parser.AddRepository(project, nexus))
+
+		assert.Equal(t, "plugins {\n\tjava\n}\n\nrepositories {\n\tmaven {\n\t\tname = \"nexus\"\n\t\turl = uri(\"https://nexus.example.com/maven\")\n\t}\n}\n\ndependencies {\n}\n", content(project))
+		assert.Equal(t, []buildtool.Repository{{ID: "nexus", URL: "https://nexus.example.com/maven"}}, project.Repositories)
+	})
+
+	t.Run("plugin repositories are not supported", func(t *testing.T) {
+		parser, project := parseContent(t, "", false)
+
+		assert.False(t, parser.AddRepository(project, buildtool.Repository{ID: "x", URL: "https://x", Plugin: true}))
+	})
+}
+
+func TestParser_RemoveRepository(t *testing.T) {
+	parser, project := parseContent(t, "repositories {\n    mavenCentral()\n    maven {\n        name = 'nexus'\n        url = 'https://nexus'\n    }\n}\n", false)
+
+	assert.True(t, parser.RemoveRepository(project, "https://nexus"))
+	assert.False(t, parser.RemoveRepository(project, "nexus"))
+
+	assert.Equal(t, "repositories {\n    mavenCentral()\n}\n", content(project))
+}
+
+func TestParser_SourceSetDependencies(t *testing.T) {
+	parser, project := parseContent(t, `dependencies {
+    implementation("org.springframework.boot:spring-boot-starter-web")
+    testImplementation("org.springframework.boot:spring-boot-starter-test")
+    "integrationTestImplementation"("org.testcontainers:postgresql")
+    integrationTestRuntimeOnly("org.postgresql:postgresql")
+}
+`, true)
+
+	assert.Len(t, project.Dependencies, 2)
+	assert.Equal(t, []buildtool.Profile{{
+		ID: "integrationTest",
+		Dependencies: []buildtool.Dependency{
+			{GroupId: "org.testcontainers", ArtifactId: "postgresql", Scope: "compile"},
+			{GroupId: "org.postgresql", ArtifactId: "postgresql", Scope: "runtime"},
+		},
+	}}, project.Profiles)
+
+	assert.True(t, parser.AddProfileDependency(project, "integrationTest", buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2", Scope: "runtime"}))
+	assert.False(t, parser.AddProfileDependency(project, "integrationTest", buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2"}))
+	assert.True(t, parser.RemoveProfileDependency(project, "integrationTest", "org.testcontainers", "postgresql"))
+
+	assert.Equal(t, `dependencies {
+    implementation("org.springframework.boot:spring-boot-starter-web")
+    testImplementation("org.springframework.boot:spring-boot-starter-test")
+    integrationTestRuntimeOnly("org.postgresql:postgresql")
+    "integrationTestRuntimeOnly"("com.h2database:h2")
+}
+`, content(project))
+}
+
+func TestParser_AddProfileDependency_Groovy(t *testing.T) {
+	parser, project := parseContent(t, "dependencies {\n}\n", false)
+
+	assert.True(t, parser.AddProfileDependency(project, "dev", buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2"}))
+
+	assert.Equal(t, "sourceSets {\n\tdev {}\n}\n\ndependencies {\n\tdevImplementation 'com.h2database:h2'\n}\n", content(project))
+}
+
+func TestParser_AddProfileDependency_DeclaresMissingSourceSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		isKotlin bool
+		profile  string
+		expected string
+	}{
+		{
+			name:     "appends to existing groovy sourceSets",
+			input:    "sourceSets {\n    integrationTest {\n    }\n}\n\ndependencies {\n}\n",
+			profile:  "dev",
+			expected: "sourceSets {\n    integrationTest {\n    }\n    dev {}\n}\n\ndependencies {\n    devImplementation 'com.h2database:h2'\n}\n",
+		},
+		{
+			name:     "creates kotlin sourceSets",
+			input:    "plugins {\n    java\n}\n\ndependencies {\n}\n",
+			isKotlin: true,
+			profile:  "dev",
+			expected: "plugins {\n    java\n}\n\nsourceSets {\n    create(\"dev\")\n}\n\ndependencies {\n    \"devImplementation\"(\"com.h2database:h2\")\n}\n",
+		},
+		{
+			name:     "keeps kotlin source set declared with val",
+			input:    "sourceSets {\n    val dev by creating\n}\n\ndependencies {\n}\n",
+			isKotlin: true,
+			profile:  "dev",
+			expected: "sourceSets {\n    val dev by creating\n}\n\ndependencies {\n    \"devImplementation\"(\"com.h2database:h2\")\n}\n",
+		},
+		{
+			name:     "keeps test suite",
+			input:    "testing {\n    suites {\n        integrationTest(JvmTestSuite) {\n        }\n    }\n}\n\ndependencies {\n}\n",
+			profile:  "integrationTest",
+			expected: "testing {\n    suites {\n        integrationTest(JvmTestSuite) {\n        }\n    }\n}\n\ndependencies {\n    integrationTestImplementation 'com.h2database:h2'\n}\n",
+		},
+		{
+			name:     "keeps built-in test source set",
+			input:    "dependencies {\n}\n",
+			profile:  "test",
+			expected: "dependencies {\n\ttestImplementation 'com.h2database:h2'\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, project := parseContent(t, tt.input, tt.isKotlin)
+
+			assert.True(t, parser.AddProfileDependency(project, tt.profile, buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2"}))
+
+			assert.Equal(t, tt.expected, content(project))
+		})
+	}
+}
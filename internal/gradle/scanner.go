@@ -0,0 +1,206 @@
+package gradle
+
+import (
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+type span struct {
+	start int
+	end   int
+}
+
+type statement struct {
+	span
+	tokens []source.Token
+}
+
+type block struct {
+	span
+	open  int
+	close int
+}
+
+func (b block) inner() (int, int) {
+	return b.open + 1, b.close
+}
+
+var continuations = map[string]bool{
+	",": true, "=": true, ".": true, "?.": true, "?:": true, "+": true, "-": true,
+	"*": true, "/": true, "&&": true, "||": true, "->": true, "+=": true, "-=": true,
+}
+
+func statements(content string, from, to int) []statement {
+	tokens := source.Tokenize(content[from:to])
+	for i := range tokens {
+		tokens[i].Offset += from
+	}
+
+	var result []statement
+	depth, begin := 0, -1
+	flush := func(end int) {
+		if begin >= 0 && end > begin {
+			first, last := tokens[begin], tokens[end-1]
+			result = append(result, statement{
+				span:   span{start: first.Offset, end: last.Offset + len(last.Text)},
+				tokens: tokens[begin:end],
+			})
+		}
+		begin = -1
+	}
+
+	for i, tok := range tokens {
+		if depth == 0 && isPunct(tok, ";") {
+			flush(i)
+			continue
+		}
+		if depth == 0 && tok.Newline && begin >= 0 && !continues(tokens[i-1], tok) {
+			flush(i)
+		}
+		if begin < 0 {
+			begin = i
+		}
+		if tok.Kind != source.TokenPunct {
+			continue
+		}
+		switch tok.Text {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	flush(len(tokens))
+	return result
+}
+
+func continues(prev, next source.Token) bool {
+	if prev.Kind == source.TokenPunct && continuations[prev.Text] {
+		return true
+	}
+	return next.Kind == source.TokenPunct && (next.Text == "." || next.Text == "?." || next.Text == "?:")
+}
+
+func isPunct(tok source.Token, text string) bool {
+	return tok.Kind == source.TokenPunct && tok.Text == text
+}
+
+func matching(tokens []source.Token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].Kind != source.TokenPunct {
+			continue
+		}
+		switch tokens[i].Text {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func trailingBlock(stmt statement) ([]source.Token, *block) {
+	tokens := stmt.tokens
+	last := len(tokens) - 1
+	if last < 1 || !isPunct(tokens[last], "}") {
+		return tokens, nil
+	}
+	for open := last - 1; open > 0; open-- {
+		if isPunct(tokens[open], "{") && matching(tokens, open) == last {
+			head := tokens[:open]
+			if isPunct(head[len(head)-1], ",") {
+				head = head[:len(head)-1]
+			}
+			return head, &block{span: stmt.span, open: tokens[open].Offset, close: tokens[last].Offset}
+		}
+	}
+	return tokens, nil
+}
+
+func findBlock(content string, from, to int, name string) (block, bool) {
+	for _, stmt := range statements(content, from, to) {
+		head, body := trailingBlock(stmt)
+		if body != nil && len(head) == 1 && head[0].Kind == source.TokenIdent && head[0].Text == name {
+			return *body, true
+		}
+	}
+	return block{}, false
+}
+
+func removeSpan(content string, s span) string {
+	start, end := s.start, s.end
+	for start > 0 && (content[start-1] == ' ' || content[start-1] == '\t') {
+		start--
+	}
+	for end < len(content) && (content[end] == ' ' || content[end] == '\t' || content[end] == ';') {
+		end++
+	}
+	if (start == 0 || content[start-1] == '\n') && strings.HasPrefix(content[end:], "\r\n") {
+		end += 2
+	} else if (start == 0 || content[start-1] == '\n') && strings.HasPrefix(content[end:], "\n") {
+		end++
+	} else {
+		start, end = s.start, s.end
+	}
+	return content[:start] + content[end:]
+}
+
+func appendToBlock(content string, b block, lines []string) string {
+	from, to := b.inner()
+	indent := indentOf(content, b.start)
+	if stmts := statements(content, from, to); len(stmts) > 0 {
+		indent = indentOf(content, stmts[0].start)
+	} else {
+		indent += indentUnit(content)
+	}
+
+	var text strings.Builder
+	for _, line := range lines {
+		text.WriteString("\n")
+		if line != "" {
+			text.WriteString(indent + line)
+		}
+	}
+
+	inner := content[from:to]
+	trimmed := strings.TrimRight(inner, " \t\r\n")
+	if trimmed == "" {
+		return content[:from] + text.String() + "\n" + indentOf(content, b.start) + content[to:]
+	}
+	at := from + len(trimmed)
+	return content[:at] + text.String() + content[at:]
+}
+
+func indentOf(content string, pos int) string {
+	start := pos
+	for start > 0 && (content[start-1] == ' ' || content[start-1] == '\t') {
+		start--
+	}
+	return content[start:pos]
+}
+
+func indentUnit(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "\t"
+}
+
+func TopLevelStatements(content string) []string {
+	var result []string
+	for _, stmt := range statements(content, 0, len(content)) {
+		result = append(result, content[stmt.start:stmt.end])
+	}
+	return result
+}
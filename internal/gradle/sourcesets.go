@@ -0,0 +1,143 @@
+package gradle
+
+import (
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/KashifKhn/haft/internal/source"
+)
+
+var sourceSetFactories = map[string]bool{"create": true, "register": true, "maybeCreate": true, "named": true, "getByName": true}
+
+func (p *Parser) extractSourceSets(decls []declaration) []buildtool.Profile {
+	project := &buildtool.Project{}
+	for _, decl := range decls {
+		if decl.sourceSet != "" {
+			project.AddProfileDependency(decl.sourceSet, decl.dep)
+		}
+	}
+	return project.Profiles
+}
+
+func (p *Parser) AddProfileDependency(project *buildtool.Project, profile string, dep buildtool.Dependency) bool {
+	known := project.Profile(profile) != nil
+	if !project.AddProfileDependency(profile, dep) {
+		return false
+	}
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return true
+	}
+	if !known && !declaredSourceSets(project, gradleProject.Content)[profile] {
+		gradleProject.Content = declareSourceSet(gradleProject, profile)
+	}
+
+	configuration := profile + sourceSetSuffix(dep.Scope)
+	if gradleProject.IsKotlin {
+		configuration = `"` + configuration + `"`
+	}
+	lines := p.declarationLines(gradleProject, dep, configuration)
+	gradleProject.Content = p.insertDependency(gradleProject.Content, lines)
+	return true
+}
+
+func (p *Parser) RemoveProfileDependency(project *buildtool.Project, profile, groupId, artifactId string) bool {
+	found := project.RemoveProfileDependency(profile, groupId, artifactId)
+
+	gradleProject := p.getGradleProject(project)
+	if gradleProject == nil {
+		return found
+	}
+
+	if p.removeDeclarations(gradleProject, profile, groupId, artifactId) {
+		found = true
+	}
+	if gradleProject.Catalog != nil {
+		p.pruneCatalog(gradleProject, groupId, artifactId)
+	}
+	return found
+}
+
+func declaredSourceSets(project *buildtool.Project, content string) map[string]bool {
+	sets := map[string]bool{"main": true, "test": true}
+	if _, ok := project.Plugin(buildtool.Plugin{ID: "java-test-fixtures"}); ok {
+		sets["testFixtures"] = true
+	}
+	if sourceSets, ok := findBlock(content, 0, len(content), "sourceSets"); ok {
+		collectSourceSets(content, sourceSets, sets)
+	}
+	if testing, ok := findBlock(content, 0, len(content), "testing"); ok {
+		from, to := testing.inner()
+		if suites, ok := findBlock(content, from, to, "suites"); ok {
+			collectSourceSets(content, suites, sets)
+		}
+	}
+	return sets
+}
+
+func collectSourceSets(content string, b block, sets map[string]bool) {
+	from, to := b.inner()
+	for _, stmt := range statements(content, from, to) {
+		if name := sourceSetName(stmt.tokens); name != "" {
+			sets[name] = true
+		}
+	}
+}
+
+func sourceSetName(tokens []source.Token) string {
+	first := tokens[0]
+	if first.Kind != source.TokenIdent {
+		return ""
+	}
+	if first.Text == "val" && len(tokens) > 1 && tokens[1].Kind == source.TokenIdent {
+		return tokens[1].Text
+	}
+	if sourceSetFactories[first.Text] {
+		for _, tok := range tokens[1:] {
+			if tok.Kind == source.TokenString {
+				return tok.Value
+			}
+		}
+		return ""
+	}
+	if len(tokens) == 1 || isPunct(tokens[1], "{") || isPunct(tokens[1], "(") {
+		return first.Text
+	}
+	return ""
+}
+
+func declareSourceSet(gradleProject *GradleProject, name string) string {
+	content := gradleProject.Content
+	line := name + " {}"
+	if gradleProject.IsKotlin {
+		line = `create("` + name + `")`
+	}
+	if sourceSets, ok := findBlock(content, 0, len(content), "sourceSets"); ok {
+		return appendToBlock(content, sourceSets, []string{line})
+	}
+
+	section := "sourceSets {\n" + indentUnit(content) + line + "\n}"
+	if deps, ok := findBlock(content, 0, len(content), "dependencies"); ok {
+		return content[:deps.start] + section + "\n\n" + content[deps.start:]
+	}
+	return appendTopLevel(content, section)
+}
+
+func sourceSetSuffix(scope string) string {
+	switch scope {
+	case "provided":
+		return "CompileOnly"
+	case "runtime":
+		return "RuntimeOnly"
+	default:
+		return "Implementation"
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,283 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tomlValue struct {
+	str   string
+	table map[string]tomlValue
+	array []tomlValue
+}
+
+type tomlEntry struct {
+	key   string
+	value tomlValue
+	start int
+	end   int
+}
+
+type tomlSection struct {
+	name    string
+	start   int
+	body    int
+	end     int
+	entries []tomlEntry
+}
+
+type tomlDocument struct {
+	content  string
+	sections []*tomlSection
+}
+
+type tomlParser struct {
+	content string
+	pos     int
+}
+
+func parseTOML(content string) (*tomlDocument, error) {
+	p := &tomlParser{content: content}
+	doc := &tomlDocument{content: content}
+	current := &tomlSection{}
+	doc.sections = append(doc.sections, current)
+
+	for p.pos < len(content) {
+		lineStart := p.pos
+		p.skipBlank()
+		switch {
+		case p.pos >= len(content):
+		case p.peek() == '#' || p.peek() == '\n' || p.peek() == '\r':
+			p.skipLine()
+		case p.peek() == '[':
+			end := strings.IndexByte(content[p.pos:], ']')
+			if end < 0 {
+				return nil, p.errorf("unterminated table header")
+			}
+			name := strings.Trim(content[p.pos+1:p.pos+end], "[] \t")
+			p.pos += end + 1
+			p.skipLine()
+			current.end = lineStart
+			current = &tomlSection{name: name, start: lineStart, body: p.pos}
+			doc.sections = append(doc.sections, current)
+		default:
+			key, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			p.skipBlank()
+			if p.pos < len(content) && p.peek() != '#' && p.peek() != '\n' && p.peek() != '\r' {
+				return nil, p.errorf("unexpected %q after value of %s", p.peek(), key)
+			}
+			p.skipLine()
+			current.entries = append(current.entries, tomlEntry{key: key, value: value, start: lineStart, end: p.pos})
+		}
+	}
+	current.end = len(content)
+	return doc, nil
+}
+
+func (d *tomlDocument) section(name string) *tomlSection {
+	for _, s := range d.sections {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func (p *tomlParser) peek() byte {
+	return p.content[p.pos]
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	line := strings.Count(p.content[:p.pos], "\n") + 1
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) skipBlank() {
+	for p.pos < len(p.content) && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *tomlParser) skipSpace() {
+	for p.pos < len(p.content) {
+		switch p.peek() {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			p.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) skipLine() {
+	if end := strings.IndexByte(p.content[p.pos:], '\n'); end >= 0 {
+		p.pos += end + 1
+	} else {
+		p.pos = len(p.content)
+	}
+}
+
+func (p *tomlParser) parseKey() (string, error) {
+	var parts []string
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.content) {
+			return "", p.errorf("unexpected end of file in key")
+		}
+		if c := p.peek(); c == '"' || c == '\'' {
+			part, err := p.parseString()
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		} else {
+			start := p.pos
+			for p.pos < len(p.content) && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return "", p.errorf("invalid key")
+			}
+			parts = append(parts, p.content[start:p.pos])
+		}
+		p.skipBlank()
+		if p.pos < len(p.content) && p.peek() == '.' {
+			p.pos++
+			continue
+		}
+		if p.pos >= len(p.content) || p.peek() != '=' {
+			return "", p.errorf("expected '=' after key %s", strings.Join(parts, "."))
+		}
+		p.pos++
+		p.skipBlank()
+		return strings.Join(parts, "."), nil
+	}
+}
+
+func (p *tomlParser) parseValue() (tomlValue, error) {
+	if p.pos >= len(p.content) {
+		return tomlValue{}, p.errorf("missing value")
+	}
+
+	switch p.peek() {
+	case '"', '\'':
+		s, err := p.parseString()
+		return tomlValue{str: s}, err
+	case '{':
+		return p.parseInlineTable()
+	case '[':
+		return p.parseArray()
+	}
+
+	start := p.pos
+	for p.pos < len(p.content) && !strings.ContainsRune(",}] \t\r\n#", rune(p.peek())) {
+		p.pos++
+	}
+	if start == p.pos {
+		return tomlValue{}, p.errorf("invalid value")
+	}
+	return tomlValue{str: p.content[start:p.pos]}, nil
+}
+
+func (p *tomlParser) parseString() (string, error) {
+	quote := p.content[p.pos : p.pos+1]
+	if triple := strings.Repeat(quote, 3); strings.HasPrefix(p.content[p.pos:], triple) {
+		end := strings.Index(p.content[p.pos+3:], triple)
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+		s := strings.TrimPrefix(p.content[p.pos+3:p.pos+3+end], "\n")
+		p.pos += end + 6
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := p.pos + 1; i < len(p.content); i++ {
+		c := p.content[i]
+		switch {
+		case c == quote[0]:
+			p.pos = i + 1
+			return b.String(), nil
+		case c == '\n':
+			return "", p.errorf("unterminated string")
+		case c == '\\' && quote == `"` && i+1 < len(p.content):
+			i++
+			switch p.content[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(p.content[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *tomlParser) parseInlineTable() (tomlValue, error) {
+	p.pos++
+	table := make(map[string]tomlValue)
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.content) {
+			return tomlValue{}, p.errorf("unterminated inline table")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return tomlValue{table: table}, nil
+		}
+		key, err := p.parseKey()
+		if err != nil {
+			return tomlValue{}, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return tomlValue{}, err
+		}
+		table[key] = value
+		p.skipBlank()
+		if p.pos < len(p.content) && p.peek() == ',' {
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) parseArray() (tomlValue, error) {
+	p.pos++
+	var values []tomlValue
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.content) {
+			return tomlValue{}, p.errorf("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return tomlValue{array: values}, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return tomlValue{}, err
+		}
+		values = append(values, value)
+		p.skipSpace()
+		if p.pos < len(p.content) && p.peek() == ',' {
+			p.pos++
+		}
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c == '-' || c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
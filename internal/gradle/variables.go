@@ -0,0 +1,97 @@
+package gradle
+
+import (
+	"bufio"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	localVariablePattern = regexp.MustCompile(`^(?:val|var|def|String)\s+(\w+)(?:\s*:\s*String)?\s*=\s*['"]([^'"$]*)['"]$`)
+	templatePattern      = regexp.MustCompile(`\$\{([^}]*)\}|\$([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)`)
+	quotedKeyPattern     = regexp.MustCompile(`['"]([^'"]+)['"]`)
+)
+
+func projectDirs(fs afero.Fs, buildFile string) []string {
+	var dirs []string
+	dir := filepath.Dir(buildFile)
+	for {
+		dirs = append(dirs, dir)
+		for _, settings := range []string{"settings.gradle", "settings.gradle.kts"} {
+			if _, err := fs.Stat(filepath.Join(dir, settings)); err == nil {
+				return dirs
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dirs
+		}
+		dir = parent
+	}
+}
+
+func (p *Parser) variables(path, content string) map[string]string {
+	vars := make(map[string]string)
+	for _, dir := range projectDirs(p.fs, path) {
+		for key, value := range readGradleProperties(p.fs, filepath.Join(dir, "gradle.properties")) {
+			if _, exists := vars[key]; !exists {
+				vars[key] = value
+			}
+		}
+	}
+	for _, entry := range parseProperties(content) {
+		vars[entry.Key] = entry.Value
+	}
+	for _, stmt := range statements(content, 0, len(content)) {
+		if match := localVariablePattern.FindStringSubmatch(content[stmt.start:stmt.end]); match != nil {
+			vars[match[1]] = match[2]
+		}
+	}
+	return vars
+}
+
+func readGradleProperties(fs afero.Fs, path string) map[string]string {
+	props := make(map[string]string)
+	file, err := fs.Open(path)
+	if err != nil {
+		return props
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			continue
+		}
+		props[strings.TrimSpace(line[:sep])] = strings.TrimSpace(line[sep+1:])
+	}
+	return props
+}
+
+func resolveTemplates(value string, vars map[string]string) string {
+	return templatePattern.ReplaceAllStringFunc(value, func(ref string) string {
+		match := templatePattern.FindStringSubmatch(ref)
+		expr := strings.TrimSpace(match[1] + match[2])
+
+		candidates := []string{expr}
+		if quoted := quotedKeyPattern.FindStringSubmatch(expr); quoted != nil {
+			candidates = []string{quoted[1]}
+		} else if dot := strings.LastIndex(expr, "."); dot >= 0 {
+			candidates = append(candidates, expr[dot+1:])
+		}
+		for _, key := range candidates {
+			if resolved, ok := vars[key]; ok {
+				return resolved
+			}
+		}
+		return ref
+	})
+}
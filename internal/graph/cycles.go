@@ -0,0 +1,85 @@
+package graph
+
+import "sort"
+
+type tarjan struct {
+	adjacency map[string][]string
+	index     map[string]int
+	lowlink   map[string]int
+	onStack   map[string]bool
+	stack     []string
+	next      int
+	result    [][]string
+}
+
+func (g *Graph) markCycles() {
+	t := &tarjan{
+		adjacency: make(map[string][]string),
+		index:     make(map[string]int),
+		lowlink:   make(map[string]int),
+		onStack:   make(map[string]bool),
+	}
+	for _, edge := range g.Edges {
+		t.adjacency[edge.From] = append(t.adjacency[edge.From], edge.To)
+	}
+	for _, node := range g.Nodes {
+		if _, visited := t.index[node.ID]; !visited {
+			t.connect(node.ID)
+		}
+	}
+
+	component := make(map[string]int)
+	for i, scc := range t.result {
+		for _, id := range scc {
+			component[id] = i + 1
+		}
+	}
+
+	for i := range g.Nodes {
+		g.Nodes[i].Cycle = component[g.Nodes[i].ID] > 0
+	}
+	for i, edge := range g.Edges {
+		g.Edges[i].Cycle = component[edge.From] > 0 && component[edge.From] == component[edge.To]
+	}
+
+	sort.Slice(t.result, func(i, j int) bool {
+		return t.result[i][0] < t.result[j][0]
+	})
+	g.Cycles = t.result
+}
+
+func (t *tarjan) connect(id string) {
+	t.index[id] = t.next
+	t.lowlink[id] = t.next
+	t.next++
+	t.stack = append(t.stack, id)
+	t.onStack[id] = true
+
+	for _, to := range t.adjacency[id] {
+		if _, visited := t.index[to]; !visited {
+			t.connect(to)
+			t.lowlink[id] = min(t.lowlink[id], t.lowlink[to])
+		} else if t.onStack[to] {
+			t.lowlink[id] = min(t.lowlink[id], t.index[to])
+		}
+	}
+
+	if t.lowlink[id] != t.index[id] {
+		return
+	}
+
+	var scc []string
+	for {
+		top := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[top] = false
+		scc = append(scc, top)
+		if top == id {
+			break
+		}
+	}
+	if len(scc) > 1 {
+		sort.Strings(scc)
+		t.result = append(t.result, scc)
+	}
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/detector"
+)
+
+type Level string
+
+const (
+	LevelPackage Level = "package"
+	LevelModule  Level = "module"
+)
+
+type Node struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Files   int    `json:"files"`
+	Feature bool   `json:"feature,omitempty"`
+	Cycle   bool   `json:"cycle,omitempty"`
+	Focus   bool   `json:"focus,omitempty"`
+}
+
+type Edge struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Imports int    `json:"imports"`
+	Cycle   bool   `json:"cycle,omitempty"`
+}
+
+type Graph struct {
+	Level       Level      `json:"level"`
+	BasePackage string     `json:"basePackage"`
+	Focus       string     `json:"focus,omitempty"`
+	Nodes       []Node     `json:"nodes"`
+	Edges       []Edge     `json:"edges"`
+	Cycles      [][]string `json:"cycles"`
+}
+
+func ParseLevel(value string) (Level, error) {
+	switch Level(value) {
+	case LevelPackage, LevelModule:
+		return Level(value), nil
+	default:
+		return "", fmt.Errorf("invalid level '%s' (expected package or module)", value)
+	}
+}
+
+func Build(files []*detector.JavaFile, basePackage string, level Level, featureModules []string) *Graph {
+	packages := make(map[string]bool)
+	for _, file := range sourceFiles(files) {
+		packages[file.Package] = true
+	}
+
+	features := make(map[string]bool, len(featureModules))
+	for _, module := range featureModules {
+		features[module] = true
+	}
+
+	nodes := make(map[string]*Node)
+	edges := make(map[[2]string]int)
+	for _, file := range sourceFiles(files) {
+		from := nodeID(file.Package, basePackage, level)
+		if from == "" {
+			continue
+		}
+		node, ok := nodes[from]
+		if !ok {
+			node = &Node{ID: from, Label: nodeLabel(from, basePackage, level), Feature: level == LevelModule && features[from]}
+			nodes[from] = node
+		}
+		node.Files++
+
+		for _, imported := range file.Imports {
+			pkg := packageOf(imported, packages)
+			if pkg == "" {
+				continue
+			}
+			to := nodeID(pkg, basePackage, level)
+			if to == "" || to == from {
+				continue
+			}
+			edges[[2]string{from, to}]++
+		}
+	}
+
+	g := &Graph{Level: level, BasePackage: basePackage, Nodes: []Node{}, Edges: []Edge{}, Cycles: [][]string{}}
+	for _, node := range nodes {
+		g.Nodes = append(g.Nodes, *node)
+	}
+	for key, count := range edges {
+		if _, ok := nodes[key[1]]; ok {
+			g.Edges = append(g.Edges, Edge{From: key[0], To: key[1], Imports: count})
+		}
+	}
+	g.sort()
+	g.markCycles()
+	return g
+}
+
+func (g *Graph) WithFocus(name string) (*Graph, error) {
+	focus := make(map[string]bool)
+	for _, node := range g.Nodes {
+		if matchesFocus(node, name) {
+			focus[node.ID] = true
+		}
+	}
+	if len(focus) == 0 {
+		return nil, fmt.Errorf("no %s matches '%s'", g.Level, name)
+	}
+
+	keep := make(map[string]bool)
+	for id := range focus {
+		keep[id] = true
+	}
+
+	result := &Graph{Level: g.Level, BasePackage: g.BasePackage, Focus: name, Nodes: []Node{}, Edges: []Edge{}, Cycles: [][]string{}}
+	for _, edge := range g.Edges {
+		if focus[edge.From] || focus[edge.To] {
+			result.Edges = append(result.Edges, edge)
+			keep[edge.From] = true
+			keep[edge.To] = true
+		}
+	}
+	for _, node := range g.Nodes {
+		if keep[node.ID] {
+			node.Focus = focus[node.ID]
+			result.Nodes = append(result.Nodes, node)
+		}
+	}
+	for _, cycle := range g.Cycles {
+		for _, id := range cycle {
+			if focus[id] {
+				result.Cycles = append(result.Cycles, cycle)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func matchesFocus(node Node, name string) bool {
+	if node.ID == name || node.Label == name {
+		return true
+	}
+	return strings.HasPrefix(node.Label, name+".") || strings.HasPrefix(node.ID, name+".")
+}
+
+func (g *Graph) sort() {
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+}
+
+func sourceFiles(files []*detector.JavaFile) []*detector.JavaFile {
+	var result []*detector.JavaFile
+	for _, file := range files {
+		if file.FileType != detector.FileTypeTest && file.Package != "" {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+func packageOf(imported string, packages map[string]bool) string {
+	name := imported
+	for {
+		idx := strings.LastIndex(name, ".")
+		if idx < 0 {
+			return ""
+		}
+		name = name[:idx]
+		if packages[name] {
+			return name
+		}
+	}
+}
+
+func nodeID(pkg, basePackage string, level Level) string {
+	if level == LevelPackage {
+		return pkg
+	}
+	if basePackage == "" {
+		return pkg
+	}
+	if pkg == basePackage {
+		return lastSegment(basePackage)
+	}
+	rest, ok := strings.CutPrefix(pkg, basePackage+".")
+	if !ok {
+		return ""
+	}
+	module, _, _ := strings.Cut(rest, ".")
+	return module
+}
+
+func nodeLabel(id, basePackage string, level Level) string {
+	if level == LevelModule || basePackage == "" || id == basePackage {
+		return id
+	}
+	if rest, ok := strings.CutPrefix(id, basePackage+"."); ok {
+		return rest
+	}
+	return id
+}
+
+func lastSegment(pkg string) string {
+	return pkg[strings.LastIndex(pkg, ".")+1:]
+}
@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func javaFile(pkg, class string, imports ...string) *detector.JavaFile {
+	return &detector.JavaFile{Package: pkg, ClassName: class, Imports: imports}
+}
+
+func sampleFiles() []*detector.JavaFile {
+	return []*detector.JavaFile{
+		javaFile("com.shop.user", "UserService", "com.shop.billing.InvoiceService", "com.shop.common.Money", "java.util.List"),
+		javaFile("com.shop.user.web", "UserController", "com.shop.user.UserService"),
+		javaFile("com.shop.billing", "InvoiceService", "com.shop.user.UserService", "com.shop.common.Money"),
+		javaFile("com.shop.common", "Money"),
+		javaFile("com.shop.order", "OrderService", "com.shop.billing.InvoiceService", "com.shop.billing.InvoiceService.Status"),
+		{Package: "com.shop.order", ClassName: "OrderServiceTest", FileType: detector.FileTypeTest, Imports: []string{"com.shop.user.UserService"}},
+	}
+}
+
+func edgeKeys(g *Graph) []string {
+	var keys []string
+	for _, edge := range g.Edges {
+		keys = append(keys, edge.From+"->"+edge.To)
+	}
+	return keys
+}
+
+func TestBuildPackageGraph(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelPackage, nil)
+
+	require.Len(t, g.Nodes, 5)
+	assert.Equal(t, "com.shop.billing", g.Nodes[0].ID)
+	assert.Equal(t, "billing", g.Nodes[0].Label)
+	assert.Equal(t, []string{
+		"com.shop.billing->com.shop.common",
+		"com.shop.billing->com.shop.user",
+		"com.shop.order->com.shop.billing",
+		"com.shop.user->com.shop.billing",
+		"com.shop.user->com.shop.common",
+		"com.shop.user.web->com.shop.user",
+	}, edgeKeys(g))
+
+	assert.Equal(t, 2, g.Edges[2].Imports)
+	assert.Equal(t, [][]string{{"com.shop.billing", "com.shop.user"}}, g.Cycles)
+	assert.True(t, g.Edges[1].Cycle)
+	assert.False(t, g.Edges[0].Cycle)
+}
+
+func TestBuildModuleGraph(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelModule, []string{"user", "billing", "order"})
+
+	var ids []string
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+		if node.ID == "user" {
+			assert.Equal(t, 2, node.Files)
+			assert.True(t, node.Feature)
+		}
+		if node.ID == "common" {
+			assert.False(t, node.Feature)
+		}
+	}
+	assert.Equal(t, []string{"billing", "common", "order", "user"}, ids)
+	assert.NotContains(t, edgeKeys(g), "user->user")
+	assert.Equal(t, [][]string{{"billing", "user"}}, g.Cycles)
+}
+
+func TestGraphWithFocus(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelModule, nil)
+
+	focused, err := g.WithFocus("order")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order->billing"}, edgeKeys(focused))
+	assert.Len(t, focused.Nodes, 2)
+	assert.Empty(t, focused.Cycles)
+
+	focused, err = g.WithFocus("billing")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"billing->common", "billing->user", "order->billing", "user->billing"}, edgeKeys(focused))
+	assert.Len(t, focused.Cycles, 1)
+
+	_, err = g.WithFocus("shipping")
+	assert.ErrorContains(t, err, "no module matches 'shipping'")
+}
+
+func TestGraphWithFocusPackagePrefix(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelPackage, nil)
+
+	focused, err := g.WithFocus("user")
+	require.NoError(t, err)
+
+	var focusIDs []string
+	for _, node := range focused.Nodes {
+		if node.Focus {
+			focusIDs = append(focusIDs, node.ID)
+		}
+	}
+	assert.Equal(t, []string{"com.shop.user", "com.shop.user.web"}, focusIDs)
+}
+
+func TestCyclesLongerThanTwo(t *testing.T) {
+	files := []*detector.JavaFile{
+		javaFile("app.a", "A", "app.b.B"),
+		javaFile("app.b", "B", "app.c.C"),
+		javaFile("app.c", "C", "app.a.A"),
+		javaFile("app.d", "D", "app.a.A"),
+	}
+
+	g := Build(files, "app", LevelPackage, nil)
+
+	assert.Equal(t, [][]string{{"app.a", "app.b", "app.c"}}, g.Cycles)
+	for _, node := range g.Nodes {
+		assert.Equal(t, node.ID != "app.d", node.Cycle, node.ID)
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelModule, []string{"user", "billing", "order"})
+	focused, err := g.WithFocus("billing")
+	require.NoError(t, err)
+
+	dot := RenderDOT(focused)
+	assert.Contains(t, dot, "digraph haft {")
+	assert.Contains(t, dot, `"billing" [label="billing", color="#e53935", style=bold];`)
+	assert.Contains(t, dot, `"common" [label="common", shape=ellipse];`)
+	assert.Contains(t, dot, `"billing" -> "user" [label="1", color="#e53935", penwidth=2];`)
+	assert.Contains(t, dot, `"order" -> "billing" [label="2"];`)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	g := Build(sampleFiles(), "com.shop", LevelModule, nil)
+
+	mermaid := RenderMermaid(g)
+	assert.Contains(t, mermaid, "graph LR\n")
+	assert.Contains(t, mermaid, `  n0["billing"]`)
+	assert.Contains(t, mermaid, "  n2 -->|2| n0\n")
+	assert.Contains(t, mermaid, "  class n0,n3 cycle\n")
+	assert.Contains(t, mermaid, "  linkStyle 1,3 stroke:#e53935")
+}
+
+func TestParseOptions(t *testing.T) {
+	_, err := ParseFormat("svg")
+	assert.ErrorContains(t, err, "expected dot, mermaid or json")
+
+	_, err = ParseLevel("class")
+	assert.ErrorContains(t, err, "expected package or module")
+
+	level, err := ParseLevel("module")
+	require.NoError(t, err)
+	assert.Equal(t, LevelModule, level)
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+	FormatJSON    Format = "json"
+)
+
+const cycleColor = "#e53935"
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case FormatDOT, FormatMermaid, FormatJSON:
+		return Format(value), nil
+	default:
+		return "", fmt.Errorf("invalid format '%s' (expected dot, mermaid or json)", value)
+	}
+}
+
+func RenderDOT(g *Graph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph haft {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, node := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%s", quote(node.Label))}
+		if g.Level == LevelModule && !node.Feature {
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if node.Cycle {
+			attrs = append(attrs, fmt.Sprintf("color=%s", quote(cycleColor)))
+		}
+		if node.Focus {
+			attrs = append(attrs, "style=bold")
+		}
+		fmt.Fprintf(&sb, "  %s [%s];\n", quote(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		attrs := []string{fmt.Sprintf("label=%s", quote(fmt.Sprint(edge.Imports)))}
+		if edge.Cycle {
+			attrs = append(attrs, fmt.Sprintf("color=%s", quote(cycleColor)), "penwidth=2")
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [%s];\n", quote(edge.From), quote(edge.To), strings.Join(attrs, ", "))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func RenderMermaid(g *Graph) string {
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+
+	ids := make(map[string]string, len(g.Nodes))
+	var cycleNodes, focusNodes []string
+	for i, node := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", id, strings.ReplaceAll(node.Label, `"`, "#quot;"))
+		if node.Cycle {
+			cycleNodes = append(cycleNodes, id)
+		}
+		if node.Focus {
+			focusNodes = append(focusNodes, id)
+		}
+	}
+
+	var cycleLinks []string
+	for i, edge := range g.Edges {
+		fmt.Fprintf(&sb, "  %s -->|%d| %s\n", ids[edge.From], edge.Imports, ids[edge.To])
+		if edge.Cycle {
+			cycleLinks = append(cycleLinks, fmt.Sprint(i))
+		}
+	}
+
+	if len(cycleNodes) > 0 {
+		fmt.Fprintf(&sb, "  classDef cycle stroke:%s,stroke-width:2px\n", cycleColor)
+		fmt.Fprintf(&sb, "  class %s cycle\n", strings.Join(cycleNodes, ","))
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&sb, "  linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(cycleLinks, ","), cycleColor)
+	}
+	if len(focusNodes) > 0 {
+		sb.WriteString("  classDef focus font-weight:bold,stroke-width:3px\n")
+		fmt.Fprintf(&sb, "  class %s focus\n", strings.Join(focusNodes, ","))
+	}
+	return sb.String()
+}
+
+func quote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
@@ -0,0 +1,246 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/spf13/afero"
+)
+
+type Event string
+
+const (
+	PostGenerate Event = "postGenerate"
+	PostAdd      Event = "postAdd"
+	PostRemove   Event = "postRemove"
+)
+
+const (
+	PolicyWarn  = "warn"
+	PolicyAbort = "abort"
+
+	DefaultTimeout = 60 * time.Second
+	maxOutputBytes = 16 * 1024
+	waitDelay      = time.Second
+)
+
+type Status string
+
+const (
+	StatusSuccess Status = "success"
+	StatusFailed  Status = "failed"
+	StatusTimeout Status = "timeout"
+	StatusSkipped Status = "skipped"
+)
+
+type Result struct {
+	Name     string
+	Command  string
+	Status   Status
+	ExitCode int
+	Duration time.Duration
+	Output   string
+	Error    string
+	Policy   string
+}
+
+type Report struct {
+	Event      Event
+	Results    []Result
+	Aborted    bool
+	RolledBack bool
+}
+
+type Runner struct {
+	projectDir string
+	settings   config.HookSettings
+}
+
+func NewRunner(projectDir string, settings config.HookSettings) *Runner {
+	return &Runner{projectDir: projectDir, settings: settings}
+}
+
+func LoadRunner(fs afero.Fs, projectDir string) (*Runner, error) {
+	cm := config.NewConfigManager(fs, projectDir, "")
+	if !cm.ProjectConfigExists() {
+		return NewRunner(projectDir, config.HookSettings{}), nil
+	}
+
+	cfg, err := cm.LoadProjectConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", config.ProjectConfigFile, err)
+	}
+	return NewRunner(projectDir, cfg.Hooks), nil
+}
+
+func (r *Runner) Hooks(event Event) []config.HookConfig {
+	switch event {
+	case PostGenerate:
+		return r.settings.PostGenerate
+	case PostAdd:
+		return r.settings.PostAdd
+	case PostRemove:
+		return r.settings.PostRemove
+	default:
+		return nil
+	}
+}
+
+func (r *Runner) HasHooks(event Event) bool {
+	return len(r.Hooks(event)) > 0
+}
+
+func (r *Runner) Run(event Event, files []string) *Report {
+	report := &Report{Event: event}
+
+	for _, hook := range r.Hooks(event) {
+		if report.Aborted {
+			report.Results = append(report.Results, Result{
+				Name:    hookName(hook),
+				Command: hook.Command,
+				Status:  StatusSkipped,
+				Policy:  policy(hook),
+			})
+			continue
+		}
+
+		result := r.runHook(event, hook, files)
+		report.Results = append(report.Results, result)
+		if result.Status != StatusSuccess && result.Policy == PolicyAbort {
+			report.Aborted = true
+		}
+	}
+
+	return report
+}
+
+func (r *Runner) runHook(event Event, hook config.HookConfig, files []string) Result {
+	result := Result{Name: hookName(hook), Command: hook.Command, Policy: policy(hook)}
+
+	timeout, err := parseTimeout(hook.Timeout)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Dir = r.projectDir
+	cmd.Env = append(os.Environ(), hookEnv(event, r.projectDir, files)...)
+	cmd.Stdin = strings.NewReader(strings.Join(files, "\n"))
+	cmd.WaitDelay = waitDelay
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	runErr := cmd.Run()
+	result.Duration = time.Since(start)
+	result.Output = truncateOutput(out.String())
+	result.ExitCode = cmd.ProcessState.ExitCode()
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Status = StatusTimeout
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+	case runErr != nil:
+		result.Status = StatusFailed
+		result.Error = runErr.Error()
+	default:
+		result.Status = StatusSuccess
+	}
+	return result
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func hookEnv(event Event, projectDir string, files []string) []string {
+	absolute := make([]string, len(files))
+	for i, file := range files {
+		absolute[i] = file
+		if !filepath.IsAbs(file) {
+			absolute[i] = filepath.Join(projectDir, file)
+		}
+	}
+
+	return []string{
+		"HAFT_EVENT=" + string(event),
+		"HAFT_PROJECT_DIR=" + projectDir,
+		"HAFT_FILES=" + strings.Join(files, "\n"),
+		"HAFT_FILES_ABS=" + strings.Join(absolute, "\n"),
+		"HAFT_FILE_COUNT=" + strconv.Itoa(len(files)),
+	}
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q (use a duration like 30s or 2m)", value)
+	}
+	return timeout, nil
+}
+
+func policy(hook config.HookConfig) string {
+	if strings.EqualFold(hook.OnFailure, PolicyAbort) {
+		return PolicyAbort
+	}
+	return PolicyWarn
+}
+
+func hookName(hook config.HookConfig) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return hook.Command
+}
+
+func truncateOutput(out string) string {
+	if len(out) <= maxOutputBytes {
+		return out
+	}
+	return out[len(out)-maxOutputBytes:]
+}
+
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Status == StatusFailed || result.Status == StatusTimeout {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (r *Report) Err() error {
+	if r == nil || !r.Aborted {
+		return nil
+	}
+	for _, result := range r.Failed() {
+		if result.Policy == PolicyAbort {
+			return fmt.Errorf("%s hook '%s' failed: %s", r.Event, result.Name, result.Error)
+		}
+	}
+	return fmt.Errorf("%s hooks aborted", r.Event)
+}
@@ -0,0 +1,181 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+}
+
+func TestRunnerHooksByEvent(t *testing.T) {
+	runner := NewRunner("/project", config.HookSettings{
+		PostGenerate: []config.HookConfig{{Command: "a"}},
+		PostAdd:      []config.HookConfig{{Command: "b"}, {Command: "c"}},
+	})
+
+	assert.Len(t, runner.Hooks(PostGenerate), 1)
+	assert.Len(t, runner.Hooks(PostAdd), 2)
+	assert.False(t, runner.HasHooks(PostRemove))
+}
+
+func TestLoadRunner(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	runner, err := LoadRunner(fs, "/project")
+	require.NoError(t, err)
+	assert.False(t, runner.HasHooks(PostGenerate))
+
+	config := `{"hooks": {"postGenerate": [{"name": "format", "command": "mvn spotless:apply", "timeout": "2m", "onFailure": "abort"}]}}`
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft.json", []byte(config), 0644))
+
+	runner, err = LoadRunner(fs, "/project")
+	require.NoError(t, err)
+	require.Len(t, runner.Hooks(PostGenerate), 1)
+	assert.Equal(t, "format", runner.Hooks(PostGenerate)[0].Name)
+	assert.Equal(t, PolicyAbort, policy(runner.Hooks(PostGenerate)[0]))
+}
+
+func TestRunPassesFilesViaEnvAndStdin(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+
+	runner := NewRunner(dir, config.HookSettings{
+		PostGenerate: []config.HookConfig{
+			{Name: "env", Command: `printf '%s|%s|%s' "$HAFT_EVENT" "$HAFT_FILE_COUNT" "$HAFT_FILES"`},
+			{Name: "stdin", Command: "cat > stdin.txt"},
+		},
+	})
+
+	report := runner.Run(PostGenerate, []string{"src/A.java", "src/B.java"})
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, StatusSuccess, report.Results[0].Status)
+	assert.Equal(t, "postGenerate|2|src/A.java\nsrc/B.java", report.Results[0].Output)
+	assert.False(t, report.Aborted)
+	assert.NoError(t, report.Err())
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "src/A.java\nsrc/B.java", string(stdin))
+}
+
+func TestRunWarnPolicyContinues(t *testing.T) {
+	skipOnWindows(t)
+
+	runner := NewRunner(t.TempDir(), config.HookSettings{
+		PostAdd: []config.HookConfig{{Command: "exit 3"}, {Command: "true"}},
+	})
+
+	report := runner.Run(PostAdd, []string{"pom.xml"})
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, StatusFailed, report.Results[0].Status)
+	assert.Equal(t, 3, report.Results[0].ExitCode)
+	assert.Equal(t, PolicyWarn, report.Results[0].Policy)
+	assert.Equal(t, StatusSuccess, report.Results[1].Status)
+	assert.False(t, report.Aborted)
+	assert.NoError(t, report.Err())
+	assert.Len(t, report.Failed(), 1)
+}
+
+func TestRunAbortPolicySkipsRemaining(t *testing.T) {
+	skipOnWindows(t)
+
+	runner := NewRunner(t.TempDir(), config.HookSettings{
+		PostRemove: []config.HookConfig{{Name: "compile", Command: "exit 1", OnFailure: "abort"}, {Command: "true"}},
+	})
+
+	report := runner.Run(PostRemove, []string{"pom.xml"})
+
+	require.Len(t, report.Results, 2)
+	assert.True(t, report.Aborted)
+	assert.Equal(t, StatusSkipped, report.Results[1].Status)
+	assert.ErrorContains(t, report.Err(), "postRemove hook 'compile' failed")
+}
+
+func TestRunTimeout(t *testing.T) {
+	skipOnWindows(t)
+
+	runner := NewRunner(t.TempDir(), config.HookSettings{
+		PostGenerate: []config.HookConfig{{Command: "sleep 5", Timeout: "100ms", OnFailure: "abort"}},
+	})
+
+	report := runner.Run(PostGenerate, []string{"A.java"})
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, StatusTimeout, report.Results[0].Status)
+	assert.True(t, report.Aborted)
+}
+
+func TestRunInvalidTimeout(t *testing.T) {
+	runner := NewRunner(t.TempDir(), config.HookSettings{
+		PostGenerate: []config.HookConfig{{Command: "true", Timeout: "soon"}},
+	})
+
+	report := runner.Run(PostGenerate, []string{"A.java"})
+
+	assert.Equal(t, StatusFailed, report.Results[0].Status)
+	assert.Contains(t, report.Results[0].Error, "invalid timeout")
+}
+
+func TestExecuteRollsBackOnAbort(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	config := `{"hooks": {"postGenerate": [{"command": "exit 1", "onFailure": "abort"}]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".haft.json"), []byte(config), 0644))
+
+	created := filepath.Join(dir, "src", "pkg", "A.java")
+	require.NoError(t, os.MkdirAll(filepath.Dir(created), 0755))
+	require.NoError(t, os.WriteFile(created, []byte("class A {}"), 0644))
+
+	rollback := NewRollback(fs, dir)
+	rollback.TrackCreated(created)
+
+	report, err := Execute(fs, dir, PostGenerate, []string{"src/pkg/A.java"}, rollback, true)
+	require.Error(t, err)
+	assert.True(t, report.RolledBack)
+	assert.NoFileExists(t, created)
+	assert.NoDirExists(t, filepath.Join(dir, "src"))
+
+	out := report.ToOutput()
+	assert.Equal(t, "postGenerate", out.Event)
+	assert.True(t, out.Aborted)
+	assert.Equal(t, "failed", out.Results[0].Status)
+}
+
+func TestExecuteWithoutHooks(t *testing.T) {
+	report, err := Execute(afero.NewMemMapFs(), "/project", PostAdd, []string{"pom.xml"}, nil, true)
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+	assert.Nil(t, report.ToOutput())
+}
+
+func TestRollbackRestoresModifiedFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/pom.xml", []byte("original"), 0644))
+
+	rollback := NewRollback(fs, "/project")
+	require.NoError(t, rollback.TrackModified("/project/pom.xml"))
+	require.NoError(t, afero.WriteFile(fs, "/project/pom.xml", []byte("changed"), 0644))
+	require.NoError(t, rollback.TrackModified("/project/pom.xml"))
+
+	require.NoError(t, rollback.Restore())
+	content, err := afero.ReadFile(fs, "/project/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+
+	assert.Error(t, rollback.TrackModified("/project/missing.xml"))
+}
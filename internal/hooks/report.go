@@ -0,0 +1,81 @@
+package hooks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KashifKhn/haft/internal/logger"
+	"github.com/KashifKhn/haft/internal/output"
+	"github.com/spf13/afero"
+)
+
+func Execute(fs afero.Fs, projectDir string, event Event, files []string, rollback *Rollback, quiet bool) (*Report, error) {
+	runner, err := LoadRunner(fs, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if !runner.HasHooks(event) || len(files) == 0 {
+		return nil, nil
+	}
+
+	report := runner.Run(event, files)
+	if !quiet {
+		printReport(report)
+	}
+
+	if report.Aborted && rollback != nil {
+		if err := rollback.Restore(); err != nil {
+			return report, fmt.Errorf("%w (rollback failed: %v)", report.Err(), err)
+		}
+		report.RolledBack = true
+		if !quiet {
+			logger.Default().Warning("Rolled back changes after hook failure")
+		}
+	}
+
+	return report, report.Err()
+}
+
+func (r *Report) ToOutput() *output.HookReport {
+	if r == nil {
+		return nil
+	}
+
+	report := &output.HookReport{
+		Event:      string(r.Event),
+		Results:    []output.HookResult{},
+		Aborted:    r.Aborted,
+		RolledBack: r.RolledBack,
+	}
+	for _, result := range r.Results {
+		report.Results = append(report.Results, output.HookResult{
+			Name:       result.Name,
+			Command:    result.Command,
+			Status:     string(result.Status),
+			Policy:     result.Policy,
+			ExitCode:   result.ExitCode,
+			DurationMs: result.Duration.Milliseconds(),
+			Output:     result.Output,
+			Error:      result.Error,
+		})
+	}
+	return report
+}
+
+func printReport(report *Report) {
+	log := logger.Default()
+	for _, result := range report.Results {
+		switch result.Status {
+		case StatusSuccess:
+			log.Success("Hook passed", "hook", result.Name, "duration", result.Duration.Round(time.Millisecond).String())
+		case StatusSkipped:
+			log.Info("Hook skipped", "hook", result.Name)
+		default:
+			log.Warning("Hook failed", "hook", result.Name, "policy", result.Policy, "error", result.Error)
+			if out := strings.TrimSpace(result.Output); out != "" {
+				fmt.Println(out)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+type Rollback struct {
+	fs        afero.Fs
+	root      string
+	created   []string
+	originals map[string][]byte
+	order     []string
+}
+
+func NewRollback(fs afero.Fs, root string) *Rollback {
+	return &Rollback{fs: fs, root: filepath.Clean(root), originals: make(map[string][]byte)}
+}
+
+func (r *Rollback) TrackCreated(path string) {
+	r.created = append(r.created, path)
+}
+
+func (r *Rollback) TrackModified(path string) error {
+	if _, ok := r.originals[path]; ok {
+		return nil
+	}
+
+	content, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s: %w", path, err)
+	}
+	r.originals[path] = content
+	r.order = append(r.order, path)
+	return nil
+}
+
+func (r *Rollback) Restore() error {
+	for i := len(r.created) - 1; i >= 0; i-- {
+		path := r.created[i]
+		if err := r.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		r.pruneEmptyDirs(filepath.Dir(path))
+	}
+
+	for _, path := range r.order {
+		if err := afero.WriteFile(r.fs, path, r.originals[path], 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rollback) pruneEmptyDirs(dir string) {
+	for strings.HasPrefix(dir, r.root+string(filepath.Separator)) {
+		entries, err := afero.ReadDir(r.fs, dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := r.fs.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
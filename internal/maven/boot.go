@@ -0,0 +1,78 @@
+package maven
+
+import (
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+const (
+	bootGroup    = "org.springframework.boot"
+	bootParent   = "spring-boot-starter-parent"
+	bootBom      = "spring-boot-dependencies"
+	bootPluginId = "spring-boot-maven-plugin"
+)
+
+func bootBomVersion(project *buildtool.Project) string {
+	bom, ok := project.ManagedDependency(bootGroup, bootBom)
+	if !ok {
+		return ""
+	}
+	if key, ok := propertyRef(bom.Version); ok {
+		value, _ := project.Property(key)
+		return value
+	}
+	return bom.Version
+}
+
+func propertyRef(value string) (string, bool) {
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		return value[2 : len(value)-1], true
+	}
+	return "", false
+}
+
+func (p *Parser) SetSpringBootVersion(project *buildtool.Project, version string) bool {
+	updated := false
+	mavenProject := p.getMavenProject(project)
+	if parent := mavenProject.Parent; parent != nil && parent.GroupId == bootGroup && parent.ArtifactId == bootParent {
+		parent.Version = version
+		updated = true
+	}
+
+	setVersion := func(current string) (string, bool) {
+		if current == "" {
+			return current, false
+		}
+		if key, ok := propertyRef(current); ok {
+			if _, exists := project.Property(key); !exists {
+				return current, false
+			}
+			p.SetProperty(project, key, version)
+			return current, true
+		}
+		return version, true
+	}
+
+	for i, dep := range project.ManagedDependencies {
+		if dep.GroupId == bootGroup && dep.ArtifactId == bootBom {
+			var ok bool
+			if project.ManagedDependencies[i].Version, ok = setVersion(dep.Version); ok {
+				updated = true
+			}
+		}
+	}
+	for i, plugin := range project.Plugins {
+		if plugin.GroupId == bootGroup && plugin.ArtifactId == bootPluginId {
+			var ok bool
+			if project.Plugins[i].Version, ok = setVersion(plugin.Version); ok {
+				updated = true
+			}
+		}
+	}
+
+	if updated {
+		project.SpringBootVersion = version
+	}
+	return updated
+}
@@ -0,0 +1,102 @@
+package maven
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSpringBootVersion_Parent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+    <parent>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-starter-parent</artifactId>
+        <version>3.3.5</version>
+    </parent>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.True(t, parser.SetSpringBootVersion(project, "3.4.1"))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(pom, "3.3.5", "3.4.1", 1), string(data))
+}
+
+func TestSetSpringBootVersion_Bom(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+    <properties>
+        <spring-boot.version>3.3.5</spring-boot.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.springframework.boot</groupId>
+                <artifactId>spring-boot-dependencies</artifactId>
+                <version>${spring-boot.version}</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.springframework.boot</groupId>
+                <artifactId>spring-boot-maven-plugin</artifactId>
+                <version>3.3.5</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "3.3.5", parser.GetSpringBootVersion(project))
+
+	assert.True(t, parser.SetSpringBootVersion(project, "3.4.1"))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(pom, "3.3.5", "3.4.1"), string(data))
+}
+
+func TestSetSpringBootVersion_NotBoot(t *testing.T) {
+	parser := NewParser()
+	project, err := parser.ParseBytes([]byte(`<project><artifactId>demo</artifactId></project>`))
+	require.NoError(t, err)
+	assert.False(t, parser.SetSpringBootVersion(project, "3.4.1"))
+}
+
+func TestMarshal_SpringBootParent(t *testing.T) {
+	parser := NewParser()
+	project := &buildtool.Project{GroupId: "com.example", ArtifactId: "demo", SpringBootVersion: "3.4.7"}
+
+	data, err := parser.Marshal(parser.toMavenProject(project))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<artifactId>spring-boot-starter-parent</artifactId>")
+
+	reparsed, err := parser.ParseBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, "3.4.7", reparsed.SpringBootVersion)
+
+	project.ManagedDependencies = []buildtool.Dependency{{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-dependencies", Version: "3.4.7", Type: "pom", Scope: "import"}}
+	data, err = parser.Marshal(parser.toMavenProject(project))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "<parent>")
+}
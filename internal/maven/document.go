@@ -0,0 +1,312 @@
+package maven
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Element struct {
+	Name       string
+	Start      int
+	End        int
+	InnerStart int
+	InnerEnd   int
+	Parent     *Element
+	Children   []*Element
+}
+
+type Node struct {
+	Name     string
+	Text     string
+	Children []Node
+}
+
+type Document struct {
+	content string
+	Root    *Element
+}
+
+func ParseDocument(data []byte) (*Document, error) {
+	doc := &Document{content: string(data)}
+	if err := doc.parse(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (d *Document) Bytes() []byte {
+	return []byte(d.content)
+}
+
+func (d *Document) String() string {
+	return d.content
+}
+
+func (d *Document) parse() error {
+	decoder := xml.NewDecoder(strings.NewReader(d.content))
+
+	var root, current *Element
+	for {
+		start := int(decoder.InputOffset())
+		tok, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse pom.xml: %w", err)
+		}
+		offset := int(decoder.InputOffset())
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			el := &Element{
+				Name:       qualifiedName(t.Name),
+				Start:      start,
+				InnerStart: offset,
+				Parent:     current,
+			}
+			if current != nil {
+				current.Children = append(current.Children, el)
+			} else if root == nil {
+				root = el
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil {
+				return fmt.Errorf("failed to parse pom.xml: unexpected </%s>", qualifiedName(t.Name))
+			}
+			current.InnerEnd = start
+			current.End = offset
+			if offset == start {
+				current.InnerStart = current.InnerEnd
+				current.End = current.InnerEnd
+			}
+			current = current.Parent
+		}
+	}
+
+	if root == nil {
+		return fmt.Errorf("failed to parse pom.xml: no root element")
+	}
+	if current != nil {
+		return fmt.Errorf("failed to parse pom.xml: unclosed <%s>", current.Name)
+	}
+	d.Root = root
+	return nil
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+func (e *Element) Child(name string) *Element {
+	if e == nil {
+		return nil
+	}
+	for _, child := range e.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func (e *Element) ChildrenNamed(name string) []*Element {
+	if e == nil {
+		return nil
+	}
+	var children []*Element
+	for _, child := range e.Children {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (d *Document) Find(path ...string) *Element {
+	el := d.Root
+	for _, name := range path {
+		el = el.Child(name)
+		if el == nil {
+			return nil
+		}
+	}
+	return el
+}
+
+func (d *Document) Text(e *Element) string {
+	if e == nil {
+		return ""
+	}
+	decoder := xml.NewDecoder(strings.NewReader(d.content[e.InnerStart:e.InnerEnd]))
+	var text strings.Builder
+	for {
+		tok, err := decoder.RawToken()
+		if err != nil {
+			break
+		}
+		if data, ok := tok.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
+
+func (d *Document) SetText(e *Element, value string) error {
+	if e == nil {
+		return fmt.Errorf("element not found")
+	}
+	if e.InnerStart == e.End {
+		tag := "<" + e.Name + ">" + escapeText(value) + "</" + e.Name + ">"
+		return d.replace(e.Start, e.End, tag)
+	}
+	return d.replace(e.InnerStart, e.InnerEnd, escapeText(value))
+}
+
+func (d *Document) Remove(e *Element) error {
+	if e == nil || e.Parent == nil {
+		return fmt.Errorf("cannot remove root element")
+	}
+
+	start, end := e.Start, e.End
+	lineStart := start
+	for lineStart > 0 && isBlank(d.content[lineStart-1]) {
+		lineStart--
+	}
+	lineEnd := end
+	for lineEnd < len(d.content) && isBlank(d.content[lineEnd]) {
+		lineEnd++
+	}
+
+	ownLine := (lineStart == 0 || d.content[lineStart-1] == '\n') &&
+		(lineEnd == len(d.content) || d.content[lineEnd] == '\n' || d.content[lineEnd] == '\r')
+	if ownLine {
+		start = lineStart
+		end = lineEnd
+		if strings.HasPrefix(d.content[end:], "\r\n") {
+			end += 2
+		} else if end < len(d.content) {
+			end++
+		}
+	}
+
+	return d.replace(start, end, "")
+}
+
+func (d *Document) AppendChild(parent *Element, node Node) error {
+	if parent == nil {
+		return fmt.Errorf("parent element not found")
+	}
+
+	indent := d.childIndent(parent)
+	if parent.InnerStart == parent.End {
+		open := d.content[parent.Start : parent.End-2]
+		markup := strings.TrimRight(open, " \t") + ">" + d.newline() + d.render(node, indent) +
+			d.newline() + d.indentOf(parent) + "</" + parent.Name + ">"
+		return d.replace(parent.Start, parent.End, markup)
+	}
+
+	inner := d.content[parent.InnerStart:parent.InnerEnd]
+	if strings.TrimSpace(inner) == "" {
+		markup := d.newline() + d.render(node, indent) + d.newline() + d.indentOf(parent)
+		return d.replace(parent.InnerStart, parent.InnerEnd, markup)
+	}
+
+	trimmed := strings.TrimRight(inner, " \t\r\n")
+	at := parent.InnerStart + len(trimmed)
+	return d.replace(at, at, d.newline()+d.render(node, indent))
+}
+
+func (d *Document) InsertAfter(sibling *Element, node Node, blankLine bool) error {
+	if sibling == nil {
+		return fmt.Errorf("sibling element not found")
+	}
+	separator := d.newline()
+	if blankLine {
+		separator += d.newline()
+	}
+	return d.replace(sibling.End, sibling.End, separator+d.render(node, d.indentOf(sibling)))
+}
+
+func (d *Document) render(node Node, indent string) string {
+	if len(node.Children) == 0 && node.Text == "" {
+		return indent + "<" + node.Name + "/>"
+	}
+	var b strings.Builder
+	b.WriteString(indent + "<" + node.Name + ">")
+	if len(node.Children) == 0 {
+		b.WriteString(escapeText(node.Text) + "</" + node.Name + ">")
+		return b.String()
+	}
+	for _, child := range node.Children {
+		b.WriteString(d.newline() + d.render(child, indent+d.indentUnit()))
+	}
+	b.WriteString(d.newline() + indent + "</" + node.Name + ">")
+	return b.String()
+}
+
+func (d *Document) replace(start, end int, text string) error {
+	original := d.content
+	d.content = original[:start] + text + original[end:]
+	if err := d.parse(); err != nil {
+		d.content = original
+		_ = d.parse()
+		return err
+	}
+	return nil
+}
+
+func (d *Document) childIndent(parent *Element) string {
+	for i := len(parent.Children) - 1; i >= 0; i-- {
+		if indent := d.indentOf(parent.Children[i]); indent != "" {
+			return indent
+		}
+	}
+	return d.indentOf(parent) + d.indentUnit()
+}
+
+func (d *Document) indentOf(e *Element) string {
+	i := e.Start
+	for i > 0 && isBlank(d.content[i-1]) {
+		i--
+	}
+	if i > 0 && d.content[i-1] != '\n' {
+		return ""
+	}
+	return d.content[i:e.Start]
+}
+
+func (d *Document) indentUnit() string {
+	if d.Root != nil {
+		for _, child := range d.Root.Children {
+			if indent := d.indentOf(child); indent != "" {
+				return indent
+			}
+		}
+	}
+	return "    "
+}
+
+func (d *Document) newline() string {
+	if strings.Contains(d.content, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func escapeText(value string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,445 @@
+package maven
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const richPom = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Company build -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+	xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+	<modelVersion>4.0.0</modelVersion>
+	<parent>
+		<groupId>org.springframework.boot</groupId>
+		<artifactId>spring-boot-starter-parent</artifactId>
+		<version>3.3.0</version>
+		<relativePath/> <!-- lookup parent from repository -->
+	</parent>
+	<groupId>com.example</groupId>
+	<artifactId>shop</artifactId>
+	<version>1.0.0</version>
+	<modules>
+		<module>api</module>
+	</modules>
+
+	<properties>
+		<java.version>17</java.version>
+		<mapstruct.version>1.5.5.Final</mapstruct.version>
+	</properties>
+
+	<dependencies>
+		<!-- web -->
+		<dependency>
+			<artifactId>spring-boot-starter-web</artifactId>
+			<groupId>org.springframework.boot</groupId>
+		</dependency>
+		<dependency>
+			<groupId>org.mapstruct</groupId>
+			<artifactId>mapstruct</artifactId>
+			<version>${mapstruct.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>org.projectlombok</groupId>
+			<artifactId>lombok</artifactId>
+			<optional>true</optional>
+		</dependency>
+	</dependencies>
+
+	<repositories>
+		<repository>
+			<id>internal</id>
+			<url>https://repo.example.com/maven</url>
+		</repository>
+	</repositories>
+
+	<profiles>
+		<profile>
+			<id>dev</id>
+			<dependencies>
+				<dependency>
+					<groupId>com.h2database</groupId>
+					<artifactId>h2</artifactId>
+				</dependency>
+			</dependencies>
+		</profile>
+	</profiles>
+</project>
+`
+
+func TestParseDocument(t *testing.T) {
+	doc, err := ParseDocument([]byte(richPom))
+	require.NoError(t, err)
+
+	assert.Equal(t, "project", doc.Root.Name)
+	assert.Equal(t, "3.3.0", doc.Text(doc.Find("parent", "version")))
+	assert.Equal(t, "", doc.Text(doc.Find("parent", "relativePath")))
+	assert.Len(t, doc.Find("dependencies").ChildrenNamed("dependency"), 3)
+	assert.Len(t, doc.Find("profiles", "profile", "dependencies").ChildrenNamed("dependency"), 1)
+	assert.Nil(t, doc.Find("build", "plugins"))
+}
+
+func TestParseDocument_Invalid(t *testing.T) {
+	_, err := ParseDocument([]byte("<project><dependencies></project>"))
+	assert.Error(t, err)
+
+	_, err = ParseDocument([]byte("   "))
+	assert.Error(t, err)
+}
+
+func TestDocument_SetTextAndRemove(t *testing.T) {
+	doc, err := ParseDocument([]byte("<project>\n  <version>1.0</version>\n  <name>a &amp; b</name>\n  <relativePath/>\n</project>\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "a & b", doc.Text(doc.Find("name")))
+
+	require.NoError(t, doc.SetText(doc.Find("version"), "2.0"))
+	require.NoError(t, doc.SetText(doc.Find("relativePath"), "../pom.xml"))
+	require.NoError(t, doc.Remove(doc.Find("name")))
+
+	assert.Equal(t, "<project>\n  <version>2.0</version>\n  <relativePath>../pom.xml</relativePath>\n</project>\n", doc.String())
+}
+
+func TestDocument_AppendChild(t *testing.T) {
+	doc, err := ParseDocument([]byte("<project>\r\n\t<dependencies/>\r\n</project>\r\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.AppendChild(doc.Find("dependencies"), Node{
+		Name:     "dependency",
+		Children: []Node{{Name: "groupId", Text: "g"}, {Name: "artifactId", Text: "a"}},
+	}))
+
+	expected := "<project>\r\n\t<dependencies>\r\n\t\t<dependency>\r\n\t\t\t<groupId>g</groupId>\r\n" +
+		"\t\t\t<artifactId>a</artifactId>\r\n\t\t</dependency>\r\n\t</dependencies>\r\n</project>\r\n"
+	assert.Equal(t, expected, doc.String())
+}
+
+func TestWrite_RoundTripIsByteIdentical(t *testing.T) {
+	poms := []string{
+		richPom,
+		strings.ReplaceAll(richPom, "\n", "\r\n"),
+		"<project><modelVersion>4.0.0</modelVersion><groupId>a</groupId><artifactId>b</artifactId></project>",
+	}
+
+	for _, pom := range poms {
+		fs := afero.NewMemMapFs()
+		parser := NewParserWithFs(fs)
+		require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+		project, err := parser.Parse("/pom.xml")
+		require.NoError(t, err)
+		require.NoError(t, parser.Write("/pom.xml", project))
+
+		data, err := afero.ReadFile(fs, "/pom.xml")
+		require.NoError(t, err)
+		assert.Equal(t, pom, string(data))
+	}
+}
+
+func TestWrite_TargetedEdits(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(richPom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+
+	parser.RemoveDependency(project, "org.mapstruct", "mapstruct")
+	parser.AddDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.boot",
+		ArtifactId: "spring-boot-starter-test",
+		Scope:      "test",
+	})
+	parser.GetDependency(project, "org.springframework.boot", "spring-boot-starter-web").Version = "3.3.1"
+	parser.GetDependency(project, "org.projectlombok", "lombok").Optional = false
+	project.SpringBootVersion = "3.4.1"
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+
+	expected := strings.NewReplacer(
+		"<version>3.3.0</version>", "<version>3.4.1</version>",
+		`			<groupId>org.springframework.boot</groupId>
+		</dependency>
+		<dependency>
+			<groupId>org.mapstruct</groupId>
+			<artifactId>mapstruct</artifactId>
+			<version>${mapstruct.version}</version>
+		</dependency>`, `			<groupId>org.springframework.boot</groupId>
+			<version>3.3.1</version>
+		</dependency>`,
+		`			<artifactId>lombok</artifactId>
+			<optional>true</optional>
+		</dependency>
+	</dependencies>`, `			<artifactId>lombok</artifactId>
+		</dependency>
+		<dependency>
+			<groupId>org.springframework.boot</groupId>
+			<artifactId>spring-boot-starter-test</artifactId>
+			<scope>test</scope>
+		</dependency>
+	</dependencies>`,
+	).Replace(richPom)
+	assert.Equal(t, expected, string(data))
+}
+
+func TestWrite_CreatesDependenciesSection(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <properties>
+    <java.version>21</java.version>
+  </properties>
+  <build>
+  </build>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	parser.AddDependency(project, buildtool.Dependency{GroupId: "org.projectlombok", ArtifactId: "lombok", Optional: true})
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, `<project>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <properties>
+    <java.version>21</java.version>
+  </properties>
+
+  <dependencies>
+    <dependency>
+      <groupId>org.projectlombok</groupId>
+      <artifactId>lombok</artifactId>
+      <optional>true</optional>
+    </dependency>
+  </dependencies>
+  <build>
+  </build>
+</project>
+`, string(data))
+}
+
+func TestWrite_DependencyExclusions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-test</artifactId>
+      <exclusions>
+        <exclusion>
+          <groupId>org.junit.vintage</groupId>
+          <artifactId>junit-vintage-engine</artifactId>
+        </exclusion>
+      </exclusions>
+    </dependency>
+  </dependencies>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []buildtool.Exclusion{{GroupId: "org.junit.vintage", ArtifactId: "junit-vintage-engine"}}, project.Dependencies[0].Exclusions)
+
+	parser.AddDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.boot",
+		ArtifactId: "spring-boot-starter-web",
+		Exclusions: []buildtool.Exclusion{{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-starter-tomcat"}},
+	})
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-web</artifactId>
+      <exclusions>
+        <exclusion>
+          <groupId>org.springframework.boot</groupId>
+          <artifactId>spring-boot-starter-tomcat</artifactId>
+        </exclusion>
+      </exclusions>
+    </dependency>`)
+}
+
+func TestParse_PropertiesRepositoriesAndProfiles(t *testing.T) {
+	project, err := NewParser().ParseBytes([]byte(richPom))
+	require.NoError(t, err)
+
+	assert.Equal(t, []buildtool.Property{
+		{Key: "java.version", Value: "17"},
+		{Key: "mapstruct.version", Value: "1.5.5.Final"},
+	}, project.Properties)
+	assert.Equal(t, []buildtool.Repository{{ID: "internal", URL: "https://repo.example.com/maven"}}, project.Repositories)
+	require.Len(t, project.Profiles, 1)
+	assert.Equal(t, "dev", project.Profiles[0].ID)
+	assert.Equal(t, "h2", project.Profiles[0].Dependencies[0].ArtifactId)
+}
+
+func TestWrite_PropertiesRepositoriesAndProfiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(richPom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+
+	parser.SetProperty(project, "mapstruct.version", "1.6.3")
+	parser.SetProperty(project, "jjwt.version", "0.12.6")
+	assert.True(t, parser.RemoveProperty(project, "java.version"))
+	assert.False(t, parser.AddRepository(project, buildtool.Repository{ID: "internal", URL: "https://other"}))
+	assert.True(t, parser.AddRepository(project, buildtool.Repository{ID: "nexus", Name: "Company Nexus", URL: "https://nexus.example.com/repository/maven-public/"}))
+	assert.True(t, parser.AddRepository(project, buildtool.Repository{ID: "nexus-plugins", URL: "https://nexus.example.com/plugins", Plugin: true}))
+	assert.True(t, parser.RemoveRepository(project, "internal"))
+	assert.True(t, parser.AddProfileDependency(project, "dev", buildtool.Dependency{GroupId: "org.springframework.boot", ArtifactId: "spring-boot-devtools", Optional: true}))
+	assert.False(t, parser.AddProfileDependency(project, "dev", buildtool.Dependency{GroupId: "com.h2database", ArtifactId: "h2"}))
+	assert.True(t, parser.AddProfileDependency(project, "ci", buildtool.Dependency{GroupId: "org.testcontainers", ArtifactId: "postgresql", Scope: "test"}))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "\t<properties>\n\t\t<mapstruct.version>1.6.3</mapstruct.version>\n\t\t<jjwt.version>0.12.6</jjwt.version>\n\t</properties>")
+	assert.Contains(t, content, `	<repositories>
+		<repository>
+			<id>nexus</id>
+			<name>Company Nexus</name>
+			<url>https://nexus.example.com/repository/maven-public/</url>
+		</repository>
+	</repositories>
+
+	<pluginRepositories>
+		<pluginRepository>
+			<id>nexus-plugins</id>
+			<url>https://nexus.example.com/plugins</url>
+		</pluginRepository>
+	</pluginRepositories>
+
+	<profiles>`)
+	assert.Contains(t, content, `					<artifactId>h2</artifactId>
+				</dependency>
+				<dependency>
+					<groupId>org.springframework.boot</groupId>
+					<artifactId>spring-boot-devtools</artifactId>
+					<optional>true</optional>
+				</dependency>
+			</dependencies>
+		</profile>
+		<profile>
+			<id>ci</id>
+			<dependencies>
+				<dependency>
+					<groupId>org.testcontainers</groupId>
+					<artifactId>postgresql</artifactId>
+					<scope>test</scope>
+				</dependency>
+			</dependencies>
+		</profile>
+	</profiles>`)
+
+	reparsed, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, project.Properties, reparsed.Properties)
+	assert.Equal(t, project.Repositories, reparsed.Repositories)
+	assert.Equal(t, project.Profiles, reparsed.Profiles)
+}
+
+func TestMarshal_PropertiesRepositoriesAndProfiles(t *testing.T) {
+	parser := NewParser()
+	project := &buildtool.Project{
+		GroupId:      "com.example",
+		ArtifactId:   "demo",
+		JavaVersion:  "21",
+		Properties:   []buildtool.Property{{Key: "jjwt.version", Value: "0.12.6"}},
+		Repositories: []buildtool.Repository{{ID: "nexus", URL: "https://nexus"}},
+		Profiles:     []buildtool.Profile{{ID: "dev", Dependencies: []buildtool.Dependency{{GroupId: "com.h2database", ArtifactId: "h2"}}}},
+	}
+
+	data, err := parser.Marshal(parser.toMavenProject(project))
+	require.NoError(t, err)
+
+	reparsed, err := parser.ParseBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, []buildtool.Property{{Key: "java.version", Value: "21"}, {Key: "jjwt.version", Value: "0.12.6"}}, reparsed.Properties)
+	assert.Equal(t, project.Repositories, reparsed.Repositories)
+	assert.Equal(t, project.Profiles, reparsed.Profiles)
+}
+
+func TestWrite_DependencyManagement(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+  <artifactId>demo</artifactId>
+  <properties>
+    <java.version>21</java.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.cloud</groupId>
+      <artifactId>spring-cloud-starter-openfeign</artifactId>
+    </dependency>
+  </dependencies>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	parser.SetProperty(project, "spring-cloud.version", "2024.0.1")
+	assert.True(t, parser.AddManagedDependency(project, buildtool.Dependency{
+		GroupId:    "org.springframework.cloud",
+		ArtifactId: "spring-cloud-dependencies",
+		Version:    "${spring-cloud.version}",
+		Type:       "pom",
+		Scope:      "import",
+	}))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, `<project>
+  <artifactId>demo</artifactId>
+  <properties>
+    <java.version>21</java.version>
+    <spring-cloud.version>2024.0.1</spring-cloud.version>
+  </properties>
+
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework.cloud</groupId>
+        <artifactId>spring-cloud-dependencies</artifactId>
+        <version>${spring-cloud.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.cloud</groupId>
+      <artifactId>spring-cloud-starter-openfeign</artifactId>
+    </dependency>
+  </dependencies>
+</project>
+`, string(data))
+
+	project, err = parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.Len(t, parser.GetManagedDependencies(project), 1)
+	assert.Len(t, project.Dependencies, 1)
+}
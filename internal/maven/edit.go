@@ -0,0 +1,373 @@
+package maven
+
+import (
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+var projectOrder = []string{
+	"modelVersion", "parent", "groupId", "artifactId", "version", "packaging", "name", "description",
+	"url", "inceptionYear", "organization", "licenses", "developers", "contributors", "mailingLists",
+	"prerequisites", "modules", "scm", "issueManagement", "ciManagement", "distributionManagement",
+	"properties", "dependencyManagement", "dependencies", "repositories", "pluginRepositories",
+	"build", "reporting", "profiles",
+}
+
+var profileOrder = []string{
+	"id", "activation", "build", "modules", "distributionManagement", "properties",
+	"dependencyManagement", "dependencies", "repositories", "pluginRepositories", "reporting",
+}
+
+type locator func(doc *Document) *Element
+
+func rootElement(doc *Document) *Element {
+	return doc.Root
+}
+
+func profileElement(id string) locator {
+	return func(doc *Document) *Element {
+		for _, el := range doc.Find("profiles").ChildrenNamed("profile") {
+			if doc.Text(el.Child("id")) == id {
+				return el
+			}
+		}
+		return nil
+	}
+}
+
+func childOf(parent locator, name string) locator {
+	return func(doc *Document) *Element {
+		return parent(doc).Child(name)
+	}
+}
+
+func ApplyProject(doc *Document, project *buildtool.Project) error {
+	var repos, pluginRepos []buildtool.Repository
+	for _, repo := range project.Repositories {
+		if repo.Plugin {
+			pluginRepos = append(pluginRepos, repo)
+		} else {
+			repos = append(repos, repo)
+		}
+	}
+
+	steps := []func() error{
+		func() error { return syncProperties(doc, project.Properties) },
+		func() error { return applyProjectFields(doc, project) },
+		func() error { return syncManagedDependencies(doc, project.ManagedDependencies) },
+		func() error { return syncDependencies(doc, rootElement, projectOrder, project.Dependencies) },
+		func() error { return syncRepositories(doc, "repositories", "repository", repos) },
+		func() error { return syncRepositories(doc, "pluginRepositories", "pluginRepository", pluginRepos) },
+		func() error { return syncProfiles(doc, project.Profiles) },
+		func() error { return syncPlugins(doc, project.Plugins) },
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyProjectFields(doc *Document, project *buildtool.Project) error {
+	if err := setExisting(doc, doc.Find("version"), project.Version); err != nil {
+		return err
+	}
+	if err := setExisting(doc, doc.Find("properties", "java.version"), project.JavaVersion); err != nil {
+		return err
+	}
+
+	parent := doc.Find("parent")
+	if doc.Text(parent.Child("groupId")) == "org.springframework.boot" &&
+		doc.Text(parent.Child("artifactId")) == "spring-boot-starter-parent" {
+		return setExisting(doc, parent.Child("version"), project.SpringBootVersion)
+	}
+	return nil
+}
+
+func setExisting(doc *Document, el *Element, value string) error {
+	value = strings.TrimSpace(value)
+	if el == nil || value == "" || doc.Text(el) == value {
+		return nil
+	}
+	return doc.SetText(el, value)
+}
+
+func syncProperties(doc *Document, props []buildtool.Property) error {
+	wanted := make(map[string]bool)
+	for _, prop := range props {
+		wanted[prop.Key] = true
+	}
+	section := childOf(rootElement, "properties")
+
+	if err := removeStale(doc, section, func(el *Element) bool { return wanted[el.Name] }); err != nil {
+		return err
+	}
+
+	for _, prop := range props {
+		if el := section(doc).Child(prop.Key); el != nil {
+			if doc.Text(el) != strings.TrimSpace(prop.Value) {
+				if err := doc.SetText(el, prop.Value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := ensureSection(doc, rootElement, "properties", projectOrder); err != nil {
+			return err
+		}
+		if err := doc.AppendChild(section(doc), Node{Name: prop.Key, Text: prop.Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncDependencies(doc *Document, container locator, order []string, deps []buildtool.Dependency) error {
+	wanted := make(map[string]bool)
+	for _, dep := range deps {
+		wanted[dependencyKey(dep.GroupId, dep.ArtifactId, dep.Type, dep.Classifier)] = true
+	}
+	section := childOf(container, "dependencies")
+
+	err := removeStale(doc, section, func(el *Element) bool {
+		return el.Name != "dependency" || wanted[elementKey(doc, el)]
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, dep := range deps {
+		entry := dependencyElement(section, dep)
+		if entry(doc) != nil {
+			if err := updateDependency(doc, entry, dep); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := ensureSection(doc, container, "dependencies", order); err != nil {
+			return err
+		}
+		if err := doc.AppendChild(section(doc), dependencyNode(dep)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncRepositories(doc *Document, sectionName, itemName string, repos []buildtool.Repository) error {
+	wanted := make(map[string]bool)
+	for _, repo := range repos {
+		wanted[repo.ID] = true
+	}
+	section := childOf(rootElement, sectionName)
+
+	err := removeStale(doc, section, func(el *Element) bool {
+		return el.Name != itemName || wanted[doc.Text(el.Child("id"))]
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		entry := repositoryElement(section, itemName, repo.ID)
+		if entry(doc) != nil {
+			if err := setChildText(doc, entry, "name", repo.Name); err != nil {
+				return err
+			}
+			if err := setChildText(doc, entry, "url", repo.URL); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := ensureSection(doc, rootElement, sectionName, projectOrder); err != nil {
+			return err
+		}
+		node := Node{Name: itemName, Children: []Node{{Name: "id", Text: repo.ID}}}
+		if repo.Name != "" {
+			node.Children = append(node.Children, Node{Name: "name", Text: repo.Name})
+		}
+		node.Children = append(node.Children, Node{Name: "url", Text: repo.URL})
+		if err := doc.AppendChild(section(doc), node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncProfiles(doc *Document, profiles []buildtool.Profile) error {
+	for _, profile := range profiles {
+		container := profileElement(profile.ID)
+		if container(doc) == nil {
+			if len(profile.Dependencies) == 0 {
+				continue
+			}
+			if err := ensureSection(doc, rootElement, "profiles", projectOrder); err != nil {
+				return err
+			}
+			node := Node{Name: "profile", Children: []Node{{Name: "id", Text: profile.ID}}}
+			if err := doc.AppendChild(doc.Find("profiles"), node); err != nil {
+				return err
+			}
+		}
+		if err := syncDependencies(doc, container, profileOrder, profile.Dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeStale(doc *Document, section locator, keep func(el *Element) bool) error {
+	for {
+		parent := section(doc)
+		if parent == nil {
+			return nil
+		}
+		var stale *Element
+		for _, el := range parent.Children {
+			if !keep(el) {
+				stale = el
+				break
+			}
+		}
+		if stale == nil {
+			return nil
+		}
+		if err := doc.Remove(stale); err != nil {
+			return err
+		}
+	}
+}
+
+func ensureSection(doc *Document, container locator, name string, order []string) error {
+	parent := container(doc)
+	if parent.Child(name) != nil {
+		return nil
+	}
+
+	index := len(order)
+	for i, candidate := range order {
+		if candidate == name {
+			index = i
+			break
+		}
+	}
+	for i := index - 1; i >= 0; i-- {
+		if anchor := parent.Child(order[i]); anchor != nil {
+			return doc.InsertAfter(anchor, Node{Name: name}, parent == doc.Root)
+		}
+	}
+	return doc.AppendChild(parent, Node{Name: name})
+}
+
+func dependencyElement(section locator, dep buildtool.Dependency) locator {
+	key := dependencyKey(dep.GroupId, dep.ArtifactId, dep.Type, dep.Classifier)
+	return func(doc *Document) *Element {
+		for _, el := range section(doc).ChildrenNamed("dependency") {
+			if elementKey(doc, el) == key {
+				return el
+			}
+		}
+		return nil
+	}
+}
+
+func repositoryElement(section locator, itemName, id string) locator {
+	return func(doc *Document) *Element {
+		for _, el := range section(doc).ChildrenNamed(itemName) {
+			if doc.Text(el.Child("id")) == id {
+				return el
+			}
+		}
+		return nil
+	}
+}
+
+func elementKey(doc *Document, el *Element) string {
+	return dependencyKey(
+		doc.Text(el.Child("groupId")),
+		doc.Text(el.Child("artifactId")),
+		doc.Text(el.Child("type")),
+		doc.Text(el.Child("classifier")),
+	)
+}
+
+func dependencyKey(groupId, artifactId, depType, classifier string) string {
+	if depType == "jar" {
+		depType = ""
+	}
+	return strings.Join([]string{
+		strings.TrimSpace(groupId),
+		strings.TrimSpace(artifactId),
+		strings.TrimSpace(depType),
+		strings.TrimSpace(classifier),
+	}, ":")
+}
+
+func dependencyNode(dep buildtool.Dependency) Node {
+	node := Node{Name: "dependency"}
+	fields := []struct{ name, value string }{
+		{"groupId", dep.GroupId},
+		{"artifactId", dep.ArtifactId},
+		{"version", dep.Version},
+		{"type", dep.Type},
+		{"classifier", dep.Classifier},
+		{"scope", dep.Scope},
+		{"optional", optionalText(dep.Optional)},
+	}
+	for _, field := range fields {
+		if field.value != "" {
+			node.Children = append(node.Children, Node{Name: field.name, Text: field.value})
+		}
+	}
+	if len(dep.Exclusions) > 0 {
+		exclusions := Node{Name: "exclusions"}
+		for _, exclusion := range dep.Exclusions {
+			exclusions.Children = append(exclusions.Children, Node{Name: "exclusion", Children: []Node{
+				{Name: "groupId", Text: exclusion.GroupId},
+				{Name: "artifactId", Text: exclusion.ArtifactId},
+			}})
+		}
+		node.Children = append(node.Children, exclusions)
+	}
+	return node
+}
+
+func updateDependency(doc *Document, entry locator, dep buildtool.Dependency) error {
+	if err := setChildText(doc, entry, "version", dep.Version); err != nil {
+		return err
+	}
+	if err := setChildText(doc, entry, "scope", dep.Scope); err != nil {
+		return err
+	}
+
+	if (doc.Text(entry(doc).Child("optional")) == "true") == dep.Optional {
+		return nil
+	}
+	return setChildText(doc, entry, "optional", optionalText(dep.Optional))
+}
+
+func setChildText(doc *Document, entry locator, name, value string) error {
+	value = strings.TrimSpace(value)
+	el := entry(doc)
+	child := el.Child(name)
+	switch {
+	case child == nil && value == "":
+		return nil
+	case child == nil:
+		return doc.AppendChild(el, Node{Name: name, Text: value})
+	case value == "":
+		return doc.Remove(child)
+	case doc.Text(child) != value:
+		return doc.SetText(child, value)
+	}
+	return nil
+}
+
+func optionalText(optional bool) string {
+	if optional {
+		return "true"
+	}
+	return ""
+}
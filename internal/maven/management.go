@@ -0,0 +1,50 @@
+package maven
+
+import "github.com/KashifKhn/haft/internal/buildtool"
+
+func syncManagedDependencies(doc *Document, deps []buildtool.Dependency) error {
+	if len(deps) > 0 {
+		if err := ensureSection(doc, rootElement, "dependencyManagement", projectOrder); err != nil {
+			return err
+		}
+	}
+	if doc.Find("dependencyManagement") == nil {
+		return nil
+	}
+	return syncDependencies(doc, childOf(rootElement, "dependencyManagement"), []string{"dependencies"}, deps)
+}
+
+func (p *Parser) GetManagedDependencies(project *buildtool.Project) []buildtool.Dependency {
+	return project.ManagedDependencies
+}
+
+func (p *Parser) AddManagedDependency(project *buildtool.Project, dep buildtool.Dependency) bool {
+	if !project.AddManagedDependency(dep) {
+		return false
+	}
+	if mavenProject := p.getMavenProject(project); mavenProject != nil {
+		if mavenProject.DependencyManagement == nil {
+			mavenProject.DependencyManagement = &DependencyManagement{}
+		}
+		if mavenProject.DependencyManagement.Dependencies == nil {
+			mavenProject.DependencyManagement.Dependencies = &Dependencies{}
+		}
+		mavenProject.DependencyManagement.Dependencies.Dependency = append(mavenProject.DependencyManagement.Dependencies.Dependency, toMavenDependency(dep))
+	}
+	return true
+}
+
+func (p *Parser) RemoveManagedDependency(project *buildtool.Project, groupId, artifactId string) bool {
+	found := project.RemoveManagedDependency(groupId, artifactId)
+	if mavenProject := p.getMavenProject(project); mavenProject != nil && mavenProject.DependencyManagement != nil && mavenProject.DependencyManagement.Dependencies != nil {
+		var deps []Dependency
+		for _, dep := range mavenProject.DependencyManagement.Dependencies.Dependency {
+			if dep.GroupId == groupId && dep.ArtifactId == artifactId {
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		mavenProject.DependencyManagement.Dependencies.Dependency = deps
+	}
+	return found
+}
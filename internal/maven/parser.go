@@ -1,10 +1,10 @@
 package maven
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"os"
-	"regexp"
 	"strings"
 
 	"github.com/KashifKhn/haft/internal/buildtool"
@@ -63,7 +63,10 @@ func (p *Parser) ParseBytes(data []byte) (*buildtool.Project, error) {
 	if err := xml.Unmarshal(data, &mavenProject); err != nil {
 		return nil, fmt.Errorf("failed to parse pom.xml: %w", err)
 	}
+	return fromMavenProject(&mavenProject), nil
+}
 
+func fromMavenProject(mavenProject *MavenProject) *buildtool.Project {
 	project := &buildtool.Project{
 		GroupId:     mavenProject.GroupId,
 		ArtifactId:  mavenProject.ArtifactId,
@@ -72,7 +75,7 @@ func (p *Parser) ParseBytes(data []byte) (*buildtool.Project, error) {
 		Description: mavenProject.Description,
 		Packaging:   mavenProject.Packaging,
 		BuildTool:   buildtool.Maven,
-		Raw:         &mavenProject,
+		Raw:         mavenProject,
 	}
 
 	if mavenProject.Properties != nil {
@@ -85,268 +88,122 @@ func (p *Parser) ParseBytes(data []byte) (*buildtool.Project, error) {
 		project.SpringBootVersion = mavenProject.Parent.Version
 	}
 
-	if mavenProject.Dependencies != nil {
-		for _, dep := range mavenProject.Dependencies.Dependency {
-			project.Dependencies = append(project.Dependencies, buildtool.Dependency{
-				GroupId:    dep.GroupId,
-				ArtifactId: dep.ArtifactId,
-				Version:    dep.Version,
-				Scope:      dep.Scope,
-				Optional:   dep.Optional == "true",
-				Type:       dep.Type,
-				Classifier: dep.Classifier,
-			})
-		}
-	}
-
-	return project, nil
-}
-
-func (p *Parser) Write(path string, project *buildtool.Project) error {
-	exists, err := afero.Exists(p.fs, path)
-	if err != nil {
-		return fmt.Errorf("failed to check file: %w", err)
+	project.Dependencies = fromMavenDependencies(mavenProject.Dependencies)
+	if mavenProject.DependencyManagement != nil {
+		project.ManagedDependencies = fromMavenDependencies(mavenProject.DependencyManagement.Dependencies)
 	}
-
-	if exists {
-		return p.WriteMinimal(path, project)
+	project.Properties = fromMavenProperties(mavenProject.Properties)
+	project.Plugins = fromMavenPlugins(mavenProject.Build)
+	if project.SpringBootVersion == "" {
+		project.SpringBootVersion = bootBomVersion(project)
 	}
 
-	return p.WriteFull(path, project)
-}
-
-func (p *Parser) WriteFull(path string, project *buildtool.Project) error {
-	mavenProject := p.toMavenProject(project)
-	data, err := p.Marshal(mavenProject)
-	if err != nil {
-		return err
-	}
-	return afero.WriteFile(p.fs, path, data, 0644)
-}
-
-func (p *Parser) WriteMinimal(path string, project *buildtool.Project) error {
-	originalData, err := afero.ReadFile(p.fs, path)
-	if err != nil {
-		return fmt.Errorf("failed to read pom.xml: %w", err)
-	}
-
-	content := string(originalData)
-	mavenProject := p.getMavenProject(project)
-
-	var originalDeps *Dependencies
-	var originalMaven MavenProject
-	if err := xml.Unmarshal(originalData, &originalMaven); err == nil {
-		originalDeps = originalMaven.Dependencies
-	}
-
-	var depsToAdd []Dependency
-	if mavenProject != nil && mavenProject.Dependencies != nil {
-		for _, dep := range mavenProject.Dependencies.Dependency {
-			if !p.hasDependencyInList(originalDeps, dep.GroupId, dep.ArtifactId) {
-				depsToAdd = append(depsToAdd, dep)
-			}
+	if mavenProject.Repositories != nil {
+		for _, repo := range mavenProject.Repositories.Repository {
+			project.Repositories = append(project.Repositories, buildtool.Repository{ID: repo.ID, Name: repo.Name, URL: repo.URL})
 		}
 	}
-
-	var depsToRemove []Dependency
-	if originalDeps != nil {
-		for _, dep := range originalDeps.Dependency {
-			if mavenProject == nil || mavenProject.Dependencies == nil {
-				depsToRemove = append(depsToRemove, dep)
-			} else if !p.hasDependencyInList(mavenProject.Dependencies, dep.GroupId, dep.ArtifactId) {
-				depsToRemove = append(depsToRemove, dep)
-			}
+	if mavenProject.PluginRepositories != nil {
+		for _, repo := range mavenProject.PluginRepositories.PluginRepository {
+			project.Repositories = append(project.Repositories, buildtool.Repository{ID: repo.ID, Name: repo.Name, URL: repo.URL, Plugin: true})
 		}
 	}
 
-	if len(depsToAdd) == 0 && len(depsToRemove) == 0 {
-		return nil
-	}
-
-	for _, dep := range depsToRemove {
-		content = p.removeDependencyFromContent(content, dep.GroupId, dep.ArtifactId)
-	}
-
-	if len(depsToAdd) > 0 {
-		content = p.insertDependencies(content, depsToAdd)
+	if mavenProject.Profiles != nil {
+		for _, profile := range mavenProject.Profiles.Profile {
+			project.Profiles = append(project.Profiles, buildtool.Profile{
+				ID:           profile.ID,
+				Dependencies: fromMavenDependencies(profile.Dependencies),
+			})
+		}
 	}
 
-	return afero.WriteFile(p.fs, path, []byte(content), 0644)
+	return project
 }
 
-func (p *Parser) hasDependencyInList(deps *Dependencies, groupId, artifactId string) bool {
+func fromMavenDependencies(deps *Dependencies) []buildtool.Dependency {
 	if deps == nil {
-		return false
+		return nil
 	}
+	var result []buildtool.Dependency
 	for _, dep := range deps.Dependency {
-		if dep.GroupId == groupId && dep.ArtifactId == artifactId {
-			return true
-		}
+		result = append(result, buildtool.Dependency{
+			GroupId:    dep.GroupId,
+			ArtifactId: dep.ArtifactId,
+			Version:    dep.Version,
+			Scope:      dep.Scope,
+			Optional:   dep.Optional == "true",
+			Type:       dep.Type,
+			Classifier: dep.Classifier,
+			Exclusions: fromMavenExclusions(dep.Exclusions),
+		})
 	}
-	return false
+	return result
 }
 
-func (p *Parser) removeDependencyFromContent(content, groupId, artifactId string) string {
-	pattern := fmt.Sprintf(`(?s)<dependency>\s*<groupId>%s</groupId>\s*<artifactId>%s</artifactId>.*?</dependency>`,
-		regexp.QuoteMeta(groupId), regexp.QuoteMeta(artifactId))
-	re := regexp.MustCompile(pattern)
-
-	loc := re.FindStringIndex(content)
-	if loc == nil {
-		return content
-	}
-
-	start := loc[0]
-	end := loc[1]
-
-	leadingNewline := start
-	for leadingNewline > 0 && (content[leadingNewline-1] == ' ' || content[leadingNewline-1] == '\t') {
-		leadingNewline--
-	}
-	if leadingNewline > 0 && content[leadingNewline-1] == '\n' {
-		leadingNewline--
-	}
-
-	trailingNewline := end
-	for trailingNewline < len(content) && (content[trailingNewline] == ' ' || content[trailingNewline] == '\t') {
-		trailingNewline++
+func fromMavenExclusions(exclusions *Exclusions) []buildtool.Exclusion {
+	if exclusions == nil {
+		return nil
 	}
-	if trailingNewline < len(content) && content[trailingNewline] == '\n' {
-		trailingNewline++
+	var result []buildtool.Exclusion
+	for _, exclusion := range exclusions.Exclusion {
+		result = append(result, buildtool.Exclusion{GroupId: exclusion.GroupId, ArtifactId: exclusion.ArtifactId})
 	}
-
-	return content[:leadingNewline] + content[trailingNewline:]
+	return result
 }
 
-func (p *Parser) insertDependencies(content string, deps []Dependency) string {
-	depsEndIdx := strings.LastIndex(content, "</dependencies>")
-	if depsEndIdx == -1 {
-		return p.insertDependenciesSection(content, deps)
+func fromMavenProperties(props *Properties) []buildtool.Property {
+	if props == nil {
+		return nil
 	}
-
-	indent := p.detectIndent(content, depsEndIdx)
-	var depXML strings.Builder
-	for _, dep := range deps {
-		depXML.WriteString(p.formatDependency(dep, indent))
+	var result []buildtool.Property
+	for _, entry := range props.entries() {
+		result = append(result, buildtool.Property{Key: entry.Key, Value: entry.Value})
 	}
-
-	return content[:depsEndIdx] + depXML.String() + content[depsEndIdx:]
+	return result
 }
 
-func (p *Parser) insertDependenciesSection(content string, deps []Dependency) string {
-	insertPoints := []string{"</properties>", "</parent>", "</version>", "</artifactId>"}
-
-	for _, point := range insertPoints {
-		idx := strings.LastIndex(content, point)
-		if idx != -1 {
-			insertPos := idx + len(point)
-			indent := p.detectBaseIndent(content)
-
-			var section strings.Builder
-			section.WriteString("\n\n")
-			section.WriteString(indent)
-			section.WriteString("<dependencies>")
-			for _, dep := range deps {
-				section.WriteString(p.formatDependency(dep, indent+indent))
-			}
-			section.WriteString("\n")
-			section.WriteString(indent)
-			section.WriteString("</dependencies>")
-
-			return content[:insertPos] + section.String() + content[insertPos:]
-		}
-	}
-
-	return content
-}
-
-func (p *Parser) detectIndent(content string, pos int) string {
-	lineStart := strings.LastIndex(content[:pos], "\n")
-	if lineStart == -1 {
-		return "        "
+func (p *Parser) Write(path string, project *buildtool.Project) error {
+	exists, err := afero.Exists(p.fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to check file: %w", err)
 	}
 
-	line := content[lineStart+1 : pos]
-	indent := ""
-	for _, ch := range line {
-		if ch == ' ' || ch == '\t' {
-			indent += string(ch)
-		} else {
-			break
-		}
+	if exists {
+		return p.WriteMinimal(path, project)
 	}
 
-	if indent == "" {
-		return "        "
-	}
-	return indent
+	return p.WriteFull(path, project)
 }
 
-func (p *Parser) detectBaseIndent(content string) string {
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		trimmed := strings.TrimLeft(line, " \t")
-		if strings.HasPrefix(trimmed, "<groupId>") || strings.HasPrefix(trimmed, "<artifactId>") {
-			indent := line[:len(line)-len(trimmed)]
-			if indent != "" {
-				return indent
-			}
-		}
+func (p *Parser) WriteFull(path string, project *buildtool.Project) error {
+	mavenProject := p.toMavenProject(project)
+	data, err := p.Marshal(mavenProject)
+	if err != nil {
+		return err
 	}
-	return "    "
+	return afero.WriteFile(p.fs, path, data, 0644)
 }
 
-func (p *Parser) formatDependency(dep Dependency, indent string) string {
-	var b strings.Builder
-	b.WriteString("\n")
-	b.WriteString(indent)
-	b.WriteString("<dependency>\n")
-	b.WriteString(indent)
-	b.WriteString("    <groupId>")
-	b.WriteString(dep.GroupId)
-	b.WriteString("</groupId>\n")
-	b.WriteString(indent)
-	b.WriteString("    <artifactId>")
-	b.WriteString(dep.ArtifactId)
-	b.WriteString("</artifactId>\n")
-
-	if dep.Version != "" {
-		b.WriteString(indent)
-		b.WriteString("    <version>")
-		b.WriteString(dep.Version)
-		b.WriteString("</version>\n")
-	}
-
-	if dep.Scope != "" {
-		b.WriteString(indent)
-		b.WriteString("    <scope>")
-		b.WriteString(dep.Scope)
-		b.WriteString("</scope>\n")
+func (p *Parser) WriteMinimal(path string, project *buildtool.Project) error {
+	original, err := afero.ReadFile(p.fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to read pom.xml: %w", err)
 	}
 
-	if dep.Optional == "true" {
-		b.WriteString(indent)
-		b.WriteString("    <optional>true</optional>\n")
+	doc, err := ParseDocument(original)
+	if err != nil {
+		return err
 	}
 
-	if dep.Type != "" {
-		b.WriteString(indent)
-		b.WriteString("    <type>")
-		b.WriteString(dep.Type)
-		b.WriteString("</type>\n")
+	if err := ApplyProject(doc, project); err != nil {
+		return fmt.Errorf("failed to update pom.xml: %w", err)
 	}
 
-	if dep.Classifier != "" {
-		b.WriteString(indent)
-		b.WriteString("    <classifier>")
-		b.WriteString(dep.Classifier)
-		b.WriteString("</classifier>\n")
+	if bytes.Equal(doc.Bytes(), original) {
+		return nil
 	}
-
-	b.WriteString(indent)
-	b.WriteString("</dependency>")
-
-	return b.String()
+	return afero.WriteFile(p.fs, path, doc.Bytes(), 0644)
 }
 
 func (p *Parser) Marshal(mavenProject *MavenProject) ([]byte, error) {
@@ -377,32 +234,89 @@ func (p *Parser) toMavenProject(project *buildtool.Project) *MavenProject {
 		Packaging:    project.Packaging,
 	}
 
-	if project.JavaVersion != "" {
+	if _, managed := project.ManagedDependency(bootGroup, bootBom); project.SpringBootVersion != "" && !managed {
+		mavenProject.Parent = &Parent{GroupId: bootGroup, ArtifactId: bootParent, Version: project.SpringBootVersion}
+	}
+
+	if project.JavaVersion != "" || len(project.Properties) > 0 {
 		mavenProject.Properties = &Properties{JavaVersion: project.JavaVersion}
+		for _, prop := range project.Properties {
+			mavenProject.Properties.Entries = append(mavenProject.Properties.Entries, PropertyEntry{Key: prop.Key, Value: prop.Value})
+		}
+	}
+
+	for _, repo := range project.Repositories {
+		entry := Repository{ID: repo.ID, Name: repo.Name, URL: repo.URL}
+		if repo.Plugin {
+			if mavenProject.PluginRepositories == nil {
+				mavenProject.PluginRepositories = &PluginRepositories{}
+			}
+			mavenProject.PluginRepositories.PluginRepository = append(mavenProject.PluginRepositories.PluginRepository, entry)
+			continue
+		}
+		if mavenProject.Repositories == nil {
+			mavenProject.Repositories = &Repositories{}
+		}
+		mavenProject.Repositories.Repository = append(mavenProject.Repositories.Repository, entry)
+	}
+
+	for _, profile := range project.Profiles {
+		if mavenProject.Profiles == nil {
+			mavenProject.Profiles = &Profiles{}
+		}
+		entry := Profile{ID: profile.ID}
+		for _, dep := range profile.Dependencies {
+			if entry.Dependencies == nil {
+				entry.Dependencies = &Dependencies{}
+			}
+			entry.Dependencies.Dependency = append(entry.Dependencies.Dependency, toMavenDependency(dep))
+		}
+		mavenProject.Profiles.Profile = append(mavenProject.Profiles.Profile, entry)
+	}
+
+	if len(project.ManagedDependencies) > 0 {
+		mavenProject.DependencyManagement = &DependencyManagement{Dependencies: &Dependencies{}}
+		for _, dep := range project.ManagedDependencies {
+			mavenProject.DependencyManagement.Dependencies.Dependency = append(mavenProject.DependencyManagement.Dependencies.Dependency, toMavenDependency(dep))
+		}
 	}
 
 	if len(project.Dependencies) > 0 {
 		mavenProject.Dependencies = &Dependencies{}
 		for _, dep := range project.Dependencies {
-			optional := ""
-			if dep.Optional {
-				optional = "true"
-			}
-			mavenProject.Dependencies.Dependency = append(mavenProject.Dependencies.Dependency, Dependency{
-				GroupId:    dep.GroupId,
-				ArtifactId: dep.ArtifactId,
-				Version:    dep.Version,
-				Scope:      dep.Scope,
-				Optional:   optional,
-				Type:       dep.Type,
-				Classifier: dep.Classifier,
-			})
+			mavenProject.Dependencies.Dependency = append(mavenProject.Dependencies.Dependency, toMavenDependency(dep))
+		}
+	}
+
+	if len(project.Plugins) > 0 {
+		mavenProject.Build = &Build{Plugins: &Plugins{}}
+		for _, plugin := range project.Plugins {
+			mavenProject.Build.Plugins.Plugin = append(mavenProject.Build.Plugins.Plugin, toMavenPlugin(plugin))
 		}
 	}
 
 	return mavenProject
 }
 
+func toMavenDependency(dep buildtool.Dependency) Dependency {
+	result := Dependency{
+		GroupId:    dep.GroupId,
+		ArtifactId: dep.ArtifactId,
+		Version:    dep.Version,
+		Scope:      dep.Scope,
+		Optional:   optionalText(dep.Optional),
+		Type:       dep.Type,
+		Classifier: dep.Classifier,
+	}
+	if len(dep.Exclusions) > 0 {
+		result.Exclusions = &Exclusions{}
+		for _, exclusion := range dep.Exclusions {
+			result.Exclusions.Exclusion = append(result.Exclusions.Exclusion, Exclusion{GroupId: exclusion.GroupId, ArtifactId: exclusion.ArtifactId})
+		}
+	}
+	return result
+}
+
 func (p *Parser) getMavenProject(project *buildtool.Project) *MavenProject {
 	if raw, ok := project.Raw.(*MavenProject); ok {
 		return raw
@@ -430,19 +344,7 @@ func (p *Parser) AddDependency(project *buildtool.Project, dep buildtool.Depende
 		if mavenProject.Dependencies == nil {
 			mavenProject.Dependencies = &Dependencies{}
 		}
-		optional := ""
-		if dep.Optional {
-			optional = "true"
-		}
-		mavenProject.Dependencies.Dependency = append(mavenProject.Dependencies.Dependency, Dependency{
-			GroupId:    dep.GroupId,
-			ArtifactId: dep.ArtifactId,
-			Version:    dep.Version,
-			Scope:      dep.Scope,
-			Optional:   optional,
-			Type:       dep.Type,
-			Classifier: dep.Classifier,
-		})
+		mavenProject.Dependencies.Dependency = append(mavenProject.Dependencies.Dependency, toMavenDependency(dep))
 	}
 }
 
@@ -485,6 +387,51 @@ func (p *Parser) GetDependency(project *buildtool.Project, groupId, artifactId s
 	return nil
 }
 
+func (p *Parser) GetProperties(project *buildtool.Project) []buildtool.Property {
+	return project.Properties
+}
+
+func (p *Parser) GetProperty(project *buildtool.Project, key string) (string, bool) {
+	return project.Property(key)
+}
+
+func (p *Parser) SetProperty(project *buildtool.Project, key, value string) {
+	project.SetProperty(key, value)
+	if key == "java.version" {
+		project.JavaVersion = value
+	}
+}
+
+func (p *Parser) RemoveProperty(project *buildtool.Project, key string) bool {
+	if key == "java.version" {
+		project.JavaVersion = ""
+	}
+	return project.RemoveProperty(key)
+}
+
+func (p *Parser) GetRepositories(project *buildtool.Project) []buildtool.Repository {
+	return project.Repositories
+}
+
+func (p *Parser) AddRepository(project *buildtool.Project, repo buildtool.Repository) bool {
+	if repo.ID == "" {
+		repo.ID = repo.Name
+	}
+	return project.AddRepository(repo)
+}
+
+func (p *Parser) RemoveRepository(project *buildtool.Project, id string) bool {
+	return project.RemoveRepository(id)
+}
+
+func (p *Parser) AddProfileDependency(project *buildtool.Project, profile string, dep buildtool.Dependency) bool {
+	return project.AddProfileDependency(profile, dep)
+}
+
+func (p *Parser) RemoveProfileDependency(project *buildtool.Project, profile, groupId, artifactId string) bool {
+	return project.RemoveProfileDependency(profile, groupId, artifactId)
+}
+
 func (p *Parser) GetJavaVersion(project *buildtool.Project) string {
 	return project.JavaVersion
 }
@@ -535,11 +482,7 @@ func (p *Parser) ParseLegacy(path string) (*MavenProject, error) {
 }
 
 func (p *Parser) WriteLegacy(path string, mavenProject *MavenProject) error {
-	data, err := p.Marshal(mavenProject)
-	if err != nil {
-		return err
-	}
-	return afero.WriteFile(p.fs, path, data, 0644)
+	return p.Write(path, fromMavenProject(mavenProject))
 }
 
 func (p *Parser) HasDependencyLegacy(mavenProject *MavenProject, groupId, artifactId string) bool {
@@ -0,0 +1,250 @@
+package maven
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+)
+
+var buildOrder = []string{
+	"defaultGoal", "directory", "finalName", "filters", "sourceDirectory", "scriptSourceDirectory",
+	"testSourceDirectory", "outputDirectory", "testOutputDirectory", "extensions", "resources",
+	"testResources", "pluginManagement", "plugins",
+}
+
+func fromMavenPlugins(build *Build) []buildtool.Plugin {
+	if build == nil || build.Plugins == nil {
+		return nil
+	}
+	var result []buildtool.Plugin
+	for _, plugin := range build.Plugins.Plugin {
+		entry := buildtool.Plugin{
+			GroupId:    plugin.GroupId,
+			ArtifactId: plugin.ArtifactId,
+			Version:    plugin.Version,
+		}
+		if plugin.Configuration != nil {
+			entry.Configuration = append(entry.Configuration, buildtool.Setting{Name: "configuration", Children: parseSettings(plugin.Configuration.Raw)})
+		}
+		if plugin.Executions != nil {
+			entry.Configuration = append(entry.Configuration, fromMavenExecutions(plugin.Executions))
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func fromMavenExecutions(executions *Executions) buildtool.Setting {
+	setting := buildtool.Setting{Name: "executions"}
+	for _, execution := range executions.Execution {
+		entry := buildtool.Setting{Name: "execution"}
+		if execution.ID != "" {
+			entry.Children = append(entry.Children, buildtool.Setting{Name: "id", Value: execution.ID})
+		}
+		if execution.Phase != "" {
+			entry.Children = append(entry.Children, buildtool.Setting{Name: "phase", Value: execution.Phase})
+		}
+		if execution.Goals != nil {
+			goals := buildtool.Setting{Name: "goals"}
+			for _, goal := range execution.Goals.Goal {
+				goals.Children = append(goals.Children, buildtool.Setting{Name: "goal", Value: goal})
+			}
+			entry.Children = append(entry.Children, goals)
+		}
+		setting.Children = append(setting.Children, entry)
+	}
+	return setting
+}
+
+func parseSettings(raw []byte) []buildtool.Setting {
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	settings, _ := decodeSettings(decoder)
+	return settings
+}
+
+func decodeSettings(decoder *xml.Decoder) ([]buildtool.Setting, string) {
+	var settings []buildtool.Setting
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return settings, strings.TrimSpace(text.String())
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			children, value := decodeSettings(decoder)
+			setting := buildtool.Setting{Name: t.Name.Local, Children: children}
+			if len(children) == 0 {
+				setting.Value = value
+			}
+			settings = append(settings, setting)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			return settings, strings.TrimSpace(text.String())
+		}
+	}
+}
+
+func toMavenPlugin(plugin buildtool.Plugin) Plugin {
+	result := Plugin{GroupId: plugin.GroupId, ArtifactId: plugin.ArtifactId, Version: plugin.Version}
+	for _, setting := range plugin.Configuration {
+		switch setting.Name {
+		case "configuration":
+			result.Configuration = &Configuration{Raw: []byte(renderSettings(setting.Children))}
+		case "executions":
+			result.Executions = &Executions{}
+			for _, execution := range setting.Children {
+				result.Executions.Execution = append(result.Executions.Execution, toMavenExecution(execution))
+			}
+		}
+	}
+	return result
+}
+
+func toMavenExecution(setting buildtool.Setting) Execution {
+	var execution Execution
+	for _, child := range setting.Children {
+		switch child.Name {
+		case "id":
+			execution.ID = child.Value
+		case "phase":
+			execution.Phase = child.Value
+		case "goals":
+			execution.Goals = &Goals{}
+			for _, goal := range child.Children {
+				execution.Goals.Goal = append(execution.Goals.Goal, goal.Value)
+			}
+		}
+	}
+	return execution
+}
+
+func renderSettings(settings []buildtool.Setting) string {
+	var b strings.Builder
+	for _, setting := range settings {
+		if len(setting.Children) == 0 && setting.Value == "" {
+			b.WriteString("<" + setting.Name + "/>")
+			continue
+		}
+		b.WriteString("<" + setting.Name + ">")
+		if len(setting.Children) > 0 {
+			b.WriteString(renderSettings(setting.Children))
+		} else {
+			b.WriteString(escapeText(setting.Value))
+		}
+		b.WriteString("</" + setting.Name + ">")
+	}
+	return b.String()
+}
+
+func settingNodes(settings []buildtool.Setting) []Node {
+	var nodes []Node
+	for _, setting := range settings {
+		nodes = append(nodes, Node{Name: setting.Name, Text: setting.Value, Children: settingNodes(setting.Children)})
+	}
+	return nodes
+}
+
+func pluginNode(plugin buildtool.Plugin) Node {
+	node := Node{Name: "plugin"}
+	fields := []struct{ name, value string }{
+		{"groupId", plugin.GroupId},
+		{"artifactId", plugin.ArtifactId},
+		{"version", plugin.Version},
+	}
+	for _, field := range fields {
+		if field.value != "" {
+			node.Children = append(node.Children, Node{Name: field.name, Text: field.value})
+		}
+	}
+	node.Children = append(node.Children, settingNodes(plugin.Configuration)...)
+	return node
+}
+
+func pluginElementKey(doc *Document, el *Element) string {
+	return buildtool.Plugin{
+		GroupId:    doc.Text(el.Child("groupId")),
+		ArtifactId: doc.Text(el.Child("artifactId")),
+	}.Key()
+}
+
+func syncPlugins(doc *Document, plugins []buildtool.Plugin) error {
+	wanted := make(map[string]bool)
+	for _, plugin := range plugins {
+		wanted[plugin.Key()] = true
+	}
+	build := childOf(rootElement, "build")
+	section := childOf(build, "plugins")
+
+	err := removeStale(doc, section, func(el *Element) bool {
+		return el.Name != "plugin" || wanted[pluginElementKey(doc, el)]
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range plugins {
+		key := plugin.Key()
+		var existing *Element
+		for _, el := range section(doc).ChildrenNamed("plugin") {
+			if pluginElementKey(doc, el) == key {
+				existing = el
+				break
+			}
+		}
+		if existing != nil {
+			if err := setExisting(doc, existing.Child("version"), plugin.Version); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := ensureSection(doc, rootElement, "build", projectOrder); err != nil {
+			return err
+		}
+		if err := ensureSection(doc, build, "plugins", buildOrder); err != nil {
+			return err
+		}
+		if err := doc.AppendChild(section(doc), pluginNode(plugin)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Parser) GetPlugins(project *buildtool.Project) []buildtool.Plugin {
+	return project.Plugins
+}
+
+func (p *Parser) AddPlugin(project *buildtool.Project, plugin buildtool.Plugin) bool {
+	if !project.AddPlugin(plugin) {
+		return false
+	}
+	if mavenProject := p.getMavenProject(project); mavenProject != nil {
+		if mavenProject.Build == nil {
+			mavenProject.Build = &Build{}
+		}
+		if mavenProject.Build.Plugins == nil {
+			mavenProject.Build.Plugins = &Plugins{}
+		}
+		mavenProject.Build.Plugins.Plugin = append(mavenProject.Build.Plugins.Plugin, toMavenPlugin(plugin))
+	}
+	return true
+}
+
+func (p *Parser) RemovePlugin(project *buildtool.Project, plugin buildtool.Plugin) bool {
+	found := project.RemovePlugin(plugin)
+	if mavenProject := p.getMavenProject(project); mavenProject != nil && mavenProject.Build != nil && mavenProject.Build.Plugins != nil {
+		var plugins []Plugin
+		for _, existing := range mavenProject.Build.Plugins.Plugin {
+			if (buildtool.Plugin{GroupId: existing.GroupId, ArtifactId: existing.ArtifactId}).Matches(plugin) {
+				continue
+			}
+			plugins = append(plugins, existing)
+		}
+		mavenProject.Build.Plugins.Plugin = plugins
+	}
+	return found
+}
@@ -0,0 +1,186 @@
+package maven
+
+import (
+	"testing"
+
+	"github.com/KashifKhn/haft/internal/buildtool"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jacocoPlugin = buildtool.Plugin{
+	GroupId:    "org.jacoco",
+	ArtifactId: "jacoco-maven-plugin",
+	Version:    "0.8.12",
+	Configuration: []buildtool.Setting{
+		{Name: "executions", Children: []buildtool.Setting{
+			{Name: "execution", Children: []buildtool.Setting{
+				{Name: "goals", Children: []buildtool.Setting{{Name: "goal", Value: "prepare-agent"}}},
+			}},
+		}},
+	},
+}
+
+func TestWrite_AddAndRemovePlugin(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	pom := `<project>
+  <build>
+    <!-- packaging -->
+    <plugins>
+      <plugin>
+        <artifactId>maven-surefire-plugin</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(pom), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []buildtool.Plugin{{ArtifactId: "maven-surefire-plugin"}}, parser.GetPlugins(project))
+
+	assert.True(t, parser.AddPlugin(project, jacocoPlugin))
+	assert.False(t, parser.AddPlugin(project, buildtool.Plugin{GroupId: "org.jacoco", ArtifactId: "jacoco-maven-plugin"}))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, `<project>
+  <build>
+    <!-- packaging -->
+    <plugins>
+      <plugin>
+        <artifactId>maven-surefire-plugin</artifactId>
+      </plugin>
+      <plugin>
+        <groupId>org.jacoco</groupId>
+        <artifactId>jacoco-maven-plugin</artifactId>
+        <version>0.8.12</version>
+        <executions>
+          <execution>
+            <goals>
+              <goal>prepare-agent</goal>
+            </goals>
+          </execution>
+        </executions>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`, string(data))
+
+	project, err = parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	assert.True(t, parser.RemovePlugin(project, buildtool.Plugin{GroupId: "org.apache.maven.plugins", ArtifactId: "maven-surefire-plugin"}))
+	assert.True(t, parser.RemovePlugin(project, jacocoPlugin))
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err = afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "<plugin>")
+	assert.Contains(t, string(data), "<!-- packaging -->")
+}
+
+func TestWrite_CreatesBuildSection(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	parser := NewParserWithFs(fs)
+	require.NoError(t, afero.WriteFile(fs, "/pom.xml", []byte(`<project>
+  <artifactId>demo</artifactId>
+</project>
+`), 0644))
+
+	project, err := parser.Parse("/pom.xml")
+	require.NoError(t, err)
+	parser.AddPlugin(project, buildtool.Plugin{
+		GroupId:    "com.diffplug.spotless",
+		ArtifactId: "spotless-maven-plugin",
+		Version:    "2.44.0",
+		Configuration: []buildtool.Setting{
+			{Name: "configuration", Children: []buildtool.Setting{
+				{Name: "java", Children: []buildtool.Setting{{Name: "googleJavaFormat"}}},
+			}},
+		},
+	})
+	require.NoError(t, parser.Write("/pom.xml", project))
+
+	data, err := afero.ReadFile(fs, "/pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, `<project>
+  <artifactId>demo</artifactId>
+
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>com.diffplug.spotless</groupId>
+        <artifactId>spotless-maven-plugin</artifactId>
+        <version>2.44.0</version>
+        <configuration>
+          <java>
+            <googleJavaFormat/>
+          </java>
+        </configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`, string(data))
+
+	marshaled, err := parser.Marshal(project.Raw.(*MavenProject))
+	require.NoError(t, err)
+	assert.Contains(t, string(marshaled), "<googleJavaFormat/>")
+}
+
+func TestParse_PluginConfiguration(t *testing.T) {
+	parser := NewParser()
+	project, err := parser.ParseBytes([]byte(`<project>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-compiler-plugin</artifactId>
+        <configuration>
+          <annotationProcessorPaths>
+            <path>
+              <groupId>org.projectlombok</groupId>
+              <artifactId>lombok</artifactId>
+            </path>
+          </annotationProcessorPaths>
+        </configuration>
+      </plugin>
+      <plugin>
+        <groupId>org.jacoco</groupId>
+        <artifactId>jacoco-maven-plugin</artifactId>
+        <executions>
+          <execution>
+            <id>report</id>
+            <phase>test</phase>
+            <goals><goal>report</goal></goals>
+          </execution>
+        </executions>
+      </plugin>
+    </plugins>
+  </build>
+</project>`))
+	require.NoError(t, err)
+	require.Len(t, project.Plugins, 2)
+
+	assert.Equal(t, []buildtool.Setting{{Name: "configuration", Children: []buildtool.Setting{
+		{Name: "annotationProcessorPaths", Children: []buildtool.Setting{
+			{Name: "path", Children: []buildtool.Setting{
+				{Name: "groupId", Value: "org.projectlombok"},
+				{Name: "artifactId", Value: "lombok"},
+			}},
+		}},
+	}}}, project.Plugins[0].Configuration)
+
+	assert.Equal(t, []buildtool.Setting{{Name: "executions", Children: []buildtool.Setting{
+		{Name: "execution", Children: []buildtool.Setting{
+			{Name: "id", Value: "report"},
+			{Name: "phase", Value: "test"},
+			{Name: "goals", Children: []buildtool.Setting{{Name: "goal", Value: "report"}}},
+		}},
+	}}}, project.Plugins[1].Configuration)
+}
@@ -0,0 +1,71 @@
+package maven
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+func (p *Properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			value = strings.TrimSpace(value)
+			p.Entries = append(p.Entries, PropertyEntry{Key: t.Name.Local, Value: value})
+			switch t.Name.Local {
+			case "java.version":
+				p.JavaVersion = value
+			case "project.build.sourceEncoding":
+				p.SourceEncoding = value
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (p Properties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, entry := range p.entries() {
+		if err := e.EncodeElement(entry.Value, xml.StartElement{Name: xml.Name{Local: entry.Key}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (p Properties) entries() []PropertyEntry {
+	entries := p.Entries
+	known := []PropertyEntry{
+		{Key: "java.version", Value: p.JavaVersion},
+		{Key: "project.build.sourceEncoding", Value: p.SourceEncoding},
+	}
+	for i := len(known) - 1; i >= 0; i-- {
+		if known[i].Value == "" || p.has(known[i].Key) {
+			continue
+		}
+		entries = append([]PropertyEntry{known[i]}, entries...)
+	}
+	return entries
+}
+
+func (p Properties) has(key string) bool {
+	for _, entry := range p.Entries {
+		if entry.Key == key {
+			return true
+		}
+	}
+	return false
+}
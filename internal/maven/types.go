@@ -18,7 +18,10 @@ type MavenProject struct {
 	Properties           *Properties           `xml:"properties,omitempty"`
 	Dependencies         *Dependencies         `xml:"dependencies,omitempty"`
 	DependencyManagement *DependencyManagement `xml:"dependencyManagement,omitempty"`
+	Repositories         *Repositories         `xml:"repositories,omitempty"`
+	PluginRepositories   *PluginRepositories   `xml:"pluginRepositories,omitempty"`
 	Build                *Build                `xml:"build,omitempty"`
+	Profiles             *Profiles             `xml:"profiles,omitempty"`
 }
 
 type Parent struct {
@@ -40,6 +43,31 @@ type PropertyEntry struct {
 	Value string
 }
 
+type Repositories struct {
+	Repository []Repository `xml:"repository"`
+}
+
+type PluginRepositories struct {
+	PluginRepository []Repository `xml:"pluginRepository"`
+}
+
+type Repository struct {
+	ID   string `xml:"id"`
+	Name string `xml:"name,omitempty"`
+	URL  string `xml:"url"`
+}
+
+type Profiles struct {
+	Profile []Profile `xml:"profile"`
+}
+
+type Profile struct {
+	ID           string        `xml:"id"`
+	Properties   *Properties   `xml:"properties,omitempty"`
+	Dependencies *Dependencies `xml:"dependencies,omitempty"`
+	Repositories *Repositories `xml:"repositories,omitempty"`
+}
+
 type Dependencies struct {
 	Dependency []Dependency `xml:"dependency"`
 }
@@ -48,10 +76,10 @@ type Dependency struct {
 	GroupId    string      `xml:"groupId"`
 	ArtifactId string      `xml:"artifactId"`
 	Version    string      `xml:"version,omitempty"`
-	Scope      string      `xml:"scope,omitempty"`
-	Optional   string      `xml:"optional,omitempty"`
 	Type       string      `xml:"type,omitempty"`
 	Classifier string      `xml:"classifier,omitempty"`
+	Scope      string      `xml:"scope,omitempty"`
+	Optional   string      `xml:"optional,omitempty"`
 	Exclusions *Exclusions `xml:"exclusions,omitempty"`
 }
 
@@ -119,6 +119,7 @@ type RouteInfo struct {
 	Handler    string `json:"handler"`
 	File       string `json:"file"`
 	Line       int    `json:"line"`
+	Module     string `json:"module,omitempty"`
 }
 
 type RoutesOutput struct {
@@ -156,14 +157,34 @@ type GenerateOutput struct {
 	Results        []GenerateResult `json:"results"`
 	TotalGenerated int              `json:"totalGenerated"`
 	TotalSkipped   int              `json:"totalSkipped"`
+	Hooks          *HookReport      `json:"hooks,omitempty"`
 }
 
 type AddRemoveResult struct {
-	Action  string   `json:"action"`
-	Added   []string `json:"added,omitempty"`
-	Removed []string `json:"removed,omitempty"`
-	Skipped []string `json:"skipped,omitempty"`
-	Errors  []string `json:"errors,omitempty"`
+	Action  string      `json:"action"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+	Skipped []string    `json:"skipped,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	Hooks   *HookReport `json:"hooks,omitempty"`
+}
+
+type HookResult struct {
+	Name       string `json:"name"`
+	Command    string `json:"command"`
+	Status     string `json:"status"`
+	Policy     string `json:"policy"`
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"`
+	Output     string `json:"output,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type HookReport struct {
+	Event      string       `json:"event"`
+	Results    []HookResult `json:"results"`
+	Aborted    bool         `json:"aborted"`
+	RolledBack bool         `json:"rolledBack"`
 }
 
 type GeneratorType struct {
@@ -233,6 +254,7 @@ type TemplateInitOutput struct {
 
 type TemplateValidationError struct {
 	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
 	Message string `json:"message"`
 }
 
@@ -251,6 +273,186 @@ type TemplateValidateOutput struct {
 	WarningCount int                        `json:"warningCount"`
 }
 
+type TemplatePackOutput struct {
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	Haft          string   `json:"haft,omitempty"`
+	Architectures []string `json:"architectures,omitempty"`
+	Archive       string   `json:"archive"`
+	Checksum      string   `json:"checksum"`
+	Files         []string `json:"files"`
+}
+
+type TemplatePackResult struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Previous string   `json:"previous,omitempty"`
+	Source   string   `json:"source,omitempty"`
+	Status   string   `json:"status"`
+	Written  []string `json:"written,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Kept     []string `json:"kept,omitempty"`
+}
+
+type TemplatePacksOutput struct {
+	Action   string               `json:"action"`
+	Scope    string               `json:"scope"`
+	LockFile string               `json:"lockFile"`
+	Packs    []TemplatePackResult `json:"packs"`
+}
+
+type TemplateTestCase struct {
+	Template string `json:"template"`
+	Fixture  string `json:"fixture,omitempty"`
+	Status   string `json:"status"`
+	Golden   string `json:"golden,omitempty"`
+	Diff     string `json:"diff,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type TemplateTestOutput struct {
+	Cases   []TemplateTestCase `json:"cases"`
+	Total   int                `json:"total"`
+	Passed  int                `json:"passed"`
+	Failed  int                `json:"failed"`
+	Missing int                `json:"missing"`
+	Updated int                `json:"updated"`
+	Errors  int                `json:"errors"`
+}
+
+type TemplateDiffResult struct {
+	Template  string `json:"template"`
+	Status    string `json:"status"`
+	Upstream  string `json:"upstream,omitempty"`
+	Local     string `json:"local,omitempty"`
+	Merged    bool   `json:"merged,omitempty"`
+	Conflicts int    `json:"conflicts,omitempty"`
+}
+
+type TemplateDiffOutput struct {
+	Scope     string               `json:"scope"`
+	Results   []TemplateDiffResult `json:"results"`
+	Merged    int                  `json:"merged"`
+	Conflicts int                  `json:"conflicts"`
+}
+
+type ProfileField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Locked bool   `json:"locked"`
+}
+
+type ProfileOutput struct {
+	Path       string         `json:"path"`
+	DetectedAt string         `json:"detectedAt"`
+	Fields     []ProfileField `json:"fields"`
+	Updated    []string       `json:"updated,omitempty"`
+}
+
+type PropertyInfo struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type PropertiesOutput struct {
+	BuildFile  string         `json:"buildFile"`
+	Properties []PropertyInfo `json:"properties"`
+	Updated    []string       `json:"updated,omitempty"`
+	Removed    []string       `json:"removed,omitempty"`
+}
+
+type RepositoryInfo struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url"`
+	Plugin bool   `json:"plugin,omitempty"`
+}
+
+type RepositoriesOutput struct {
+	BuildFile    string           `json:"buildFile"`
+	Repositories []RepositoryInfo `json:"repositories"`
+	Added        []string         `json:"added,omitempty"`
+	Removed      []string         `json:"removed,omitempty"`
+}
+
+type PluginInfo struct {
+	ID          string `json:"id"`
+	Version     string `json:"version,omitempty"`
+	Shortcut    string `json:"shortcut,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type PluginsOutput struct {
+	BuildFile string       `json:"buildFile,omitempty"`
+	Plugins   []PluginInfo `json:"plugins"`
+	Added     []string     `json:"added,omitempty"`
+	Removed   []string     `json:"removed,omitempty"`
+}
+
+type UpgradeChange struct {
+	File string `json:"file"`
+	Item string `json:"item"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+type UpgradeIssue struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Item    string `json:"item"`
+	Message string `json:"message"`
+}
+
+type BootUpgradeOutput struct {
+	BuildFile string          `json:"buildFile"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	DryRun    bool            `json:"dryRun,omitempty"`
+	Changes   []UpgradeChange `json:"changes"`
+	Manual    []UpgradeIssue  `json:"manual"`
+}
+
+type MigratedFile struct {
+	File         string `json:"file"`
+	Kind         string `json:"kind"`
+	Replacements int    `json:"replacements,omitempty"`
+	Diff         string `json:"diff,omitempty"`
+}
+
+type MigrateOutput struct {
+	Migration        string          `json:"migration"`
+	DryRun           bool            `json:"dryRun,omitempty"`
+	Files            []MigratedFile  `json:"files"`
+	Dependencies     []UpgradeChange `json:"dependencies"`
+	Manual           []UpgradeIssue  `json:"manual"`
+	ProfileRefreshed bool            `json:"profileRefreshed"`
+}
+
+type ConvertOutput struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	BuildFile string         `json:"buildFile"`
+	Created   []string       `json:"created"`
+	Removed   []string       `json:"removed"`
+	Unmapped  []UpgradeIssue `json:"unmapped"`
+}
+
+type ArchitectureScore struct {
+	Architecture string  `json:"architecture"`
+	Score        float64 `json:"score"`
+}
+
+type ProfileExplainOutput struct {
+	Field      string              `json:"field"`
+	Value      string              `json:"value"`
+	Detected   string              `json:"detected"`
+	Locked     bool                `json:"locked"`
+	Confidence float64             `json:"confidence"`
+	Scores     []ArchitectureScore `json:"scores,omitempty"`
+	Evidence   []string            `json:"evidence"`
+}
+
 func JSON(v interface{}) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -278,6 +480,17 @@ func Error(code, message string, details ...string) error {
 	})
 }
 
+func ErrorWithData(code, message string, data interface{}) error {
+	return JSON(Response{
+		Success: false,
+		Data:    data,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
 func ErrorWithExit(code, message string, exitCode int) {
 	_ = Error(code, message)
 	os.Exit(exitCode)
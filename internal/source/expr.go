@@ -0,0 +1,219 @@
+package source
+
+import "strings"
+
+type TermKind string
+
+const (
+	TermString TermKind = "string"
+	TermRef    TermKind = "ref"
+	TermOther  TermKind = "other"
+)
+
+type Term struct {
+	Kind     TermKind
+	Value    string
+	Template bool
+}
+
+type Expr struct {
+	Text     string
+	Terms    []Term
+	Elements []Expr
+}
+
+type Resolver func(ref string) (string, bool)
+
+func newExpr(tokens []Token, lang Language) Expr {
+	e := Expr{Text: render(tokens)}
+	if inner, ok := arrayElements(tokens, lang); ok {
+		e.Elements = []Expr{}
+		for _, element := range splitTokens(inner, ",") {
+			if len(element) > 0 {
+				e.Elements = append(e.Elements, newExpr(element, lang))
+			}
+		}
+		return e
+	}
+
+	for _, operand := range splitTokens(tokens, "+") {
+		e.Terms = append(e.Terms, newTerm(operand, lang))
+	}
+	return e
+}
+
+func newTerm(tokens []Token, lang Language) Term {
+	if len(tokens) == 1 && tokens[0].Kind == TokenString {
+		return Term{Kind: TermString, Value: tokens[0].Value, Template: lang == LanguageKotlin}
+	}
+
+	ref := len(tokens)%2 == 1
+	for i, tok := range tokens {
+		if i%2 == 0 && tok.Kind != TokenIdent || i%2 == 1 && tok.Text != "." {
+			ref = false
+			break
+		}
+	}
+	if ref {
+		return Term{Kind: TermRef, Value: render(tokens)}
+	}
+	return Term{Kind: TermOther, Value: render(tokens)}
+}
+
+func (e Expr) IsArray() bool {
+	return e.Elements != nil
+}
+
+func (e Expr) Values() []Expr {
+	if e.IsArray() {
+		return e.Elements
+	}
+	return []Expr{e}
+}
+
+func (e Expr) Ref() string {
+	if len(e.Terms) == 1 && e.Terms[0].Kind == TermRef {
+		return e.Terms[0].Value
+	}
+	return ""
+}
+
+func (e Expr) StringValue(resolve Resolver) (string, bool) {
+	if e.IsArray() || len(e.Terms) == 0 {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for _, term := range e.Terms {
+		switch {
+		case term.Kind == TermString && term.Template:
+			sb.WriteString(expandTemplate(term.Value, resolve))
+		case term.Kind == TermString:
+			sb.WriteString(term.Value)
+		case term.Kind == TermRef && resolve != nil:
+			value, ok := resolve(term.Value)
+			if !ok {
+				return "", false
+			}
+			sb.WriteString(value)
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+func expandTemplate(s string, resolve Resolver) string {
+	if resolve == nil || !strings.Contains(s, "$") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		name, end := "", i+1
+		if s[i+1] == '{' {
+			close := strings.IndexByte(s[i:], '}')
+			if close < 0 {
+				sb.WriteByte(s[i])
+				continue
+			}
+			name, end = s[i+2:i+close], i+close+1
+		} else {
+			for end < len(s) && isIdentPart(rune(s[end])) && s[end] != '$' {
+				end++
+			}
+			name = s[i+1 : end]
+		}
+
+		if value, ok := resolve(strings.TrimSpace(name)); ok && name != "" {
+			sb.WriteString(value)
+			i = end - 1
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+func arrayElements(tokens []Token, lang Language) ([]Token, bool) {
+	if len(tokens) < 2 {
+		return nil, false
+	}
+	first, last := tokens[0].Text, tokens[len(tokens)-1].Text
+	switch {
+	case lang == LanguageJava && first == "{" && last == "}":
+	case lang == LanguageKotlin && first == "[" && last == "]":
+	case lang == LanguageKotlin && first == "arrayOf" && len(tokens) > 2 && tokens[1].Text == "(" && last == ")":
+		tokens = tokens[1:]
+	default:
+		return nil, false
+	}
+	if closing(tokens, 0) != len(tokens)-1 {
+		return nil, false
+	}
+	return tokens[1 : len(tokens)-1], true
+}
+
+func splitTokens(tokens []Token, separator string) [][]Token {
+	var parts [][]Token
+	depth, start := 0, 0
+	for i, tok := range tokens {
+		if tok.Kind != TokenPunct {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case separator:
+			if depth == 0 {
+				parts = append(parts, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tokens[start:])
+}
+
+func closing(tokens []Token, open int) int {
+	pairs := map[string]string{"(": ")", "[": "]", "{": "}", "<": ">"}
+	opener := tokens[open].Text
+	closer := pairs[opener]
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].Kind != TokenPunct {
+			continue
+		}
+		switch tokens[i].Text {
+		case opener:
+			depth++
+		case closer:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+func render(tokens []Token) string {
+	var sb strings.Builder
+	for i, tok := range tokens {
+		if i > 0 && (isWord(tokens[i-1]) && isWord(tok) || tokens[i-1].Text == ",") {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(tok.Text)
+	}
+	return sb.String()
+}
+
+func isWord(tok Token) bool {
+	return tok.Kind != TokenPunct
+}
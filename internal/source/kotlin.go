@@ -0,0 +1,207 @@
+package source
+
+func (p *parser) kotlinHeader(t *Type) {
+	start := p.pos
+	p.prefix()
+	p.accept("constructor")
+	if p.is("(") && !p.peek().Newline {
+		t.Parameters = p.parameters()
+		for _, param := range t.Parameters {
+			if param.Property {
+				t.Fields = append(t.Fields, Field{
+					Name:        param.Name,
+					Type:        param.Type,
+					Modifiers:   param.Modifiers,
+					Annotations: param.Annotations,
+					Value:       param.Default,
+					Mutable:     param.Modifiers.Has("var"),
+					Line:        t.Line,
+				})
+			}
+		}
+	} else {
+		p.pos = start
+	}
+
+	if p.accept(":") {
+		for {
+			ref := p.typeRef()
+			if ref == "" {
+				break
+			}
+			isClass := false
+			if p.is("(") && !p.peek().Newline {
+				p.skipGroup()
+				isClass = true
+			}
+			if p.accept("by") {
+				p.expression(true, ",", "{")
+			}
+			if isClass && t.Kind != KindInterface && t.Extends == "" {
+				t.Extends = ref
+			} else {
+				t.Interfaces = append(t.Interfaces, ref)
+			}
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	p.skipWhere()
+}
+
+func (p *parser) kotlinMember(owner *Type, f *File, annotations Annotations, modifiers Modifiers) {
+	switch p.peek().Text {
+	case "fun":
+		p.kotlinFunction(owner, f, annotations, modifiers)
+	case "val", "var":
+		p.kotlinProperty(owner, f, annotations, modifiers)
+	case "constructor":
+		line := p.next().Line
+		if !p.is("(") {
+			return
+		}
+		m := Method{Modifiers: modifiers, Annotations: annotations, Constructor: true, Line: line}
+		if owner != nil {
+			m.Name = owner.Name
+		}
+		m.Parameters = p.parameters()
+		if p.accept(":") {
+			p.next()
+			if p.is("(") {
+				p.skipGroup()
+			}
+		}
+		if p.is("{") {
+			p.skipGroup()
+		}
+		addMethod(owner, f, m)
+	case "init":
+		p.next()
+		if p.is("{") {
+			p.skipGroup()
+		}
+	case "get", "set":
+		p.next()
+		if !p.is("(") || p.peek().Newline {
+			return
+		}
+		p.skipGroup()
+		if p.accept(":") {
+			p.typeRef()
+		}
+		if p.accept("=") {
+			p.expression(true)
+		} else if p.is("{") {
+			p.skipGroup()
+		}
+	case "typealias":
+		p.next()
+		p.expression(true)
+	default:
+		p.next()
+	}
+}
+
+func (p *parser) kotlinFunction(owner *Type, f *File, annotations Annotations, modifiers Modifiers) {
+	p.next()
+	if p.is("<") {
+		p.skipGroup()
+	}
+
+	var name Token
+	for !p.done() && !p.is("(") {
+		switch {
+		case p.is("<"):
+			p.skipGroup()
+			continue
+		case p.peek().Kind == TokenIdent:
+			name = p.peek()
+		case !p.is(".") && !p.is("?.") && !p.is("?"):
+			return
+		}
+		p.next()
+	}
+	if !p.is("(") || name.Value == "" {
+		return
+	}
+
+	m := Method{Name: name.Value, Modifiers: modifiers, Annotations: annotations, Line: name.Line}
+	m.Parameters = p.parameters()
+	if p.accept(":") {
+		m.ReturnType = p.typeRef()
+	}
+	p.skipWhere()
+	if p.accept("=") {
+		p.expression(true)
+	} else if p.is("{") {
+		p.skipGroup()
+	}
+	addMethod(owner, f, m)
+}
+
+func (p *parser) kotlinProperty(owner *Type, f *File, annotations Annotations, modifiers Modifiers) {
+	mutable := p.next().Text == "var"
+	if p.is("<") {
+		p.skipGroup()
+	}
+	if p.is("(") {
+		p.skipGroup()
+		if p.accept("=") {
+			p.expression(true)
+		}
+		return
+	}
+
+	name := p.peek()
+	if name.Kind != TokenIdent {
+		return
+	}
+	p.next()
+	for p.is(".") && p.peekAt(1).Kind == TokenIdent {
+		p.next()
+		name = p.next()
+	}
+
+	field := Field{Name: name.Value, Modifiers: modifiers, Annotations: annotations, Mutable: mutable, Line: name.Line}
+	if p.accept(":") {
+		field.Type = p.typeRef()
+	}
+	if p.accept("=") {
+		value := p.expression(true)
+		field.Value = &value
+	} else if p.accept("by") {
+		p.expression(true)
+	}
+	addField(owner, f, field)
+}
+
+func (p *parser) kotlinParameter() Parameter {
+	var param Parameter
+	param.Annotations, param.Modifiers = p.prefix()
+	if p.is("val") || p.is("var") {
+		param.Property = true
+		param.Modifiers = append(param.Modifiers, p.next().Text)
+	}
+	if p.peek().Kind != TokenIdent {
+		return param
+	}
+	param.Name = p.next().Value
+	if p.accept(":") {
+		param.Type = p.typeRef()
+	}
+	if p.accept("=") {
+		value := p.expression(false, ",")
+		param.Default = &value
+	}
+	return param
+}
+
+func (p *parser) skipWhere() {
+	if !p.accept("where") {
+		return
+	}
+	for !p.done() && !p.is("{") && !p.is("=") && !p.peek().Newline {
+		p.next()
+	}
+}
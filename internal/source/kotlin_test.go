@@ -0,0 +1,205 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kotlinController = `@file:JvmName("Users")
+package com.example.` + "`web`" + `
+
+import com.example.user.UserService
+import org.springframework.web.bind.annotation.*
+import java.time.Instant as Time
+
+const val BASE = "/api"
+
+@RestController
+@RequestMapping("$BASE/users", "/v2/users")
+class UserController @Autowired constructor(
+    private val userService: UserService,
+    @Value("\${app.limit}") val limit: Int = 10,
+    debug: Boolean,
+) : BaseController<User>(), Auditable, Logger by LoggerImpl() {
+
+    companion object {
+        const val BY_ID = "/{id}"
+        private val log = LoggerFactory.getLogger(UserController::class.java)
+    }
+
+    @Autowired
+    lateinit var clock: Clock
+
+    var cached: Map<String, List<User>>? = null
+        private set
+
+    val size: Int
+        get() = cached?.size
+            ?: 0
+
+    init {
+        require(limit > 0)
+    }
+
+    @GetMapping(BY_ID)
+    fun getById(@PathVariable id: Long): ResponseEntity<UserResponse> =
+        userService.findById(id)
+            .map { ResponseEntity.ok(it) }
+            .orElseThrow()
+
+    @PostMapping(value = ["/a", "/b"], consumes = arrayOf("application/json"))
+    suspend fun <T : Any> create(@RequestBody request: UserRequest, vararg tags: String): T? where T : Comparable<T> {
+        return null
+    }
+
+    private fun List<User>.active() = filter { it.active }
+}
+
+data class UserResponse(val id: Long?, var name: String)
+
+enum class Status(val code: String) {
+    ACTIVE("a"), INACTIVE("i");
+
+    fun label() = code.uppercase()
+}
+
+sealed interface Event
+object Registry : Event
+
+fun main(args: Array<String>) {
+    runApplication<App>(*args)
+}
+`
+
+func TestParseKotlinFileHeader(t *testing.T) {
+	f := Parse(kotlinController, LanguageKotlin)
+
+	assert.Equal(t, "com.example.web", f.Package)
+	require.Len(t, f.Annotations, 1)
+	assert.Equal(t, "JvmName", f.Annotations[0].Name)
+	assert.Equal(t, "file", f.Annotations[0].Target)
+
+	require.Len(t, f.Imports, 3)
+	assert.Equal(t, "org.springframework.web.bind.annotation.*", f.Imports[1].Path)
+	assert.Equal(t, Import{Path: "java.time.Instant", Alias: "Time", Line: 6}, f.Imports[2])
+
+	assert.Equal(t, []string{"BASE"}, fieldNames(f.Fields))
+	assert.Equal(t, []string{"main"}, methodNames(f.Methods))
+}
+
+func TestParseKotlinClass(t *testing.T) {
+	f := Parse(kotlinController, LanguageKotlin)
+
+	var names []string
+	for _, typ := range f.Types {
+		names = append(names, typ.Name)
+	}
+	assert.Equal(t, []string{"UserController", "UserResponse", "Status", "Event", "Registry"}, names)
+
+	controller := f.Types[0]
+	assert.Equal(t, KindClass, controller.Kind)
+	assert.Equal(t, 12, controller.Line)
+	assert.Equal(t, "BaseController<User>", controller.Extends)
+	assert.Equal(t, []string{"Auditable", "Logger"}, controller.Interfaces)
+
+	require.Len(t, controller.Parameters, 3)
+	assert.True(t, controller.Parameters[0].Property)
+	assert.False(t, controller.Parameters[2].Property)
+	assert.Equal(t, "10", controller.Parameters[1].Default.Text)
+
+	assert.Equal(t, []string{"userService", "limit", "clock", "cached", "size"}, fieldNames(controller.Fields))
+	limit, _ := controller.Field("limit")
+	assert.True(t, limit.Annotations.Has("Value"))
+	clock, _ := controller.Field("clock")
+	assert.True(t, clock.Modifiers.Has("lateinit"))
+	assert.True(t, clock.Mutable)
+	assert.True(t, clock.Annotations.Has("Autowired"))
+	cached, _ := controller.Field("cached")
+	assert.Equal(t, "Map<String, List<User>>?", cached.Type)
+
+	require.Len(t, controller.Types, 1)
+	companion := controller.Types[0]
+	assert.Equal(t, "Companion", companion.Name)
+	assert.Equal(t, KindObject, companion.Kind)
+	assert.Equal(t, []string{"BY_ID", "log"}, fieldNames(companion.Fields))
+}
+
+func TestParseKotlinFunctions(t *testing.T) {
+	controller := Parse(kotlinController, LanguageKotlin).Types[0]
+
+	assert.Equal(t, []string{"getById", "create", "active"}, methodNames(controller.Methods))
+
+	getByID := controller.Methods[0]
+	assert.Equal(t, "ResponseEntity<UserResponse>", getByID.ReturnType)
+	mapping, _ := getByID.Annotations.Get("GetMapping")
+	assert.Equal(t, 37, mapping.Line)
+	require.Len(t, getByID.Parameters, 1)
+	assert.Equal(t, "Long", getByID.Parameters[0].Type)
+	assert.True(t, getByID.Parameters[0].Annotations.Has("PathVariable"))
+
+	create := controller.Methods[1]
+	assert.Equal(t, "T?", create.ReturnType)
+	assert.True(t, create.Modifiers.Has("suspend"))
+	require.Len(t, create.Parameters, 2)
+	assert.Equal(t, "tags", create.Parameters[1].Name)
+
+	post, _ := create.Annotations.Get("PostMapping")
+	value, _ := post.Arg("value")
+	require.True(t, value.IsArray())
+	assert.Len(t, value.Elements, 2)
+	consumes, _ := post.Arg("consumes")
+	assert.Len(t, consumes.Values(), 1)
+}
+
+func TestParseKotlinAnnotationVarargAndTemplates(t *testing.T) {
+	f := Parse(kotlinController, LanguageKotlin)
+	controller := f.Types[0]
+
+	mapping, _ := controller.Annotations.Get("RequestMapping")
+	value, _ := mapping.Arg("value")
+	require.Len(t, value.Values(), 2)
+
+	resolve := func(ref string) (string, bool) {
+		return "/api", ref == "BASE"
+	}
+	path, ok := value.Elements[0].StringValue(resolve)
+	require.True(t, ok)
+	assert.Equal(t, "/api/users", path)
+
+	limit, _ := controller.Field("limit")
+	placeholder, _ := limit.Annotations.Get("Value")
+	arg, _ := placeholder.Arg("value")
+	text, ok := arg.StringValue(resolve)
+	require.True(t, ok)
+	assert.Equal(t, "${app.limit}", text)
+}
+
+func TestParseKotlinDataAndEnumClasses(t *testing.T) {
+	f := Parse(kotlinController, LanguageKotlin)
+
+	response := f.Types[1]
+	assert.True(t, response.Modifiers.Has("data"))
+	assert.Equal(t, []string{"id", "name"}, fieldNames(response.Fields))
+	assert.Equal(t, "Long?", response.Fields[0].Type)
+	assert.False(t, response.Fields[0].Mutable)
+	assert.True(t, response.Fields[1].Mutable)
+
+	status := f.Types[2]
+	assert.Equal(t, KindEnum, status.Kind)
+	assert.Equal(t, []string{"ACTIVE", "INACTIVE"}, status.Constants)
+	assert.Equal(t, []string{"label"}, methodNames(status.Methods))
+
+	assert.Equal(t, KindInterface, f.Types[3].Kind)
+	assert.True(t, f.Types[3].Modifiers.Has("sealed"))
+	assert.Equal(t, KindObject, f.Types[4].Kind)
+	assert.Equal(t, []string{"Event"}, f.Types[4].Interfaces)
+}
+
+func TestParseKotlinBacktickTestNames(t *testing.T) {
+	f := Parse("class UserServiceTest {\n    @Test\n    fun `should create user`() {\n    }\n\n    @ParameterizedTest\n    fun givenUser_thenOk() = Unit\n}", LanguageKotlin)
+
+	require.Len(t, f.Types, 1)
+	assert.Equal(t, []string{"should create user", "givenUser_thenOk"}, methodNames(f.Types[0].Methods))
+}
@@ -0,0 +1,232 @@
+package source
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	TokenIdent TokenKind = iota
+	TokenString
+	TokenChar
+	TokenNumber
+	TokenPunct
+)
+
+type Token struct {
+	Kind    TokenKind
+	Text    string
+	Value   string
+	Line    int
+	Offset  int
+	Newline bool
+}
+
+var punctuators = []string{"...", "::", "->", "?.", "?:", "!!", "==", "!=", "&&", "||", "+=", "-=", "++", "--"}
+
+type lexer struct {
+	src     string
+	pos     int
+	line    int
+	newline bool
+	tokens  []Token
+}
+
+func Tokenize(content string) []Token {
+	l := &lexer{src: content, line: 1}
+	for l.pos < len(l.src) {
+		l.scan()
+	}
+	return l.tokens
+}
+
+func (l *lexer) scan() {
+	ch := l.src[l.pos]
+	switch {
+	case ch == '\n':
+		l.line++
+		l.newline = true
+		l.pos++
+	case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\f':
+		l.pos++
+	case strings.HasPrefix(l.src[l.pos:], "//"):
+		for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+			l.pos++
+		}
+	case strings.HasPrefix(l.src[l.pos:], "/*"):
+		end := strings.Index(l.src[l.pos+2:], "*/")
+		if end < 0 {
+			end = len(l.src) - l.pos - 2
+		}
+		l.skip(end + 4)
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		l.textBlock(`"""`, TokenString)
+	case strings.HasPrefix(l.src[l.pos:], "'''"):
+		l.textBlock("'''", TokenChar)
+	case ch == '"':
+		l.quoted('"', TokenString)
+	case ch == '\'':
+		l.quoted('\'', TokenChar)
+	case ch == '`':
+		l.backtick()
+	case ch >= '0' && ch <= '9':
+		l.number()
+	default:
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if isIdentStart(r) {
+			l.ident()
+			return
+		}
+		for _, punct := range punctuators {
+			if strings.HasPrefix(l.src[l.pos:], punct) {
+				size = len(punct)
+				break
+			}
+		}
+		text := l.src[l.pos : l.pos+size]
+		l.emit(TokenPunct, l.pos, text, text, l.line)
+		l.pos += size
+	}
+}
+
+func (l *lexer) emit(kind TokenKind, offset int, text, value string, line int) {
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: text, Value: value, Line: line, Offset: offset, Newline: l.newline})
+	l.newline = false
+}
+
+func (l *lexer) skip(n int) {
+	end := min(l.pos+n, len(l.src))
+	l.line += strings.Count(l.src[l.pos:end], "\n")
+	l.pos = end
+}
+
+func (l *lexer) ident() {
+	start := l.pos
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !isIdentPart(r) {
+			break
+		}
+		l.pos += size
+	}
+	text := l.src[start:l.pos]
+	l.emit(TokenIdent, start, text, text, l.line)
+}
+
+func (l *lexer) backtick() {
+	end := strings.IndexAny(l.src[l.pos+1:], "`\n")
+	if end < 0 {
+		end = len(l.src) - l.pos - 1
+	}
+	text := l.src[l.pos : l.pos+end+2]
+	l.emit(TokenIdent, l.pos, text, l.src[l.pos+1:l.pos+1+end], l.line)
+	l.pos += end + 2
+}
+
+func (l *lexer) number() {
+	start := l.pos
+	for l.pos < len(l.src) {
+		ch := l.src[l.pos]
+		if ch == '.' && (l.pos+1 >= len(l.src) || l.src[l.pos+1] < '0' || l.src[l.pos+1] > '9') {
+			break
+		}
+		if !isIdentPart(rune(ch)) && ch != '.' {
+			break
+		}
+		l.pos++
+	}
+	text := l.src[start:l.pos]
+	l.emit(TokenNumber, start, text, text, l.line)
+}
+
+func (l *lexer) textBlock(delim string, kind TokenKind) {
+	start, line := l.pos, l.line
+	end := strings.Index(l.src[l.pos+3:], delim)
+	if end < 0 {
+		end = len(l.src) - l.pos - 3
+	}
+	l.skip(end + 6)
+	for l.pos < len(l.src) && l.src[l.pos] == delim[0] {
+		l.pos++
+	}
+	text := l.src[start:l.pos]
+	value := strings.TrimSuffix(strings.TrimPrefix(text, delim), delim)
+	l.emit(kind, start, text, strings.TrimPrefix(value, "\n"), line)
+}
+
+func (l *lexer) quoted(quote byte, kind TokenKind) {
+	start, line := l.pos, l.line
+	var value strings.Builder
+	l.pos++
+	for l.pos < len(l.src) {
+		ch := l.src[l.pos]
+		switch {
+		case ch == quote:
+			l.pos++
+			l.emit(kind, start, l.src[start:l.pos], value.String(), line)
+			return
+		case ch == '\n':
+			l.emit(kind, start, l.src[start:l.pos], value.String(), line)
+			return
+		case ch == '\\' && l.pos+1 < len(l.src):
+			value.WriteByte(unescape(l.src[l.pos+1]))
+			l.pos += 2
+		case ch == '$' && quote == '"' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '{':
+			end := l.templateEnd(l.pos + 1)
+			value.WriteString(l.src[l.pos:end])
+			l.pos = end
+		default:
+			value.WriteByte(ch)
+			l.pos++
+		}
+	}
+	l.emit(kind, start, l.src[start:], value.String(), line)
+}
+
+func (l *lexer) templateEnd(open int) int {
+	depth := 0
+	for i := open; i < len(l.src); i++ {
+		switch l.src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '\n':
+			return i
+		}
+	}
+	return len(l.src)
+}
+
+func unescape(ch byte) byte {
+	switch ch {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	case '0':
+		return 0
+	default:
+		return ch
+	}
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,71 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tokenTexts(tokens []Token) []string {
+	var texts []string
+	for _, tok := range tokens {
+		texts = append(texts, tok.Text)
+	}
+	return texts
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("@GetMapping(value = {\"/a\", \"/b\"})\npublic List<User> list() { return repo::findAll; }")
+
+	assert.Equal(t, []string{
+		"@", "GetMapping", "(", "value", "=", "{", `"/a"`, ",", `"/b"`, "}", ")",
+		"public", "List", "<", "User", ">", "list", "(", ")", "{", "return", "repo", "::", "findAll", ";", "}",
+	}, tokenTexts(tokens))
+	assert.Equal(t, 1, tokens[0].Line)
+	assert.Equal(t, 2, tokens[11].Line)
+	assert.True(t, tokens[11].Newline)
+	assert.Equal(t, 34, tokens[11].Offset)
+	assert.False(t, tokens[12].Newline)
+}
+
+func TestTokenizeSkipsComments(t *testing.T) {
+	tokens := Tokenize("// class Hidden\n/* @Entity\n class Other */ class Visible /** doc */ {}")
+
+	assert.Equal(t, []string{"class", "Visible", "{", "}"}, tokenTexts(tokens))
+	assert.Equal(t, 3, tokens[0].Line)
+}
+
+func TestTokenizeStrings(t *testing.T) {
+	tokens := Tokenize(`"say \"hi\"" 'x' "// not a comment" """
+multi
+line""" "${base}/users/${id}"`)
+
+	require.Len(t, tokens, 5)
+	assert.Equal(t, `say "hi"`, tokens[0].Value)
+	assert.Equal(t, TokenChar, tokens[1].Kind)
+	assert.Equal(t, "// not a comment", tokens[2].Value)
+	assert.Equal(t, "multi\nline", tokens[3].Value)
+	assert.Equal(t, "${base}/users/${id}", tokens[4].Value)
+	assert.Equal(t, 3, tokens[4].Line)
+}
+
+func TestTokenizeGroovyTripleQuotes(t *testing.T) {
+	tokens := Tokenize("x '''a 'b'\nc''' y")
+
+	require.Len(t, tokens, 3)
+	assert.Equal(t, TokenChar, tokens[1].Kind)
+	assert.Equal(t, "a 'b'\nc", tokens[1].Value)
+	assert.Equal(t, 2, tokens[1].Offset)
+	assert.Equal(t, 16, tokens[2].Offset)
+}
+
+func TestTokenizeKotlin(t *testing.T) {
+	tokens := Tokenize("fun `should return user`() = user?.name ?: \"none\"!!\nval range = 1..10")
+
+	assert.Equal(t, "should return user", tokens[1].Value)
+	assert.Equal(t, TokenIdent, tokens[1].Kind)
+	assert.Contains(t, tokenTexts(tokens), "?.")
+	assert.Contains(t, tokenTexts(tokens), "?:")
+	assert.Equal(t, []string{"1", ".", ".", "10"}, tokenTexts(tokens[len(tokens)-4:]))
+}
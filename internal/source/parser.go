@@ -0,0 +1,621 @@
+package source
+
+var (
+	javaModifiers = map[string]bool{
+		"public": true, "protected": true, "private": true, "static": true, "final": true, "abstract": true,
+		"native": true, "synchronized": true, "transient": true, "volatile": true, "strictfp": true,
+		"default": true, "sealed": true,
+	}
+	kotlinModifiers = map[string]bool{
+		"public": true, "protected": true, "private": true, "internal": true, "open": true, "final": true,
+		"abstract": true, "sealed": true, "override": true, "lateinit": true, "const": true, "data": true,
+		"enum": true, "annotation": true, "inner": true, "value": true, "companion": true, "inline": true,
+		"noinline": true, "crossinline": true, "suspend": true, "tailrec": true, "operator": true,
+		"infix": true, "external": true, "vararg": true, "expect": true, "actual": true, "reified": true,
+	}
+	useSiteTargets = map[string]bool{
+		"field": true, "get": true, "set": true, "param": true, "property": true, "file": true,
+		"receiver": true, "setparam": true, "delegate": true,
+	}
+)
+
+type parser struct {
+	tokens []Token
+	pos    int
+	lang   Language
+}
+
+func Parse(content string, lang Language) *File {
+	p := &parser{tokens: Tokenize(content), lang: lang}
+	f := &File{Language: lang}
+
+	for !p.done() {
+		start := p.pos
+		switch {
+		case p.is("package"):
+			p.next()
+			f.Package = p.qualifiedName()
+			p.accept(";")
+		case p.is("import"):
+			f.Imports = append(f.Imports, p.importDecl())
+		case p.is("@") && p.peekAt(1).Text == "file" && p.peekAt(2).Text == ":":
+			f.Annotations = append(f.Annotations, p.annotation())
+		default:
+			p.member(nil, f)
+		}
+		if p.pos == start {
+			p.next()
+		}
+	}
+	return f
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() Token {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(offset int) Token {
+	if p.pos+offset >= len(p.tokens) {
+		return Token{Kind: TokenPunct, Line: p.lastLine()}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) lastLine() int {
+	if len(p.tokens) == 0 {
+		return 0
+	}
+	return p.tokens[len(p.tokens)-1].Line
+}
+
+func (p *parser) next() Token {
+	tok := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) is(text string) bool {
+	tok := p.peek()
+	return tok.Text == text && (tok.Kind == TokenIdent || tok.Kind == TokenPunct)
+}
+
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipGroup() {
+	p.pos = closing(p.tokens, p.pos) + 1
+}
+
+func (p *parser) qualifiedName() string {
+	if p.peek().Kind != TokenIdent {
+		return ""
+	}
+	name := p.next().Value
+	for p.is(".") && (p.peekAt(1).Kind == TokenIdent || p.peekAt(1).Text == "*") {
+		p.next()
+		name += "." + p.next().Value
+	}
+	return name
+}
+
+func (p *parser) importDecl() Import {
+	imp := Import{Line: p.next().Line}
+	imp.Static = p.accept("static")
+	imp.Path = p.qualifiedName()
+	if p.accept("as") {
+		imp.Alias = p.next().Value
+	}
+	p.accept(";")
+	return imp
+}
+
+func (p *parser) annotations() Annotations {
+	var result Annotations
+	for p.is("@") && p.peekAt(1).Kind == TokenIdent && p.peekAt(1).Text != "interface" {
+		result = append(result, p.annotation())
+	}
+	return result
+}
+
+func (p *parser) annotation() Annotation {
+	a := Annotation{Line: p.next().Line, Args: map[string]Expr{}}
+	if p.lang == LanguageKotlin && useSiteTargets[p.peek().Text] && p.peekAt(1).Text == ":" {
+		a.Target = p.next().Text
+		p.next()
+	}
+	a.Name = SimpleName(p.qualifiedName())
+
+	if !p.is("(") || p.peek().Newline {
+		return a
+	}
+
+	end := closing(p.tokens, p.pos)
+	p.next()
+	var positional []Expr
+	for p.pos < end {
+		start := p.pos
+		if p.peek().Kind == TokenIdent && p.peekAt(1).Text == "=" {
+			key := p.next().Value
+			p.next()
+			a.Args[key] = p.expression(false, ",")
+		} else {
+			positional = append(positional, p.expression(false, ","))
+		}
+		p.accept(",")
+		if p.pos == start {
+			p.next()
+		}
+	}
+	p.pos = end + 1
+
+	switch {
+	case len(positional) == 1:
+		a.Args["value"] = positional[0]
+	case len(positional) > 1:
+		value := Expr{Elements: positional}
+		for i, e := range positional {
+			if i > 0 {
+				value.Text += ", "
+			}
+			value.Text += e.Text
+		}
+		a.Args["value"] = value
+	}
+	return a
+}
+
+func (p *parser) modifiers() Modifiers {
+	var result Modifiers
+	for !p.done() {
+		tok := p.peek()
+		next := p.peekAt(1)
+		switch {
+		case tok.Kind != TokenIdent:
+			return result
+		case p.lang == LanguageJava && tok.Text == "non" && next.Text == "-" && p.peekAt(2).Text == "sealed":
+			p.pos += 3
+			result = append(result, "non-sealed")
+		case p.lang == LanguageJava && javaModifiers[tok.Text]:
+			p.next()
+			result = append(result, tok.Text)
+		case p.lang == LanguageKotlin && tok.Text == "fun" && next.Text == "interface":
+			p.next()
+			result = append(result, tok.Text)
+		case p.lang == LanguageKotlin && kotlinModifiers[tok.Text] && (next.Kind == TokenIdent || next.Text == "@"):
+			p.next()
+			result = append(result, tok.Text)
+		default:
+			return result
+		}
+	}
+	return result
+}
+
+func (p *parser) prefix() (Annotations, Modifiers) {
+	var annotations Annotations
+	var modifiers Modifiers
+	for {
+		a := p.annotations()
+		m := p.modifiers()
+		if len(a) == 0 && len(m) == 0 {
+			return annotations, modifiers
+		}
+		annotations = append(annotations, a...)
+		modifiers = append(modifiers, m...)
+	}
+}
+
+func (p *parser) member(owner *Type, f *File) {
+	annotations, modifiers := p.prefix()
+
+	if kind, ok := p.typeKeyword(modifiers); ok {
+		t := p.typeDecl(kind, annotations, modifiers)
+		if owner != nil {
+			owner.Types = append(owner.Types, t)
+		} else {
+			f.Types = append(f.Types, t)
+		}
+		return
+	}
+
+	switch {
+	case p.is(";"):
+		p.next()
+	case p.is("{"):
+		p.skipGroup()
+	case p.lang == LanguageKotlin:
+		p.kotlinMember(owner, f, annotations, modifiers)
+	default:
+		p.javaMember(owner, f, annotations, modifiers)
+	}
+}
+
+func (p *parser) typeKeyword(modifiers Modifiers) (Kind, bool) {
+	tok := p.peek()
+	if tok.Kind != TokenIdent && tok.Text != "@" {
+		return "", false
+	}
+
+	switch tok.Text {
+	case "class":
+		switch {
+		case p.lang == LanguageKotlin && modifiers.Has("enum"):
+			return KindEnum, true
+		case p.lang == LanguageKotlin && modifiers.Has("annotation"):
+			return KindAnnotation, true
+		}
+		return KindClass, true
+	case "interface":
+		return KindInterface, true
+	case "@":
+		return KindAnnotation, p.peekAt(1).Text == "interface"
+	case "enum":
+		return KindEnum, p.lang == LanguageJava
+	case "record":
+		next := p.peekAt(2).Text
+		return KindRecord, p.lang == LanguageJava && p.peekAt(1).Kind == TokenIdent && (next == "(" || next == "<")
+	case "object":
+		return KindObject, p.lang == LanguageKotlin
+	}
+	return "", false
+}
+
+func (p *parser) typeDecl(kind Kind, annotations Annotations, modifiers Modifiers) *Type {
+	if p.is("@") {
+		p.next()
+	}
+	keyword := p.next()
+	t := &Type{Kind: kind, Annotations: annotations, Modifiers: modifiers, Line: keyword.Line}
+
+	if name := p.peek(); name.Kind == TokenIdent && !name.Newline {
+		t.Name, t.Line = name.Value, name.Line
+		p.next()
+	} else if kind == KindObject {
+		t.Name = "Companion"
+	}
+	if p.is("<") {
+		p.skipGroup()
+	}
+
+	if p.lang == LanguageKotlin {
+		p.kotlinHeader(t)
+	} else {
+		p.javaHeader(t)
+	}
+
+	if p.is("{") {
+		p.body(t)
+	}
+	return t
+}
+
+func (p *parser) javaHeader(t *Type) {
+	if t.Kind == KindRecord && p.is("(") {
+		t.Parameters = p.parameters()
+	}
+	for {
+		switch {
+		case p.accept("extends"):
+			refs := p.typeList()
+			if t.Kind == KindInterface {
+				t.Interfaces = append(t.Interfaces, refs...)
+			} else if len(refs) > 0 {
+				t.Extends = refs[0]
+			}
+		case p.accept("implements"):
+			t.Interfaces = append(t.Interfaces, p.typeList()...)
+		case p.accept("permits"):
+			p.typeList()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) body(t *Type) {
+	p.next()
+	if t.Kind == KindEnum {
+		p.enumConstants(t)
+	}
+	for !p.done() && !p.is("}") {
+		start := p.pos
+		p.member(t, nil)
+		if p.pos == start {
+			p.next()
+		}
+	}
+	p.accept("}")
+}
+
+func (p *parser) enumConstants(t *Type) {
+	for {
+		start := p.pos
+		p.annotations()
+		tok := p.peek()
+		switch p.peekAt(1).Text {
+		case "(", "{", ",", ";", "}":
+		default:
+			p.pos = start
+			p.accept(";")
+			return
+		}
+		if tok.Kind != TokenIdent {
+			p.pos = start
+			p.accept(";")
+			return
+		}
+
+		p.next()
+		t.Constants = append(t.Constants, tok.Value)
+		if p.is("(") {
+			p.skipGroup()
+		}
+		if p.is("{") {
+			p.skipGroup()
+		}
+		if !p.accept(",") {
+			p.accept(";")
+			return
+		}
+	}
+}
+
+func (p *parser) javaMember(owner *Type, f *File, annotations Annotations, modifiers Modifiers) {
+	if p.is("<") {
+		p.skipGroup()
+	}
+
+	typeTok := p.peek()
+	typ := p.typeRef()
+	if typ == "" {
+		p.skipStatement()
+		return
+	}
+
+	if p.is("(") || (p.is("{") && owner != nil && owner.Kind == KindRecord && typ == owner.Name) {
+		m := Method{Name: typ, Modifiers: modifiers, Annotations: annotations, Constructor: true, Line: typeTok.Line}
+		if p.is("(") {
+			m.Parameters = p.parameters()
+		}
+		p.methodTail()
+		addMethod(owner, f, m)
+		return
+	}
+
+	nameTok := p.peek()
+	if nameTok.Kind != TokenIdent {
+		p.skipStatement()
+		return
+	}
+	p.next()
+
+	if p.is("(") {
+		m := Method{Name: nameTok.Value, ReturnType: typ, Modifiers: modifiers, Annotations: annotations, Line: nameTok.Line}
+		m.Parameters = p.parameters()
+		p.methodTail()
+		addMethod(owner, f, m)
+		return
+	}
+
+	for {
+		field := Field{Name: nameTok.Value, Type: typ, Modifiers: modifiers, Annotations: annotations, Mutable: !modifiers.Has("final"), Line: nameTok.Line}
+		for p.is("[") && p.peekAt(1).Text == "]" {
+			p.pos += 2
+			field.Type += "[]"
+		}
+		if p.accept("=") {
+			value := p.expression(false, ",", ";")
+			field.Value = &value
+		}
+		addField(owner, f, field)
+
+		if !p.accept(",") || p.peek().Kind != TokenIdent {
+			break
+		}
+		nameTok = p.next()
+	}
+	p.accept(";")
+}
+
+func (p *parser) methodTail() {
+	for p.is("[") && p.peekAt(1).Text == "]" {
+		p.pos += 2
+	}
+	if p.accept("throws") {
+		p.typeList()
+	}
+	if p.accept("default") {
+		p.expression(false, ";")
+	}
+	if p.is("{") {
+		p.skipGroup()
+		return
+	}
+	p.accept(";")
+}
+
+func (p *parser) skipStatement() {
+	for !p.done() {
+		switch {
+		case p.is(";"):
+			p.next()
+			return
+		case p.is("}"):
+			return
+		case p.is("{"):
+			p.skipGroup()
+			return
+		case p.is("("), p.is("["):
+			p.skipGroup()
+		default:
+			p.next()
+		}
+	}
+}
+
+func (p *parser) parameters() []Parameter {
+	end := closing(p.tokens, p.pos)
+	p.next()
+
+	var params []Parameter
+	for p.pos < end {
+		start := p.pos
+		var param Parameter
+		if p.lang == LanguageKotlin {
+			param = p.kotlinParameter()
+		} else {
+			param.Annotations, param.Modifiers = p.prefix()
+			param.Type = p.typeRef()
+			if p.peek().Kind == TokenIdent {
+				param.Name = p.next().Value
+			}
+		}
+		if param.Name != "" {
+			params = append(params, param)
+		}
+
+		for p.pos < end && !p.is(",") {
+			if p.is("(") || p.is("[") || p.is("{") || p.is("<") {
+				p.skipGroup()
+				continue
+			}
+			p.next()
+		}
+		p.accept(",")
+		if p.pos == start {
+			p.next()
+		}
+	}
+	p.pos = end + 1
+	return params
+}
+
+func (p *parser) typeList() []string {
+	var refs []string
+	for {
+		ref := p.typeRef()
+		if ref == "" {
+			return refs
+		}
+		refs = append(refs, ref)
+		if !p.accept(",") {
+			return refs
+		}
+	}
+}
+
+func (p *parser) typeRef() string {
+	p.annotations()
+	start := p.pos
+
+	if p.lang == LanguageKotlin {
+		p.accept("suspend")
+		if p.is("(") {
+			p.skipGroup()
+			if !p.accept("->") {
+				p.pos = start
+				return ""
+			}
+			p.typeRef()
+			return render(p.tokens[start:p.pos])
+		}
+	}
+
+	if p.peek().Kind != TokenIdent {
+		return ""
+	}
+	for {
+		p.next()
+		if p.is("<") {
+			p.skipGroup()
+		}
+		if !p.is(".") || p.peekAt(1).Kind != TokenIdent {
+			break
+		}
+		p.next()
+	}
+	for p.is("[") && p.peekAt(1).Text == "]" {
+		p.pos += 2
+	}
+	if p.is("...") || (p.lang == LanguageKotlin && p.is("?")) {
+		p.next()
+	}
+	return render(p.tokens[start:p.pos])
+}
+
+func (p *parser) expression(statement bool, stops ...string) Expr {
+	start := p.pos
+	depth := 0
+	for !p.done() {
+		tok := p.peek()
+		if depth == 0 && tok.Kind == TokenPunct {
+			if contains(stops, tok.Text) || tok.Text == ")" || tok.Text == "]" || tok.Text == "}" {
+				break
+			}
+		}
+		if depth == 0 && statement && p.lang == LanguageKotlin && p.pos > start && tok.Newline && !continues(p.tokens[p.pos-1], tok) {
+			break
+		}
+		if tok.Kind == TokenPunct {
+			switch tok.Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+		}
+		p.next()
+	}
+	return newExpr(p.tokens[start:p.pos], p.lang)
+}
+
+func continues(prev, next Token) bool {
+	switch next.Text {
+	case ".", "?.", "?:", "&&", "||", "->":
+		return true
+	}
+	if prev.Kind != TokenPunct {
+		return false
+	}
+	switch prev.Text {
+	case "+", "-", "*", "/", "%", "=", "==", "!=", "&&", "||", "?:", ".", "?.", "->", ",", "::", "<", ">", "!":
+		return true
+	}
+	return false
+}
+
+func addField(owner *Type, f *File, field Field) {
+	if owner != nil {
+		owner.Fields = append(owner.Fields, field)
+	} else {
+		f.Fields = append(f.Fields, field)
+	}
+}
+
+func addMethod(owner *Type, f *File, m Method) {
+	if owner != nil {
+		owner.Methods = append(owner.Methods, m)
+	} else {
+		f.Methods = append(f.Methods, m)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
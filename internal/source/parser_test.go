@@ -0,0 +1,288 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const javaController = `package com.example.web;
+
+import java.util.*;
+import static org.junit.Assert.assertEquals;
+import com.example.user.UserService;
+
+/**
+ * class Fake {}
+ */
+@RestController @Validated
+@RequestMapping(
+    value = {ApiPaths.USERS, "/v2" + "/users"},
+    produces = MediaType.APPLICATION_JSON_VALUE
+)
+public final class UserController<T extends Comparable<T>> extends BaseController<T> implements Api, Auditable<Map<String, List<T>>> {
+    private static final String BY_ID = "/{id}";
+    private final UserService userService, backupService;
+    @Autowired(required = false) private Map<String, List<Long>> cache = new HashMap<>() {{ put("a", List.of()); }};
+    int[] scores;
+
+    static {
+        System.out.println("class Nope {}");
+    }
+
+    public UserController(UserService userService) {
+        this.userService = userService;
+    }
+
+    @GetMapping(BY_ID)
+    public ResponseEntity<Map<String, List<UserResponse>>> getById(
+            @PathVariable("id") final Long id,
+            @RequestParam(required = false) String... fields) throws NotFoundException {
+        if (id > 0) { return null; }
+        return ResponseEntity.ok(null);
+    }
+
+    public abstract <R> R convert(Function<T, R> mapper);
+
+    enum Status { ACTIVE("a") { }, INACTIVE("i"); private final String code; Status(String code) { this.code = code; } }
+
+    record Page(int number, @Min(1) int size) implements Serializable {
+        Page {
+            if (size < 1) throw new IllegalArgumentException();
+        }
+    }
+}
+
+@interface Audit {
+    String value() default "";
+    String[] tags() default {};
+}
+`
+
+func TestParseJavaFileHeader(t *testing.T) {
+	f := Parse(javaController, LanguageJava)
+
+	assert.Equal(t, "com.example.web", f.Package)
+	require.Len(t, f.Imports, 3)
+	assert.Equal(t, Import{Path: "java.util.*", Line: 3}, f.Imports[0])
+	assert.Equal(t, Import{Path: "org.junit.Assert.assertEquals", Static: true, Line: 4}, f.Imports[1])
+	assert.Equal(t, 5, f.Imports[2].Line)
+}
+
+func TestParseJavaType(t *testing.T) {
+	f := Parse(javaController, LanguageJava)
+
+	require.Len(t, f.Types, 2)
+	controller := f.Types[0]
+	assert.Equal(t, "UserController", controller.Name)
+	assert.Equal(t, KindClass, controller.Kind)
+	assert.Equal(t, 15, controller.Line)
+	assert.Equal(t, []string{"RestController", "Validated", "RequestMapping"}, controller.Annotations.Names())
+	assert.True(t, controller.Modifiers.Has("final"))
+	assert.Equal(t, "BaseController<T>", controller.Extends)
+	assert.Equal(t, []string{"Api", "Auditable<Map<String, List<T>>>"}, controller.Interfaces)
+	assert.Equal(t, "Auditable", SimpleName(controller.Interfaces[1]))
+
+	audit := f.Types[1]
+	assert.Equal(t, KindAnnotation, audit.Kind)
+	assert.Equal(t, []string{"value", "tags"}, methodNames(audit.Methods))
+}
+
+func TestParseJavaAnnotationArguments(t *testing.T) {
+	controller := Parse(javaController, LanguageJava).Types[0]
+
+	mapping, ok := controller.Annotations.Get("RequestMapping")
+	require.True(t, ok)
+	assert.Equal(t, 11, mapping.Line)
+
+	value, ok := mapping.Arg("path", "value")
+	require.True(t, ok)
+	require.True(t, value.IsArray())
+	require.Len(t, value.Values(), 2)
+	assert.Equal(t, "ApiPaths.USERS", value.Elements[0].Ref())
+
+	resolve := func(ref string) (string, bool) {
+		return map[string]string{"ApiPaths.USERS": "/api/users"}[ref], ref == "ApiPaths.USERS"
+	}
+	first, ok := value.Elements[0].StringValue(resolve)
+	require.True(t, ok)
+	assert.Equal(t, "/api/users", first)
+	second, ok := value.Elements[1].StringValue(nil)
+	require.True(t, ok)
+	assert.Equal(t, "/v2/users", second)
+
+	produces, _ := mapping.Arg("produces")
+	assert.Equal(t, "MediaType.APPLICATION_JSON_VALUE", produces.Ref())
+	_, ok = produces.StringValue(nil)
+	assert.False(t, ok)
+}
+
+func TestParseJavaFields(t *testing.T) {
+	controller := Parse(javaController, LanguageJava).Types[0]
+
+	assert.Equal(t, []string{"BY_ID", "userService", "backupService", "cache", "scores"}, fieldNames(controller.Fields))
+
+	byID, ok := controller.Field("BY_ID")
+	require.True(t, ok)
+	assert.Equal(t, "String", byID.Type)
+	assert.True(t, byID.Modifiers.Has("static"))
+	assert.False(t, byID.Mutable)
+	value, ok := byID.Value.StringValue(nil)
+	require.True(t, ok)
+	assert.Equal(t, "/{id}", value)
+
+	backup, _ := controller.Field("backupService")
+	assert.Equal(t, "UserService", backup.Type)
+
+	cache, _ := controller.Field("cache")
+	assert.Equal(t, "Map<String, List<Long>>", cache.Type)
+	assert.True(t, cache.Annotations.Has("Autowired"))
+
+	scores, _ := controller.Field("scores")
+	assert.Equal(t, "int[]", scores.Type)
+}
+
+func TestParseJavaMethods(t *testing.T) {
+	controller := Parse(javaController, LanguageJava).Types[0]
+
+	assert.Equal(t, []string{"UserController", "getById", "convert"}, methodNames(controller.Methods))
+	assert.True(t, controller.Methods[0].Constructor)
+
+	getByID := controller.Methods[1]
+	assert.Equal(t, "ResponseEntity<Map<String, List<UserResponse>>>", getByID.ReturnType)
+	assert.Equal(t, 30, getByID.Line)
+	require.Len(t, getByID.Parameters, 2)
+	assert.Equal(t, Parameter{
+		Name:        "id",
+		Type:        "Long",
+		Modifiers:   Modifiers{"final"},
+		Annotations: getByID.Parameters[0].Annotations,
+	}, getByID.Parameters[0])
+	assert.True(t, getByID.Parameters[0].Annotations.Has("PathVariable"))
+	assert.Equal(t, "String...", getByID.Parameters[1].Type)
+
+	mapping, _ := getByID.Annotations.Get("GetMapping")
+	assert.Equal(t, 29, mapping.Line)
+	path, _ := mapping.Arg("value")
+	assert.Equal(t, "BY_ID", path.Ref())
+
+	assert.Equal(t, "R", controller.Methods[2].ReturnType)
+}
+
+func TestParseJavaNestedTypes(t *testing.T) {
+	f := Parse(javaController, LanguageJava)
+	controller := f.Types[0]
+
+	require.Len(t, controller.Types, 2)
+	status := controller.Types[0]
+	assert.Equal(t, KindEnum, status.Kind)
+	assert.Equal(t, []string{"ACTIVE", "INACTIVE"}, status.Constants)
+	assert.Equal(t, []string{"code"}, fieldNames(status.Fields))
+
+	page := controller.Types[1]
+	assert.Equal(t, KindRecord, page.Kind)
+	assert.Equal(t, []string{"Serializable"}, page.Interfaces)
+	require.Len(t, page.Parameters, 2)
+	assert.Equal(t, "size", page.Parameters[1].Name)
+	assert.True(t, page.Parameters[1].Annotations.Has("Min"))
+	require.Len(t, page.Methods, 1)
+	assert.True(t, page.Methods[0].Constructor)
+
+	var names []string
+	for _, typ := range f.AllTypes() {
+		names = append(names, typ.Name)
+	}
+	assert.Equal(t, []string{"UserController", "Status", "Page", "Audit"}, names)
+}
+
+func TestParseJavaInterface(t *testing.T) {
+	f := Parse(`package com.example;
+public interface UserRepository extends JpaRepository<User, Long>, JpaSpecificationExecutor<User> {
+    Optional<User> findByEmail(String email);
+    @Query("select u from User u")
+    List<User> findActive();
+    default boolean exists(String email) { return findByEmail(email).isPresent(); }
+}`, LanguageJava)
+
+	require.Len(t, f.Types, 1)
+	repo := f.Types[0]
+	assert.Equal(t, KindInterface, repo.Kind)
+	assert.Empty(t, repo.Extends)
+	assert.Equal(t, []string{"JpaRepository<User, Long>", "JpaSpecificationExecutor<User>"}, repo.Interfaces)
+	assert.Equal(t, []string{"findByEmail", "findActive", "exists"}, methodNames(repo.Methods))
+	assert.True(t, repo.Methods[2].Modifiers.Has("default"))
+}
+
+func TestParseJavaSealed(t *testing.T) {
+	f := Parse(`public sealed abstract class Shape permits Circle, Square {}
+non-sealed class Circle extends Shape {}
+final class Square extends com.example.Shape {}`, LanguageJava)
+
+	require.Len(t, f.Types, 3)
+	assert.True(t, f.Types[0].Modifiers.Has("sealed"))
+	assert.True(t, f.Types[0].Modifiers.Has("abstract"))
+	assert.True(t, f.Types[1].Modifiers.Has("non-sealed"))
+	assert.Equal(t, "com.example.Shape", f.Types[2].Extends)
+	assert.Equal(t, "Shape", SimpleName(f.Types[2].Extends))
+}
+
+func TestPrimaryType(t *testing.T) {
+	f := Parse("class Helper {}\npublic class OrderService {}", LanguageJava)
+
+	assert.Equal(t, "OrderService", f.PrimaryType("/src/OrderService.java").Name)
+	assert.Equal(t, "Helper", f.PrimaryType("/src/Other.java").Name)
+	assert.Nil(t, Parse("package a;", LanguageJava).PrimaryType("/src/A.java"))
+}
+
+func TestParseJavaHeaders(t *testing.T) {
+	tests := []struct {
+		content     string
+		pkg         string
+		imports     []string
+		annotations []string
+	}{
+		{content: "package com.example.app;", pkg: "com.example.app"},
+		{content: "  package com.example.app;  ", pkg: "com.example.app"},
+		{content: "package myapp;\nimport java.util.List;", pkg: "myapp", imports: []string{"java.util.List"}},
+		{content: "import static org.junit.Assert.assertEquals;", imports: []string{"org.junit.Assert.assertEquals"}},
+		{content: "@Service class A {}", annotations: []string{"Service"}},
+		{content: "@RequestMapping(\"/api\") class A {}", annotations: []string{"RequestMapping"}},
+		{content: "@jakarta.persistence.Entity(name = \"user\") class A {}", annotations: []string{"Entity"}},
+		{content: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			f := Parse(tt.content, LanguageJava)
+
+			assert.Equal(t, tt.pkg, f.Package)
+			var imports []string
+			for _, imp := range f.Imports {
+				imports = append(imports, imp.Path)
+			}
+			assert.Equal(t, tt.imports, imports)
+			var annotations []string
+			for _, typ := range f.Types {
+				annotations = append(annotations, typ.Annotations.Names()...)
+			}
+			assert.Equal(t, tt.annotations, annotations)
+		})
+	}
+}
+
+func methodNames(methods []Method) []string {
+	var names []string
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+func fieldNames(fields []Field) []string {
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
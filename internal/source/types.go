@@ -0,0 +1,188 @@
+package source
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+type Language string
+
+const (
+	LanguageJava   Language = "java"
+	LanguageKotlin Language = "kotlin"
+)
+
+type Kind string
+
+const (
+	KindClass      Kind = "class"
+	KindInterface  Kind = "interface"
+	KindEnum       Kind = "enum"
+	KindRecord     Kind = "record"
+	KindAnnotation Kind = "annotation"
+	KindObject     Kind = "object"
+)
+
+type File struct {
+	Language    Language
+	Package     string
+	Imports     []Import
+	Annotations Annotations
+	Types       []*Type
+	Fields      []Field
+	Methods     []Method
+}
+
+type Import struct {
+	Path   string
+	Static bool
+	Alias  string
+	Line   int
+}
+
+type Type struct {
+	Name        string
+	Kind        Kind
+	Modifiers   Modifiers
+	Annotations Annotations
+	Extends     string
+	Interfaces  []string
+	Parameters  []Parameter
+	Constants   []string
+	Fields      []Field
+	Methods     []Method
+	Types       []*Type
+	Line        int
+}
+
+type Field struct {
+	Name        string
+	Type        string
+	Modifiers   Modifiers
+	Annotations Annotations
+	Value       *Expr
+	Mutable     bool
+	Line        int
+}
+
+type Method struct {
+	Name        string
+	ReturnType  string
+	Modifiers   Modifiers
+	Annotations Annotations
+	Parameters  []Parameter
+	Constructor bool
+	Line        int
+}
+
+type Parameter struct {
+	Name        string
+	Type        string
+	Modifiers   Modifiers
+	Annotations Annotations
+	Property    bool
+	Default     *Expr
+}
+
+type Annotation struct {
+	Name   string
+	Target string
+	Args   map[string]Expr
+	Line   int
+}
+
+type Annotations []Annotation
+
+type Modifiers []string
+
+func LanguageFor(path string) Language {
+	switch filepath.Ext(path) {
+	case ".kt", ".kts":
+		return LanguageKotlin
+	default:
+		return LanguageJava
+	}
+}
+
+func SimpleName(name string) string {
+	if idx := strings.Index(name, "<"); idx >= 0 {
+		name = name[:idx]
+	}
+	name = strings.TrimSuffix(strings.TrimSpace(name), "?")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func (f *File) AllTypes() []*Type {
+	var result []*Type
+	var walk func(types []*Type)
+	walk = func(types []*Type) {
+		for _, t := range types {
+			result = append(result, t)
+			walk(t.Types)
+		}
+	}
+	walk(f.Types)
+	return result
+}
+
+func (f *File) PrimaryType(path string) *Type {
+	if len(f.Types) == 0 {
+		return nil
+	}
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	for _, t := range f.Types {
+		if t.Name == base {
+			return t
+		}
+	}
+	return f.Types[0]
+}
+
+func (t *Type) Field(name string) (Field, bool) {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+func (as Annotations) Has(name string) bool {
+	_, ok := as.Get(name)
+	return ok
+}
+
+func (as Annotations) Get(name string) (Annotation, bool) {
+	for _, a := range as {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Annotation{}, false
+}
+
+func (as Annotations) Names() []string {
+	var names []string
+	for _, a := range as {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+func (a Annotation) Arg(names ...string) (Expr, bool) {
+	for _, name := range names {
+		if value, ok := a.Args[name]; ok {
+			return value, true
+		}
+	}
+	return Expr{}, false
+}
+
+func (ms Modifiers) Has(modifier string) bool {
+	for _, m := range ms {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
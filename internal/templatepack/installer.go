@@ -0,0 +1,232 @@
+package templatepack
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/KashifKhn/haft/internal/generator"
+	"github.com/spf13/afero"
+)
+
+type Installer struct {
+	fs   afero.Fs
+	root string
+}
+
+type InstallResult struct {
+	Name     string
+	Version  string
+	Previous string
+	Written  []string
+	Removed  []string
+	Kept     []string
+}
+
+type RemoveResult struct {
+	Name    string
+	Version string
+	Removed []string
+	Kept    []string
+}
+
+func NewInstaller(fs afero.Fs, root string) *Installer {
+	return &Installer{fs: fs, root: root}
+}
+
+func (i *Installer) TemplateDir() string {
+	return filepath.Join(i.root, generator.ProjectTemplateDir)
+}
+
+func (i *Installer) LockPath() string {
+	return filepath.Join(filepath.Dir(i.TemplateDir()), LockFile)
+}
+
+func (i *Installer) ReadLock() (*Lock, error) {
+	return ReadLock(i.fs, i.LockPath())
+}
+
+func (i *Installer) Install(pack *Pack, source string, force bool) (*InstallResult, error) {
+	lock, err := i.ReadLock()
+	if err != nil {
+		return nil, err
+	}
+
+	name := pack.Manifest.Name
+	previous := lock.Find(name)
+
+	if !force {
+		if conflicts := i.findConflicts(lock, pack, previous); len(conflicts) > 0 {
+			return nil, fmt.Errorf("pack '%s' conflicts with existing templates: %s (use --force to overwrite)",
+				name, strings.Join(conflicts, ", "))
+		}
+	}
+
+	result := &InstallResult{Name: name, Version: pack.Manifest.Version}
+	if previous != nil {
+		result.Previous = previous.Version
+		result.Removed, result.Kept = i.removeStaleFiles(previous, pack, force)
+	}
+
+	locked := LockedPack{
+		Name:     name,
+		Version:  pack.Manifest.Version,
+		Source:   source,
+		Checksum: pack.Checksum(),
+		Files:    make(map[string]string),
+	}
+
+	for _, file := range pack.FileNames() {
+		content := pack.Files[file]
+		if err := i.writeTemplate(file, content); err != nil {
+			return nil, err
+		}
+		locked.Files[file] = hashContent(content)
+		result.Written = append(result.Written, file)
+	}
+
+	lock.Upsert(locked)
+	if err := lock.Write(i.fs, i.LockPath()); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (i *Installer) InstallLocked(pack *Pack, locked LockedPack, force bool) (*InstallResult, error) {
+	if pack.Manifest.Name != locked.Name {
+		return nil, fmt.Errorf("source %s provides pack '%s', expected '%s'", locked.Source, pack.Manifest.Name, locked.Name)
+	}
+	if checksum := pack.Checksum(); checksum != locked.Checksum && !force {
+		return nil, fmt.Errorf("pack '%s' from %s no longer matches the lock file (locked %s, found %s); run 'haft template update %s'",
+			locked.Name, locked.Source, locked.Version, pack.Manifest.Version, locked.Name)
+	}
+	return i.Install(pack, locked.Source, force)
+}
+
+func (i *Installer) Remove(name string, force bool) (*RemoveResult, error) {
+	lock, err := i.ReadLock()
+	if err != nil {
+		return nil, err
+	}
+
+	locked := lock.Find(name)
+	if locked == nil {
+		return nil, fmt.Errorf("pack '%s' is not installed", name)
+	}
+
+	result := &RemoveResult{Name: locked.Name, Version: locked.Version}
+	for _, file := range sortedKeys(locked.Files) {
+		if i.deleteIfUnmodified(file, locked.Files[file], force) {
+			result.Removed = append(result.Removed, file)
+		} else {
+			result.Kept = append(result.Kept, file)
+		}
+	}
+
+	lock.Delete(name)
+	if err := lock.Write(i.fs, i.LockPath()); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (i *Installer) findConflicts(lock *Lock, pack *Pack, previous *LockedPack) []string {
+	var conflicts []string
+	for _, file := range pack.FileNames() {
+		owner := lock.Owner(file)
+		switch {
+		case owner != "" && owner != pack.Manifest.Name:
+			conflicts = append(conflicts, fmt.Sprintf("%s (owned by %s)", file, owner))
+		case owner == "" && i.templateExists(file):
+			conflicts = append(conflicts, fmt.Sprintf("%s (local template)", file))
+		case owner != "" && previous != nil && i.isModified(file, previous.Files[file]):
+			conflicts = append(conflicts, fmt.Sprintf("%s (modified locally)", file))
+		}
+	}
+	return conflicts
+}
+
+func (i *Installer) removeStaleFiles(previous *LockedPack, pack *Pack, force bool) ([]string, []string) {
+	var removed, kept []string
+	for _, file := range sortedKeys(previous.Files) {
+		if _, ok := pack.Files[file]; ok {
+			continue
+		}
+		if i.deleteIfUnmodified(file, previous.Files[file], force) {
+			removed = append(removed, file)
+		} else {
+			kept = append(kept, file)
+		}
+	}
+	return removed, kept
+}
+
+func (i *Installer) deleteIfUnmodified(file, hash string, force bool) bool {
+	if !i.templateExists(file) {
+		return true
+	}
+	if i.isModified(file, hash) && !force {
+		return false
+	}
+
+	path := i.templatePath(file)
+	if err := i.fs.Remove(path); err != nil {
+		return false
+	}
+	i.pruneEmptyDirs(filepath.Dir(path))
+	return true
+}
+
+func (i *Installer) pruneEmptyDirs(dir string) {
+	templateDir := i.TemplateDir()
+	for strings.HasPrefix(dir, templateDir) && dir != templateDir {
+		entries, err := afero.ReadDir(i.fs, dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := i.fs.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (i *Installer) isModified(file, hash string) bool {
+	content, err := afero.ReadFile(i.fs, i.templatePath(file))
+	if err != nil {
+		return false
+	}
+	return hashContent(content) != hash
+}
+
+func (i *Installer) templateExists(file string) bool {
+	exists, _ := afero.Exists(i.fs, i.templatePath(file))
+	return exists
+}
+
+func (i *Installer) templatePath(file string) string {
+	return filepath.Join(i.TemplateDir(), filepath.FromSlash(file))
+}
+
+func (i *Installer) writeTemplate(file string, content []byte) error {
+	path := i.templatePath(file)
+	if err := i.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := afero.WriteFile(i.fs, path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
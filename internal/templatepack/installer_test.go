@@ -0,0 +1,191 @@
+package templatepack
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPack(version string, files map[string]string) *Pack {
+	pack := New(Manifest{Name: "acme", Version: version})
+	for name, content := range files {
+		pack.Files[name] = []byte(content)
+	}
+	return pack
+}
+
+func readTemplate(t *testing.T, fs afero.Fs, name string) string {
+	t.Helper()
+	content, err := afero.ReadFile(fs, "/project/.haft/templates/"+name)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestInstallerPaths(t *testing.T) {
+	installer := NewInstaller(afero.NewMemMapFs(), "/project")
+
+	assert.Equal(t, "/project/.haft/templates", installer.TemplateDir())
+	assert.Equal(t, "/project/.haft/"+LockFile, installer.LockPath())
+}
+
+func TestInstallWritesTemplatesAndLock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+	pack := newTestPack("1.0.0", map[string]string{"resource/layered/Controller.java.tmpl": "v1"})
+
+	result, err := installer.Install(pack, "https://github.com/acme/templates.git#v1.0.0", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", result.Name)
+	assert.Equal(t, []string{"resource/layered/Controller.java.tmpl"}, result.Written)
+	assert.Equal(t, "v1", readTemplate(t, fs, "resource/layered/Controller.java.tmpl"))
+
+	lock, err := installer.ReadLock()
+	require.NoError(t, err)
+	require.Len(t, lock.Packs, 1)
+	assert.Equal(t, "1.0.0", lock.Packs[0].Version)
+	assert.Equal(t, "https://github.com/acme/templates.git#v1.0.0", lock.Packs[0].Source)
+	assert.Equal(t, pack.Checksum(), lock.Packs[0].Checksum)
+	assert.Equal(t, "acme", lock.Owner("resource/layered/Controller.java.tmpl"))
+}
+
+func TestInstallRejectsLocalTemplateConflict(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft/templates/resource/layered/Controller.java.tmpl", []byte("mine"), 0644))
+	installer := NewInstaller(fs, "/project")
+	pack := newTestPack("1.0.0", map[string]string{"resource/layered/Controller.java.tmpl": "v1"})
+
+	_, err := installer.Install(pack, "acme", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local template")
+	assert.Equal(t, "mine", readTemplate(t, fs, "resource/layered/Controller.java.tmpl"))
+
+	_, err = installer.Install(pack, "acme", true)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", readTemplate(t, fs, "resource/layered/Controller.java.tmpl"))
+}
+
+func TestInstallRejectsFilesOwnedByAnotherPack(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+	_, err := installer.Install(newTestPack("1.0.0", map[string]string{"a.tmpl": "a"}), "acme", false)
+	require.NoError(t, err)
+
+	other := New(Manifest{Name: "other", Version: "1.0.0"})
+	other.Files["a.tmpl"] = []byte("other")
+
+	_, err = installer.Install(other, "other", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "owned by acme")
+}
+
+func TestInstallUpgradeRemovesStaleFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+
+	_, err := installer.Install(newTestPack("1.0.0", map[string]string{
+		"resource/layered/Controller.java.tmpl": "v1",
+		"resource/layered/Legacy.java.tmpl":     "legacy",
+	}), "acme", false)
+	require.NoError(t, err)
+
+	result, err := installer.Install(newTestPack("1.1.0", map[string]string{
+		"resource/layered/Controller.java.tmpl": "v2",
+	}), "acme", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0", result.Previous)
+	assert.Equal(t, []string{"resource/layered/Legacy.java.tmpl"}, result.Removed)
+	assert.Equal(t, "v2", readTemplate(t, fs, "resource/layered/Controller.java.tmpl"))
+
+	exists, _ := afero.Exists(fs, "/project/.haft/templates/resource/layered/Legacy.java.tmpl")
+	assert.False(t, exists)
+}
+
+func TestInstallUpgradeRejectsLocalModifications(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+
+	_, err := installer.Install(newTestPack("1.0.0", map[string]string{"a.tmpl": "v1"}), "acme", false)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft/templates/a.tmpl", []byte("edited"), 0644))
+
+	_, err = installer.Install(newTestPack("1.1.0", map[string]string{"a.tmpl": "v2"}), "acme", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "modified locally")
+}
+
+func TestInstallLockedVerifiesChecksum(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+	pack := newTestPack("1.0.0", map[string]string{"a.tmpl": "v1"})
+
+	_, err := installer.Install(pack, "packs/acme", false)
+	require.NoError(t, err)
+	lock, err := installer.ReadLock()
+	require.NoError(t, err)
+	locked := lock.Packs[0]
+
+	_, err = installer.InstallLocked(pack, locked, false)
+	assert.NoError(t, err)
+
+	changed := newTestPack("1.0.1", map[string]string{"a.tmpl": "v2"})
+	_, err = installer.InstallLocked(changed, locked, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no longer matches")
+
+	other := New(Manifest{Name: "other", Version: "1.0.0"})
+	_, err = installer.InstallLocked(other, locked, false)
+	assert.Error(t, err)
+}
+
+func TestInstallLockedRejectsLocalModifications(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+	pack := newTestPack("1.0.0", map[string]string{"a.tmpl": "v1"})
+
+	_, err := installer.Install(pack, "packs/acme", false)
+	require.NoError(t, err)
+	lock, err := installer.ReadLock()
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft/templates/a.tmpl", []byte("edited"), 0644))
+
+	_, err = installer.InstallLocked(pack, lock.Packs[0], false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "modified locally")
+	assert.Equal(t, "edited", readTemplate(t, fs, "a.tmpl"))
+
+	_, err = installer.InstallLocked(pack, lock.Packs[0], true)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", readTemplate(t, fs, "a.tmpl"))
+}
+
+func TestRemoveDeletesFilesAndLockEntry(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	installer := NewInstaller(fs, "/project")
+
+	_, err := installer.Install(newTestPack("1.0.0", map[string]string{
+		"resource/layered/Controller.java.tmpl": "v1",
+		"test/layered/ServiceTest.java.tmpl":    "t1",
+	}), "acme", false)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "/project/.haft/templates/test/layered/ServiceTest.java.tmpl", []byte("edited"), 0644))
+
+	result, err := installer.Remove("acme", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"resource/layered/Controller.java.tmpl"}, result.Removed)
+	assert.Equal(t, []string{"test/layered/ServiceTest.java.tmpl"}, result.Kept)
+
+	exists, _ := afero.DirExists(fs, "/project/.haft/templates/resource")
+	assert.False(t, exists)
+
+	lock, err := installer.ReadLock()
+	require.NoError(t, err)
+	assert.Empty(t, lock.Packs)
+
+	_, err = installer.Remove("acme", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package templatepack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	LockFile        = "templates-lock.json"
+	lockFileVersion = 1
+)
+
+type Lock struct {
+	LockVersion int          `json:"lockVersion"`
+	Packs       []LockedPack `json:"packs"`
+}
+
+type LockedPack struct {
+	Name     string            `json:"name"`
+	Version  string            `json:"version"`
+	Source   string            `json:"source"`
+	Checksum string            `json:"checksum"`
+	Files    map[string]string `json:"files"`
+}
+
+func ReadLock(fs afero.Fs, lockPath string) (*Lock, error) {
+	data, err := afero.ReadFile(fs, lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Lock{LockVersion: lockFileVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", lockPath, err)
+	}
+	return &lock, nil
+}
+
+func (l *Lock) Write(fs afero.Fs, lockPath string) error {
+	sort.Slice(l.Packs, func(i, j int) bool {
+		return l.Packs[i].Name < l.Packs[j].Name
+	})
+	l.LockVersion = lockFileVersion
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return afero.WriteFile(fs, lockPath, append(data, '\n'), 0644)
+}
+
+func (l *Lock) Find(name string) *LockedPack {
+	for i := range l.Packs {
+		if l.Packs[i].Name == name {
+			return &l.Packs[i]
+		}
+	}
+	return nil
+}
+
+func (l *Lock) Upsert(pack LockedPack) {
+	if existing := l.Find(pack.Name); existing != nil {
+		*existing = pack
+		return
+	}
+	l.Packs = append(l.Packs, pack)
+}
+
+func (l *Lock) Delete(name string) bool {
+	for i := range l.Packs {
+		if l.Packs[i].Name == name {
+			l.Packs = append(l.Packs[:i], l.Packs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Lock) Owner(file string) string {
+	for _, pack := range l.Packs {
+		if _, ok := pack.Files[file]; ok {
+			return pack.Name
+		}
+	}
+	return ""
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package templatepack
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	ManifestFile = "haft-pack.json"
+	TemplatesDir = "templates"
+)
+
+var packNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+type Manifest struct {
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	Description   string   `json:"description,omitempty"`
+	Haft          string   `json:"haft,omitempty"`
+	Architectures []string `json:"architectures,omitempty"`
+}
+
+func ReadManifest(fs afero.Fs, dir string) (*Manifest, error) {
+	data, err := afero.ReadFile(fs, filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+	return ParseManifest(data)
+}
+
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func (m *Manifest) Validate() error {
+	if !packNamePattern.MatchString(m.Name) {
+		return fmt.Errorf("invalid pack name '%s': use lowercase letters, digits, '.', '-' or '_'", m.Name)
+	}
+	if _, err := parseVersion(m.Version); err != nil {
+		return fmt.Errorf("invalid pack version '%s': %w", m.Version, err)
+	}
+	if _, err := parseConstraint(m.Haft); err != nil {
+		return fmt.Errorf("invalid haft constraint '%s': %w", m.Haft, err)
+	}
+	return nil
+}
+
+func (m *Manifest) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (m *Manifest) SupportsHaft(haftVersion string) (bool, error) {
+	constraint, err := parseConstraint(m.Haft)
+	if err != nil {
+		return false, err
+	}
+	if haftVersion == "" || haftVersion == "dev" {
+		return true, nil
+	}
+	version, err := parseVersion(haftVersion)
+	if err != nil {
+		return false, fmt.Errorf("invalid haft version '%s': %w", haftVersion, err)
+	}
+	return constraint.matches(version), nil
+}
+
+func (m *Manifest) SupportsArchitecture(arch string) bool {
+	if len(m.Architectures) == 0 || arch == "" {
+		return true
+	}
+	for _, supported := range m.Architectures {
+		if strings.EqualFold(supported, arch) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manifest) ID() string {
+	return m.Name + "@" + m.Version
+}
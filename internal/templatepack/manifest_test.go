@@ -0,0 +1,106 @@
+package templatepack
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifest(t *testing.T) {
+	data := []byte(`{
+  "name": "acme-templates",
+  "version": "1.2.0",
+  "haft": ">=0.5.0 <1.0.0",
+  "architectures": ["layered", "feature"]
+}`)
+
+	manifest, err := ParseManifest(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme-templates", manifest.Name)
+	assert.Equal(t, "1.2.0", manifest.Version)
+	assert.Equal(t, "acme-templates@1.2.0", manifest.ID())
+	assert.Equal(t, []string{"layered", "feature"}, manifest.Architectures)
+}
+
+func TestParseManifestInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"bad json", `{`},
+		{"missing name", `{"version": "1.0.0"}`},
+		{"uppercase name", `{"name": "Acme", "version": "1.0.0"}`},
+		{"bad version", `{"name": "acme", "version": "one"}`},
+		{"bad constraint", `{"name": "acme", "version": "1.0.0", "haft": ">=x"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReadManifestMissing(t *testing.T) {
+	_, err := ReadManifest(afero.NewMemMapFs(), "/pack")
+	assert.Error(t, err)
+}
+
+func TestManifestSupportsHaft(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"", "v0.1.0", true},
+		{"*", "v0.1.0", true},
+		{">=0.5.0", "v0.5.0", true},
+		{">=0.5.0", "v0.4.9", false},
+		{">=0.5.0 <1.0.0", "v1.0.0", false},
+		{">=0.5.0, <1.0.0", "v0.9.3", true},
+		{"^0.6.0", "v0.7.1", true},
+		{"^1.2.0", "v2.0.0", false},
+		{"~0.6.0", "v0.6.4", true},
+		{"~0.6.0", "v0.7.0", false},
+		{"=0.6.0", "v0.6.0-rc1", true},
+		{"!=0.6.0", "v0.6.0", false},
+		{">=0.5.0", "dev", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+"/"+tt.version, func(t *testing.T) {
+			manifest := Manifest{Name: "acme", Version: "1.0.0", Haft: tt.constraint}
+			supported, err := manifest.SupportsHaft(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, supported)
+		})
+	}
+}
+
+func TestManifestSupportsArchitecture(t *testing.T) {
+	manifest := Manifest{Architectures: []string{"layered", "Feature"}}
+
+	assert.True(t, manifest.SupportsArchitecture("layered"))
+	assert.True(t, manifest.SupportsArchitecture("feature"))
+	assert.False(t, manifest.SupportsArchitecture("hexagonal"))
+	assert.True(t, manifest.SupportsArchitecture(""))
+
+	assert.True(t, (&Manifest{}).SupportsArchitecture("clean"))
+}
+
+func TestCompareVersions(t *testing.T) {
+	cmp, err := CompareVersions("1.2.0", "1.10.0")
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = CompareVersions("v2.0", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	_, err = CompareVersions("abc", "1.0.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,223 @@
+package templatepack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+type Pack struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+func New(manifest Manifest) *Pack {
+	return &Pack{Manifest: manifest, Files: make(map[string][]byte)}
+}
+
+func LoadDir(fs afero.Fs, dir string) (*Pack, error) {
+	manifest, err := ReadManifest(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pack := New(*manifest)
+	if err := pack.AddDir(fs, filepath.Join(dir, TemplatesDir)); err != nil {
+		return nil, err
+	}
+
+	if len(pack.Files) == 0 {
+		return nil, fmt.Errorf("pack '%s' contains no templates", manifest.Name)
+	}
+
+	return pack, nil
+}
+
+func (p *Pack) AddDir(fs afero.Fs, dir string) error {
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil || !exists {
+		return fmt.Errorf("templates directory not found: %s", dir)
+	}
+
+	return afero.Walk(fs, dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := afero.ReadFile(fs, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		p.Files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+}
+
+func (p *Pack) FileNames() []string {
+	names := make([]string, 0, len(p.Files))
+	for name := range p.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Pack) Checksum() string {
+	hash := sha256.New()
+	for _, name := range p.FileNames() {
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00", name, len(p.Files[name]))
+		hash.Write(p.Files[name])
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+func (p *Pack) ArchiveName() string {
+	return fmt.Sprintf("%s-%s.tar.gz", p.Manifest.Name, p.Manifest.Version)
+}
+
+func (p *Pack) WriteTarball(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest, err := p.Manifest.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := writeTarEntry(tw, ManifestFile, manifest); err != nil {
+		return err
+	}
+
+	for _, name := range p.FileNames() {
+		if err := writeTarEntry(tw, path.Join(TemplatesDir, name), p.Files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return gz.Close()
+}
+
+func writeTarEntry(tw *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Unix(0, 0),
+		Format:  tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func LoadTarball(fs afero.Fs, archivePath string) (*Pack, error) {
+	file, err := fs.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", archivePath, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return ReadTarball(file)
+}
+
+func ReadTarball(r io.Reader) (*Pack, error) {
+	entries, err := readTarEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, ok := findManifestPrefix(entries)
+	if !ok {
+		return nil, fmt.Errorf("archive does not contain %s", ManifestFile)
+	}
+
+	manifest, err := ParseManifest(entries[prefix+ManifestFile])
+	if err != nil {
+		return nil, err
+	}
+
+	pack := New(*manifest)
+	templatesPrefix := prefix + TemplatesDir + "/"
+	for name, content := range entries {
+		if strings.HasPrefix(name, templatesPrefix) {
+			pack.Files[strings.TrimPrefix(name, templatesPrefix)] = content
+		}
+	}
+
+	if len(pack.Files) == 0 {
+		return nil, fmt.Errorf("pack '%s' contains no templates", manifest.Name)
+	}
+
+	return pack, nil
+}
+
+func readTarEntries(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("archive contains unsafe path: %s", header.Name)
+		}
+
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		entries[name] = buf.Bytes()
+	}
+}
+
+func findManifestPrefix(entries map[string][]byte) (string, bool) {
+	if _, ok := entries[ManifestFile]; ok {
+		return "", true
+	}
+	for name := range entries {
+		if path.Base(name) == ManifestFile && strings.Count(name, "/") == 1 {
+			return path.Dir(name) + "/", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,175 @@
+package templatepack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePackDir(t *testing.T, fs afero.Fs, dir string, manifest string, files map[string]string) {
+	t.Helper()
+	require.NoError(t, afero.WriteFile(fs, dir+"/"+ManifestFile, []byte(manifest), 0644))
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, dir+"/templates/"+name, []byte(content), 0644))
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writePackDir(t, fs, "/src/acme", `{"name": "acme", "version": "1.0.0"}`, map[string]string{
+		"resource/layered/Controller.java.tmpl": "controller",
+		"test/layered/ServiceTest.java.tmpl":    "test",
+	})
+
+	pack, err := LoadDir(fs, "/src/acme")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", pack.Manifest.Name)
+	assert.Equal(t, []string{
+		"resource/layered/Controller.java.tmpl",
+		"test/layered/ServiceTest.java.tmpl",
+	}, pack.FileNames())
+	assert.Equal(t, "acme-1.0.0.tar.gz", pack.ArchiveName())
+}
+
+func TestLoadDirWithoutTemplates(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/src/acme/"+ManifestFile, []byte(`{"name": "acme", "version": "1.0.0"}`), 0644))
+
+	_, err := LoadDir(fs, "/src/acme")
+	assert.Error(t, err)
+}
+
+func TestTarballRoundTrip(t *testing.T) {
+	pack := New(Manifest{Name: "acme", Version: "2.1.0", Haft: ">=0.5.0", Architectures: []string{"feature"}})
+	pack.Files["resource/feature/Entity.java.tmpl"] = []byte("entity")
+	pack.Files["resource/feature/Service.java.tmpl"] = []byte("service")
+
+	var buf bytes.Buffer
+	require.NoError(t, pack.WriteTarball(&buf))
+
+	loaded, err := ReadTarball(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, pack.Manifest, loaded.Manifest)
+	assert.Equal(t, pack.Files, loaded.Files)
+	assert.Equal(t, pack.Checksum(), loaded.Checksum())
+}
+
+func TestTarballIsDeterministic(t *testing.T) {
+	pack := New(Manifest{Name: "acme", Version: "1.0.0"})
+	pack.Files["a.tmpl"] = []byte("a")
+	pack.Files["b.tmpl"] = []byte("b")
+
+	var first, second bytes.Buffer
+	require.NoError(t, pack.WriteTarball(&first))
+	require.NoError(t, pack.WriteTarball(&second))
+
+	assert.Equal(t, first.Bytes(), second.Bytes())
+}
+
+func TestReadTarballWithTopLevelDirectory(t *testing.T) {
+	archive := buildTarball(t, map[string]string{
+		"acme-1.0.0/" + ManifestFile:                  `{"name": "acme", "version": "1.0.0"}`,
+		"acme-1.0.0/templates/resource/X.java.tmpl":   "x",
+		"acme-1.0.0/README.md":                        "readme",
+		"acme-1.0.0/templates/resource/Y.java.golden": "y",
+	})
+
+	pack, err := ReadTarball(bytes.NewReader(archive))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"resource/X.java.tmpl", "resource/Y.java.golden"}, pack.FileNames())
+}
+
+func TestReadTarballRejectsUnsafePaths(t *testing.T) {
+	archive := buildTarball(t, map[string]string{
+		ManifestFile:          `{"name": "acme", "version": "1.0.0"}`,
+		"../templates/x.tmpl": "x",
+		"templates/ok.tmpl":   "ok",
+	})
+
+	_, err := ReadTarball(bytes.NewReader(archive))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsafe path")
+}
+
+func TestReadTarballWithoutManifest(t *testing.T) {
+	archive := buildTarball(t, map[string]string{"templates/x.tmpl": "x"})
+
+	_, err := ReadTarball(bytes.NewReader(archive))
+	assert.Error(t, err)
+}
+
+func TestChecksumChangesWithContent(t *testing.T) {
+	pack := New(Manifest{Name: "acme", Version: "1.0.0"})
+	pack.Files["a.tmpl"] = []byte("a")
+	before := pack.Checksum()
+
+	pack.Files["a.tmpl"] = []byte("changed")
+	assert.NotEqual(t, before, pack.Checksum())
+	assert.Contains(t, pack.Checksum(), "sha256:")
+}
+
+func TestDetectSourceKind(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected SourceKind
+	}{
+		{"./packs/acme", SourceDirectory},
+		{"/opt/packs/acme", SourceDirectory},
+		{"acme-1.0.0.tar.gz", SourceTarball},
+		{"acme.tgz", SourceTarball},
+		{"https://example.com/acme-1.0.0.tar.gz", SourceTarball},
+		{"https://github.com/acme/haft-templates", SourceGit},
+		{"https://github.com/acme/haft-templates.git#v1.2.0", SourceGit},
+		{"git@github.com:acme/haft-templates.git", SourceGit},
+		{"ssh://git@example.com/acme.git", SourceGit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectSourceKind(tt.source))
+		})
+	}
+}
+
+func TestFetchLocalSources(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writePackDir(t, fs, "/work/packs/acme", `{"name": "acme", "version": "1.0.0"}`, map[string]string{
+		"resource/X.java.tmpl": "x",
+	})
+
+	pack, err := Fetch(fs, "packs/acme", "/work")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, pack.WriteTarball(&buf))
+	require.NoError(t, afero.WriteFile(fs, "/work/acme.tar.gz", buf.Bytes(), 0644))
+
+	fromTarball, err := Fetch(fs, "acme.tar.gz", "/work")
+	require.NoError(t, err)
+	assert.Equal(t, pack.Checksum(), fromTarball.Checksum())
+
+	_, err = Fetch(fs, "missing", "/work")
+	assert.Error(t, err)
+}
+
+func buildTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,123 @@
+package templatepack
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+type SourceKind string
+
+const (
+	SourceDirectory SourceKind = "directory"
+	SourceTarball   SourceKind = "tarball"
+	SourceGit       SourceKind = "git"
+)
+
+const downloadTimeout = 60 * time.Second
+
+func DetectSourceKind(source string) SourceKind {
+	repo, _ := splitGitRef(source)
+	switch {
+	case isTarballName(source):
+		return SourceTarball
+	case strings.HasPrefix(repo, "git@"),
+		strings.HasPrefix(repo, "git://"),
+		strings.HasPrefix(repo, "ssh://"),
+		strings.HasPrefix(repo, "git+"),
+		strings.HasSuffix(repo, ".git"),
+		isRemote(repo):
+		return SourceGit
+	default:
+		return SourceDirectory
+	}
+}
+
+func isTarballName(source string) bool {
+	return strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz")
+}
+
+func isRemote(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+func Fetch(fs afero.Fs, source, baseDir string) (*Pack, error) {
+	switch DetectSourceKind(source) {
+	case SourceGit:
+		return fetchGit(source)
+	case SourceTarball:
+		if isRemote(source) {
+			return fetchRemoteTarball(source)
+		}
+		return LoadTarball(fs, resolveLocalPath(source, baseDir))
+	default:
+		dir := resolveLocalPath(source, baseDir)
+		if exists, _ := afero.DirExists(fs, dir); !exists {
+			return nil, fmt.Errorf("pack source not found: %s", source)
+		}
+		return LoadDir(fs, dir)
+	}
+}
+
+func fetchRemoteTarball(url string) (*Pack, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+
+	return ReadTarball(resp.Body)
+}
+
+func resolveLocalPath(source, baseDir string) string {
+	if filepath.IsAbs(source) || baseDir == "" {
+		return source
+	}
+	return filepath.Join(baseDir, source)
+}
+
+func splitGitRef(source string) (string, string) {
+	if idx := strings.LastIndex(source, "#"); idx != -1 {
+		return source[:idx], source[idx+1:]
+	}
+	return source, ""
+}
+
+func fetchGit(source string) (*Pack, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git is required to install packs from %s", source)
+	}
+
+	repo, ref := splitGitRef(source)
+	repo = strings.TrimPrefix(repo, "git+")
+
+	tmpDir, err := os.MkdirTemp("", "haft-pack-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, repo, tmpDir)
+
+	cmd := exec.Command("git", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git clone failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	return LoadDir(afero.NewOsFs(), tmpDir)
+}
@@ -0,0 +1,137 @@
+package templatepack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type version struct {
+	major int
+	minor int
+	patch int
+}
+
+type comparator struct {
+	op      string
+	version version
+}
+
+type constraint []comparator
+
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+func parseVersion(v string) (version, error) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if idx := strings.IndexAny(v, "-+"); idx != -1 {
+		v = v[:idx]
+	}
+	if v == "" {
+		return version{}, fmt.Errorf("empty version")
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return version{}, fmt.Errorf("too many version components")
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("invalid version component '%s'", part)
+		}
+		numbers[i] = n
+	}
+
+	return version{major: numbers[0], minor: numbers[1], patch: numbers[2]}, nil
+}
+
+func (v version) compare(other version) int {
+	switch {
+	case v.major != other.major:
+		return sign(v.major - other.major)
+	case v.minor != other.minor:
+		return sign(v.minor - other.minor)
+	default:
+		return sign(v.patch - other.patch)
+	}
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+func parseConstraint(s string) (constraint, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "*" {
+		return nil, nil
+	}
+
+	var result constraint
+	for _, field := range strings.Fields(strings.ReplaceAll(s, ",", " ")) {
+		op := "="
+		for _, candidate := range constraintOperators {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				break
+			}
+		}
+		v, err := parseVersion(strings.TrimPrefix(field, op))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comparator{op: op, version: v})
+	}
+
+	return result, nil
+}
+
+func (c constraint) matches(v version) bool {
+	for _, cmp := range c {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparator) matches(v version) bool {
+	result := v.compare(c.version)
+	switch c.op {
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	case "^":
+		return result >= 0 && v.major == c.version.major
+	case "~":
+		return result >= 0 && v.major == c.version.major && v.minor == c.version.minor
+	default:
+		return result == 0
+	}
+}
+
+func CompareVersions(a, b string) (int, error) {
+	va, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.compare(vb), nil
+}
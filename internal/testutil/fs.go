@@ -0,0 +1,28 @@
+// Package testutil provides fixtures shared by the package tests.
+package testutil
+
+import (
+	"path"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// MemFs returns an in-memory filesystem holding files, keyed by path relative to root.
+func MemFs(t testing.TB, root string, files map[string]string) afero.Fs {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path.Join(root, name), []byte(content), 0644))
+	}
+	return fs
+}
+
+// ReadFile returns the content of name, failing the test if it cannot be read.
+func ReadFile(t testing.TB, fs afero.Fs, name string) string {
+	t.Helper()
+	data, err := afero.ReadFile(fs, name)
+	require.NoError(t, err)
+	return string(data)
+}